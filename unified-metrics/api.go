@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	maxHeatmapDays    = 7
+	maxHeatmapBuckets = 2016 // 7일 x 5분 버킷
+
+	sseKeepAliveInterval = 15 * time.Second
+)
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		httpLog.Error("Error encoding JSON response", "error", err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
+
+// JSON API 설정
+type APIOptions struct {
+	AdminToken string          // 비어 있으면 관리 API 비활성화
+	Limiter    *RequestLimiter // /api/* 요청 제한
+	CORS       *CORSConfig     // /api/* CORS (메트릭 엔드포인트에는 적용하지 않음)
+}
+
+// JSON API 핸들러 등록 (apiRoutes 표 기준)
+func (vt *UnifiedValidatorTracker) RegisterAPI(mux *http.ServeMux, opts APIOptions) {
+	for _, route := range vt.apiRoutes() {
+		handler := allowMethod(route.Method, route.Handler)
+		if route.Admin {
+			handler = requireAdmin(opts.AdminToken, handler)
+		} else {
+			handler = vt.requireTenant(opts.AdminToken, handler)
+		}
+		// 동시 실행 제한은 /all-metrics 집계에만 적용하고 /api/*는 속도 제한만 적용
+		mux.HandleFunc(route.Path, opts.CORS.Wrap(opts.Limiter.Wrap(route.Path, false, handler)))
+	}
+	mux.HandleFunc("/debug/state", requireAdmin(opts.AdminToken, vt.handleDebugState))
+}
+
+func allowMethod(method string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeAPIError(w, http.StatusMethodNotAllowed, "method %s not allowed", r.Method)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// /api/status 응답
+type StatusSummary struct {
+	ChainID          string                 `json:"chain_id"`
+	LastBlockHeight  int64                  `json:"last_block_height"`
+	Ready            bool                   `json:"ready"`
+	NotReadyReason   string                 `json:"not_ready_reason,omitempty"`
+	Degraded         bool                   `json:"degraded"` // 노드 버전 호환성을 확인하지 못함
+	StalenessSeconds float64                `json:"staleness_seconds"`
+	FetchErrors      map[string]string      `json:"fetch_errors,omitempty"` // 마지막 시도가 실패한 fetch -> 실패 종류
+	Nodes            []NodeStatusEntry      `json:"nodes,omitempty"`        // NODES에 설정한 노드의 역할별 상태
+	Validators       []ValidatorStatusEntry `json:"validators"`
+}
+
+type ValidatorStatusEntry struct {
+	Label        string `json:"label"`
+	Address      string `json:"address"`
+	MissStreak   int    `json:"miss_streak"`
+	SignedBlocks int    `json:"signed_blocks"`
+	MissedBlocks int    `json:"missed_blocks"`
+}
+
+// /api/heatmap 응답 (버킷은 스트리밍으로 인코딩)
+type HeatmapResponse struct {
+	Validator string          `json:"validator"`
+	From      string          `json:"from"`
+	To        string          `json:"to"`
+	Bucket    string          `json:"bucket"`
+	Buckets   []HeatmapBucket `json:"buckets"`
+}
+
+// GET /api/status
+func (vt *UnifiedValidatorTracker) handleStatus(w http.ResponseWriter, r *http.Request) {
+	ready, reason := vt.Ready(vt.readyMaxStaleness)
+	summary := StatusSummary{
+		Ready:            ready,
+		NotReadyReason:   reason,
+		Degraded:         vt.Degraded(),
+		StalenessSeconds: -1,
+		Validators:       []ValidatorStatusEntry{},
+	}
+	if staleness, ok := vt.dataStaleness(); ok {
+		summary.StalenessSeconds = staleness.Seconds()
+	}
+	summary.Nodes = vt.nodeHealthSnapshot()
+
+	tenant := tenantFromContext(r.Context())
+	validators := vt.Validators()
+	vt.mu.RLock()
+	summary.ChainID = vt.chainID
+	summary.LastBlockHeight = vt.lastBlockHeight
+	for method, status := range vt.endpointStatus {
+		if status.LastError.After(status.LastSuccess) {
+			if summary.FetchErrors == nil {
+				summary.FetchErrors = make(map[string]string)
+			}
+			summary.FetchErrors[method] = status.ErrorReason
+		}
+	}
+	for address, label := range validators {
+		if !tenant.Allows(label) {
+			continue
+		}
+		summary.Validators = append(summary.Validators, ValidatorStatusEntry{
+			Label:        label,
+			Address:      address,
+			MissStreak:   vt.missStreak[label],
+			SignedBlocks: vt.signedTotal[label],
+			MissedBlocks: vt.missedTotal[label],
+		})
+	}
+	vt.mu.RUnlock()
+
+	sort.Slice(summary.Validators, func(i, j int) bool { return summary.Validators[i].Label < summary.Validators[j].Label })
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// GET /api/heatmap?validator=label&days=7&bucket=1h
+func (vt *UnifiedValidatorTracker) handleHeatmap(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	validator := query.Get("validator")
+	if !vt.isTrackedLabel(validator) || !tenantFromContext(r.Context()).Allows(validator) {
+		writeAPIError(w, http.StatusNotFound, "unknown validator %q", validator)
+		return
+	}
+
+	days := 7
+	if value := query.Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxHeatmapDays {
+			writeAPIError(w, http.StatusBadRequest, "days must be between 1 and %d", maxHeatmapDays)
+			return
+		}
+		days = parsed
+	}
+
+	bucket := time.Hour
+	if value := query.Get("bucket"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < time.Minute {
+			writeAPIError(w, http.StatusBadRequest, "bucket must be a duration of at least 1m")
+			return
+		}
+		bucket = parsed
+	}
+
+	to := time.Now().UTC()
+	from := to.Add(-time.Duration(days) * 24 * time.Hour)
+	if count := int(to.Sub(from.Truncate(bucket))/bucket) + 1; count > maxHeatmapBuckets {
+		writeAPIError(w, http.StatusBadRequest, "range produces %d buckets (max %d), use a larger bucket", count, maxHeatmapBuckets)
+		return
+	}
+
+	buckets := vt.history.Heatmap(validator, from, to, bucket)
+
+	// 버킷 단위로 스트리밍 인코딩
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"validator":%q,"from":%q,"to":%q,"bucket":%q,"buckets":[`,
+		validator, from.Format(time.RFC3339), to.Format(time.RFC3339), bucket.String())
+	encoder := json.NewEncoder(w)
+	for i, b := range buckets {
+		if i > 0 {
+			w.Write([]byte(","))
+		}
+		if err := encoder.Encode(b); err != nil {
+			httpLog.Warn("Error streaming heatmap bucket", "validator", validator, "error", err)
+			return
+		}
+	}
+	w.Write([]byte("]}\n"))
+}
+
+// 추적 중인 벨리데이터 라벨인지 확인
+func (vt *UnifiedValidatorTracker) isTrackedLabel(label string) bool {
+	for _, tracked := range vt.Validators() {
+		if tracked == label {
+			return true
+		}
+	}
+	return false
+}
+
+// GET /api/events/stream: 트래커 이벤트를 server-sent events로 전달
+func (vt *UnifiedValidatorTracker) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	tenant := tenantFromContext(r.Context())
+	sub, err := vt.events.Subscribe()
+	if err != nil {
+		writeAPIError(w, http.StatusTooManyRequests, "%v", err)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// 연결 유지 및 끊어진 클라이언트 감지용 주석
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := w.Write([]byte(": keep-alive\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event := <-sub.Events():
+			if !tenant.AllowsEvent(event) {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				httpLog.Error("Error encoding event", "type", event.Type, "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
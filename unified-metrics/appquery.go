@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"og-galileo-unified-metrics/internal/jsonpath"
+)
+
+const defaultAppQueryInterval = time.Minute
+
+// REST 경로의 응답에서 숫자 하나를 꺼내 게이지로 노출하는 설정 (0G 전용 모듈 조회를 코드 수정 없이 추가)
+//
+//	{"name": "inflation", "path": "/cosmos/mint/v1beta1/inflation", "extract": "$.inflation",
+//	 "metric": "og_galileo_app_inflation", "labels": {"module": "mint"}, "interval": "5m"}
+type AppQueryConfig struct {
+	Name     string            `json:"name"`
+	Path     string            `json:"path"`    // REST 엔드포인트 기준 경로 (쿼리 문자열 포함 가능)
+	Extract  string            `json:"extract"` // $.a.b[0].c 또는 $.list.length()
+	Metric   string            `json:"metric"`
+	Help     string            `json:"help,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"` // 고정 라벨
+	Interval jsonDuration      `json:"interval,omitempty"`
+
+	path  *jsonpath.Path
+	gauge prometheus.Gauge
+}
+
+// APP_QUERIES_FILE(JSON 파일) 또는 APP_QUERIES(JSON 문자열)에서 조회 목록 읽기
+// 메트릭 이름, 라벨 이름, 추출 경로는 여기서 검증해 잘못된 설정이면 시작하지 않음
+func appQueriesFromEnv() ([]*AppQueryConfig, error) {
+	data := []byte(getEnv("APP_QUERIES", ""))
+	if path := getEnv("APP_QUERIES_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var queries []*AppQueryConfig
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("decode app queries: %w", err)
+	}
+	names := make(map[string]bool)
+	for i, query := range queries {
+		if query.Name == "" || query.Path == "" || query.Extract == "" || query.Metric == "" {
+			return nil, fmt.Errorf("app query %d: name, path, extract and metric are required", i)
+		}
+		if names[query.Name] {
+			return nil, fmt.Errorf("duplicate app query name %q", query.Name)
+		}
+		names[query.Name] = true
+		if !strings.HasPrefix(query.Path, "/") {
+			return nil, fmt.Errorf("app query %q: path must start with /", query.Name)
+		}
+		if !model.IsValidMetricName(model.LabelValue(query.Metric)) {
+			return nil, fmt.Errorf("app query %q: invalid metric name %q", query.Name, query.Metric)
+		}
+		for label := range query.Labels {
+			if !model.LabelName(label).IsValid() || strings.HasPrefix(label, "__") {
+				return nil, fmt.Errorf("app query %q: invalid label name %q", query.Name, label)
+			}
+		}
+		path, err := jsonpath.Compile(query.Extract)
+		if err != nil {
+			return nil, fmt.Errorf("app query %q: %w", query.Name, err)
+		}
+		query.path = path
+		if query.Interval <= 0 {
+			query.Interval = jsonDuration(defaultAppQueryInterval)
+		}
+		if query.Help == "" {
+			query.Help = fmt.Sprintf("Value of %s from %s (app query %s)", query.Extract, query.Path, query.Name)
+		}
+	}
+	return queries, nil
+}
+
+// 조회별 게이지 등록 (이름이 기존 메트릭과 겹치거나 같은 이름끼리 라벨이 다르면 에러)
+func (vt *UnifiedValidatorTracker) RegisterAppQueries(queries []*AppQueryConfig) error {
+	registerer := vt.catalog.Wrap(vt.registry)
+	for _, query := range queries {
+		query.gauge = newLazyGauge(prometheus.GaugeOpts{
+			Name:        query.Metric,
+			Help:        query.Help,
+			ConstLabels: prometheus.Labels(query.Labels),
+		})
+		if err := registerer.Register(query.gauge); err != nil {
+			return fmt.Errorf("app query %q: register %s: %w", query.Name, query.Metric, err)
+		}
+	}
+	return nil
+}
+
+// 조회마다 스케줄러 수집기로 등록 (수집기 이름 app_query_<name>)
+func (vt *UnifiedValidatorTracker) StartAppQueries(queries []*AppQueryConfig) {
+	for _, query := range queries {
+		query := query
+		vt.scheduler.Register(Collector{
+			Name:     "app_query_" + query.Name,
+			Interval: time.Duration(query.Interval),
+			Jitter:   defaultCollectorJitter,
+			Run:      func(ctx context.Context) error { return vt.runAppQuery(ctx, query) },
+		})
+	}
+}
+
+func (vt *UnifiedValidatorTracker) runAppQuery(ctx context.Context, query *AppQueryConfig) (err error) {
+	method := "app_query_" + query.Name
+	defer func(start time.Time) { vt.recordFetch(method, time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	var raw json.RawMessage
+	if err := vt.getJSON(ctx, method, endpoint, endpoint+query.Path, &raw); err != nil {
+		return err
+	}
+	// 큰 정수가 float64로 바뀌며 잘리지 않도록 json.Number로 디코딩
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return newFetchError(method, endpoint, decodeFailure(err))
+	}
+	value, err := query.path.Float(doc)
+	if err != nil {
+		restLog.Warn("App query value not found", "query", query.Name, "extract", query.Extract, "error", err)
+		return newFetchError(method, endpoint, decodeFailure(err))
+	}
+	query.gauge.Set(value)
+	return nil
+}
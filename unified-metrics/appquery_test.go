@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppQueriesFromEnvValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"missing fields", `[{"name": "a", "path": "/x"}]`, "name, path, extract and metric are required"},
+		{"duplicate name", `[{"name": "a", "path": "/x", "extract": "$.v", "metric": "m_a"}, {"name": "a", "path": "/y", "extract": "$.v", "metric": "m_b"}]`, `duplicate app query name "a"`},
+		{"relative path", `[{"name": "a", "path": "x", "extract": "$.v", "metric": "m_a"}]`, "path must start with /"},
+		{"invalid metric name", `[{"name": "a", "path": "/x", "extract": "$.v", "metric": "0g-epoch"}]`, `invalid metric name "0g-epoch"`},
+		{"invalid label name", `[{"name": "a", "path": "/x", "extract": "$.v", "metric": "m_a", "labels": {"module-name": "x"}}]`, `invalid label name "module-name"`},
+		{"reserved label name", `[{"name": "a", "path": "/x", "extract": "$.v", "metric": "m_a", "labels": {"__name__": "x"}}]`, `invalid label name "__name__"`},
+		{"bad extractor", `[{"name": "a", "path": "/x", "extract": "$..v", "metric": "m_a"}]`, "empty field name"},
+		{"not a list", `{"name": "a"}`, "decode app queries"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("APP_QUERIES_FILE", "")
+			t.Setenv("APP_QUERIES", tt.config)
+			if _, err := appQueriesFromEnv(); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error = %v, want containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAppQueriesFromEnvDefaults(t *testing.T) {
+	t.Setenv("APP_QUERIES", "")
+	t.Setenv("APP_QUERIES_FILE", writeConfigFile(t, "queries.json",
+		`[{"name": "da_epoch", "path": "/0g/dasigners/v1/epoch-number", "extract": "$.epoch_number", "metric": "og_galileo_app_dasigners_epoch"}]`))
+	queries, err := appQueriesFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 1 || time.Duration(queries[0].Interval) != defaultAppQueryInterval || queries[0].Help == "" || queries[0].path == nil {
+		t.Errorf("queries = %+v", queries)
+	}
+}
+
+// 노드 응답 형식의 본문(internal/jsonpath/testdata)을 돌려주는 REST 서버로 조회부터 게이지까지 확인
+func TestRunAppQueries(t *testing.T) {
+	responses := map[string]string{
+		"/cosmos/mint/v1beta1/inflation":           "mint_inflation.json",
+		"/0g/dasigners/v1/epoch-number":            "dasigners_epoch_number.json",
+		"/0g/dasigners/v1/quorum":                  "dasigners_quorum.json",
+		"/cosmos/upgrade/v1beta1/current_plan":     "upgrade_current_plan.json",
+		"/cosmos/bank/v1beta1/supply/by_denom":     "bank_supply_by_denom.json",
+		"/cosmos/gov/v1/proposals":                 "gov_proposals.json",
+		"/cosmos/staking/v1beta1/pool":             "staking_pool.json",
+		"/0g/dasigners/v1/params":                  "dasigners_params.json",
+		"/cosmos/slashing/v1beta1/signing_infos/x": "slashing_signing_info.json",
+	}
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile(filepath.Join("internal", "jsonpath", "testdata", file))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Write(data)
+	}))
+	defer rest.Close()
+
+	t.Setenv("APP_QUERIES_FILE", "")
+	t.Setenv("APP_QUERIES", `[
+		{"name": "inflation", "path": "/cosmos/mint/v1beta1/inflation", "extract": "$.inflation", "metric": "og_galileo_app_inflation", "labels": {"module": "mint"}},
+		{"name": "da_epoch", "path": "/0g/dasigners/v1/epoch-number", "extract": "$.epoch_number", "metric": "og_galileo_app_dasigners_epoch"},
+		{"name": "da_signers", "path": "/0g/dasigners/v1/quorum?epoch=1523&quorum_id=0", "extract": "$.quorum.signers.length()", "metric": "og_galileo_app_dasigners_quorum_signers"},
+		{"name": "supply", "path": "/cosmos/bank/v1beta1/supply/by_denom?denom=ua0gi", "extract": "$.amount.amount", "metric": "og_galileo_app_supply"},
+		{"name": "upgrade", "path": "/cosmos/upgrade/v1beta1/current_plan", "extract": "$.plan.height", "metric": "og_galileo_app_upgrade_height"},
+		{"name": "missing", "path": "/0g/unknown", "extract": "$.v", "metric": "og_galileo_app_missing"}
+	]`)
+	queries, err := appQueriesFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newTestHarness(t, "alpha")
+	h.tracker.restEndpoint = rest.URL
+	if err := h.tracker.RegisterAppQueries(queries); err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]*AppQueryConfig)
+	for _, query := range queries {
+		byName[query.Name] = query
+		err := h.tracker.runAppQuery(h.ctx, query)
+		switch query.Name {
+		case "upgrade":
+			if !errors.Is(err, ErrDecode) {
+				t.Errorf("upgrade query error = %v, want a decode error for a null plan", err)
+			}
+		case "missing":
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("missing query error = %v, want not found", err)
+			}
+		default:
+			if err != nil {
+				t.Errorf("%s: %v", query.Name, err)
+			}
+		}
+	}
+
+	for metric, want := range map[string]float64{
+		"og_galileo_app_dasigners_epoch":          1523,
+		"og_galileo_app_dasigners_quorum_signers": 3,
+		"og_galileo_app_supply":                   1e27,
+	} {
+		if got := h.mustValue(metric); got != want {
+			t.Errorf("%s = %v, want %v", metric, got, want)
+		}
+	}
+	if got := h.mustValue("og_galileo_app_inflation", "module", "mint"); got != 0.13 {
+		t.Errorf("inflation = %v, want 0.13", got)
+	}
+	// 값을 얻지 못한 조회는 시리즈를 내보내지 않음
+	for _, metric := range []string{"og_galileo_app_upgrade_height", "og_galileo_app_missing"} {
+		if _, ok := h.value(metric); ok {
+			t.Errorf("%s exported without a value", metric)
+		}
+	}
+	if got := h.mustValue("og_galileo_exporter_rpc_errors_total", "method", "app_query_missing", "reason", fetchReasonNotFound); got != 1 {
+		t.Errorf("rpc_errors_total for the missing query = %v, want 1", got)
+	}
+}
+
+// 기존 메트릭과 이름이 겹치면 등록 단계에서 거부
+func TestRegisterAppQueriesConflict(t *testing.T) {
+	t.Setenv("APP_QUERIES_FILE", "")
+	t.Setenv("APP_QUERIES", `[{"name": "height", "path": "/x", "extract": "$.v", "metric": "og_galileo_validator_block_height"}]`)
+	queries, err := appQueriesFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newTestHarness(t, "alpha")
+	if err := h.tracker.RegisterAppQueries(queries); err == nil || !strings.Contains(err.Error(), `app query "height"`) {
+		t.Errorf("error = %v, want a registration conflict", err)
+	}
+}
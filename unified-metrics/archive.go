@@ -0,0 +1,427 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultArchiveQueueMax      = 1000
+	defaultArchiveRetryInterval = 30 * time.Second
+	archiveTimeout              = 30 * time.Second
+	// 매니페스트 파일 하나에 담는 항목 수 (넘으면 새 파일 시작)
+	archiveManifestChunk = 1000
+
+	archiveFormatRaw     = "raw"     // 노드 응답 그대로의 블록과 직전 블록 + 서명 판단 결과
+	archiveFormatCompact = "compact" // sink와 같은 BlockRecord만
+)
+
+// 블록 증거를 보관하는 저장소 (archiver 고루틴에서만 사용)
+// 같은 key로 다시 쓰면 덮어씀
+type ArchiveStore interface {
+	Name() string
+	Put(ctx context.Context, key, contentType string, data []byte) error
+}
+
+// 서명 기록 증빙용 블록 표본 보관 (ARCHIVE_EVERY번째 블록마다)
+// 저장소 장애 중에는 큐에 쌓고, 가득 차면 오래된 것부터 버림 (블록 적용은 큐에 넣기만 하므로 막히지 않음)
+type BlockArchiver struct {
+	store    ArchiveStore
+	every    int64
+	format   string
+	prefix   string
+	queueMax int
+
+	mu    sync.Mutex
+	queue []archiveJob
+	wake  chan struct{}
+
+	// 현재 매니페스트 파일 (archiver 고루틴에서만 사용)
+	manifestKey     string
+	manifestEntries []ArchiveManifestEntry
+}
+
+type archiveJob struct {
+	height  int64
+	payload []byte // 압축 전 JSON
+}
+
+// 매니페스트(NDJSON) 한 줄: 보관한 블록 하나
+type ArchiveManifestEntry struct {
+	Height     int64     `json:"height"`
+	Key        string    `json:"key"`
+	Bytes      int       `json:"bytes"`  // 압축 후 크기
+	SHA256     string    `json:"sha256"` // 압축 후 내용의 해시
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+// raw 형식 보관 내용
+type archivedBlock struct {
+	ChainID       string          `json:"chain_id"`
+	Height        int64           `json:"height"`
+	Signed        map[string]bool `json:"signed"`
+	Block         json.RawMessage `json:"block"`
+	PreviousBlock json.RawMessage `json:"previous_block,omitempty"` // 서명 판단에 쓴 커밋을 담은 블록
+}
+
+// ARCHIVE_EVERY가 0이면 nil
+// ARCHIVE_DIR(로컬 디렉터리) 또는 ARCHIVE_S3_ENDPOINT/ARCHIVE_S3_BUCKET(S3 호환 버킷) 중 하나 필요
+func archiverFromEnv() (*BlockArchiver, error) {
+	every := getEnvInt64("ARCHIVE_EVERY", 0)
+	if every <= 0 {
+		return nil, nil
+	}
+	format := getEnv("ARCHIVE_FORMAT", archiveFormatRaw)
+	if format != archiveFormatRaw && format != archiveFormatCompact {
+		return nil, fmt.Errorf("invalid ARCHIVE_FORMAT %q (expected %s or %s)", format, archiveFormatRaw, archiveFormatCompact)
+	}
+	queueMax := int(getEnvInt64("ARCHIVE_QUEUE_MAX", defaultArchiveQueueMax))
+	if queueMax <= 0 {
+		return nil, fmt.Errorf("ARCHIVE_QUEUE_MAX must be positive")
+	}
+
+	var store ArchiveStore
+	dir := getEnv("ARCHIVE_DIR", "")
+	endpoint := getEnv("ARCHIVE_S3_ENDPOINT", "")
+	switch {
+	case dir != "" && endpoint != "":
+		return nil, fmt.Errorf("set either ARCHIVE_DIR or ARCHIVE_S3_ENDPOINT, not both")
+	case dir != "":
+		store = &LocalArchiveStore{dir: dir}
+	case endpoint != "":
+		s3, err := newS3ArchiveStore(endpoint, getEnv("ARCHIVE_S3_BUCKET", ""), getEnv("ARCHIVE_S3_REGION", "us-east-1"),
+			getEnv("ARCHIVE_S3_ACCESS_KEY", ""), getEnv("ARCHIVE_S3_SECRET_KEY", ""))
+		if err != nil {
+			return nil, err
+		}
+		store = s3
+	default:
+		return nil, fmt.Errorf("ARCHIVE_EVERY is set but neither ARCHIVE_DIR nor ARCHIVE_S3_ENDPOINT is configured")
+	}
+
+	return &BlockArchiver{
+		store:    store,
+		every:    every,
+		format:   format,
+		prefix:   strings.Trim(getEnv("ARCHIVE_PREFIX", ""), "/"),
+		queueMax: queueMax,
+		wake:     make(chan struct{}, 1),
+	}, nil
+}
+
+func (a *BlockArchiver) key(name string) string {
+	if a.prefix == "" {
+		return name
+	}
+	return a.prefix + "/" + name
+}
+
+// 큐에 추가 (가득 차면 가장 오래된 작업을 버리고 버린 수 반환)
+func (a *BlockArchiver) enqueue(job archiveJob) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.queue = append(a.queue, job)
+	dropped := 0
+	if len(a.queue) > a.queueMax {
+		dropped = len(a.queue) - a.queueMax
+		a.queue = a.queue[dropped:]
+	}
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+func (a *BlockArchiver) peek() (archiveJob, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.queue) == 0 {
+		return archiveJob{}, false
+	}
+	return a.queue[0], true
+}
+
+// 보관을 마친 작업 제거 (그사이 버려졌으면 그대로 둠)
+func (a *BlockArchiver) ack(height int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.queue) > 0 && a.queue[0].height == height {
+		a.queue = a.queue[1:]
+	}
+}
+
+func (a *BlockArchiver) backlog() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.queue)
+}
+
+// 블록 하나를 압축해 저장하고 매니페스트 갱신
+func (a *BlockArchiver) write(ctx context.Context, job archiveJob) error {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write(job.payload); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	key := a.key(fmt.Sprintf("blocks/%012d.json.gz", job.height))
+	if err := a.store.Put(ctx, key, "application/gzip", compressed.Bytes()); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(compressed.Bytes())
+	entry := ArchiveManifestEntry{
+		Height:     job.height,
+		Key:        key,
+		Bytes:      compressed.Len(),
+		SHA256:     hex.EncodeToString(sum[:]),
+		ArchivedAt: time.Now().UTC(),
+	}
+	// 매니페스트는 첫 항목의 높이로 이름 붙인 NDJSON 파일을 통째로 다시 씀 (재시작하면 새 파일 시작)
+	if a.manifestKey == "" || len(a.manifestEntries) >= archiveManifestChunk {
+		a.manifestKey = a.key(fmt.Sprintf("manifest/%012d.ndjson", job.height))
+		a.manifestEntries = nil
+	}
+	entries := append(a.manifestEntries, entry)
+	var manifest bytes.Buffer
+	encoder := json.NewEncoder(&manifest)
+	for _, e := range entries {
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+	}
+	if err := a.store.Put(ctx, a.manifestKey, "application/x-ndjson", manifest.Bytes()); err != nil {
+		return err
+	}
+	a.manifestEntries = entries
+	return nil
+}
+
+// 적용한 블록이 표본 대상이면 보관 큐에 추가 (applier 고루틴에서 호출, 실패해도 블록 처리에는 영향 없음)
+func (vt *UnifiedValidatorTracker) archiveBlock(height int64, block, previous *BlockInfo, records []SigningRecord) {
+	archiver := vt.archiver
+	if archiver == nil || height%archiver.every != 0 {
+		return
+	}
+
+	signed := make(map[string]bool, len(records))
+	for _, record := range records {
+		signed[record.Validator] = record.Signed
+	}
+	var payload []byte
+	var err error
+	if archiver.format == archiveFormatCompact {
+		header := block.Result.Block.Header
+		payload, err = json.Marshal(BlockRecord{
+			ChainID:           vt.ChainID(),
+			Height:            height,
+			Proposer:          header.ProposerAddress,
+			ProposerValidator: vt.validators[header.ProposerAddress],
+			TxCount:           len(block.Result.Block.Data.Txs),
+			Signed:            signed,
+		})
+	} else {
+		archived := archivedBlock{ChainID: vt.ChainID(), Height: height, Signed: signed}
+		if archived.Block, err = rawBlockJSON(block); err == nil && previous != nil {
+			archived.PreviousBlock, err = rawBlockJSON(previous)
+		}
+		if err == nil {
+			payload, err = json.Marshal(archived)
+		}
+	}
+	if err != nil {
+		trackerLog.Warn("Could not encode block for archive", "height", height, "error", err)
+		return
+	}
+
+	if dropped := archiver.enqueue(archiveJob{height: height, payload: payload}); dropped > 0 {
+		trackerLog.Warn("Archive queue full, dropped oldest pending blocks", "dropped", dropped)
+		vt.metrics.exporter.archiveDroppedMetric.Add(float64(dropped))
+	}
+	vt.metrics.exporter.archiveBacklogMetric.Set(float64(archiver.backlog()))
+}
+
+// 노드 응답 원문 (fetchBlock에서 보관하지 않은 경우 파싱한 필드만 다시 인코딩)
+func rawBlockJSON(block *BlockInfo) (json.RawMessage, error) {
+	if len(block.raw) > 0 {
+		return block.raw, nil
+	}
+	return json.Marshal(block)
+}
+
+// 보관 큐를 저장소에 씀 (실패하면 retry 후 같은 블록부터 다시 시도)
+func (vt *UnifiedValidatorTracker) StartArchiver(ctx context.Context, retry time.Duration) {
+	archiver := vt.archiver
+	trackerLog.Info("Starting block archiver", "store", archiver.store.Name(), "every", archiver.every,
+		"format", archiver.format, "retry_interval", retry)
+	ticker := time.NewTicker(retry)
+	defer ticker.Stop()
+
+	for {
+		if err := vt.flushArchive(ctx); err != nil && ctx.Err() == nil {
+			trackerLog.Warn("Failed to archive block, will retry", "store", archiver.store.Name(),
+				"backlog", archiver.backlog(), "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-archiver.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) flushArchive(ctx context.Context) error {
+	archiver := vt.archiver
+	metrics := vt.metrics.exporter
+	for ctx.Err() == nil {
+		job, ok := archiver.peek()
+		if !ok {
+			return nil
+		}
+		writeCtx, cancel := context.WithTimeout(ctx, archiveTimeout)
+		err := archiver.write(writeCtx, job)
+		cancel()
+		if err != nil {
+			metrics.archiveFailuresMetric.Inc()
+			return fmt.Errorf("height %d: %w", job.height, err)
+		}
+		archiver.ack(job.height)
+		metrics.archivedBlocksMetric.Inc()
+		metrics.archiveBacklogMetric.Set(float64(archiver.backlog()))
+	}
+	return ctx.Err()
+}
+
+// 로컬 디렉터리 (임시 파일에 쓴 뒤 rename해 반쯤 쓴 파일이 남지 않도록 함)
+type LocalArchiveStore struct {
+	dir string
+}
+
+func (s *LocalArchiveStore) Name() string { return "local" }
+
+func (s *LocalArchiveStore) Put(_ context.Context, key, _ string, data []byte) error {
+	target := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	tmp := target + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
+
+// S3 호환 버킷 (path-style PUT, AWS Signature V4)
+type S3ArchiveStore struct {
+	client    *http.Client
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+}
+
+func newS3ArchiveStore(endpoint, bucket, region, accessKey, secretKey string) (*S3ArchiveStore, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid ARCHIVE_S3_ENDPOINT %q", sanitizeURL(endpoint))
+	}
+	if bucket == "" {
+		return nil, fmt.Errorf("ARCHIVE_S3_BUCKET is required with ARCHIVE_S3_ENDPOINT")
+	}
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("ARCHIVE_S3_ACCESS_KEY and ARCHIVE_S3_SECRET_KEY are required with ARCHIVE_S3_ENDPOINT")
+	}
+	return &S3ArchiveStore{
+		client:    &http.Client{Timeout: archiveTimeout},
+		endpoint:  u,
+		bucket:    bucket,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+	}, nil
+}
+
+func (s *S3ArchiveStore) Name() string { return "s3" }
+
+func (s *S3ArchiveStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	objectPath := path.Join("/", strings.TrimSuffix(s.endpoint.EscapedPath(), "/"), url.PathEscape(s.bucket), strings.Join(segments, "/"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.endpoint.Scheme+"://"+s.endpoint.Host+objectPath, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, objectPath, data, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("s3 put %s: status %d: %s", key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// AWS Signature V4 헤더 서명 (host, content-type, x-amz-content-sha256, x-amz-date)
+func (s *S3ArchiveStore) sign(req *http.Request, escapedPath string, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256.Sum256(payload)
+	payloadHex := hex.EncodeToString(payloadHash[:])
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHex)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n",
+		req.Header.Get("Content-Type"), req.URL.Host, payloadHex, amzDate)
+	canonicalRequest := strings.Join([]string{req.Method, escapedPath, "", canonicalHeaders, signedHeaders, payloadHex}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + s.secretKey)
+	for _, part := range []string{date, s.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
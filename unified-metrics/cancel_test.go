@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// 응답이 오지 않는 조회 중에 취소하면 1초 안에 중단 (HTTP 타임아웃을 기다리지 않음)
+func TestCancelMidFetch(t *testing.T) {
+	h := newTestHarness(t, "alpha")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	h.chain.SetLatency(time.Minute)
+	h.chain.Advance(1)
+
+	ctx, cancel := context.WithCancel(h.ctx)
+	done := make(chan error, 1)
+	go func() { done <- h.tracker.collectBlocks(ctx) }()
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("collectBlocks error = %v, want context.Canceled", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("fetch aborted %v after cancel, want < 1s", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("fetch still running 1s after cancel")
+	}
+}
+
+// 추적 고루틴(스케줄러)도 진행 중인 조회를 버리고 종료
+func TestCancelStopsTracking(t *testing.T) {
+	h := newTestHarness(t, "alpha")
+	h.chain.SetLatency(time.Minute)
+	h.tracker.scheduler = NewScheduler(h.tracker.metrics.exporter, nil, h.tracker.goroutines)
+	h.tracker.StartTracking(h.ctx)
+	time.Sleep(100 * time.Millisecond)
+	if running := h.tracker.goroutines.Running(); running[componentScheduler] == 0 {
+		t.Fatalf("scheduler not running: %v", running)
+	}
+
+	h.cancel()
+	if remaining := h.tracker.goroutines.WaitIdle(time.Second); remaining != nil {
+		t.Errorf("goroutines still running 1s after cancel: %v", remaining)
+	}
+}
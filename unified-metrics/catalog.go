@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// 익스포터가 낼 수 있는 메트릭 하나의 설명 (/api/metrics/catalog, 대시보드 툴팁과 문서 생성용)
+type MetricDefinition struct {
+	Name      string   `json:"name"`
+	Type      string   `json:"type"` // gauge, counter, histogram, summary
+	Help      string   `json:"help"`
+	Labels    []string `json:"labels"`
+	Unit      string   `json:"unit,omitempty"`
+	Collector string   `json:"collector"` // 값을 갱신하는 수집기 또는 구성 요소
+}
+
+type MetricCatalogResponse struct {
+	Metrics []MetricDefinition `json:"metrics"`
+}
+
+// 등록된 메트릭의 정의 표 (이름, 타입, 도움말, 라벨은 등록된 collector에서 읽으므로 실제 노출과 어긋나지 않음)
+type MetricCatalog struct {
+	mu          sync.Mutex
+	definitions map[string]MetricDefinition
+	collectors  []prometheus.Collector // 시계열 삭제용
+}
+
+func NewMetricCatalog() *MetricCatalog {
+	return &MetricCatalog{definitions: make(map[string]MetricDefinition)}
+}
+
+// 등록하면서 정의를 기록하는 Registerer
+func (c *MetricCatalog) Wrap(inner prometheus.Registerer) prometheus.Registerer {
+	return &catalogRegisterer{inner: inner, catalog: c}
+}
+
+type catalogRegisterer struct {
+	inner   prometheus.Registerer
+	catalog *MetricCatalog
+}
+
+func (r *catalogRegisterer) Register(collector prometheus.Collector) error {
+	if err := r.inner.Register(collector); err != nil {
+		return err
+	}
+	r.catalog.add(collector)
+	return nil
+}
+
+func (r *catalogRegisterer) MustRegister(collectors ...prometheus.Collector) {
+	for _, collector := range collectors {
+		if err := r.Register(collector); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (r *catalogRegisterer) Unregister(collector prometheus.Collector) bool {
+	return r.inner.Unregister(collector)
+}
+
+func (c *MetricCatalog) add(collector prometheus.Collector) {
+	metricType := collectorType(collector)
+	descs := make(chan *prometheus.Desc, 4)
+	go func() {
+		collector.Describe(descs)
+		close(descs)
+	}()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.collectors = append(c.collectors, collector)
+	for desc := range descs {
+		name, help, labels, err := parseDesc(desc.String())
+		if err != nil {
+			continue
+		}
+		c.definitions[name] = MetricDefinition{
+			Name:      name,
+			Type:      metricType,
+			Help:      help,
+			Labels:    labels,
+			Unit:      metricUnit(name),
+			Collector: metricCollector(name),
+		}
+	}
+}
+
+// 등록된 모든 벡터에서 labels가 일치하는 시계열 삭제 (라벨 이름이 없는 벡터는 그대로), 삭제한 수 반환
+func (c *MetricCatalog) DeleteSeries(labels prometheus.Labels) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for _, collector := range c.collectors {
+		if vec, ok := collector.(interface {
+			DeletePartialMatch(prometheus.Labels) int
+		}); ok {
+			deleted += vec.DeletePartialMatch(labels)
+		}
+	}
+	return deleted
+}
+
+// 이름 순으로 정렬한 전체 정의
+func (c *MetricCatalog) Definitions() []MetricDefinition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	definitions := make([]MetricDefinition, 0, len(c.definitions))
+	for _, definition := range c.definitions {
+		definitions = append(definitions, definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].Name < definitions[j].Name })
+	return definitions
+}
+
+// Vec 타입은 타입으로, 단일 메트릭은 현재 값을 dto로 써 보고 판단 (Gauge도 Inc/Add가 있어 인터페이스로 구분 불가)
+func collectorType(collector prometheus.Collector) string {
+	switch collector.(type) {
+	case *prometheus.GaugeVec, *lazyGauge: // lazyGauge는 값을 쓰기 전까지 아무것도 내보내지 않음
+		return "gauge"
+	case *prometheus.CounterVec:
+		return "counter"
+	case *prometheus.HistogramVec:
+		return "histogram"
+	case *prometheus.SummaryVec:
+		return "summary"
+	}
+
+	metrics := make(chan prometheus.Metric, 4)
+	go func() {
+		collector.Collect(metrics)
+		close(metrics)
+	}()
+	metricType := "untyped"
+	for metric := range metrics {
+		var m dto.Metric
+		if metric.Write(&m) != nil || metricType != "untyped" {
+			continue
+		}
+		switch {
+		case m.Gauge != nil:
+			metricType = "gauge"
+		case m.Counter != nil:
+			metricType = "counter"
+		case m.Histogram != nil:
+			metricType = "histogram"
+		case m.Summary != nil:
+			metricType = "summary"
+		}
+	}
+	return metricType
+}
+
+// Desc.String() 형식: Desc{fqName: "name", help: "help", constLabels: {...}, variableLabels: {a,b}}
+func parseDesc(s string) (name, help string, labels []string, err error) {
+	rest, ok := strings.CutPrefix(s, "Desc{fqName: ")
+	if !ok {
+		return "", "", nil, fmt.Errorf("unexpected descriptor %q", s)
+	}
+	if name, rest, err = cutQuoted(rest); err != nil {
+		return "", "", nil, err
+	}
+	if rest, ok = strings.CutPrefix(rest, ", help: "); !ok {
+		return "", "", nil, fmt.Errorf("unexpected descriptor %q", s)
+	}
+	if help, rest, err = cutQuoted(rest); err != nil {
+		return "", "", nil, err
+	}
+	_, variable, ok := strings.Cut(rest, "variableLabels: {")
+	if !ok {
+		return "", "", nil, fmt.Errorf("unexpected descriptor %q", s)
+	}
+	labels = []string{}
+	for _, label := range strings.Split(strings.TrimSuffix(variable, "}}"), ",") {
+		// 제약 조건이 있는 라벨은 c(name)으로 표시됨
+		label = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(label), "c("), ")")
+		if label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return name, help, labels, nil
+}
+
+func cutQuoted(s string) (string, string, error) {
+	quoted, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", err
+	}
+	value, err := strconv.Unquote(quoted)
+	return value, s[len(quoted):], err
+}
+
+// 이름 접미사로 정한 단위 (예외는 metricUnitOverrides)
+var metricUnitSuffixes = []struct{ suffix, unit string }{
+	{"_timestamp", "unix_seconds"},
+	{"_end_time", "unix_seconds"},
+	{"_seconds", "seconds"},
+	{"_duration", "seconds"},
+	{"_bytes", "bytes"},
+	{"_ratio", "ratio"},
+	{"_fullness", "ratio"},
+	{"_ewma", "ratio"},
+	{"_display", "display_denom"},
+	{"_value", "quote_currency"},
+	{"_tokens", "base_denom"},
+	{"_rewards", "base_denom"},
+	{"_commission", "base_denom"},
+	{"_seat_price", "base_denom"},
+	{"_height", "blocks"},
+	{"_blocks", "blocks"},
+	{"_blocks_window", "blocks"},
+	{"_blocks_per_window", "blocks"},
+	{"_blocks_to_jail", "blocks"},
+	{"_voting_power", "voting_power"},
+	{"_threshold_power", "voting_power"},
+	{"_gas_price", "base_denom_per_gas"},
+	{"_base_fee", "wei_per_gas"},
+}
+
+var metricUnitOverrides = map[string]string{
+	"og_galileo_validator_commission":                 "ratio",
+	"og_galileo_validator_slash_fraction_double_sign": "ratio",
+	"og_galileo_validator_slash_fraction_downtime":    "ratio",
+	"og_galileo_validator_proposals_ratio":            "ratio",
+	"og_galileo_network_stake_gini":                   "ratio",
+	"og_galileo_network_stake_share_top":              "ratio",
+	"og_galileo_network_top_validator_commission":     "ratio",
+	"og_galileo_validator_tokens_change_24h":          "base_denom",
+	"og_galileo_fee_evm_gas_price":                    "wei_per_gas",
+	"og_galileo_token_price":                          "quote_currency",
+	"og_galileo_chain_quorum_margin":                  "voting_power",
+	"og_galileo_validator_commit_latency_percentile":  "percent",
+}
+
+func metricUnit(name string) string {
+	if unit, ok := metricUnitOverrides[name]; ok {
+		return unit
+	}
+	for _, rule := range metricUnitSuffixes {
+		if strings.HasSuffix(name, rule.suffix) {
+			return rule.unit
+		}
+	}
+	return ""
+}
+
+// 이름 접두사로 정한 담당 수집기 (위에서부터 처음 맞는 규칙, 이름이 정확히 같으면 접두사보다 우선)
+var metricCollectorRules = []struct{ prefix, collector string }{
+	{"og_galileo_exporter_collector_", "scheduler"},
+	{"og_galileo_exporter_sink_", "sink"},
+	{"og_galileo_exporter_archive_", "archiver"},
+	{"og_galileo_exporter_hook_", "hooks"},
+	{"og_galileo_exporter_rpc_endpoint_", "endpoint_selector"},
+	{"og_galileo_rpc_request_duration_seconds", "exporter"},
+	{"og_galileo_rpc_last_success_timestamp", "exporter"},
+	{"og_galileo_rpc_", "endpoint_selector"},
+	{"og_galileo_http_", "exporter"},
+	{"og_galileo_exporter_verification", "verifier"},
+	{"og_galileo_exporter_data_discrepancies", "verifier"},
+	{"og_galileo_exporter_history_", "janitor"},
+	{"og_galileo_exporter_source_stale", "aggregator"},
+	{"og_galileo_exporter_scrape_success", "aggregator"},
+	{"og_galileo_exporter_heartbeat", "heartbeat"},
+	{"og_galileo_exporter_", "exporter"},
+	{"og_galileo_fee_observed_", collectorBlocks},
+	{"og_galileo_fee_", collectorEconomics},
+	{"og_galileo_token_price", "price_feed"},
+	{"og_galileo_validator_stake_value", "price_feed"},
+	{"og_galileo_validator_accumulated_commission_value", "price_feed"},
+	{"og_galileo_probe_", "probes"},
+	{"og_galileo_node_expected_peer", "peer_checks"},
+	{"og_galileo_node_peers_total", collectorNodeStatus},
+	{"og_galileo_node_outbound_peers", collectorNodeStatus},
+	{"og_galileo_node_inbound_peers", collectorNodeStatus},
+	{"og_galileo_node_", "node_health"},
+	{"og_galileo_staking_max_validators", collectorParams},
+	{"og_galileo_validator_signed_blocks_window", collectorSlashing},
+	{"og_galileo_validator_min_signed_blocks_per_window", collectorSlashing},
+	{"og_galileo_validator_blocks_to_jail", collectorSlashing},
+	{"og_galileo_validator_start_height", collectorStaking},
+	{"og_galileo_validator_jailed_until_timestamp", collectorStaking},
+	{"og_galileo_validator_tombstoned", collectorStaking},
+	{"og_galileo_validator_signing_missed_blocks", collectorStaking},
+	{"og_galileo_validator_downtime_jail_duration", collectorSlashing},
+	{"og_galileo_validator_slash_fraction_", collectorSlashing},
+	{"og_galileo_validator_node_synced", collectorNodeStatus},
+	{"og_galileo_validator_vote", collectorGovernance},
+	{"og_galileo_validator_proposal_end_time", collectorGovernance},
+	{"og_galileo_validator_upgrade_plan", collectorGovernance},
+	{"og_galileo_blocks_until_upgrade", collectorGovernance},
+	{"og_galileo_validator_info", collectorStaking},
+	{"og_galileo_validator_pubkey_rotated", collectorStaking},
+	{"og_galileo_network_commit_latency", collectorBlocks},
+	{"og_galileo_chain_", collectorBlocks},
+	{"og_galileo_network_", collectorStaking},
+	{"og_galileo_active_set_fullness", collectorStaking},
+	{"og_galileo_validator_tokens", collectorStaking},
+	{"og_galileo_validator_rank", collectorStaking},
+	{"og_galileo_validator_commission", collectorStaking},
+	{"og_galileo_validator_accumulated_commission", collectorStaking},
+	{"og_galileo_validator_outstanding_rewards", collectorStaking},
+	{"og_galileo_validator_is_", collectorStaking},
+	{"og_galileo_validator_bonded_pool", collectorStaking},
+	{"og_galileo_validator_seat_price", collectorStaking},
+	{"og_galileo_validator_change_24h", collectorStaking},
+	{"og_galileo_validator_active_set", collectorStaking},
+	{"cometbft_", collectorStaking},
+	{"og_galileo_validator_", collectorBlocks},
+}
+
+func metricCollector(name string) string {
+	for _, rule := range metricCollectorRules {
+		if name == rule.prefix {
+			return rule.collector
+		}
+	}
+	for _, rule := range metricCollectorRules {
+		if strings.HasPrefix(name, rule.prefix) {
+			return rule.collector
+		}
+	}
+	return "exporter"
+}
+
+// GET /api/metrics/catalog
+func (vt *UnifiedValidatorTracker) handleMetricCatalog(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, MetricCatalogResponse{Metrics: vt.catalog.Definitions()})
+}
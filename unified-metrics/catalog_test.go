@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "testdata의 golden 파일을 현재 출력으로 갱신")
+
+const catalogGoldenPath = "testdata/metric_catalog.golden"
+
+// 등록되는 메트릭의 이름, 타입, 라벨은 대시보드와 알림 규칙이 의존하므로 바뀌면 golden 파일을 함께 고쳐야 함
+// 갱신: go test -run TestMetricCatalogGolden -update
+func TestMetricCatalogGolden(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+
+	var b strings.Builder
+	for _, definition := range h.tracker.catalog.Definitions() {
+		// 값이 아직 없는 메트릭도 타입을 알아야 함
+		if definition.Type == "untyped" {
+			t.Errorf("%s has no type in the catalog", definition.Name)
+		}
+		b.WriteString(definition.Name + " " + definition.Type + " {" + strings.Join(definition.Labels, ",") + "}\n")
+	}
+	got := b.String()
+
+	if *updateGolden {
+		if err := os.WriteFile(catalogGoldenPath, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(catalogGoldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("registered metrics differ from %s (run with -update if the change is intended)\n%s",
+			catalogGoldenPath, lineDiff(string(want), got))
+	}
+}
+
+// 실제로 노출된 패밀리는 모두 카탈로그에 있어야 함 (정의 표를 거치지 않은 등록 방지)
+func TestGatheredFamiliesInCatalog(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	if err := h.advance(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	defined := make(map[string]bool)
+	for _, definition := range h.tracker.catalog.Definitions() {
+		defined[definition.Name] = true
+	}
+	names := gatheredNames(t, h.tracker.registry)
+	if len(names) == 0 {
+		t.Fatal("nothing gathered")
+	}
+	for name := range names {
+		if !defined[name] && !strings.HasPrefix(name, "go_") && !strings.HasPrefix(name, "process_") {
+			t.Errorf("%s gathered but missing from the catalog", name)
+		}
+	}
+}
+
+// golden 비교 실패 시 빠지거나 추가된 줄만 표시
+func lineDiff(want, got string) string {
+	wantLines := make(map[string]bool)
+	for _, line := range strings.Split(want, "\n") {
+		wantLines[line] = true
+	}
+	gotLines := make(map[string]bool)
+	for _, line := range strings.Split(got, "\n") {
+		gotLines[line] = true
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(want, "\n") {
+		if line != "" && !gotLines[line] {
+			b.WriteString("- " + line + "\n")
+		}
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if line != "" && !wantLines[line] {
+			b.WriteString("+ " + line + "\n")
+		}
+	}
+	return b.String()
+}
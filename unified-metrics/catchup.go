@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"strconv"
+)
+
+// 재시작 시 이 블록 수 이하의 공백은 따라잡고, 초과하면 건너뜀
+const defaultCatchUpMaxBlocks = 500
+
+// 추적 주기 사이에 생성된 중간 블록을 한 주기에 최대 이만큼 처리 (긴 장애 후 무한정 따라잡지 않도록)
+const defaultBackfillMaxBlocks = 50
+
+// 저장된 마지막 처리 높이부터 현재 팁까지의 공백을 처리한 뒤 실시간 추적으로 전환
+func (vt *UnifiedValidatorTracker) CatchUp(ctx context.Context, maxBlocks int64) {
+	lastHeight := vt.LastHeight()
+	if lastHeight == 0 {
+		vt.metrics.exporter.startupCatchUpMetric.WithLabelValues("none").Set(1)
+		return
+	}
+
+	blockInfo, err := vt.fetchBlock(ctx, 0)
+	if err != nil {
+		trackerLog.Error("Error fetching tip for startup catch-up", "error", err)
+		return
+	}
+	tip, _ := strconv.ParseInt(string(blockInfo.Result.Block.Header.Height), 10, 64)
+	gap := tip - lastHeight - 1 // 팁 자체는 실시간 추적에서 처리
+	if gap <= 0 {
+		vt.metrics.exporter.startupGapMetric.Set(0)
+		vt.metrics.exporter.startupCatchUpMetric.WithLabelValues("none").Set(1)
+		return
+	}
+	vt.metrics.exporter.startupGapMetric.Set(float64(gap))
+
+	if gap > maxBlocks {
+		trackerLog.Warn("Startup gap exceeds catch-up limit, resuming at tip",
+			"last_height", lastHeight, "tip", tip, "gap", gap, "limit", maxBlocks)
+		vt.metrics.exporter.missedCoverageMetric.Add(float64(gap))
+		vt.metrics.exporter.startupCatchUpMetric.WithLabelValues("skipped").Set(1)
+		return
+	}
+
+	trackerLog.Info("Catching up on blocks missed while stopped",
+		"last_height", lastHeight, "tip", tip, "gap", gap, "limit", maxBlocks)
+	vt.metrics.exporter.startupCatchUpMetric.WithLabelValues("caught_up").Set(1)
+
+	// 블록을 순서대로 큐에 넣고 다음 블록의 직전 블록으로 재사용 (applier가 늦으면 큐에서 대기)
+	var previous *BlockInfo
+	for height := lastHeight + 1; height < tip; height++ {
+		if ctx.Err() != nil {
+			return
+		}
+		block, err := vt.fetchBlock(ctx, height)
+		if err != nil {
+			// 나머지 공백은 누락 구간으로 기록
+			remaining := tip - height
+			trackerLog.Error("Startup catch-up aborted", "height", height, "remaining", remaining, "error", err)
+			vt.metrics.exporter.missedCoverageMetric.Add(float64(remaining))
+			return
+		}
+		if !vt.enqueueBlock(ctx, vt.newBlockSummary(ctx, height, block, previous, false)) {
+			return
+		}
+		previous = block
+	}
+	trackerLog.Info("Startup catch-up queued", "blocks", gap)
+}
+
+// 추적 주기 사이의 중간 블록(from~to)을 순서대로 큐에 넣고 마지막으로 넣은 블록을 반환 (팁 블록의 직전 블록으로 재사용)
+// backfillLimit를 넘는 앞쪽 블록과 조회에 실패한 나머지 블록은 건너뛴 블록으로 기록
+func (vt *UnifiedValidatorTracker) backfillBlocks(ctx context.Context, from, to int64) *BlockInfo {
+	gap := to - from + 1
+	if limit := max(vt.backfillLimit, 0); gap > limit {
+		skipped := gap - limit
+		trackerLog.Warn("Intermediate block gap exceeds backfill limit, skipping oldest blocks",
+			"from", from, "to", to, "gap", gap, "limit", limit, "skipped", skipped)
+		vt.metrics.cosmos.skippedBlocksMetric.Add(float64(skipped))
+		from += skipped
+	}
+	if from > to {
+		return nil
+	}
+	trackerLog.Debug("Backfilling intermediate blocks", "from", from, "to", to)
+
+	var previous *BlockInfo
+	for height := from; height <= to; height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		block, err := vt.fetchBlock(ctx, height)
+		if err != nil {
+			remaining := to - height + 1
+			trackerLog.Error("Backfill aborted", "height", height, "remaining", remaining, "error", err)
+			vt.metrics.cosmos.skippedBlocksMetric.Add(float64(remaining))
+			return nil
+		}
+		if !vt.enqueueBlock(ctx, vt.newBlockSummary(ctx, height, block, previous, false)) {
+			return nil
+		}
+		previous = block
+	}
+	return previous
+}
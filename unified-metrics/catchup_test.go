@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+// 주기 사이에 10블록이 쌓이면 중간 블록을 모두 높이 순서대로 처리해 높이별 서명 여부를 남김
+func TestBackfillGapSigning(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	base := h.tracker.LastHeight()
+
+	// beta는 세 번째 블록마다 서명하지 않음 (블록 base+i 생성 시점의 서명 여부)
+	signing := map[int64]bool{base: true}
+	for i := int64(1); i <= 10; i++ {
+		signing[base+i] = i%3 != 0
+		h.chain.SetSigning("beta", signing[base+i])
+		h.chain.Advance(1)
+	}
+	h.chain.SetSigning("beta", true)
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	tip := base + 11
+	if got := h.tracker.LastHeight(); got != tip {
+		t.Fatalf("last height = %d, want %d", got, tip)
+	}
+
+	// 서명 여부는 직전 블록 기준이므로 높이 n의 값은 블록 n-1 생성 시점의 서명 여부
+	for height := base + 1; height <= tip; height++ {
+		label := strconv.FormatInt(height, 10)
+		want := boolToFloat(signing[height-1])
+		if got := h.mustValue("og_galileo_validator_beacon_block_signed", "validator", "beta", "block_height", label); got != want {
+			t.Errorf("beta signed at %d = %v, want %v", height, got, want)
+		}
+		if got := h.mustValue("og_galileo_validator_beacon_block_signed", "validator", "alpha", "block_height", label); got != 1 {
+			t.Errorf("alpha signed at %d = %v, want 1", height, got)
+		}
+	}
+	if got := h.mustValue("og_galileo_validator_skipped_blocks"); got != 0 {
+		t.Errorf("skipped blocks = %v, want 0", got)
+	}
+	if got := h.mustValue("og_galileo_exporter_catchup_blocks_total"); got != 10 {
+		t.Errorf("catch-up blocks = %v, want 10", got)
+	}
+}
+
+// BACKFILL_MAX_BLOCKS를 넘는 앞쪽 블록은 건너뛴 블록으로 기록
+func TestBackfillGapBeyondLimit(t *testing.T) {
+	h := newTestHarness(t, "alpha")
+	h.tracker.backfillLimit = 4
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	base := h.tracker.LastHeight()
+	if err := h.advance(11); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := h.mustValue("og_galileo_validator_skipped_blocks"); got != 6 {
+		t.Errorf("skipped blocks = %v, want 6", got)
+	}
+	for height := base + 1; height <= base+11; height++ {
+		_, ok := h.value("og_galileo_validator_beacon_block_signed", "validator", "alpha", "block_height", strconv.FormatInt(height, 10))
+		if want := height > base+6; ok != want {
+			t.Errorf("height %d exported = %v, want %v", height, ok, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 팁 높이가 마지막 처리 높이보다 이만큼 넘게 낮으면 체인이 리셋된 것으로 판단 (0이면 높이로는 판단하지 않음)
+const defaultChainResetHeightDrop = 1000
+
+// 테스트넷 리셋 (체인 ID 변경 또는 높이가 처음부터 다시 시작)
+// fetcher가 감지해 큐에 넣고, applier가 큐 순서대로 상태를 비운 뒤 done을 닫음
+type chainReset struct {
+	oldChainID string
+	newChainID string // 헤더에 체인 ID가 없으면 빈 문자열 (기존 값 유지)
+	oldHeight  int64
+	newHeight  int64
+	reason     string
+	done       chan struct{}
+}
+
+func (r *chainReset) message() string {
+	return fmt.Sprintf("chain reset detected (%s): height %d on %s -> %d on %s", r.reason,
+		r.oldHeight, displayChainID(r.oldChainID), r.newHeight, displayChainID(r.newChainID))
+}
+
+func displayChainID(chainID string) string {
+	if chainID == "" {
+		return "unknown chain"
+	}
+	return chainID
+}
+
+// 최신 블록 헤더의 체인 ID와 높이를 지금까지 추적한 체인과 비교 (fetcher 고루틴에서만 호출)
+// /status의 체인 ID가 바뀐 경우도 updateNodeStatus가 기존 값을 유지하므로 여기서 함께 감지됨
+func (vt *UnifiedValidatorTracker) detectChainReset(chainID string, height int64) *chainReset {
+	known := vt.ChainID()
+	last := max(vt.LastHeight(), vt.lastQueued)
+	reset := &chainReset{oldChainID: known, newChainID: chainID, oldHeight: last, newHeight: height}
+	switch {
+	case chainID != "" && known != "" && chainID != known:
+		reset.reason = "chain id changed"
+	case vt.resetHeightDrop > 0 && last-height > vt.resetHeightDrop:
+		reset.reason = fmt.Sprintf("tip is %d blocks below the last processed height", last-height)
+	default:
+		return nil
+	}
+	if reset.newChainID == "" {
+		reset.newChainID = known
+	}
+	return reset
+}
+
+// applier가 리셋을 적용할 때까지 기다린 뒤 fetcher 쪽 상태도 비움 (종료 시에만 false)
+func (vt *UnifiedValidatorTracker) resetChain(ctx context.Context, reset *chainReset) bool {
+	trackerLog.Error("CHAIN RESET DETECTED, resetting tracker state and continuing on the new chain",
+		"reason", reset.reason, "old_chain_id", reset.oldChainID, "new_chain_id", reset.newChainID,
+		"old_height", reset.oldHeight, "new_height", reset.newHeight)
+
+	reset.done = make(chan struct{})
+	// 높이 0으로 넣으므로 lastQueued도 0이 됨
+	if !vt.enqueueBlock(ctx, blockSummary{reset: reset}) {
+		return false
+	}
+	select {
+	case <-reset.done:
+	case <-ctx.Done():
+		return false
+	}
+
+	vt.lastSeenTip = 0
+	vt.lastHeightChange = time.Time{}
+	vt.chainHalted = false
+	return true
+}
+
+// 높이 기준 상태와 체인별 카운터 초기화 (applier 고루틴에서만 호출, 이전 블록은 모두 적용된 뒤)
+func (vt *UnifiedValidatorTracker) applyChainReset(reset *chainReset) {
+	defer close(reset.done)
+
+	if err := vt.archiveStateFile(reset.oldChainID); err != nil {
+		persistenceLog.Error("Failed to archive state file of the previous chain", "path", vt.stateFile, "error", err)
+	}
+	// 이전 체인의 미전송 블록은 새 체인 높이와 섞이지 않도록 버림
+	if dropped := vt.history.ResetChain(); dropped > 0 {
+		sinkLog.Warn("Dropped unpublished blocks of the previous chain", "dropped", dropped, "chain_id", reset.oldChainID)
+		vt.metrics.exporter.sinkDroppedMetric.Add(float64(dropped))
+		vt.metrics.exporter.sinkBacklogMetric.Set(0)
+	}
+	vt.validatorSets.Reset()
+	vt.missedWindow.Reset()
+	vt.uptime.Reset()
+	vt.processed.Reset()
+	vt.lag.Reset()
+	if vt.gasPrices != nil {
+		vt.gasPrices.Reset()
+	}
+
+	vt.mu.Lock()
+	vt.chainID = reset.newChainID
+	vt.lastBlockHeight = 0
+	vt.sinkPublished = 0
+	clear(vt.signedHeights)
+	clear(vt.missStreak)
+	clear(vt.signedTotal)
+	clear(vt.missedTotal)
+	clear(vt.consensusPubkeys)
+	// 제안 ID도 새 체인에서 다시 시작 (노출된 시리즈는 proposalSeries에 남아 janitor가 삭제)
+	clear(vt.activeProposals)
+	clear(vt.proposalVotes)
+	clear(vt.proposalPeriods)
+	vt.proposers = NewProposerWindow(vt.proposers.Capacity())
+	vt.mu.Unlock()
+
+	// block_height 라벨은 새 체인 높이가 보존 기준보다 낮아 janitor가 지우지 못하므로 여기서 삭제
+	vt.metrics.custom.beaconBlockSignedMetric.Reset()
+	vt.metrics.cosmos.consecutiveMissedBlocksMetric.Reset()
+	vt.metrics.cosmos.missedBlocksMetric.Reset()
+	vt.metrics.cosmos.missedBlocksWindowMetric.Reset()
+	vt.metrics.cosmos.soloMissedBlocksMetric.Reset()
+	vt.metrics.cosmos.uptimeRatioMetric.Reset()
+	vt.upgradeHeight.Store(0) // 새 체인의 업그레이드 계획은 다음 governance 수집 때 다시 조회
+	vt.metrics.exporter.chainResetsMetric.Inc()
+
+	message := reset.message()
+	vt.events.Publish(Event{Type: EventChainReset, Height: reset.newHeight, Message: message})
+	if len(vt.notifiers) > 0 {
+		vt.notifyAsync(Message{Title: "Chain reset detected", Markdown: message})
+	}
+}
+
+// 이전 체인의 상태 파일을 체인 ID를 붙인 이름으로 보관 (state.json → state.<chain-id>.json)
+func (vt *UnifiedValidatorTracker) archiveStateFile(chainID string) error {
+	if vt.stateFile == "" {
+		return nil
+	}
+	archived := archivedStatePath(vt.stateFile, chainID)
+	if vt.dryRun {
+		persistenceLog.Info("Dry run: state file not archived", "path", vt.stateFile, "archive", archived)
+		return nil
+	}
+	if err := os.Rename(vt.stateFile, archived); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	persistenceLog.Warn("Archived state file of the previous chain", "path", vt.stateFile, "archive", archived)
+	return nil
+}
+
+func archivedStatePath(path, chainID string) string {
+	if chainID == "" {
+		chainID = "unknown"
+	}
+	// 체인 ID를 파일 이름에 쓸 수 있는 문자로 제한
+	chainID = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, chainID)
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + chainID + ext
+}
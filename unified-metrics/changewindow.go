@@ -0,0 +1,114 @@
+package main
+
+import (
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	changeWindow         = 24 * time.Hour
+	changeSampleInterval = 10 * time.Minute // 벨리데이터별 샘플 간격 (24시간에 약 144개)
+)
+
+// 토큰/순위 변화량 계산용 샘플
+type ChangeSample struct {
+	Time   time.Time `json:"time"`
+	Tokens string    `json:"tokens"`
+	Rank   int       `json:"rank"`
+}
+
+// 벨리데이터별 최근 24시간 샘플 (시간 순서, 기준점으로 24시간보다 오래된 샘플 하나만 유지)
+type ChangeWindow struct {
+	mu      sync.Mutex
+	samples map[string][]ChangeSample
+}
+
+func NewChangeWindow() *ChangeWindow {
+	return &ChangeWindow{samples: make(map[string][]ChangeSample)}
+}
+
+// 샘플 기록 (마지막 샘플 이후 changeSampleInterval이 지나지 않았으면 무시)
+func (w *ChangeWindow) Add(validator string, sample ChangeSample) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	samples := w.samples[validator]
+	if n := len(samples); n > 0 && sample.Time.Sub(samples[n-1].Time) < changeSampleInterval {
+		return
+	}
+	samples = append(samples, sample)
+
+	// 24시간 전 시점 이전의 샘플은 가장 최근 것 하나만 기준점으로 남김
+	cutoff := sample.Time.Add(-changeWindow)
+	drop := 0
+	for drop+1 < len(samples) && !samples[drop+1].Time.After(cutoff) {
+		drop++
+	}
+	w.samples[validator] = append([]ChangeSample(nil), samples[drop:]...)
+}
+
+// 24시간 전(없으면 가장 오래된) 샘플 대비 현재 값의 변화량과 실제 비교 구간
+func (w *ChangeWindow) Change(validator string, now time.Time, tokens string, rank int) (tokensDelta *big.Int, rankDelta int, span time.Duration, ok bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	samples := w.samples[validator]
+	if len(samples) == 0 {
+		return nil, 0, 0, false
+	}
+	baseline := samples[0]
+	cutoff := now.Add(-changeWindow)
+	for _, sample := range samples {
+		if sample.Time.After(cutoff) {
+			break
+		}
+		baseline = sample
+	}
+
+	current, okCurrent := new(big.Int).SetString(tokens, 10)
+	previous, okPrevious := new(big.Int).SetString(baseline.Tokens, 10)
+	if !okCurrent || !okPrevious {
+		return nil, 0, 0, false
+	}
+	span = now.Sub(baseline.Time)
+	if span > changeWindow {
+		span = changeWindow
+	}
+	return current.Sub(current, previous), rank - baseline.Rank, span, true
+}
+
+func (w *ChangeWindow) Export() map[string][]ChangeSample {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	exported := make(map[string][]ChangeSample, len(w.samples))
+	for validator, samples := range w.samples {
+		exported[validator] = append([]ChangeSample(nil), samples...)
+	}
+	return exported
+}
+
+func (w *ChangeWindow) Import(samples map[string][]ChangeSample) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.samples = make(map[string][]ChangeSample, len(samples))
+	for validator, list := range samples {
+		w.samples[validator] = append([]ChangeSample(nil), list...)
+	}
+}
+
+// 샘플 기록 후 24시간 변화량과 구간 커버리지 갱신 (순위 변화가 음수면 순위 상승)
+func (vt *UnifiedValidatorTracker) updateChangeMetrics(label string, now time.Time, tokens string, rank int) {
+	vt.changes.Add(label, ChangeSample{Time: now, Tokens: tokens, Rank: rank})
+	tokensDelta, rankDelta, span, ok := vt.changes.Change(label, now, tokens, rank)
+	if !ok {
+		return
+	}
+
+	delta, _ := new(big.Float).SetInt(tokensDelta).Float64()
+	vt.metrics.cosmos.tokensChangeMetric.WithLabelValues(label).Set(delta)
+	vt.metrics.cosmos.rankChangeMetric.WithLabelValues(label).Set(float64(rankDelta))
+	vt.metrics.cosmos.changeCoverageMetric.WithLabelValues(label).Set(span.Seconds() / changeWindow.Seconds())
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// 주소별 서명 시각(기준 시각으로부터 ms)으로 스캔 버퍼 구성
+func scanWithCommitTimes(offsets map[string]int) *signatureScan {
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	scan := &signatureScan{signedAt: make(map[string]int64)}
+	for address, ms := range offsets {
+		at := base + int64(ms)*int64(time.Millisecond)
+		scan.signedAt[address] = at
+		scan.times = append(scan.times, at)
+	}
+	return scan
+}
+
+func TestCommitLatencyQuantile(t *testing.T) {
+	ten := make(map[string]int)
+	for i, ms := range []int{70, 0, 30, 90, 10, 50, 20, 80, 60, 40} { // 섞인 순서
+		ten[fmt.Sprintf("V%d", i)] = ms
+	}
+	tests := []struct {
+		name    string
+		offsets map[string]int
+		q       float64
+		want    time.Duration
+	}{
+		{"no samples q=0", nil, 0, 0},
+		{"no samples q=0.5", nil, 0.5, 0},
+		{"no samples q=1", nil, 1, 0},
+		{"one sample q=0", map[string]int{"A": 100}, 0, 0},
+		{"one sample q=0.99", map[string]int{"A": 100}, 0.99, 0},
+		{"one sample q=1", map[string]int{"A": 100}, 1, 0},
+		{"ten samples q=0", ten, 0, 0},
+		{"ten samples q=0.11", ten, 0.11, 10 * time.Millisecond}, // ceil(1.1) = 2번째
+		{"ten samples q=0.5", ten, 0.5, 40 * time.Millisecond},   // ceil(5) = 5번째
+		{"ten samples q=0.9", ten, 0.9, 80 * time.Millisecond},
+		{"ten samples q=0.99", ten, 0.99, 90 * time.Millisecond},
+		{"ten samples q=1", ten, 1, 90 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scan := scanWithCommitTimes(tt.offsets)
+			if sorted := scan.sortCommitTimes(); sorted != (len(tt.offsets) > 0) {
+				t.Errorf("sortCommitTimes = %v with %d samples", sorted, len(tt.offsets))
+			}
+			if got := scan.commitLatencyQuantile(tt.q); got != tt.want {
+				t.Errorf("q=%v: %v, want %v", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+// 같은 시각에 서명한 벨리데이터는 같은 위치 (자신보다 늦지 않은 서명을 모두 셈)
+func TestCommitLatencyPercentileTies(t *testing.T) {
+	scan := scanWithCommitTimes(map[string]int{"A": 0, "B": 10, "C": 10, "D": 20})
+	scan.sortCommitTimes()
+	for address, want := range map[string]float64{"A": 25, "B": 75, "C": 75, "D": 100} {
+		if got, ok := scan.commitLatencyPercentile(address); !ok || got != want {
+			t.Errorf("%s = %v, %v; want %v", address, got, ok, want)
+		}
+	}
+	if _, ok := scan.commitLatencyPercentile("E"); ok {
+		t.Error("percentile reported for a validator that did not sign")
+	}
+
+	all := scanWithCommitTimes(map[string]int{"A": 5, "B": 5, "C": 5})
+	all.sortCommitTimes()
+	for _, address := range []string{"A", "B", "C"} {
+		if got, _ := all.commitLatencyPercentile(address); got != 100 {
+			t.Errorf("all tied: %s = %v, want 100", address, got)
+		}
+	}
+	if got := all.commitLatencyQuantile(0.99); got != 0 {
+		t.Errorf("all tied: p99 = %v, want 0", got)
+	}
+}
+
+// 블록마다 하는 정렬, 분위수 3개, 추적 벨리데이터 위치 조회
+func BenchmarkCommitLatency(b *testing.B) {
+	for _, n := range []int{200, 500, 1000} {
+		f := newScanFixture(b, rand.New(rand.NewSource(int64(n))), n)
+		scan := acquireSignatureScan()
+		scan.loadSignatures(f.block)
+		unsorted := append([]int64(nil), scan.times...)
+		tracked := make([]string, len(f.tracked))
+		for i, address := range f.tracked {
+			tracked[i] = normalizeAddress(address)
+		}
+		b.Run(fmt.Sprintf("validators=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(scan.times, unsorted)
+				scan.sortCommitTimes()
+				for _, quantile := range commitLatencyQuantiles {
+					scan.commitLatencyQuantile(quantile.q)
+				}
+				for _, address := range tracked {
+					scan.commitLatencyPercentile(address)
+				}
+			}
+		})
+		scan.release()
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RPC 호환성 검사 결과
+const (
+	rpcCompatCompatible  = "compatible"
+	rpcCompatUnknown     = "unknown"     // 버전을 조회/파싱하지 못함
+	rpcCompatUnsupported = "unsupported" // 호환성 표의 범위를 벗어남
+)
+
+// 호환되지 않을 때 동작 (RPC_COMPAT_MODE)
+const (
+	rpcCompatModeDegraded = "degraded" // 경고와 degraded 표시 후 계속 실행
+	rpcCompatModeRefuse   = "refuse"   // 시작 거부
+)
+
+// 응답 형식을 확인한 버전 범위 (Min 이상, Max 미만)
+type compatRange struct {
+	Component string
+	Min       semver
+	Max       semver
+}
+
+// 0G Galileo에서 확인한 CometBFT/0gchaind 버전
+var rpcCompatibility = []compatRange{
+	{Component: "cometbft", Min: semver{0, 37, 0}, Max: semver{0, 39, 0}},
+	{Component: "app", Min: semver{0, 4, 0}, Max: semver{4, 0, 0}},
+}
+
+type semver [3]int
+
+func (v semver) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+func (v semver) less(o semver) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+// "v0.38.12", "0.38.12-rc1", "v1.0.2+galileo" 같은 버전 문자열 파싱 (pre-release/build 표기는 무시)
+func parseSemver(value string) (semver, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "v")
+	if i := strings.IndexAny(value, "-+ "); i >= 0 {
+		value = value[:i]
+	}
+	parts := strings.Split(value, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return semver{}, false
+	}
+	var version semver
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return semver{}, false
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+// 컴포넌트 버전을 호환성 표와 비교
+func checkCompat(component, version string) string {
+	parsed, ok := parseSemver(version)
+	if !ok {
+		return rpcCompatUnknown
+	}
+	for _, entry := range rpcCompatibility {
+		if entry.Component != component {
+			continue
+		}
+		if parsed.less(entry.Min) || !parsed.less(entry.Max) {
+			return rpcCompatUnsupported
+		}
+		return rpcCompatCompatible
+	}
+	return rpcCompatUnknown
+}
+
+type ABCIInfoResponse struct {
+	Result struct {
+		Response struct {
+			Data    string `json:"data"`
+			Version string `json:"version"`
+		} `json:"response"`
+	} `json:"result"`
+}
+
+// 노드 버전 정보 (/api/targets에 노출)
+type NodeVersions struct {
+	CometBFT string `json:"cometbft"`
+	App      string `json:"app"`
+	AppName  string `json:"app_name,omitempty"`
+	Compat   string `json:"compat"`
+}
+
+func (vt *UnifiedValidatorTracker) fetchABCIInfo(ctx context.Context) (result *ABCIInfoResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("abci_info", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.endpoints.Selected()
+	url := fmt.Sprintf("%s/abci_info", endpoint)
+	var info ABCIInfoResponse
+	if err := vt.getJSON(ctx, "abci_info", endpoint, url, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// 노드의 CometBFT/앱 버전을 확인 (refuse 모드에서 지원하지 않는 버전이면 에러)
+// 시작 시 노드에 연결하지 못했으면 버전을 확인할 때까지 추적 주기마다 다시 시도
+func (vt *UnifiedValidatorTracker) checkRPCCompat(ctx context.Context) error {
+	versions := NodeVersions{Compat: rpcCompatUnknown}
+	if status, err := vt.fetchStatus(ctx); err != nil {
+		rpcLog.Warn("Could not detect CometBFT version", "error", err)
+	} else {
+		versions.CometBFT = sanitizeOptionalLabel(status.Result.NodeInfo.Version)
+	}
+	if info, err := vt.fetchABCIInfo(ctx); err != nil {
+		rpcLog.Warn("Could not detect app version", "error", err)
+	} else {
+		versions.App = sanitizeOptionalLabel(info.Result.Response.Version)
+		versions.AppName = sanitizeOptionalLabel(info.Result.Response.Data)
+	}
+
+	if versions.CometBFT != "" && versions.App != "" {
+		cometCompat, appCompat := checkCompat("cometbft", versions.CometBFT), checkCompat("app", versions.App)
+		switch {
+		case cometCompat == rpcCompatUnsupported || appCompat == rpcCompatUnsupported:
+			versions.Compat = rpcCompatUnsupported
+		case cometCompat == rpcCompatCompatible && appCompat == rpcCompatCompatible:
+			versions.Compat = rpcCompatCompatible
+		}
+	}
+
+	vt.mu.Lock()
+	vt.nodeVersions = versions
+	vt.mu.Unlock()
+	vt.metrics.exporter.rpcCompatMetric.Set(boolToFloat(versions.Compat == rpcCompatCompatible))
+
+	if versions.Compat == rpcCompatCompatible {
+		rpcLog.Info("Node versions are supported", "cometbft", versions.CometBFT, "app", versions.App)
+		return nil
+	}
+	if versions.Compat == rpcCompatUnsupported && vt.rpcCompatMode == rpcCompatModeRefuse {
+		return fmt.Errorf("unsupported node versions (cometbft %s, app %s); set RPC_COMPAT_MODE=degraded to run anyway",
+			versions.CometBFT, versions.App)
+	}
+	rpcLog.Error("DEGRADED: node versions are not in the compatibility table, responses may be misparsed",
+		"compat", versions.Compat, "cometbft", versions.CometBFT, "app", versions.App)
+	return nil
+}
+
+// 호환성을 확인하지 못했거나 지원하지 않는 버전이면 degraded
+func (vt *UnifiedValidatorTracker) Degraded() bool {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	return vt.nodeVersions.Compat != "" && vt.nodeVersions.Compat != rpcCompatCompatible
+}
+
+func (vt *UnifiedValidatorTracker) nodeVersionsSnapshot() NodeVersions {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	return vt.nodeVersions
+}
+
+// 버전 조회에 실패해 아직 확인하지 못했는지 여부
+func (vt *UnifiedValidatorTracker) versionsDetected() bool {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	return vt.nodeVersions.CometBFT != "" && vt.nodeVersions.App != ""
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		value string
+		want  semver
+		ok    bool
+	}{
+		{"0.38.12", semver{0, 38, 12}, true},
+		{"v0.38.17", semver{0, 38, 17}, true},
+		{"0.37.4", semver{0, 37, 4}, true},
+		{"0.38.0-rc3", semver{0, 38, 0}, true},
+		{"v1.0.2+galileo", semver{1, 0, 2}, true},
+		{"v3.0.4-0.20250311021510-8f2c6a8f4f2a", semver{3, 0, 4}, true}, // go 모듈 의사 버전
+		{" v0.4.1 \n", semver{0, 4, 1}, true},
+		{"1.0", semver{1, 0, 0}, true},
+		{"", semver{}, false},
+		{"v", semver{}, false},
+		{"main-5c0e9ea", semver{}, false}, // 태그 없이 빌드한 git describe
+		{"1", semver{}, false},
+		{"1.2.3.4", semver{}, false},
+		{"0.x.1", semver{}, false},
+		{"0.-1.1", semver{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSemver(tt.value)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseSemver(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCheckCompat(t *testing.T) {
+	tests := []struct {
+		component, version, want string
+	}{
+		{"cometbft", "0.38.12", rpcCompatCompatible},
+		{"cometbft", "0.37.0", rpcCompatCompatible},
+		{"cometbft", "0.38.99", rpcCompatCompatible},
+		{"cometbft", "0.36.9", rpcCompatUnsupported},
+		{"cometbft", "0.34.29", rpcCompatUnsupported}, // Tendermint 시절 응답 형식
+		{"cometbft", "0.39.0", rpcCompatUnsupported},  // 상한은 포함하지 않음
+		{"cometbft", "1.0.0", rpcCompatUnsupported},
+		{"cometbft", "", rpcCompatUnknown},
+		{"app", "v1.0.2", rpcCompatCompatible},
+		{"app", "0.4.0", rpcCompatCompatible},
+		{"app", "v3.0.4-testnet", rpcCompatCompatible},
+		{"app", "0.3.9", rpcCompatUnsupported},
+		{"app", "v4.0.0", rpcCompatUnsupported},
+		{"app", "main-5c0e9ea", rpcCompatUnknown},
+		{"evm", "1.0.0", rpcCompatUnknown}, // 표에 없는 컴포넌트
+	}
+	for _, tt := range tests {
+		if got := checkCompat(tt.component, tt.version); got != tt.want {
+			t.Errorf("checkCompat(%q, %q) = %q, want %q", tt.component, tt.version, got, tt.want)
+		}
+	}
+}
+
+// /status와 /abci_info만 응답하는 노드
+func newVersionTracker(t *testing.T, cometVersion, appVersion, mode string) *UnifiedValidatorTracker {
+	t.Helper()
+	tracker := newServedTracker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/status":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":-1,"result":{"node_info":{"network":"zgtendermint_16601-2","version":%q,"moniker":"node"},"sync_info":{"latest_block_height":"100","catching_up":false}}}`, cometVersion)
+		case "/abci_info":
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":-1,"result":{"response":{"data":"0gchaind","version":%q,"last_block_height":"100"}}}`, appVersion)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	tracker.rpcCompatMode = mode
+	return tracker
+}
+
+func TestCheckRPCCompatModes(t *testing.T) {
+	tests := []struct {
+		name         string
+		comet, app   string
+		mode         string
+		wantErr      bool
+		wantCompat   string
+		wantDegraded bool
+	}{
+		{"supported", "0.38.12", "v1.0.2", rpcCompatModeRefuse, false, rpcCompatCompatible, false},
+		{"unsupported degraded", "0.34.29", "v1.0.2", rpcCompatModeDegraded, false, rpcCompatUnsupported, true},
+		{"unsupported refuse", "0.34.29", "v1.0.2", rpcCompatModeRefuse, true, rpcCompatUnsupported, true},
+		// 버전을 알 수 없으면 refuse 모드에서도 degraded로 계속 실행
+		{"unknown refuse", "0.38.12", "main-5c0e9ea", rpcCompatModeRefuse, false, rpcCompatUnknown, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newVersionTracker(t, tt.comet, tt.app, tt.mode)
+			err := tracker.checkRPCCompat(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkRPCCompat error = %v, want error %v", err, tt.wantErr)
+			}
+			if got := tracker.nodeVersionsSnapshot().Compat; got != tt.wantCompat {
+				t.Errorf("compat = %q, want %q", got, tt.wantCompat)
+			}
+			if got := tracker.Degraded(); got != tt.wantDegraded {
+				t.Errorf("Degraded = %v, want %v", got, tt.wantDegraded)
+			}
+			if got := testutil.ToFloat64(tracker.metrics.exporter.rpcCompatMetric); got != boolToFloat(!tt.wantDegraded) {
+				t.Errorf("og_galileo_exporter_rpc_compat = %v", got)
+			}
+		})
+	}
+}
+
+// refuse 모드에서 지원하지 않는 버전이면 프로세스를 바로 끝내지 않고 루트 컨텍스트를 취소
+func TestCollectBlocksStopsOnUnsupportedVersion(t *testing.T) {
+	tracker := newVersionTracker(t, "0.34.29", "v1.0.2", rpcCompatModeRefuse)
+	ctx, stop := context.WithCancelCause(context.Background())
+	defer stop(nil)
+	tracker.stop = stop
+
+	err := tracker.collectBlocks(ctx)
+	if err == nil {
+		t.Fatal("collectBlocks succeeded with unsupported node versions")
+	}
+	if ctx.Err() == nil {
+		t.Fatal("root context not cancelled")
+	}
+	if got := tracker.stopError(ctx); got != err {
+		t.Errorf("stopError = %v, want %v", got, err)
+	}
+}
+
+// 시그널로 인한 종료는 오류로 보지 않음
+func TestStopErrorOnSignal(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{})
+	signalCtx, cancel := context.WithCancel(context.Background())
+	ctx, stop := context.WithCancelCause(signalCtx)
+	defer stop(nil)
+	tracker.stop = stop
+
+	cancel()
+	if err := tracker.stopError(ctx); err != nil {
+		t.Errorf("stopError after a signal = %v, want nil", err)
+	}
+
+	// Stop이 먼저 호출됐으면 그 오류를 유지
+	ctx, stop = context.WithCancelCause(context.Background())
+	tracker.stop = stop
+	stopErr := fmt.Errorf("unsupported node versions")
+	tracker.Stop(stopErr)
+	stop(nil)
+	if err := tracker.stopError(ctx); err != stopErr {
+		t.Errorf("stopError = %v, want %v", err, stopErr)
+	}
+}
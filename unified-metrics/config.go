@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"og-galileo-unified-metrics/internal/confparse"
+)
+
+// 설정 파일 (--config=config.yaml 또는 config.toml)
+// 파일에 있는 값이 같은 이름의 환경 변수보다 우선하고, 파일에 없는 값은 환경 변수와 기본값을 그대로 사용
+type Config struct {
+	RPCEndpoint      string            `json:"rpc_endpoint,omitempty"`
+	RESTEndpoint     string            `json:"rest_endpoint,omitempty"` // 비어 있으면 RPC 엔드포인트로 REST 조회
+	OGNodeMetricsURL string            `json:"og_node_metrics_url,omitempty"`
+	NodeExporterURL  string            `json:"node_exporter_url,omitempty"`
+	ListenAddr       string            `json:"listen_addr,omitempty"`
+	ScrapeInterval   string            `json:"scrape_interval,omitempty"`
+	Validators       map[string]string `json:"validators,omitempty"` // 합의 주소 -> 라벨
+	Renames          map[string]string `json:"renames,omitempty"`    // 이전 라벨 -> 새 라벨 (LABEL_RENAMES)
+	Env              map[string]string `json:"env,omitempty"`        // 그 밖의 환경 변수 (이름 그대로)
+	Probes           []ProbeConfig     `json:"probes,omitempty"`     // PROBES (목록)
+
+	validatorConfigs []ValidatorConfig // validators 항목 (운영자 주소, 모니커 포함)
+}
+
+// 설정 파일 키 -> 같은 의미의 환경 변수
+var configEnvKeys = []struct {
+	key string
+	env string
+}{
+	{"rpc_endpoint", "RPC_ENDPOINT"},
+	{"rest_endpoint", "REST_ENDPOINT"},
+	{"og_node_metrics_url", "OG_NODE_METRICS_URL"},
+	{"node_exporter_url", "NODE_EXPORTER_URL"},
+	{"listen_addr", "LISTEN_ADDR"},
+	{"scrape_interval", "POLL_INTERVAL"},
+}
+
+// 확장자(.yaml, .yml, .toml)로 형식을 골라 설정 파일 읽기
+func loadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc confparse.Document
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		doc, err = confparse.ParseYAML(data)
+	case ".toml":
+		doc, err = confparse.ParseTOML(data)
+	default:
+		return nil, fmt.Errorf("%s: unsupported config format (expected .yaml, .yml or .toml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	config, err := configFromDocument(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+func configFromDocument(doc confparse.Document) (*Config, error) {
+	config := &Config{}
+	fields := map[string]*string{
+		"rpc_endpoint":        &config.RPCEndpoint,
+		"rest_endpoint":       &config.RESTEndpoint,
+		"og_node_metrics_url": &config.OGNodeMetricsURL,
+		"node_exporter_url":   &config.NodeExporterURL,
+		"listen_addr":         &config.ListenAddr,
+		"scrape_interval":     &config.ScrapeInterval,
+	}
+	for key, value := range doc {
+		switch key {
+		case "validators":
+			table, ok := value.(confparse.Document)
+			if !ok {
+				return nil, fmt.Errorf("validators must be a table")
+			}
+			validators, err := validatorConfigsFromMap(table)
+			if err != nil {
+				return nil, err
+			}
+			config.validatorConfigs = validators
+			config.Validators = validatorLabels(validators)
+		case "env":
+			env, err := configStringMap(key, value)
+			if err != nil {
+				return nil, err
+			}
+			config.Env = env
+		case "renames":
+			renames, err := configStringMap(key, value)
+			if err != nil {
+				return nil, err
+			}
+			config.Renames = renames
+		case "probes":
+			probes, err := probeConfigsFromList(value)
+			if err != nil {
+				return nil, err
+			}
+			config.Probes = probes
+		default:
+			field, ok := fields[key]
+			if !ok {
+				return nil, fmt.Errorf("unknown key %q", key)
+			}
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a single value", key)
+			}
+			*field = text
+		}
+	}
+	if config.ScrapeInterval != "" {
+		if interval, err := time.ParseDuration(config.ScrapeInterval); err != nil || interval <= 0 {
+			return nil, fmt.Errorf("scrape_interval: invalid duration %q", config.ScrapeInterval)
+		}
+	}
+	return config, nil
+}
+
+func configStringMap(key string, value interface{}) (map[string]string, error) {
+	table, ok := value.(confparse.Document)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a table", key)
+	}
+	result := make(map[string]string, len(table))
+	for name, item := range table {
+		text, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s.%s must be a single value", key, name)
+		}
+		result[name] = text
+	}
+	return result, nil
+}
+
+// probes 목록의 각 항목 (파일 값은 모두 문자열이므로 숫자, 불리언, 기간을 여기서 변환)
+// 이름, URL 검증과 기본값은 PROBES와 같은 경로(probesFromEnv)에서 처리
+func probeConfigsFromList(value interface{}) ([]ProbeConfig, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("probes must be a list")
+	}
+	probes := make([]ProbeConfig, 0, len(items))
+	for i, item := range items {
+		fields, err := configStringMap(fmt.Sprintf("probes[%d]", i), item)
+		if err != nil {
+			return nil, err
+		}
+		var probe ProbeConfig
+		for name, text := range fields {
+			switch name {
+			case "name":
+				probe.Name = text
+			case "url":
+				probe.URL = text
+			case "expected_status":
+				status, err := strconv.Atoi(text)
+				if err != nil {
+					return nil, fmt.Errorf("probes[%d].expected_status: invalid status %q", i, text)
+				}
+				probe.ExpectedStatus = status
+			case "interval", "timeout":
+				duration, err := time.ParseDuration(text)
+				if err != nil {
+					return nil, fmt.Errorf("probes[%d].%s: invalid duration %q", i, name, text)
+				}
+				if name == "interval" {
+					probe.Interval = jsonDuration(duration)
+				} else {
+					probe.Timeout = jsonDuration(duration)
+				}
+			case "insecure_skip_verify":
+				skip, err := strconv.ParseBool(text)
+				if err != nil {
+					return nil, fmt.Errorf("probes[%d].insecure_skip_verify: invalid boolean %q", i, text)
+				}
+				probe.InsecureSkipVerify = skip
+			default:
+				return nil, fmt.Errorf("probes[%d]: unknown key %q", i, name)
+			}
+		}
+		probes = append(probes, probe)
+	}
+	return probes, nil
+}
+
+// 환경 변수 헬퍼가 먼저 참조할 값 (env 섹션과 전용 키가 겹치면 전용 키 우선)
+func (c *Config) settings() map[string]string {
+	settings := make(map[string]string, len(c.Env)+len(configEnvKeys))
+	for key, value := range c.Env {
+		settings[key] = value
+	}
+	values := map[string]string{
+		"rpc_endpoint":        c.RPCEndpoint,
+		"rest_endpoint":       c.RESTEndpoint,
+		"og_node_metrics_url": c.OGNodeMetricsURL,
+		"node_exporter_url":   c.NodeExporterURL,
+		"listen_addr":         c.ListenAddr,
+		"scrape_interval":     c.ScrapeInterval,
+	}
+	for _, entry := range configEnvKeys {
+		if value := values[entry.key]; value != "" {
+			settings[entry.env] = value
+		}
+	}
+	if len(c.Renames) > 0 {
+		settings["LABEL_RENAMES"] = formatLabelRenames(c.Renames)
+	}
+	if len(c.Probes) > 0 {
+		data, _ := json.Marshal(c.Probes)
+		settings["PROBES"] = string(data)
+	}
+	return settings
+}
+
+// --validate-config 출력용: 비밀 환경 변수 값은 가림
+func (c *Config) redacted() *Config {
+	copied := *c
+	if len(c.Env) > 0 {
+		copied.Env = make(map[string]string, len(c.Env))
+		for key, value := range c.Env {
+			if isSecretEnvKey(key) {
+				value = "<redacted>"
+			}
+			copied.Env[key] = value
+		}
+	}
+	return &copied
+}
+
+func isSecretEnvKey(key string) bool {
+	for _, entry := range diagnosticEnvKeys {
+		if entry.key == key {
+			return entry.secret
+		}
+	}
+	return false
+}
+
+// 플래그 기본값이 설정 파일을 반영해야 하므로 flag.Parse 전에 --config 값을 먼저 찾음 (없으면 CONFIG_FILE)
+func configPathFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv("CONFIG_FILE")
+}
+
+// --validate-config: 파싱한 설정 파일과 실제로 적용될 설정(환경 변수 포함)을 JSON으로 출력
+func printConfig(config *Config, validators map[string]string) {
+	output := struct {
+		File       *Config           `json:"file,omitempty"`
+		Validators map[string]string `json:"validators"`
+		Effective  map[string]string `json:"effective"`
+	}{Validators: validators, Effective: configSummaryFromEnv()}
+	if config != nil {
+		output.File = config.redacted()
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(output)
+}
+
+// 설정 파일의 키 목록 (시작 로그용)
+func (c *Config) keys() []string {
+	var keys []string
+	for key := range c.settings() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 목록 형태의 probes가 PROBES와 같은 경로로 적용됨
+func TestConfigFileProbes(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": `
+listen_addr: :8080
+probes:
+  - name: grafana
+    url: http://grafana:3000/api/health
+  - name: prometheus
+    url: http://prometheus:9090/-/ready
+    expected_status: 204
+    interval: 1m
+    timeout: 2s
+    insecure_skip_verify: true
+`,
+		"config.toml": `
+listen_addr = ":8080"
+
+[[probes]]
+name = "grafana"
+url = "http://grafana:3000/api/health"
+
+[[probes]]
+name = "prometheus"
+url = "http://prometheus:9090/-/ready"
+expected_status = 204
+interval = "1m"
+timeout = "2s"
+insecure_skip_verify = true
+`,
+	}
+	want := []ProbeConfig{
+		{Name: "grafana", URL: "http://grafana:3000/api/health", ExpectedStatus: 200,
+			Interval: jsonDuration(defaultProbeInterval), Timeout: jsonDuration(defaultProbeTimeout)},
+		{Name: "prometheus", URL: "http://prometheus:9090/-/ready", ExpectedStatus: 204,
+			Interval: jsonDuration(time.Minute), Timeout: jsonDuration(2 * time.Second), InsecureSkipVerify: true},
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			config, err := loadConfigFile(writeConfigFile(t, name, content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(config.Probes) != 2 {
+				t.Fatalf("parsed %d probes, want 2", len(config.Probes))
+			}
+
+			previous := fileSettings
+			fileSettings = config.settings()
+			t.Cleanup(func() { fileSettings = previous })
+			probes, err := probesFromEnv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(probes, want) {
+				t.Errorf("probes = %+v, want %+v", probes, want)
+			}
+		})
+	}
+}
+
+func TestConfigFileProbeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"not a list", "probes:\n  name: a\n", "probes must be a list"},
+		{"scalar item", "probes:\n  - http://a\n", "probes[0] must be a table"},
+		{"unknown key", "probes:\n  - name: a\n    method: POST\n", `probes[0]: unknown key "method"`},
+		{"bad status", "probes:\n  - name: a\n    expected_status: ok\n", "probes[0].expected_status"},
+		{"bad interval", "probes:\n  - name: a\n  - name: b\n    interval: soon\n", "probes[1].interval"},
+		{"bad boolean", "probes:\n  - name: a\n    insecure_skip_verify: maybe\n", "probes[0].insecure_skip_verify"},
+		{"nested value", "probes:\n  - name: [a]\n", "probes[0].name must be a single value"},
+		{"list for a scalar key", "listen_addr: [a, b]\n", "listen_addr must be a single value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfigFile(writeConfigFile(t, "config.yaml", tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// /api/* 전용 CORS 설정 (허용 origin이 없으면 비활성화)
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	MaxAge           time.Duration
+	AllowCredentials bool
+}
+
+func corsConfigFromEnv() (*CORSConfig, error) {
+	config := &CORSConfig{
+		AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS", nil),
+		AllowedMethods:   getEnvList("CORS_ALLOWED_METHODS", []string{"GET", "POST", "OPTIONS"}),
+		AllowedHeaders:   getEnvList("CORS_ALLOWED_HEADERS", []string{"Authorization", "Content-Type"}),
+		MaxAge:           getEnvDuration("CORS_MAX_AGE", 10*time.Minute),
+		AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *CORSConfig) Validate() error {
+	if c.AllowCredentials && c.allowsAnyOrigin() {
+		return errors.New("CORS wildcard origin cannot be combined with credentialed requests")
+	}
+	return nil
+}
+
+func (c *CORSConfig) enabled() bool {
+	return c != nil && len(c.AllowedOrigins) > 0
+}
+
+func (c *CORSConfig) allowsAnyOrigin() bool {
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORSConfig) allowsOrigin(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS 헤더 추가 및 preflight(OPTIONS) 처리
+func (c *CORSConfig) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	if !c.enabled() {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		if origin == "" {
+			next(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		if !c.allowsOrigin(origin) {
+			if preflight {
+				http.Error(w, "origin not allowed", http.StatusForbidden)
+				return
+			}
+			next(w, r)
+			return
+		}
+
+		if c.allowsAnyOrigin() {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if c.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(c.AllowedMethods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(c.AllowedHeaders, ", "))
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge.Seconds())))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next(w, r)
+	}
+}
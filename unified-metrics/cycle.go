@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPollInterval           = 5 * time.Second
+	minPollInterval               = 500 * time.Millisecond // 이보다 짧으면 RPC만 낭비하고 블록 시간보다 빨라짐
+	defaultCycleOverrunAlertRatio = 0.5
+	cycleOverrunWindow            = 5 * time.Minute
+	minCyclesForOverrunAlert      = 10 // 시작 직후 몇 주기만으로 경고하지 않도록
+)
+
+// 주기별 overrun 여부 기록
+type cycleRecord struct {
+	at      time.Time
+	overrun bool
+}
+
+// 추적 주기가 폴링 간격을 넘기는 비율을 최근 cycleOverrunWindow 동안 계산
+type CycleMonitor struct {
+	interval   time.Duration
+	alertRatio float64
+	mu         sync.Mutex
+	records    []cycleRecord
+	alerting   bool
+}
+
+// POLL_INTERVAL 읽기: 기본값과 달리 잘못된 값은 경고 후 무시하지 않고 시작을 막음
+func pollIntervalFromEnv() (time.Duration, error) {
+	value := getEnv("POLL_INTERVAL", "")
+	if value == "" {
+		return defaultPollInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid POLL_INTERVAL %q: %w", value, err)
+	}
+	return interval, validatePollInterval(interval)
+}
+
+func validatePollInterval(interval time.Duration) error {
+	if interval < minPollInterval {
+		return fmt.Errorf("poll interval %s must be at least %s", interval, minPollInterval)
+	}
+	return nil
+}
+
+func NewCycleMonitor(interval time.Duration, alertRatio float64) *CycleMonitor {
+	return &CycleMonitor{interval: interval, alertRatio: alertRatio}
+}
+
+// 주기 결과 반영 후 최근 구간의 overrun 비율과 경고 상태 변화 반환
+// changed가 true면 alerting이 새 상태
+func (m *CycleMonitor) Observe(now time.Time, overrun bool) (ratio float64, alerting, changed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records = append(m.records, cycleRecord{at: now, overrun: overrun})
+	cutoff := now.Add(-cycleOverrunWindow)
+	i := 0
+	for i < len(m.records) && m.records[i].at.Before(cutoff) {
+		i++
+	}
+	m.records = m.records[i:]
+
+	overruns := 0
+	for _, record := range m.records {
+		if record.overrun {
+			overruns++
+		}
+	}
+	ratio = float64(overruns) / float64(len(m.records))
+
+	next := m.alerting
+	if ratio > m.alertRatio && len(m.records) >= minCyclesForOverrunAlert {
+		next = true
+	} else if ratio <= m.alertRatio {
+		next = false
+	}
+	changed = next != m.alerting
+	m.alerting = next
+	return ratio, next, changed
+}
+
+// overrun 원인: 주기 시간의 절반 이상을 RPC/REST 응답 대기에 썼으면 rpc, 아니면 processing
+func overrunReason(total, rpc time.Duration) string {
+	if rpc*2 >= total {
+		return "rpc"
+	}
+	return "processing"
+}
+
+func (vt *UnifiedValidatorTracker) observeCycle(start time.Time, total, rpc time.Duration) {
+	overrun := total > vt.cycles.interval
+	if overrun {
+		reason := overrunReason(total, rpc)
+		vt.metrics.exporter.cycleOverrunsMetric.WithLabelValues(reason).Inc()
+		trackerLog.Debug("Tracking cycle overran poll interval", "duration", total, "rpc", rpc,
+			"interval", vt.cycles.interval, "reason", reason)
+	}
+
+	ratio, alerting, changed := vt.cycles.Observe(start, overrun)
+	vt.metrics.exporter.cycleOverrunRatioMetric.Set(ratio)
+	if !changed {
+		return
+	}
+	if !alerting {
+		trackerLog.Info("Tracking cycles are keeping up with the poll interval again", "overrun_ratio", ratio)
+		return
+	}
+
+	message := fmt.Sprintf("%.0f%% of tracking cycles in the last %s exceeded the %s poll interval (last cycle %s, %s in RPC)",
+		ratio*100, cycleOverrunWindow, vt.cycles.interval, total.Round(time.Millisecond), rpc.Round(time.Millisecond))
+	trackerLog.Warn("Exporter is falling behind the poll interval", "overrun_ratio", ratio, "threshold", vt.cycles.alertRatio)
+	vt.events.Publish(Event{Type: EventCycleOverrun, Height: vt.LastHeight(), Message: message})
+	if len(vt.notifiers) > 0 {
+		vt.notifyAsync(Message{
+			Title:    "Exporter falling behind poll interval",
+			Markdown: message,
+		})
+	}
+}
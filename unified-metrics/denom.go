@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math/big"
+	"strings"
+)
+
+// 토큰 단위 메타데이터 (base 단위 정수 금액을 display 단위로 환산)
+type DenomMetadata struct {
+	Base     string `json:"base"`     // 체인이 사용하는 최소 단위 (예: ua0gi)
+	Display  string `json:"display"`  // 사람이 읽는 단위 (예: a0gi)
+	Exponent int    `json:"exponent"` // display = base / 10^Exponent
+}
+
+// 0G 갈릴레오 기본 단위
+var galileoDenom = DenomMetadata{Base: "ua0gi", Display: "a0gi", Exponent: 18}
+
+// base 단위 금액 (정수 또는 DecCoin 소수 문자열)을 float64로 변환
+// big.Rat에서 한 번만 반올림하므로 ParseFloat 경유보다 오차가 누적되지 않음
+func (d DenomMetadata) Raw(amount string) (float64, bool) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return 0, false
+	}
+	f, _ := value.Float64()
+	return f, true
+}
+
+// base 단위 금액을 display 단위로 변환 (나눗셈은 정확한 유리수 연산 후 마지막에만 반올림)
+func (d DenomMetadata) ToDisplay(amount string) (float64, bool) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return 0, false
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Exponent)), nil)
+	value.Quo(value, new(big.Rat).SetInt(scale))
+	f, _ := value.Float64()
+	return f, true
+}
+
+// 같은 금액을 raw/display 게이지에 함께 설정
+func (d DenomMetadata) setGauges(raw, display interface{ Set(float64) }, amount string) bool {
+	rawValue, ok := d.Raw(amount)
+	if !ok {
+		return false
+	}
+	displayValue, _ := d.ToDisplay(amount)
+	raw.Set(rawValue)
+	display.Set(displayValue)
+	return true
+}
+
+// DecCoins에서 base denom 금액만 추출
+func (d DenomMetadata) amountOf(coins []DecCoin) string {
+	for _, coin := range coins {
+		if coin.Denom == d.Base {
+			return coin.Amount
+		}
+	}
+	return "0"
+}
+
+type DecCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
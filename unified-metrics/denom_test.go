@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDenomConversions(t *testing.T) {
+	tests := []struct {
+		amount  string
+		raw     float64
+		display float64
+	}{
+		{"0", 0, 0},
+		{"1", 1, 1e-18},
+		{"1000000000000000000", 1e18, 1},
+		{"999999999999999999", 999999999999999999, 0.999999999999999999},
+		{"123456789012345678", 123456789012345678, 0.123456789012345678},
+		{"2500000000000000000000", 2.5e21, 2500},
+		{"123456789012345678901234567", 123456789012345678901234567, 123456789.012345678901234567},
+		// DecCoin 소수 문자열 (분배 보상, 수수료)
+		{"1234567890123456789.123456789012345678", 1234567890123456789.123456789012345678, 1.234567890123456789123456789012345678},
+		{"0.000000000000000001", 1e-18, 1e-36},
+		{" 42000000000000000000 ", 4.2e19, 42},
+		{"-1000000000000000000", -1e18, -1},
+	}
+	for _, tt := range tests {
+		raw, ok := galileoDenom.Raw(tt.amount)
+		if !ok || raw != tt.raw {
+			t.Errorf("Raw(%q) = %v, %v, want %v", tt.amount, raw, ok, tt.raw)
+		}
+		display, ok := galileoDenom.ToDisplay(tt.amount)
+		if !ok || display != tt.display {
+			t.Errorf("ToDisplay(%q) = %v, %v, want %v", tt.amount, display, ok, tt.display)
+		}
+	}
+}
+
+// display 값은 소수점을 18자리 옮긴 10진수 문자열을 한 번 반올림한 값과 같아야 함
+// 18자리 금액에서 ParseFloat(amount) / 1e18처럼 두 번 반올림하면 마지막 자리가 달라질 수 있음
+func TestDenomDisplayRoundsOnce(t *testing.T) {
+	amounts := []string{
+		"9007199254740993",
+		"123456789012345678",
+		"100000000000000001",
+		"333333333333333333",
+		"777777777777777777777",
+		"18446744073709551617",
+		"1000000000000000000000000001",
+	}
+	for _, amount := range amounts {
+		want, err := strconv.ParseFloat(shiftDecimal(amount, galileoDenom.Exponent), 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := galileoDenom.ToDisplay(amount); !ok || got != want {
+			t.Errorf("ToDisplay(%q) = %v, want %v", amount, got, want)
+		}
+	}
+}
+
+// 정수 문자열의 소수점을 왼쪽으로 places자리 옮김
+func shiftDecimal(amount string, places int) string {
+	if len(amount) <= places {
+		amount = strings.Repeat("0", places-len(amount)+1) + amount
+	}
+	return amount[:len(amount)-places] + "." + amount[len(amount)-places:]
+}
+
+func TestDenomInvalidAmounts(t *testing.T) {
+	for _, amount := range []string{"", " ", "abc", "1.2.3", "12a0gi", "1/0"} {
+		if _, ok := galileoDenom.Raw(amount); ok {
+			t.Errorf("Raw(%q) accepted", amount)
+		}
+		if _, ok := galileoDenom.ToDisplay(amount); ok {
+			t.Errorf("ToDisplay(%q) accepted", amount)
+		}
+	}
+}
+
+type recordedGauge struct {
+	value float64
+	set   bool
+}
+
+func (g *recordedGauge) Set(value float64) { g.value, g.set = value, true }
+
+func TestDenomSetGauges(t *testing.T) {
+	var raw, display recordedGauge
+	if !galileoDenom.setGauges(&raw, &display, "1500000000000000000") {
+		t.Fatal("setGauges rejected a valid amount")
+	}
+	if raw.value != 1.5e18 || display.value != 1.5 {
+		t.Errorf("raw = %v, display = %v", raw.value, display.value)
+	}
+
+	// 잘못된 금액이면 이전 값을 그대로 둠
+	if galileoDenom.setGauges(&raw, &display, "not a number") {
+		t.Error("setGauges accepted an invalid amount")
+	}
+	if raw.value != 1.5e18 || display.value != 1.5 {
+		t.Errorf("gauges changed on invalid amount: raw = %v, display = %v", raw.value, display.value)
+	}
+}
+
+func TestDenomAmountOf(t *testing.T) {
+	coins := []DecCoin{{Denom: "uatom", Amount: "5"}, {Denom: "ua0gi", Amount: "1234567890123456789.5"}}
+	if got := galileoDenom.amountOf(coins); got != "1234567890123456789.5" {
+		t.Errorf("amountOf = %q", got)
+	}
+	if got := galileoDenom.amountOf([]DecCoin{{Denom: "uatom", Amount: "5"}}); got != "0" {
+		t.Errorf("amountOf without base denom = %q, want 0", got)
+	}
+	if got := galileoDenom.amountOf(nil); got != "0" {
+		t.Errorf("amountOf(nil) = %q, want 0", got)
+	}
+}
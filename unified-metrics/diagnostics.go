@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// fetch 종류별 마지막 성공/실패 기록
+type EndpointStatus struct {
+	LastSuccess time.Time `json:"last_success,omitempty"`
+	LastError   time.Time `json:"last_error,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	ErrorReason string    `json:"error_reason,omitempty"` // fetchErrorReason 값 (timeout, decode, ...)
+	Successes   int64     `json:"successes"`
+	Failures    int64     `json:"failures"`
+}
+
+type ValidatorDiagnostics struct {
+	MissStreak   int `json:"miss_streak"`
+	SignedBlocks int `json:"signed_blocks"`
+	MissedBlocks int `json:"missed_blocks"`
+}
+
+type MemoryDiagnostics struct {
+	AllocBytes     uint64 `json:"alloc_bytes"`
+	HeapInuseBytes uint64 `json:"heap_inuse_bytes"`
+	SysBytes       uint64 `json:"sys_bytes"`
+	NumGC          uint32 `json:"num_gc"`
+}
+
+// SIGUSR1 덤프와 /debug/state가 공유하는 진단 상태
+type DiagnosticState struct {
+	Time            time.Time                       `json:"time"`
+	Config          map[string]string               `json:"config"`
+	ChainID         string                          `json:"chain_id"`
+	LastBlockHeight int64                           `json:"last_block_height"`
+	ProcessedBlocks int                             `json:"processed_blocks"`
+	Endpoints       map[string]EndpointStatus       `json:"endpoints"`
+	Validators      map[string]ValidatorDiagnostics `json:"validators"`
+	Goroutines      int                             `json:"goroutines"`
+	Components      map[string]int                  `json:"component_goroutines"` // 구성 요소별 고루틴 수
+	Memory          MemoryDiagnostics               `json:"memory"`
+}
+
+// 설정 요약에 포함할 환경 변수 (true면 비밀 값)
+var diagnosticEnvKeys = []struct {
+	key    string
+	secret bool
+}{
+	{"RPC_ENDPOINT", false},
+	{"REST_ENDPOINT", false},
+	{"VALIDATORS", false},
+	{"VALIDATORS_FILE", false},
+	{"RPC_ENDPOINTS", false},
+	{"RPC_ENDPOINT_PIN", false},
+	{"RPC_ENDPOINT_MAX_LAG", false},
+	{"RPC_ENDPOINT_EVAL_INTERVAL", false},
+	{"RPC_FAILOVER_THRESHOLD", false},
+	{"HTTP_TIMEOUT", false},
+	{"HTTP_MAX_ATTEMPTS", false},
+	{"RPC_MODE", false},
+	{"STAKING_PARAMS_INTERVAL", false},
+	{"SLASHING_PARAMS_INTERVAL", false},
+	{"GOVERNANCE_INTERVAL", false},
+	{"MISS_RATE_EWMA_ALPHA", false},
+	{"MISS_RATE_EWMA_HALF_LIFE", false},
+	{"HEALTH_SCORE_WEIGHTS", false},
+	{"POLL_INTERVAL", false},
+	{"POLL_MODE", false},
+	{"CYCLE_OVERRUN_ALERT_RATIO", false},
+	{"PROCESSED_LAG_ALERT_BLOCKS", false},
+	{"PROCESSED_LAG_ALERT_AFTER", false},
+	{"METRIC_SOURCES_AUTH", true},
+	{"METRIC_SOURCES_AUTH_FILE", false},
+	{"DRY_RUN", false},
+	{"RPC_COMPAT_MODE", false},
+	{"NETWORK_TOP_N", false},
+	{"VERIFY_ENDPOINT", false},
+	{"VERIFY_SAMPLE_RATE", false},
+	{"LISTEN_ADDR", false},
+	{"LISTEN_SOCKET_MODE", false},
+	{"NODE_EXPORTER_URL", false},
+	{"OG_NODE_METRICS_URL", false},
+	{"LABEL_RETENTION_BLOCKS", false},
+	{"LABEL_RENAMES", false},
+	{"PROPOSER_WINDOW", false},
+	{"DENOM_BASE", false},
+	{"DENOM_DISPLAY", false},
+	{"DENOM_EXPONENT", false},
+	{"SOURCE_STALE_AFTER", false},
+	{"PROBES", false},
+	{"PROBES_FILE", false},
+	{"PEER_CHECKS", false},
+	{"PEER_CHECKS_FILE", false},
+	{"NODES", false},
+	{"NODES_FILE", false},
+	{"NODE_MAX_LAG", false},
+	{"PEER_DISCONNECT_ALERT_AFTER", false},
+	{"FEE_WINDOW_BLOCKS", false},
+	{"FEE_REFRESH_INTERVAL", false},
+	{"EVM_RPC_ENDPOINT", false},
+	{"SINK_URL", true},
+	{"SINK_TOPIC", false},
+	{"SINK_TIMEOUT", false},
+	{"SINK_REDIS_MAXLEN", false},
+	{"SINK_BUFFER_MAX", false},
+	{"SINK_RETRY_INTERVAL", false},
+	{"ARCHIVE_EVERY", false},
+	{"ARCHIVE_FORMAT", false},
+	{"ARCHIVE_DIR", false},
+	{"ARCHIVE_PREFIX", false},
+	{"ARCHIVE_S3_ENDPOINT", false},
+	{"ARCHIVE_S3_BUCKET", false},
+	{"ARCHIVE_S3_REGION", false},
+	{"ARCHIVE_S3_ACCESS_KEY", true},
+	{"ARCHIVE_S3_SECRET_KEY", true},
+	{"ARCHIVE_QUEUE_MAX", false},
+	{"ARCHIVE_RETRY_INTERVAL", false},
+	{"PRICE_FEEDS", true},
+	{"PRICE_FEEDS_FILE", false},
+	{"COLLECTOR_INTERVALS", false},
+	{"COLLECTOR_JITTER", false},
+	{"JANITOR_INTERVAL", false},
+	{"HISTORY_RETENTION", false},
+	{"HISTORY_MAX_ROWS", false},
+	{"HISTORY_PRUNE_INTERVAL", false},
+	{"STATE_FILE", false},
+	{"STATE_SAVE_INTERVAL", false},
+	{"CATCHUP_MAX_BLOCKS", false},
+	{"BACKFILL_MAX_BLOCKS", false},
+	{"UPTIME_WINDOWS", false},
+	{"MEMPOOL_MAX_TXS", false},
+	{"READY_MAX_STALENESS", false},
+	{"REPORT_SCHEDULE", false},
+	{"REPORT_FORMAT", false},
+	{"SSE_MAX_SUBSCRIBERS", false},
+	{"CHAIN_HALT_WINDOW", false},
+	{"CHAIN_RESET_HEIGHT_DROP", false},
+	{"DIAG_DUMP_DIR", false},
+	{"LOG_LEVEL", false},
+	{"LOG_LEVELS", false},
+	{"LOG_LEVELS_FILE", false},
+	{"LOG_DEDUP_WINDOW", false},
+	{"ALL_METRICS_MAX_INFLIGHT", false},
+	{"METRIC_DEDUP_MODE", false},
+	{"RATE_LIMIT_RPS", false},
+	{"RATE_LIMIT_BURST", false},
+	{"CORS_ALLOWED_ORIGINS", false},
+	{"CORS_ALLOW_CREDENTIALS", false},
+	{"ADMIN_TOKEN", true},
+	{"TENANTS", true},
+	{"TENANTS_FILE", false},
+	{"NOTIFY_WEBHOOK_URL", true},
+	{"NOTIFY_DISCORD_WEBHOOK_URL", true},
+	{"NOTIFY_SLACK_WEBHOOK_URL", true},
+	{"APP_QUERIES", false},
+	{"APP_QUERIES_FILE", false},
+	{"HOOKS_ENABLED", false},
+	{"HOOKS", false},
+	{"HOOKS_FILE", false},
+	{"HOOKS_WORKDIR", false},
+	{"HEARTBEAT_URL", true},
+	{"HEARTBEAT_INTERVAL", false},
+	{"HEARTBEAT_METHOD", false},
+}
+
+func configSummaryFromEnv() map[string]string {
+	summary := make(map[string]string)
+	for _, entry := range diagnosticEnvKeys {
+		value, _ := lookupEnv(entry.key)
+		if value == "" {
+			continue
+		}
+		if entry.secret {
+			value = "<redacted>"
+		}
+		summary[entry.key] = value
+	}
+	return summary
+}
+
+// fetch 결과 기록
+func (vt *UnifiedValidatorTracker) recordFetch(endpoint string, latency time.Duration, err error) {
+	vt.cycleRPCTime.Add(int64(latency))
+	// 종료 중 취소된 요청은 실패로 집계하지 않음
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	vt.observeFetch(endpoint, latency, err)
+	reason := fetchErrorReason(err)
+	if err != nil {
+		vt.metrics.exporter.rpcErrorsMetric.WithLabelValues(endpoint, reason).Inc()
+		vt.endpoints.ReportFailure(err)
+	} else if vt.fetchUsesPool(endpoint) {
+		vt.endpoints.ReportSuccess()
+	}
+
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	status, ok := vt.endpointStatus[endpoint]
+	if !ok {
+		status = &EndpointStatus{}
+		vt.endpointStatus[endpoint] = status
+	}
+	if err != nil {
+		status.LastError = time.Now().UTC()
+		status.Error = err.Error()
+		status.ErrorReason = reason
+		status.Failures++
+		return
+	}
+	status.LastSuccess = time.Now().UTC()
+	status.Successes++
+}
+
+// 종류별 fetch 소요 시간과 마지막 성공 시각 (업스트림 메트릭 소스도 같은 메트릭에 기록)
+func (vt *UnifiedValidatorTracker) observeFetch(endpointType string, latency time.Duration, err error) {
+	vt.metrics.exporter.rpcDurationMetric.WithLabelValues(endpointType).Observe(latency.Seconds())
+	if err == nil {
+		vt.metrics.exporter.rpcLastSuccessMetric.WithLabelValues(endpointType).Set(float64(time.Now().Unix()))
+	}
+}
+
+// 서명 결과 누적 후 현재 연속 누락 수 반환
+func (vt *UnifiedValidatorTracker) recordSigning(validator string, signed bool) int {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	if signed {
+		vt.signedTotal[validator]++
+		vt.missStreak[validator] = 0
+	} else {
+		vt.missedTotal[validator]++
+		vt.missStreak[validator]++
+	}
+	return vt.missStreak[validator]
+}
+
+func (vt *UnifiedValidatorTracker) DiagnosticState() DiagnosticState {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	state := DiagnosticState{
+		Time:            time.Now().UTC(),
+		Config:          vt.configSummary,
+		ChainID:         vt.chainID,
+		LastBlockHeight: vt.lastBlockHeight,
+		ProcessedBlocks: vt.processed.Len(),
+		Endpoints:       make(map[string]EndpointStatus, len(vt.endpointStatus)),
+		Validators:      make(map[string]ValidatorDiagnostics, len(vt.validators)),
+		Goroutines:      runtime.NumGoroutine(),
+		Components:      vt.goroutines.Running(),
+		Memory: MemoryDiagnostics{
+			AllocBytes:     mem.Alloc,
+			HeapInuseBytes: mem.HeapInuse,
+			SysBytes:       mem.Sys,
+			NumGC:          mem.NumGC,
+		},
+	}
+	for endpoint, status := range vt.endpointStatus {
+		state.Endpoints[endpoint] = *status
+	}
+	for _, label := range vt.validators {
+		state.Validators[label] = ValidatorDiagnostics{
+			MissStreak:   vt.missStreak[label],
+			SignedBlocks: vt.signedTotal[label],
+			MissedBlocks: vt.missedTotal[label],
+		}
+	}
+	return state
+}
+
+// SIGUSR1마다 진단 상태를 로그 또는 dumpDir 아래 파일로 기록
+func (vt *UnifiedValidatorTracker) HandleDiagnosticSignals(ctx context.Context, dumpDir string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			if err := vt.dumpDiagnostics(dumpDir); err != nil {
+				persistenceLog.Error("Error writing diagnostic dump", "dir", dumpDir, "error", err)
+			}
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) dumpDiagnostics(dumpDir string) error {
+	data, err := json.MarshalIndent(vt.DiagnosticState(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// 드라이런 모드에서는 파일 대신 로그로 출력
+	if dumpDir == "" || vt.dryRun {
+		persistenceLog.Info("Diagnostic state dump\n" + string(data))
+		return nil
+	}
+
+	path := filepath.Join(dumpDir, fmt.Sprintf("diagnostics-%s.json", time.Now().UTC().Format("20060102T150405.000")))
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	persistenceLog.Info("Diagnostic state written", "path", path)
+	return nil
+}
+
+// GET /debug/state
+func (vt *UnifiedValidatorTracker) handleDebugState(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, vt.DiagnosticState())
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// 드라이런 모드의 알림 채널: 실제로 전송하지 않고 전체 페이로드를 로그로 남김
+type dryRunNotifier struct {
+	inner Notifier
+}
+
+func (n dryRunNotifier) Name() string {
+	return n.inner.Name()
+}
+
+func (n dryRunNotifier) Notify(ctx context.Context, msg Message) error {
+	payload, _ := json.Marshal(msg)
+	alertsLog.Info("Dry run: notification simulated, not sent", "notifier", n.inner.Name(),
+		"title", msg.Title, "payload", string(payload))
+	return nil
+}
+
+// 설정된 알림 채널을 모두 시뮬레이션 채널로 교체
+func dryRunNotifiers(notifiers []Notifier) []Notifier {
+	simulated := make([]Notifier, len(notifiers))
+	for i, notifier := range notifiers {
+		simulated[i] = dryRunNotifier{inner: notifier}
+	}
+	return simulated
+}
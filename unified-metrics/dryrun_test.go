@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// 디렉터리의 파일 이름과 내용 (쓰기가 없었는지 비교용)
+func dirContents(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[entry.Name()] = string(data)
+	}
+	return contents
+}
+
+func assertNoWrites(t *testing.T, dir string, before map[string]string) {
+	t.Helper()
+	if after := dirContents(t, dir); !reflect.DeepEqual(after, before) {
+		t.Errorf("dry run touched %s:\nbefore %v\nafter  %v", dir, before, after)
+	}
+}
+
+// 블록을 처리한 뒤 주기 저장과 종료 저장 모두 파일을 만들거나 덮어쓰지 않음
+func TestDryRunStateFileNotWritten(t *testing.T) {
+	dir := t.TempDir()
+	h := newTestHarness(t, "alpha", "beta")
+	h.tracker.dryRun = true
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.advance(3); err != nil {
+		t.Fatal(err)
+	}
+	if h.tracker.LastHeight() == 0 {
+		t.Fatal("no blocks processed")
+	}
+
+	fresh := filepath.Join(dir, "state.json")
+	if err := h.tracker.saveStateFile(fresh); err != nil {
+		t.Fatal(err)
+	}
+	assertNoWrites(t, dir, map[string]string{})
+
+	// 이전 실행이 남긴 파일도 그대로 유지
+	existing := `{"created_at":"2025-06-01T12:00:00Z","last_block_height":1}`
+	if err := os.WriteFile(fresh, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	before := dirContents(t, dir)
+	h.tracker.shuttingDown.Store(true)
+	if err := h.tracker.saveStateFile(fresh); err != nil {
+		t.Fatal(err)
+	}
+	assertNoWrites(t, dir, before)
+}
+
+// 손상된 상태 파일을 옮기지 않고 새로 시작
+func TestDryRunCorruptStateFileNotMoved(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	before := dirContents(t, dir)
+
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{})
+	tracker.dryRun = true
+	if err := tracker.loadStateFile(path); err != nil {
+		t.Fatalf("load corrupt state file: %v", err)
+	}
+	assertNoWrites(t, dir, before)
+}
+
+// 다른 체인의 상태 파일을 보관용 이름으로 옮기지 않음
+func TestDryRunPreviousChainStateNotArchived(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	if err := os.WriteFile(path, []byte(`{"chain_id":"zgtendermint_16600-1","last_block_height":100}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	before := dirContents(t, dir)
+
+	h := newTestHarness(t, "alpha")
+	h.tracker.dryRun = true
+	h.tracker.stateFile = path
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.loadStateFile(path); err != nil {
+		t.Fatal(err)
+	}
+	assertNoWrites(t, dir, before)
+	if err := h.tracker.archiveStateFile("zgtendermint_16600-1"); err != nil {
+		t.Fatal(err)
+	}
+	assertNoWrites(t, dir, before)
+}
+
+// 진단 덤프는 디렉터리를 지정해도 로그로만 출력
+func TestDryRunDiagnosticsNotWritten(t *testing.T) {
+	dir := t.TempDir()
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha"})
+	tracker.dryRun = true
+	if err := tracker.dumpDiagnostics(dir); err != nil {
+		t.Fatal(err)
+	}
+	assertNoWrites(t, dir, map[string]string{})
+
+	// 드라이런이 아니면 실제로 기록
+	tracker.dryRun = false
+	if err := tracker.dumpDiagnostics(dir); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(dirContents(t, dir)); n != 1 {
+		t.Errorf("diagnostic files = %d, want 1 outside dry run", n)
+	}
+}
+
+// 시뮬레이션 채널은 원래 채널 이름을 유지하고 요청을 보내지 않음
+func TestDryRunNotifiersDoNotSend(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	notifiers := dryRunNotifiers([]Notifier{
+		NewWebhookNotifier(server.URL),
+		NewDiscordNotifier(server.URL),
+		NewSlackNotifier(server.URL),
+	})
+	var names []string
+	for _, notifier := range notifiers {
+		names = append(names, notifier.Name())
+		if err := notifier.Notify(context.Background(), Message{Title: "beta jailed", Markdown: "beta", Payload: map[string]string{"validator": "beta"}}); err != nil {
+			t.Errorf("%s: %v", notifier.Name(), err)
+		}
+	}
+	notifyAll(context.Background(), notifiers, Message{Title: "daily report"})
+
+	if !reflect.DeepEqual(names, []string{"webhook", "discord", "slack"}) {
+		t.Errorf("names = %v", names)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("dry-run notifiers sent %d requests", n)
+	}
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultEndpointEvalInterval = 30 * time.Second
+	defaultEndpointMaxLag       = 5 // 최고 높이보다 이 블록 수 이상 뒤처지면 비정상
+	defaultFailoverThreshold    = 3 // 선택된 엔드포인트가 이 횟수만큼 연속 실패하면 다음 엔드포인트로 전환
+	endpointProbeTimeout        = 5 * time.Second
+	endpointErrorDecay          = 0.3 // 오류율 지수 이동 평균 가중치
+
+	// 점수 가중치 (낮을수록 좋음): 지연(초) + 오류율*5 + 높이 지연 블록*0.5
+	endpointErrorWeight = 5.0
+	endpointLagWeight   = 0.5
+)
+
+// 엔드포인트별 최근 프로브 결과
+type endpointState struct {
+	url       string
+	latency   time.Duration
+	errorRate float64
+	height    int64
+	lag       int64
+	healthy   bool
+	score     float64
+	probed    bool
+	failures  int // 실제 fetch의 연속 실패 수 (성공 또는 프로브 성공 시 0)
+}
+
+// 여러 RPC 엔드포인트 중 점수가 가장 좋은 정상 엔드포인트를 선택
+// 선택은 평가 주기 동안 유지하되, 선택된 엔드포인트의 fetch가 failoverThreshold번 연속 실패하면
+// 평가를 기다리지 않고 설정 순서상 다음 정상 엔드포인트로 바로 전환
+type EndpointPool struct {
+	mu                sync.Mutex
+	states            []*endpointState
+	selected          string
+	pinned            string
+	maxLag            int64
+	failoverThreshold int // 0이면 즉시 전환 비활성화
+
+	scoreMetric    *prometheus.GaugeVec
+	selectedMetric *prometheus.GaugeVec
+	healthyMetric  *prometheus.GaugeVec
+	liveMetric     *prometheus.GaugeVec // 연속 실패 기준 상태 (og_galileo_rpc_endpoint_healthy)
+	upMetric       *prometheus.GaugeVec // 마지막 fetch/프로브 성공 여부 (og_galileo_rpc_endpoint_up)
+	failoverMetric prometheus.Counter
+}
+
+func NewEndpointPool(endpoints []string, pinned string, maxLag int64) *EndpointPool {
+	pool := &EndpointPool{pinned: pinned, maxLag: maxLag, failoverThreshold: defaultFailoverThreshold}
+	for _, endpoint := range endpoints {
+		pool.states = append(pool.states, &endpointState{url: endpoint})
+	}
+	// 첫 평가 전에는 고정 엔드포인트 또는 설정 순서상 첫 번째 사용
+	pool.selected = endpoints[0]
+	if pinned != "" {
+		pool.selected = pinned
+	}
+	return pool
+}
+
+// 수동 고정 (RPC_ENDPOINT_PIN, 빈 문자열이면 고정 없음)
+func (p *EndpointPool) Pin(endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pinned = endpoint
+	if endpoint != "" {
+		p.selected = endpoint
+	}
+}
+
+func (p *EndpointPool) Selected() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.selected
+}
+
+// 선택된 RPC 엔드포인트로 보내는 fetch (REST 조회는 REST_ENDPOINT가 없을 때만 해당)
+var rpcFetchMethods = map[string]bool{
+	"block": true, "validators": true, "status": true, "mempool": true, "block_results": true, "abci_info": true,
+}
+
+func (vt *UnifiedValidatorTracker) fetchUsesPool(method string) bool {
+	return rpcFetchMethods[method] || (vt.restEndpoint == "" && restFetchMethods[method])
+}
+
+var restFetchMethods = map[string]bool{
+	"staking_validators": true, "staking_pool": true, "outstanding_rewards": true, "validator_commission": true,
+	"staking_params": true, "node_config": true, "slashing_params": true, "signing_infos": true,
+}
+
+// REST 조회를 엔드포인트 순서대로 시도 (REST_ENDPOINT가 있으면 그것만 사용)
+func (vt *UnifiedValidatorTracker) tryREST(fetch func(endpoint string) error) error {
+	if vt.restEndpoint != "" {
+		return fetch(vt.restEndpoint)
+	}
+	return vt.endpoints.Try(fetch)
+}
+
+// REST(/cosmos/...) 조회에 쓸 엔드포인트 (REST_ENDPOINT가 없으면 RPC 엔드포인트에서 함께 조회)
+func (vt *UnifiedValidatorTracker) restBase() string {
+	if vt.restEndpoint != "" {
+		return vt.restEndpoint
+	}
+	return vt.endpoints.Selected()
+}
+
+func (p *EndpointPool) Endpoints() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoints := make([]string, 0, len(p.states))
+	for _, state := range p.states {
+		endpoints = append(endpoints, state.url)
+	}
+	return endpoints
+}
+
+// 모든 엔드포인트를 프로브하고 점수를 다시 계산해 선택을 갱신
+func (p *EndpointPool) Evaluate(ctx context.Context) {
+	type probeResult struct {
+		latency    time.Duration
+		height     int64
+		catchingUp bool
+		err        error
+	}
+
+	p.mu.Lock()
+	states := append([]*endpointState(nil), p.states...)
+	p.mu.Unlock()
+
+	results := make([]probeResult, len(states))
+	var wg sync.WaitGroup
+	for i, state := range states {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			start := time.Now()
+			height, catchingUp, err := probeEndpoint(ctx, endpoint)
+			results[i] = probeResult{latency: time.Since(start), height: height, catchingUp: catchingUp, err: err}
+		}(i, state.url)
+	}
+	wg.Wait()
+
+	var maxHeight int64
+	for _, result := range results {
+		if result.err == nil && result.height > maxHeight {
+			maxHeight = result.height
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, state := range states {
+		result := results[i]
+		failed := 0.0
+		if result.err != nil {
+			failed = 1.0
+			rpcLog.Debug("Endpoint probe failed", "endpoint", sanitizeEndpoint(state.url), "error", result.err)
+		}
+		if state.probed {
+			state.errorRate = endpointErrorDecay*failed + (1-endpointErrorDecay)*state.errorRate
+		} else {
+			state.errorRate = failed
+		}
+		state.probed = true
+		state.latency = result.latency
+		if result.err == nil {
+			state.height = result.height
+			state.failures = 0
+		}
+		if p.upMetric != nil {
+			p.upMetric.WithLabelValues(sanitizeEndpoint(state.url)).Set(boolToFloat(result.err == nil))
+		}
+		state.lag = maxHeight - state.height
+		state.healthy = result.err == nil && !result.catchingUp && state.lag <= p.maxLag
+		state.score = state.latency.Seconds() + state.errorRate*endpointErrorWeight + float64(state.lag)*endpointLagWeight
+	}
+
+	previous := p.selected
+	if p.pinned != "" {
+		p.selected = p.pinned
+	} else if best := p.bestLocked(); best != nil {
+		p.selected = best.url
+	}
+	// 정상 엔드포인트가 없으면 현재 선택 유지
+
+	for _, state := range p.states {
+		label := sanitizeEndpoint(state.url)
+		if p.scoreMetric != nil {
+			p.scoreMetric.WithLabelValues(label).Set(state.score)
+			p.healthyMetric.WithLabelValues(label).Set(boolToFloat(state.healthy))
+		}
+	}
+	p.updateSelectionMetricsLocked()
+
+	if p.selected != previous {
+		rpcLog.Info("Switched RPC endpoint", "from", sanitizeEndpoint(previous), "to", sanitizeEndpoint(p.selected))
+	}
+	if p.pinned != "" {
+		for _, state := range p.states {
+			if state.url == p.pinned && !state.healthy {
+				rpcLog.Warn("Pinned RPC endpoint is unhealthy", "endpoint", sanitizeEndpoint(p.pinned), "lag", state.lag)
+			}
+		}
+	}
+}
+
+func (p *EndpointPool) bestLocked() *endpointState {
+	var healthy []*endpointState
+	for _, state := range p.states {
+		if state.healthy && !p.failingLocked(state) {
+			healthy = append(healthy, state)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+	// 점수가 같으면 설정 순서 유지
+	sort.SliceStable(healthy, func(i, j int) bool { return healthy[i].score < healthy[j].score })
+	return healthy[0]
+}
+
+// 즉시 전환 기준 설정 (메트릭 연결 후 호출해 엔드포인트별 상태를 처음부터 노출)
+func (p *EndpointPool) SetFailoverThreshold(threshold int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failoverThreshold = max(threshold, 0)
+	p.updateSelectionMetricsLocked()
+}
+
+func (p *EndpointPool) failingLocked(state *endpointState) bool {
+	return p.failoverThreshold > 0 && state.failures >= p.failoverThreshold
+}
+
+// 선택된 엔드포인트로 보낸 fetch의 성공 기록 (연속 실패 수 초기화)
+func (p *EndpointPool) ReportSuccess() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, state := range p.states {
+		if state.url == p.selected && state.failures > 0 {
+			state.failures = 0
+			p.updateSelectionMetricsLocked()
+		}
+	}
+}
+
+// fetch 실패 기록 (FetchError의 엔드포인트로 풀의 URL을 찾음)
+// 노드가 응답하지 못한 실패(타임아웃, 연결, HTTP 상태, 속도 제한)만 세고, 디코딩 실패나 없는 높이는 세지 않음
+// 선택된 엔드포인트가 기준 횟수에 닿으면 바로 전환 (고정 엔드포인트가 있으면 전환하지 않음)
+func (p *EndpointPool) ReportFailure(err error) {
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) || !failoverReason(err) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var failed *endpointState
+	for _, state := range p.states {
+		if sanitizeEndpoint(state.url) == fetchErr.Endpoint {
+			failed = state
+		}
+	}
+	if failed == nil {
+		return
+	}
+	failed.failures++
+	defer p.updateSelectionMetricsLocked()
+	if failed.url != p.selected || p.pinned != "" || !p.failingLocked(failed) || len(p.states) < 2 {
+		return
+	}
+
+	// 설정 순서상 현재 다음부터 한 바퀴 돌며 연속 실패 기준 미만이고 마지막 프로브가 정상(또는 아직 프로브 전)인 엔드포인트
+	current := 0
+	for i, state := range p.states {
+		if state.url == p.selected {
+			current = i
+		}
+	}
+	for offset := 1; offset < len(p.states); offset++ {
+		candidate := p.states[(current+offset)%len(p.states)]
+		if p.failingLocked(candidate) || (candidate.probed && !candidate.healthy) {
+			continue
+		}
+		rpcLog.Warn("RPC endpoint failing, failing over", "from", sanitizeEndpoint(p.selected), "to", sanitizeEndpoint(candidate.url),
+			"consecutive_failures", failed.failures, "error", err)
+		p.selected = candidate.url
+		if p.failoverMetric != nil {
+			p.failoverMetric.Inc()
+		}
+		return
+	}
+	rpcLog.Error("RPC endpoint failing and no healthy alternative", "endpoint", sanitizeEndpoint(p.selected),
+		"consecutive_failures", failed.failures)
+}
+
+// 선택된 엔드포인트부터 설정 순서대로 fetch를 시도하고, 성공한 엔드포인트를 다음 fetch부터 사용
+// 노드 장애로 볼 수 있는 실패(타임아웃, 연결, HTTP 상태, 속도 제한)일 때만 다음 엔드포인트로 넘어가
+// 한 폴링 주기 안에 전환됨 (고정 엔드포인트가 있으면 그것만 시도)
+// 중간 시도의 실패는 여기서 기록하고, 마지막 결과는 호출한 fetch의 recordFetch가 기록
+func (p *EndpointPool) Try(fetch func(endpoint string) error) error {
+	p.mu.Lock()
+	attempts := []string{p.selected}
+	if p.pinned == "" {
+		current := 0
+		for i, state := range p.states {
+			if state.url == p.selected {
+				current = i
+			}
+		}
+		for offset := 1; offset < len(p.states); offset++ {
+			attempts = append(attempts, p.states[(current+offset)%len(p.states)].url)
+		}
+	}
+	p.mu.Unlock()
+
+	var err error
+	for i, endpoint := range attempts {
+		if err = fetch(endpoint); err == nil {
+			p.markSucceeded(endpoint)
+			return nil
+		}
+		// 종료로 취소된 요청은 엔드포인트 문제가 아니므로 다음 엔드포인트로 넘어가지 않음
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+		p.setUp(endpoint, false)
+		if !failoverReason(err) {
+			return err
+		}
+		if i < len(attempts)-1 {
+			rpcLog.Warn("RPC fetch failed, trying next endpoint", "endpoint", sanitizeEndpoint(endpoint), "error", err)
+			p.ReportFailure(err)
+		}
+	}
+	return err
+}
+
+func (p *EndpointPool) markSucceeded(endpoint string) {
+	p.setUp(endpoint, true)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if endpoint == p.selected {
+		return
+	}
+	rpcLog.Warn("Switched active RPC endpoint", "from", sanitizeEndpoint(p.selected), "to", sanitizeEndpoint(endpoint))
+	p.selected = endpoint
+	p.updateSelectionMetricsLocked()
+}
+
+func (p *EndpointPool) setUp(endpoint string, up bool) {
+	if p.upMetric != nil {
+		p.upMetric.WithLabelValues(sanitizeEndpoint(endpoint)).Set(boolToFloat(up))
+	}
+}
+
+// 다른 엔드포인트로 넘어갈 만한 실패인지 (디코딩 실패나 없는 높이는 노드를 바꿔도 같은 결과)
+func failoverReason(err error) bool {
+	switch fetchErrorReason(err) {
+	case fetchReasonTimeout, fetchReasonConnection, fetchReasonHTTPStatus, fetchReasonRateLimited:
+		return true
+	}
+	return false
+}
+
+func (p *EndpointPool) updateSelectionMetricsLocked() {
+	for _, state := range p.states {
+		label := sanitizeEndpoint(state.url)
+		if p.selectedMetric != nil {
+			p.selectedMetric.WithLabelValues(label).Set(boolToFloat(state.url == p.selected))
+		}
+		if p.liveMetric != nil {
+			p.liveMetric.WithLabelValues(label).Set(boolToFloat(!p.failingLocked(state)))
+		}
+	}
+}
+
+func probeEndpoint(ctx context.Context, endpoint string) (height int64, catchingUp bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, endpointProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/status", nil)
+	if err != nil {
+		return 0, false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+
+	var status StatusResponse
+	if err := decodeJSONResponse("endpoint_probe", endpoint, resp, err, &status); err != nil {
+		return 0, false, err
+	}
+	height, err = strconv.ParseInt(string(status.Result.SyncInfo.LatestBlockHeight), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid latest_block_height: %w", err)
+	}
+	return height, status.Result.SyncInfo.CatchingUp, nil
+}
+
+// 메트릭 라벨/로그용으로 인증 정보와 쿼리 문자열 제거
+func sanitizeEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "<invalid>"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+func sanitizeEndpoints(endpoints []string) []string {
+	sanitized := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		sanitized = append(sanitized, sanitizeEndpoint(endpoint))
+	}
+	return sanitized
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// 엔드포인트가 여러 개일 때 주기적으로 재평가
+func (vt *UnifiedValidatorTracker) StartEndpointSelector(ctx context.Context, interval time.Duration) {
+	rpcLog.Info("Starting RPC endpoint selector", "interval", interval, "endpoints", len(vt.endpoints.Endpoints()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	vt.endpoints.Evaluate(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			vt.endpoints.Evaluate(ctx)
+		}
+	}
+}
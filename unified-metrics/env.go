@@ -0,0 +1,95 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 설정 파일(--config)에서 읽은 값 (환경 변수 이름 -> 값, 같은 이름의 환경 변수보다 우선)
+var fileSettings map[string]string
+
+// 설정 파일 값이 있으면 그 값, 없으면 환경 변수
+func lookupEnv(key string) (string, bool) {
+	if value, ok := fileSettings[key]; ok {
+		return value, true
+	}
+	return os.LookupEnv(key)
+}
+
+// 환경 변수 헬퍼: 값이 없거나 잘못된 경우 기본값을 사용
+func getEnv(key, fallback string) string {
+	if value, _ := lookupEnv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt64(key string, fallback int64) int64 {
+	value, _ := lookupEnv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		slog.Warn("Invalid environment variable, using default", "key", key, "value", value, "default", fallback, "error", err)
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, _ := lookupEnv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("Invalid environment variable, using default", "key", key, "value", value, "default", fallback, "error", err)
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, _ := lookupEnv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		slog.Warn("Invalid environment variable, using default", "key", key, "value", value, "default", fallback, "error", err)
+		return fallback
+	}
+	return parsed
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, _ := lookupEnv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		slog.Warn("Invalid environment variable, using default", "key", key, "value", value, "default", fallback, "error", err)
+		return fallback
+	}
+	return parsed
+}
+
+// 쉼표로 구분된 목록 (빈 항목은 무시)
+func getEnvList(key string, fallback []string) []string {
+	value, _ := lookupEnv(key)
+	if value == "" {
+		return fallback
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
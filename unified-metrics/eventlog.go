@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultEventPageSize = 100
+	maxEventPageSize     = 1000
+)
+
+// 이벤트 로그에 저장하지 않는 고빈도 이벤트 (서명 기록으로 이미 보관됨)
+var unloggedEventTypes = map[string]bool{
+	EventBlockProcessed:  true,
+	EventValidatorMissed: true,
+}
+
+// 이벤트 버스 소비자: 개별 사건을 히스토리 저장소의 이벤트 로그에 기록
+func (vt *UnifiedValidatorTracker) logEvent(event Event) {
+	if unloggedEventTypes[event.Type] {
+		return
+	}
+	vt.history.AddEvent(event)
+}
+
+// /api/events 응답
+type EventPage struct {
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"next_cursor,omitempty"` // 다음 페이지 요청 시 cursor 값
+}
+
+// GET /api/events
+func (vt *UnifiedValidatorTracker) handleEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	eventQuery := EventQuery{
+		Type:      query.Get("type"),
+		Validator: query.Get("validator"),
+		Visible:   tenantFromContext(r.Context()).Allows,
+		Limit:     defaultEventPageSize,
+	}
+
+	for name, target := range map[string]*time.Time{"from": &eventQuery.From, "to": &eventQuery.To} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, dateOnly, err := parseReportTime(value)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid %s %q (expected YYYY-MM-DD or RFC3339)", name, value)
+			return
+		}
+		// to는 포함하지 않으므로 날짜만 지정하면 그날 끝까지
+		if dateOnly && name == "to" {
+			parsed = parsed.AddDate(0, 0, 1)
+		}
+		*target = parsed.UTC()
+	}
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxEventPageSize {
+			writeAPIError(w, http.StatusBadRequest, "limit must be between 1 and %d", maxEventPageSize)
+			return
+		}
+		eventQuery.Limit = parsed
+	}
+	if value := query.Get("cursor"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			writeAPIError(w, http.StatusBadRequest, "invalid cursor %q", value)
+			return
+		}
+		eventQuery.After = parsed
+	}
+
+	events, more := vt.history.Events(eventQuery)
+	page := EventPage{Events: events}
+	if more {
+		page.NextCursor = strconv.FormatInt(events[len(events)-1].ID, 10)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 트래커가 발행하는 이벤트 종류
+const (
+	EventBlockProcessed    = "block_processed"
+	EventValidatorMissed   = "validator_missed"
+	EventJailed            = "jailed"
+	EventUnjailed          = "unjailed"
+	EventBondStatusChanged = "bond_status_changed"
+	EventNodeUnsynced      = "node_unsynced"
+	EventNodeHealthChanged = "node_health_changed"
+	EventChainHalt         = "chain_halt"
+	EventExporterStarted   = "exporter_started"
+	EventCycleOverrun      = "cycle_overrun"
+	EventPeerDisconnected  = "expected_peer_disconnected"
+	EventPeerReconnected   = "expected_peer_reconnected"
+	EventChainReset        = "chain_reset"
+	EventPubkeyRotated     = "pubkey_rotated"
+	EventProposalNew       = "proposal_new"
+	EventLabelRenamed      = "label_renamed"
+	EventExporterLagging   = "exporter_lagging"
+)
+
+const (
+	defaultMaxSubscribers  = 16
+	subscriberBufferSize   = 64
+	defaultChainHaltWindow = time.Minute
+)
+
+var ErrTooManySubscribers = errors.New("too many event subscribers")
+
+type Event struct {
+	ID        int64     `json:"id,omitempty"` // 이벤트 로그에 저장될 때 부여
+	Type      string    `json:"type"`
+	Time      time.Time `json:"time"`
+	Height    int64     `json:"height,omitempty"`
+	Validator string    `json:"validator,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// 구독자별 버퍼가 가득 차면 가장 오래된 이벤트를 버리는 내부 이벤트 버스
+type EventBus struct {
+	mu             sync.Mutex
+	subscribers    map[*Subscription]struct{}
+	maxSubscribers int
+	sinks          []func(Event) // 모든 이벤트를 동기적으로 받는 내부 소비자 (이벤트 로그 등)
+}
+
+type Subscription struct {
+	bus    *EventBus
+	events chan Event
+}
+
+func NewEventBus(maxSubscribers int) *EventBus {
+	return &EventBus{
+		subscribers:    make(map[*Subscription]struct{}),
+		maxSubscribers: maxSubscribers,
+	}
+}
+
+func (b *EventBus) Subscribe() (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subscribers) >= b.maxSubscribers {
+		return nil, ErrTooManySubscribers
+	}
+	sub := &Subscription{bus: b, events: make(chan Event, subscriberBufferSize)}
+	b.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// 내부 소비자 등록 (구독자 수 제한에 포함되지 않고 이벤트를 버리지 않음)
+func (b *EventBus) AddSink(sink func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sinks = append(b.sinks, sink)
+}
+
+// 이벤트 발행 (느린 구독자 때문에 트래커가 막히지 않음)
+func (b *EventBus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sink := range b.sinks {
+		sink(event)
+	}
+
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+			continue
+		default:
+		}
+		// 버퍼가 가득 찬 경우 가장 오래된 이벤트를 버리고 다시 시도
+		select {
+		case <-sub.events:
+		default:
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	delete(s.bus.subscribers, s)
+}
+
+// 본딩/감금 상태 변화 시 이벤트 발행 (첫 관측은 기준값으로만 기록)
+func (vt *UnifiedValidatorTracker) publishStateChange(label string, bonded, jailed bool) {
+	vt.mu.Lock()
+	prevBonded, seen := vt.prevBonded[label]
+	prevJailed := vt.prevJailed[label]
+	vt.prevBonded[label] = bonded
+	vt.prevJailed[label] = jailed
+	height := vt.lastBlockHeight
+	vt.mu.Unlock()
+	if !seen {
+		return
+	}
+
+	if jailed && !prevJailed {
+		vt.events.Publish(Event{Type: EventJailed, Height: height, Validator: label})
+	} else if !jailed && prevJailed {
+		vt.events.Publish(Event{Type: EventUnjailed, Height: height, Validator: label})
+	}
+	if bonded != prevBonded {
+		vt.events.Publish(Event{Type: EventBondStatusChanged, Height: height, Validator: label,
+			Message: fmt.Sprintf("bonded=%t", bonded)})
+	}
+}
+
+// 팁 높이가 chainHaltWindow 동안 변하지 않으면 체인 정지 이벤트 발행
+func (vt *UnifiedValidatorTracker) checkChainHalt(tipHeight int64) {
+	now := time.Now()
+	if tipHeight > vt.lastSeenTip || vt.lastHeightChange.IsZero() {
+		vt.lastSeenTip = tipHeight
+		vt.lastHeightChange = now
+		vt.chainHalted = false
+		return
+	}
+
+	if !vt.chainHalted && now.Sub(vt.lastHeightChange) > vt.chainHaltWindow {
+		vt.chainHalted = true
+		vt.events.Publish(Event{Type: EventChainHalt, Height: tipHeight,
+			Message: fmt.Sprintf("no new block for %s", now.Sub(vt.lastHeightChange).Round(time.Second))})
+	}
+}
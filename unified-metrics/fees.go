@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 관측한 가스 가격을 유지하는 최근 블록 수 (0이면 block_results 조회 안 함)
+	defaultFeeWindowBlocks = 100
+	// 노드 최소 가스 가격과 EVM 수수료 조회 주기
+	defaultFeeRefreshInterval = time.Minute
+	// eth_feeHistory로 조회하는 블록 수
+	evmFeeHistoryBlocks = 20
+)
+
+// 최근 블록들의 트랜잭션별 가스 가격 (fee / gas_wanted, 기본 단위)
+type GasPriceWindow struct {
+	mu     sync.Mutex
+	size   int
+	blocks []blockGasPrices // 높이 순서, 최대 size개
+}
+
+type blockGasPrices struct {
+	height int64
+	prices []float64
+}
+
+func NewGasPriceWindow(size int) *GasPriceWindow {
+	return &GasPriceWindow{size: size}
+}
+
+// 블록 하나의 관측값 추가 (트랜잭션이 없는 블록도 창을 채움)
+func (w *GasPriceWindow) Observe(height int64, prices []float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if n := len(w.blocks); n > 0 && height <= w.blocks[n-1].height {
+		return
+	}
+	w.blocks = append(w.blocks, blockGasPrices{height: height, prices: prices})
+	if len(w.blocks) > w.size {
+		w.blocks = w.blocks[len(w.blocks)-w.size:]
+	}
+}
+
+// 체인 리셋 시 이전 체인의 관측값 삭제
+func (w *GasPriceWindow) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.blocks = nil
+}
+
+// 창 안의 가스 가격 중앙값/p90과 트랜잭션 수 (트랜잭션이 없으면 ok=false)
+func (w *GasPriceWindow) Quantiles() (median, p90 float64, count int, ok bool) {
+	w.mu.Lock()
+	var prices []float64
+	for _, block := range w.blocks {
+		prices = append(prices, block.prices...)
+	}
+	w.mu.Unlock()
+
+	if len(prices) == 0 {
+		return 0, 0, 0, false
+	}
+	sort.Float64s(prices)
+	return percentileFloat(prices, 0.5), percentileFloat(prices, 0.9), len(prices), true
+}
+
+// nearest-rank 백분위 (정렬된 입력)
+func percentileFloat(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+type BlockResultsResponse struct {
+	Result struct {
+		Height     numericString `json:"height"`
+		TxsResults []struct {
+			Code      int    `json:"code"`
+			GasWanted string `json:"gas_wanted"`
+			Events    []struct {
+				Type       string `json:"type"`
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"attributes"`
+			} `json:"events"`
+		} `json:"txs_results"`
+	} `json:"result"`
+}
+
+func (vt *UnifiedValidatorTracker) fetchBlockResults(ctx context.Context, height int64) (result *BlockResultsResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("block_results", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.endpoints.Selected()
+	url := fmt.Sprintf("%s/block_results?height=%d", endpoint, height)
+	var results BlockResultsResponse
+	if err := vt.getJSON(ctx, "block_results", endpoint, url, &results); err != nil {
+		return nil, err
+	}
+	return &results, nil
+}
+
+// 트랜잭션의 tx 이벤트 fee 속성을 gas_wanted로 나눈 가격 (실패한 트랜잭션도 수수료는 지불하므로 포함)
+// fee가 없거나 다른 denom이면 제외
+func gasPricesFromResults(results *BlockResultsResponse, denom string) []float64 {
+	var prices []float64
+	for _, tx := range results.Result.TxsResults {
+		gasWanted, err := strconv.ParseFloat(tx.GasWanted, 64)
+		if err != nil || gasWanted <= 0 {
+			continue
+		}
+		for _, event := range tx.Events {
+			if event.Type != "tx" {
+				continue
+			}
+			for _, attribute := range event.Attributes {
+				if attribute.Key != "fee" {
+					continue
+				}
+				if amount, ok := coinAmount(attribute.Value, denom); ok {
+					prices = append(prices, amount/gasWanted)
+				}
+			}
+		}
+	}
+	return prices
+}
+
+// "1000ua0gi,5uother" 형식에서 denom의 금액
+func coinAmount(coins, denom string) (float64, bool) {
+	for _, coin := range strings.Split(coins, ",") {
+		coin = strings.TrimSpace(coin)
+		if !strings.HasSuffix(coin, denom) {
+			continue
+		}
+		amount, err := strconv.ParseFloat(strings.TrimSuffix(coin, denom), 64)
+		if err != nil {
+			return 0, false
+		}
+		return amount, true
+	}
+	return 0, false
+}
+
+// fetcher 단계: 블록의 가스 가격 조회 (실패하면 ok=false로 창에 반영하지 않음)
+func (vt *UnifiedValidatorTracker) observeGasPrices(ctx context.Context, height int64) ([]float64, bool) {
+	if vt.gasPrices == nil {
+		return nil, false
+	}
+	results, err := vt.fetchBlockResults(ctx, height)
+	if err != nil {
+		rpcLog.Debug("Error fetching block results for gas prices", "height", height, "error", err)
+		return nil, false
+	}
+	return gasPricesFromResults(results, vt.denom.Base), true
+}
+
+// applier 단계: 창에 반영하고 메트릭 갱신
+func (vt *UnifiedValidatorTracker) applyGasPrices(height int64, prices []float64) {
+	vt.gasPrices.Observe(height, prices)
+	median, p90, count, ok := vt.gasPrices.Quantiles()
+	vt.metrics.cosmos.observedTxsMetric.Set(float64(count))
+	if !ok {
+		return
+	}
+	vt.metrics.cosmos.gasPriceMetric.WithLabelValues("0.5").Set(median)
+	vt.metrics.cosmos.gasPriceMetric.WithLabelValues("0.9").Set(p90)
+}
+
+// 노드 설정의 minimum_gas_price ("0.000000000000000000ua0gi,...")
+type NodeConfigResponse struct {
+	MinimumGasPrice string `json:"minimum_gas_price"`
+}
+
+func (vt *UnifiedValidatorTracker) fetchNodeConfig(ctx context.Context) (result *NodeConfigResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("node_config", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/base/node/v1beta1/config", endpoint)
+	var config NodeConfigResponse
+	if err := vt.getJSON(ctx, "node_config", endpoint, url, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// EVM JSON-RPC 단일 호출 (params는 위치 인자)
+func evmCall(ctx context.Context, client *http.Client, endpoint, method string, params []interface{}, result interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := decodeJSONResponse(method, endpoint, resp, err, &envelope); err != nil {
+		return err
+	}
+	if envelope.Error != nil {
+		return newFetchError(method, endpoint, errors.New(envelope.Error.Message))
+	}
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return newFetchError(method, endpoint, decodeFailure(err))
+	}
+	return nil
+}
+
+// "0x..." 16진수 수량 (wei 단위는 uint64를 넘을 수 있음)
+func parseHexQuantity(value string) (float64, error) {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(value, "0x"), 16)
+	if !ok {
+		return 0, fmt.Errorf("invalid hex quantity %q", value)
+	}
+	f, _ := new(big.Float).SetInt(n).Float64()
+	return f, nil
+}
+
+// economics 수집기: 노드 최소 가스 가격과 (설정된 경우) EVM 수수료 시장 조회
+func (vt *UnifiedValidatorTracker) economicsCollector(evmEndpoint string) func(ctx context.Context) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(ctx context.Context) error {
+		err := vt.refreshMinGasPrice(ctx)
+		if evmEndpoint != "" {
+			err = errors.Join(err, vt.refreshEVMFees(ctx, client, evmEndpoint))
+		}
+		return err
+	}
+}
+
+func (vt *UnifiedValidatorTracker) refreshMinGasPrice(ctx context.Context) error {
+	config, err := vt.fetchNodeConfig(ctx)
+	if err != nil {
+		restLog.Debug("Could not fetch node minimum gas price", "error", err)
+		return err
+	}
+	price, ok := coinAmount(config.MinimumGasPrice, vt.denom.Base)
+	if !ok {
+		// 설정하지 않았거나 다른 denom만 있으면 0 (수수료 하한 없음)
+		price = 0
+	}
+	vt.metrics.cosmos.minGasPriceMetric.Set(price)
+	return nil
+}
+
+func (vt *UnifiedValidatorTracker) refreshEVMFees(ctx context.Context, client *http.Client, endpoint string) error {
+	var gasPrice string
+	gasPriceErr := evmCall(ctx, client, endpoint, "eth_gasPrice", []interface{}{}, &gasPrice)
+	if gasPriceErr != nil {
+		rpcLog.Warn("Error fetching EVM gas price", "endpoint", sanitizeEndpoint(endpoint), "error", gasPriceErr)
+	} else if value, err := parseHexQuantity(gasPrice); err == nil {
+		vt.metrics.cosmos.evmGasPriceMetric.Set(value)
+	}
+
+	var history struct {
+		BaseFeePerGas []string `json:"baseFeePerGas"`
+	}
+	params := []interface{}{fmt.Sprintf("0x%x", evmFeeHistoryBlocks), "latest", []int{}}
+	if err := evmCall(ctx, client, endpoint, "eth_feeHistory", params, &history); err != nil {
+		rpcLog.Warn("Error fetching EVM fee history", "endpoint", sanitizeEndpoint(endpoint), "error", err)
+		return errors.Join(gasPriceErr, err)
+	}
+	// 마지막 값은 다음 블록의 base fee
+	if n := len(history.BaseFeePerGas); n > 0 {
+		if value, err := parseHexQuantity(history.BaseFeePerGas[n-1]); err == nil {
+			vt.metrics.cosmos.evmBaseFeeMetric.Set(value)
+		}
+	}
+	return gasPriceErr
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// CometBFT가 정리된 높이 요청에 보내는 응답 본문 (노드 버전에 따라 200 또는 500과 함께)
+const prunedHeightBody = `{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"height 2 is not available, lowest height is 7"}}`
+
+// 고정 응답을 돌려주는 노드에 붙인 트래커
+func newCannedTracker(t *testing.T, status int, body string) *UnifiedValidatorTracker {
+	t.Helper()
+	return newServedTracker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestFetchBlockHeightNotAvailable(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		heightNA      bool
+		wantReason    string
+		wantErrorCode int // ErrHTTPStatus 코드 (0이면 HTTP 에러가 아님)
+	}{
+		{"pruned with 200", http.StatusOK, prunedHeightBody, true, fetchReasonNotFound, 0},
+		{"pruned with 500", http.StatusInternalServerError, prunedHeightBody, true, fetchReasonNotFound, 500},
+		{"future height", http.StatusInternalServerError,
+			`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"height 90 must be less than or equal to the current blockchain height 12"}}`,
+			true, fetchReasonNotFound, 500},
+		{"other rpc error", http.StatusInternalServerError,
+			`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"database closed"}}`,
+			false, fetchReasonHTTPStatus, 500},
+		{"plain 500", http.StatusInternalServerError, `internal server error`, false, fetchReasonHTTPStatus, 500},
+		{"proxy 502 page", http.StatusBadGateway, `<html><body>Bad Gateway</body></html>`, false, fetchReasonHTTPStatus, 502},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newCannedTracker(t, tt.status, tt.body)
+			_, err := tracker.fetchBlock(context.Background(), 2)
+			if err == nil {
+				t.Fatal("fetchBlock succeeded")
+			}
+			if got := errors.Is(err, ErrHeightNotAvailable); got != tt.heightNA {
+				t.Errorf("errors.Is(%v, ErrHeightNotAvailable) = %v, want %v", err, got, tt.heightNA)
+			}
+			if got := fetchErrorReason(err); got != tt.wantReason {
+				t.Errorf("reason = %q, want %q", got, tt.wantReason)
+			}
+			var statusErr ErrHTTPStatus
+			if got := errors.As(err, &statusErr); got != (tt.wantErrorCode != 0) {
+				t.Errorf("errors.As(ErrHTTPStatus) = %v", got)
+			} else if got && statusErr.Code != tt.wantErrorCode {
+				t.Errorf("status = %d, want %d", statusErr.Code, tt.wantErrorCode)
+			}
+		})
+	}
+}
+
+// 가짜 체인도 보관 범위 밖 높이를 500과 JSON-RPC error로 응답
+func TestFetchBlockPrunedOnMockChain(t *testing.T) {
+	h := newTestHarness(t, "alpha")
+	h.chain.Advance(1005)
+
+	if _, err := h.tracker.fetchBlock(h.ctx, 2); !errors.Is(err, ErrHeightNotAvailable) {
+		t.Errorf("pruned height error = %v, want ErrHeightNotAvailable", err)
+	}
+	if _, err := h.tracker.fetchBlock(h.ctx, h.chain.Height()); err != nil {
+		t.Errorf("latest height: %v", err)
+	}
+}
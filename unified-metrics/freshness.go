@@ -0,0 +1,60 @@
+package main
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 소스 데이터가 이 시간보다 오래되면 해당 시리즈를 삭제
+const defaultSourceStaleAfter = 2 * time.Minute
+
+// fetch 종류(소스)별로 그 소스에서만 채워지는 시리즈
+func (vt *UnifiedValidatorTracker) sourceSeries() map[string][]*prometheus.GaugeVec {
+	cosmos := vt.metrics.cosmos
+	return map[string][]*prometheus.GaugeVec{
+		"staking_validators": {
+			cosmos.isBondedMetric,
+			cosmos.isJailedMetric,
+			cosmos.tokensMetric,
+			cosmos.tokensDisplayMetric,
+			cosmos.rankMetric,
+			cosmos.commissionMetric,
+		},
+		"outstanding_rewards": {
+			cosmos.rewardsMetric,
+			cosmos.rewardsDisplayMetric,
+		},
+	}
+}
+
+// 마지막 성공이 sourceStaleAfter보다 오래된 소스의 시리즈를 삭제 (0으로 두지 않음)
+// Prometheus가 stale로 표시하도록 하고, 다음 성공한 fetch에서 다시 채워짐
+func (vt *UnifiedValidatorTracker) checkSourceFreshness(now time.Time) {
+	for source, vecs := range vt.sourceSeries() {
+		vt.mu.Lock()
+		status := vt.endpointStatus[source]
+		stale := false
+		if status != nil {
+			if status.LastSuccess.IsZero() {
+				stale = status.Failures > 0
+			} else {
+				stale = now.Sub(status.LastSuccess) > vt.sourceStaleAfter
+			}
+		}
+		wasStale := vt.staleSources[source]
+		vt.staleSources[source] = stale
+		vt.mu.Unlock()
+
+		vt.metrics.exporter.sourceStaleMetric.WithLabelValues(source).Set(boolToFloat(stale))
+		if stale && !wasStale {
+			for _, vec := range vecs {
+				vec.Reset()
+			}
+			trackerLog.Warn("Source data is stale, removed its series", "source", source,
+				"last_success", status.LastSuccess, "stale_after", vt.sourceStaleAfter)
+		} else if !stale && wasStale {
+			trackerLog.Info("Source recovered", "source", source)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// 스테이킹 조회가 계속 실패하면 시리즈가 사라지고, 다시 성공하면 돌아옴
+func TestSourceFreshnessStaleAndRecovered(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	h.tracker.checkSourceFreshness(time.Now())
+	if got := h.mustValue("og_galileo_exporter_source_stale", "source", "staking_validators"); got != 0 {
+		t.Fatalf("staking_validators stale = %v before the outage", got)
+	}
+	if got := h.mustValue("og_galileo_validator_is_bonded", "validator", "alpha"); got != 1 {
+		t.Fatalf("alpha is_bonded = %v", got)
+	}
+
+	h.chain.SetOutage(true)
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err == nil {
+		t.Fatal("staking update succeeded during the outage")
+	}
+	// 기한 안에서는 마지막 값을 유지
+	h.tracker.checkSourceFreshness(time.Now())
+	if _, ok := h.value("og_galileo_validator_is_bonded", "validator", "alpha"); !ok {
+		t.Fatal("is_bonded removed before the source went stale")
+	}
+
+	h.tracker.checkSourceFreshness(time.Now().Add(h.tracker.sourceStaleAfter + time.Second))
+	if got := h.mustValue("og_galileo_exporter_source_stale", "source", "staking_validators"); got != 1 {
+		t.Errorf("staking_validators stale = %v, want 1", got)
+	}
+	for _, name := range []string{"og_galileo_validator_is_bonded", "og_galileo_validator_is_jailed", "og_galileo_validator_tokens"} {
+		if _, ok := h.value(name, "validator", "alpha"); ok {
+			t.Errorf("%s still exported for a stale source", name)
+		}
+	}
+	// 다른 소스의 시리즈는 그대로
+	if _, ok := h.value("og_galileo_validator_status", "validator", "alpha"); !ok {
+		t.Error("validator_status removed with the staking source")
+	}
+
+	h.chain.SetOutage(false)
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	h.tracker.checkSourceFreshness(time.Now())
+	if got := h.mustValue("og_galileo_exporter_source_stale", "source", "staking_validators"); got != 0 {
+		t.Errorf("staking_validators stale = %v after recovery, want 0", got)
+	}
+	if got := h.mustValue("og_galileo_validator_is_bonded", "validator", "alpha"); got != 1 {
+		t.Errorf("alpha is_bonded after recovery = %v, want 1", got)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 종료 시 구성 요소 고루틴이 끝나기를 기다리는 시간
+const goroutineExitTimeout = 5 * time.Second
+
+// 구성 요소 이름 (og_galileo_exporter_goroutines의 component 라벨)
+const (
+	componentTracker          = "tracker"
+	componentApplier          = "applier"
+	componentScheduler        = "scheduler"
+	componentEndpointSelector = "endpoint_selector"
+	componentProbes           = "probes"
+	componentPriceFeeds       = "price_feeds"
+	componentPeerChecks       = "peer_checks"
+	componentNodeHealth       = "node_health"
+	componentNotifier         = "notifier"
+	componentHooks            = "hooks"
+	componentVerifier         = "verifier"
+	componentSink             = "sink"
+	componentArchiver         = "archiver"
+	componentPersister        = "persister"
+	componentJanitor          = "janitor"
+	componentReporter         = "reporter"
+	componentHeartbeat        = "heartbeat"
+	componentSignals          = "signals"
+	componentHTTPServer       = "http_server"
+	componentWebSocket        = "websocket"
+)
+
+// 구성 요소별로 시작한 고루틴 수를 세는 래퍼
+// 새 수집기나 워커를 추가할 때 go 대신 Go를 쓰면 누수가 메트릭과 종료 로그에 바로 드러남
+type GoroutineTracker struct {
+	mu      sync.Mutex
+	running map[string]int
+	metric  *prometheus.GaugeVec
+	idle    *sync.Cond // running이 바뀔 때 알림 (WaitIdle용)
+}
+
+func NewGoroutineTracker(metric *prometheus.GaugeVec) *GoroutineTracker {
+	g := &GoroutineTracker{running: make(map[string]int), metric: metric}
+	g.idle = sync.NewCond(&g.mu)
+	return g
+}
+
+// component 이름으로 fn을 고루틴으로 실행 (끝나면 패닉이어도 수를 줄임)
+func (g *GoroutineTracker) Go(component string, fn func()) {
+	g.mu.Lock()
+	g.running[component]++
+	g.metric.WithLabelValues(component).Inc()
+	g.mu.Unlock()
+
+	go func() {
+		defer g.done(component)
+		fn()
+	}()
+}
+
+func (g *GoroutineTracker) done(component string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.running[component]--
+	if g.running[component] == 0 {
+		delete(g.running, component)
+	}
+	g.metric.WithLabelValues(component).Dec()
+	g.idle.Broadcast()
+}
+
+// 구성 요소별 실행 중인 고루틴 수 (/debug/state)
+func (g *GoroutineTracker) Running() map[string]int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	running := make(map[string]int, len(g.running))
+	for component, n := range g.running {
+		running[component] = n
+	}
+	return running
+}
+
+// 컨텍스트 취소 후 호출: timeout 안에 모두 끝나면 nil, 아니면 아직 남은 구성 요소와 고루틴 수
+// (알림 전송처럼 컨텍스트를 따르지 않는 짧은 작업은 except로 제외)
+func (g *GoroutineTracker) WaitIdle(timeout time.Duration, except ...string) map[string]int {
+	deadline := time.Now().Add(timeout)
+	timer := time.AfterFunc(timeout, func() {
+		g.mu.Lock()
+		g.idle.Broadcast()
+		g.mu.Unlock()
+	})
+	defer timer.Stop()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for {
+		remaining := make(map[string]int)
+		for component, n := range g.running {
+			if !slices.Contains(except, component) {
+				remaining[component] = n
+			}
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return remaining
+		}
+		g.idle.Wait()
+	}
+}
+
+// 로그용 정렬된 "component=n" 목록
+func formatGoroutineCounts(counts map[string]int) []string {
+	entries := make([]string, 0, len(counts))
+	for component, n := range counts {
+		entries = append(entries, component+"="+strconv.Itoa(n))
+	}
+	sort.Strings(entries)
+	return entries
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// main이 시작하는 구성 요소 고루틴을 가짜 체인에 붙여 모두 띄운 뒤 취소하면 전부 끝나야 함
+func TestComponentGoroutinesExitOnCancel(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	tracker := h.tracker
+	tracker.scheduler = NewScheduler(tracker.metrics.exporter, nil, tracker.goroutines)
+	tracker.blockEvents = NewWebSocketTracker(tracker)
+	tracker.scheduler.Register(Collector{Name: collectorStaking, Interval: 10 * time.Millisecond, Run: tracker.updateCosmosMetrics})
+	tracker.StartTracking(h.ctx)
+	tracker.StartProbes(h.ctx, []ProbeConfig{{Name: "node", URL: h.server.URL + "/status", ExpectedStatus: 200,
+		Interval: jsonDuration(10 * time.Millisecond), Timeout: jsonDuration(time.Second)}})
+	tracker.StartNodeHealth(h.ctx, []NodeConfig{{Name: "sentry", Role: "sentry", RPC: h.server.URL,
+		Interval: jsonDuration(10 * time.Millisecond)}}, 10)
+	tracker.goroutines.Go(componentEndpointSelector, func() { tracker.StartEndpointSelector(h.ctx, 10*time.Millisecond) })
+	tracker.goroutines.Go(componentJanitor, func() { tracker.StartJanitor(h.ctx, 10*time.Millisecond) })
+	tracker.goroutines.Go(componentJanitor, func() { tracker.StartHistoryPruner(h.ctx, 10*time.Millisecond) })
+	tracker.goroutines.Go(componentSignals, func() { HandleLogLevelReload(h.ctx) })
+
+	// 블록이 쌓이면서 수집기, 프로브, 노드 확인이 몇 번씩 돌도록 기다림
+	for i := 0; i < 5; i++ {
+		h.chain.Advance(1)
+		time.Sleep(20 * time.Millisecond)
+	}
+	running := tracker.goroutines.Running()
+	for _, component := range []string{componentApplier, componentScheduler, componentWebSocket, componentProbes,
+		componentNodeHealth, componentEndpointSelector, componentJanitor, componentSignals} {
+		if running[component] == 0 {
+			t.Errorf("%s not running before cancel: %v", component, running)
+		}
+	}
+	if tracker.LastHeight() == 0 {
+		t.Error("no block tracked before cancel")
+	}
+
+	h.cancel()
+	checkGoroutineLeaks(t, tracker.goroutines)
+	if got := h.mustValue("og_galileo_exporter_goroutines", "component", componentScheduler); got != 0 {
+		t.Errorf("goroutines{component=scheduler} = %v after cancel, want 0", got)
+	}
+}
+
+func TestGoroutineTrackerWaitIdle(t *testing.T) {
+	h := newTestHarness(t)
+	goroutines := h.tracker.goroutines
+	release := make(chan struct{})
+	goroutines.Go(componentNotifier, func() { <-release })
+	goroutines.Go(componentHooks, func() { <-release })
+
+	if remaining := goroutines.WaitIdle(20*time.Millisecond, componentApplier); len(remaining) != 2 ||
+		remaining[componentNotifier] != 1 || remaining[componentHooks] != 1 {
+		t.Errorf("remaining = %v, want notifier and hooks", remaining)
+	}
+	// except로 지정한 구성 요소는 기다리지 않음
+	h.cancel()
+	if remaining := goroutines.WaitIdle(time.Second, componentNotifier, componentHooks); remaining != nil {
+		t.Errorf("remaining with except = %v, want nil", remaining)
+	}
+	close(release)
+	if remaining := goroutines.WaitIdle(time.Second); remaining != nil {
+		t.Errorf("remaining after release = %v", remaining)
+	}
+}
+
+// 헬퍼가 Go를 거치지 않은 누수도 잡는지 확인
+func TestLeakedGoroutinesDetectsRawGo(t *testing.T) {
+	h := newTestHarness(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	go h.tracker.StartJanitor(ctx, time.Hour)
+	deadline := time.Now().Add(time.Second)
+	for len(leakedGoroutines()) == 0 {
+		if time.Now().After(deadline) {
+			cancel()
+			t.Fatal("raw goroutine not reported as leaked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 제안 목록 (gov v1은 id, v1beta1은 proposal_id)
+type ProposalsResponse struct {
+	Proposals []struct {
+		ID              string `json:"id"`
+		ProposalID      string `json:"proposal_id"`
+		VotingStartTime string `json:"voting_start_time"`
+		VotingEndTime   string `json:"voting_end_time"`
+	} `json:"proposals"`
+	Pagination struct {
+		NextKey string `json:"next_key"`
+	} `json:"pagination"`
+}
+
+// 투표 기간인 제안
+type ActiveProposal struct {
+	ID              string
+	VotingStartTime time.Time // 응답에 없거나 형식이 다르면 zero
+	VotingEndTime   time.Time // 응답에 없거나 형식이 다르면 zero
+}
+
+// 투표 기간인 제안 목록 (gov v1을 먼저, 노드가 v1을 제공하지 않으면 v1beta1)
+func (vt *UnifiedValidatorTracker) fetchActiveProposals(ctx context.Context) (result []ActiveProposal, err error) {
+	defer func(start time.Time) { vt.recordFetch("gov_proposals", time.Since(start), err) }(time.Now())
+
+	result, err = vt.fetchProposalsPages(ctx, "v1")
+	if err != nil && govVersionUnsupported(err) {
+		result, err = vt.fetchProposalsPages(ctx, "v1beta1")
+	}
+	return result, err
+}
+
+func (vt *UnifiedValidatorTracker) fetchProposalsPages(ctx context.Context, version string) ([]ActiveProposal, error) {
+	endpoint := vt.restBase()
+	proposals := []ActiveProposal{}
+	nextKey := ""
+	for {
+		url := fmt.Sprintf("%s/cosmos/gov/%s/proposals?proposal_status=PROPOSAL_STATUS_VOTING_PERIOD&pagination.limit=%d",
+			endpoint, version, stakingValidatorsPerPage)
+		if nextKey != "" {
+			url += "&pagination.key=" + neturl.QueryEscape(nextKey)
+		}
+		var page ProposalsResponse
+		if err := vt.getJSON(ctx, "gov_proposals", endpoint, url, &page); err != nil {
+			return nil, err
+		}
+		for _, raw := range page.Proposals {
+			id := raw.ID
+			if id == "" {
+				id = raw.ProposalID
+			}
+			if id == "" {
+				continue
+			}
+			proposal := ActiveProposal{ID: sanitizeLabel(id)}
+			if startTime, err := time.Parse(time.RFC3339Nano, raw.VotingStartTime); err == nil {
+				proposal.VotingStartTime = startTime
+			}
+			if endTime, err := time.Parse(time.RFC3339Nano, raw.VotingEndTime); err == nil {
+				proposal.VotingEndTime = endTime
+			}
+			proposals = append(proposals, proposal)
+		}
+		nextKey = page.Pagination.NextKey
+		if nextKey == "" {
+			break
+		}
+	}
+	return proposals, nil
+}
+
+// v1 경로가 없는 노드 (SDK 0.46 이전): 404 또는 501
+func govVersionUnsupported(err error) bool {
+	var statusErr ErrHTTPStatus
+	if errors.As(err, &statusErr) && statusErr.Code == http.StatusNotImplemented {
+		return true
+	}
+	return errors.Is(err, ErrNotFound)
+}
+
+// voter가 proposalID에 투표했는지
+// 투표가 없으면 SDK 버전에 따라 404 또는 400("... not found for proposal")으로 응답하므로 둘 다 미투표로 처리
+func (vt *UnifiedValidatorTracker) fetchVote(ctx context.Context, proposalID, voter string) (voted bool, err error) {
+	defer func(start time.Time) { vt.recordFetch("gov_vote", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/gov/v1beta1/proposals/%s/votes/%s", endpoint, neturl.PathEscape(proposalID), neturl.PathEscape(voter))
+	var vote struct {
+		Vote struct {
+			Voter string `json:"voter"`
+		} `json:"vote"`
+	}
+	err = vt.getJSON(ctx, "gov_vote", endpoint, url, &vote)
+	if err != nil {
+		if voteNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return vote.Vote.Voter != "", nil
+}
+
+func voteNotFound(err error) bool {
+	if errors.Is(err, ErrNotFound) {
+		return true
+	}
+	var statusErr ErrHTTPStatus
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusBadRequest && strings.Contains(statusErr.Body, "not found")
+}
+
+// 추적 벨리데이터의 운영자 주소 -> 라벨 (VALIDATORS_FILE의 운영자 주소, 없으면 스테이킹 목록에서 연결한 주소)
+func (vt *UnifiedValidatorTracker) trackedOperators() map[string]string {
+	operators := make(map[string]string, len(vt.operatorLabels))
+	for operator, label := range vt.operatorLabels {
+		operators[operator] = label
+	}
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+	for _, identity := range vt.identities {
+		if identity.OperatorAddress != "" && identity.Label != "" {
+			operators[identity.OperatorAddress] = identity.Label
+		}
+	}
+	return operators
+}
+
+// governance 수집기: 투표 기간인 제안의 종료 시각과 추적 벨리데이터의 투표 여부 갱신
+// 투표 조회가 실패한 벨리데이터는 이전 값을 유지 (미투표로 바꾸지 않음)
+func (vt *UnifiedValidatorTracker) refreshGovernance(ctx context.Context) error {
+	proposals, err := vt.fetchActiveProposals(ctx)
+	if err != nil {
+		restLog.Error("Error fetching governance proposals", "error", err)
+		return err
+	}
+	sort.Slice(proposals, func(i, j int) bool { return proposals[i].ID < proposals[j].ID })
+	ids := make([]string, 0, len(proposals))
+	for _, proposal := range proposals {
+		ids = append(ids, proposal.ID)
+	}
+	vt.setActiveProposals(ids)
+
+	operators := vt.trackedOperators()
+	previous := vt.proposalVotesSnapshot()
+	var failed error
+	for _, proposal := range proposals {
+		if !proposal.VotingEndTime.IsZero() {
+			vt.metrics.cosmos.proposalEndTimeMetric.WithLabelValues(proposal.ID).Set(float64(proposal.VotingEndTime.Unix()))
+		}
+		votes := copyBoolMap(previous[proposal.ID])
+		for operator, label := range operators {
+			voted, err := vt.fetchVote(ctx, proposal.ID, operator)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				restLog.Warn("Error fetching governance vote", "proposal_id", proposal.ID, "validator", label, "error", err)
+				failed = err
+				continue
+			}
+			votes[label] = voted
+			vt.metrics.cosmos.voteMetric.WithLabelValues(label, proposal.ID).Set(boolToFloat(voted))
+		}
+		vt.setProposalVotes(proposal, votes)
+	}
+	restLog.Debug("Updated governance proposals", "active", len(proposals), "validators", len(operators))
+	return failed
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"og-galileo-unified-metrics/internal/rpcmock"
+)
+
+// 테스트 출력에는 에러 로그만 남김
+func TestMain(m *testing.M) {
+	logLevels.apply("error")
+	os.Exit(m.Run())
+}
+
+// 가짜 체인(rpcmock)에 실제 트래커를 붙인 통합 테스트 환경
+// applier 고루틴은 실제로 돌리고, 추적 주기(blocks 수집기)는 테스트가 직접 호출
+type testHarness struct {
+	t       *testing.T
+	chain   *rpcmock.Chain
+	server  *httptest.Server
+	tracker *UnifiedValidatorTracker
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// 벨리데이터 이름으로 체인을 만들고 모두 추적하는 트래커 생성 (종료는 t.Cleanup)
+func newTestHarness(t *testing.T, names ...string) *testHarness {
+	t.Helper()
+	chain := rpcmock.NewChain(names...)
+	server := httptest.NewServer(chain.Handler())
+
+	h := &testHarness{t: t, chain: chain, server: server}
+	h.startTracker()
+	t.Cleanup(h.stop)
+	return h
+}
+
+// 체인의 모든 벨리데이터를 추적하는 새 트래커를 만들고 applier 시작
+func (h *testHarness) startTracker() {
+	h.t.Helper()
+	validators := make(map[string]string)
+	for _, v := range h.chain.Validators() {
+		validators[v.Address] = v.Name
+	}
+	tracker := newSingleAttemptTracker(h.server.URL, validators, 2*time.Second)
+	if err := tracker.RegisterMetrics(); err != nil {
+		h.t.Fatalf("register metrics: %v", err)
+	}
+
+	h.ctx, h.cancel = context.WithCancel(context.Background())
+	h.tracker = tracker
+	tracker.StartApplier(h.ctx)
+}
+
+// RPC 요청을 한 번만 시도하는 트래커 (장애 시나리오가 재시도 대기로 느려지지 않도록)
+func newSingleAttemptTracker(endpoint string, validators map[string]string, timeout time.Duration) *UnifiedValidatorTracker {
+	tracker := NewUnifiedValidatorTracker([]string{endpoint}, validators)
+	tracker.http = NewHTTPClient(timeout, 1, tracker.metrics.exporter.httpDurationMetric,
+		tracker.metrics.exporter.httpErrorsMetric, tracker.metrics.exporter.httpRetriesMetric)
+	return tracker
+}
+
+// handler로 응답하는 노드에 붙인, 추적 벨리데이터가 없는 트래커 (노드는 t.Cleanup으로 닫음)
+func newServedTracker(t *testing.T, handler http.Handler) *UnifiedValidatorTracker {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return newSingleAttemptTracker(server.URL, map[string]string{}, time.Second)
+}
+
+// exporter 재시작: 트래커를 멈추고 같은 체인에 새 트래커를 붙여 상태 파일에서 복원
+// (테스트의 가짜 브로커 같은 고루틴이 남아 있을 수 있어 여기서는 구성 요소 고루틴만 확인)
+func (h *testHarness) restart(stateFile string) {
+	h.t.Helper()
+	h.cancel()
+	if remaining := h.tracker.goroutines.WaitIdle(5 * time.Second); remaining != nil {
+		h.t.Errorf("component goroutines still running after cancel: %v", remaining)
+	}
+	h.startTracker()
+	// main과 같이 체인 ID를 확인한 뒤 복원
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		h.t.Fatalf("node status: %v", err)
+	}
+	if err := h.tracker.loadStateFile(stateFile); err != nil {
+		h.t.Fatalf("load state file: %v", err)
+	}
+}
+
+// 트래커 고루틴을 멈추고 가짜 노드를 닫음 (여러 번 호출해도 됨)
+func (h *testHarness) stop() {
+	h.cancel()
+	checkGoroutineLeaks(h.t, h.tracker.goroutines)
+	h.server.Close()
+}
+
+// 취소 후 구성 요소 고루틴이 모두 끝났는지 확인
+// Go로 시작한 고루틴은 구성 요소별 수로, go로 직접 시작한 고루틴은 이 패키지 함수가 남은 스택으로 찾음
+func checkGoroutineLeaks(t *testing.T, goroutines *GoroutineTracker) {
+	t.Helper()
+	if remaining := goroutines.WaitIdle(5 * time.Second); remaining != nil {
+		t.Errorf("component goroutines still running after cancel: %v", remaining)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		leaked := leakedGoroutines()
+		if len(leaked) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("%d goroutines of this package still running after cancel:\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// 테스트 고루틴이 아니면서 스택에 이 패키지 함수가 있는 고루틴의 스택
+func leakedGoroutines() []string {
+	// 스택에 찍히는 이 패키지의 함수 이름 접두사 (테스트 바이너리에서는 main이 아니라 모듈 경로)
+	pc, _, _, _ := runtime.Caller(0)
+	packageFramePrefix := "\n" + strings.TrimSuffix(runtime.FuncForPC(pc).Name(), "leakedGoroutines")
+
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	var leaked []string
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if !strings.Contains(stack, packageFramePrefix) || strings.Contains(stack, "testing.tRunner") || strings.Contains(stack, "testing.(*M)") {
+			continue
+		}
+		leaked = append(leaked, stack)
+	}
+	return leaked
+}
+
+// 블록 n개를 진행하고 한 추적 주기를 돌림
+func (h *testHarness) advance(n int) error {
+	h.t.Helper()
+	h.chain.Advance(n)
+	return h.cycle()
+}
+
+// blocks 수집기 한 번 실행 후 큐에 넣은 블록이 모두 적용될 때까지 대기
+func (h *testHarness) cycle() error {
+	h.t.Helper()
+	err := h.tracker.collectBlocks(h.ctx)
+	h.waitApplied()
+	return err
+}
+
+func (h *testHarness) waitApplied() {
+	h.t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for h.tracker.LastHeight() < h.tracker.lastQueued {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("applier did not reach height %d (at %d)", h.tracker.lastQueued, h.tracker.LastHeight())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// 레지스트리에서 이름과 라벨(일부만 지정 가능)이 맞는 시계열 값
+func (h *testHarness) value(name string, labels ...string) (float64, bool) {
+	h.t.Helper()
+	return gatherValue(h.t, h.tracker.registry, name, labels...)
+}
+
+// 값이 있어야 하는 시계열 (없으면 테스트 실패)
+func (h *testHarness) mustValue(name string, labels ...string) float64 {
+	h.t.Helper()
+	value, ok := h.value(name, labels...)
+	if !ok {
+		h.t.Fatalf("%s%v not exported", name, labels)
+	}
+	return value
+}
+
+// labels는 "name", "value" 쌍의 나열
+func gatherValue(t *testing.T, gatherer prometheus.Gatherer, name string, labels ...string) (float64, bool) {
+	t.Helper()
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metricHasLabels(metric, labels) {
+				return metricValue(metric), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func metricHasLabels(metric *dto.Metric, labels []string) bool {
+	for i := 0; i+1 < len(labels); i += 2 {
+		found := false
+		for _, pair := range metric.GetLabel() {
+			if pair.GetName() == labels[i] && pair.GetValue() == labels[i+1] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func metricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.Gauge != nil:
+		return metric.GetGauge().GetValue()
+	case metric.Counter != nil:
+		return metric.GetCounter().GetValue()
+	case metric.Untyped != nil:
+		return metric.GetUntyped().GetValue()
+	case metric.Histogram != nil:
+		return float64(metric.GetHistogram().GetSampleCount())
+	case metric.Summary != nil:
+		return float64(metric.GetSummary().GetSampleCount())
+	}
+	return 0
+}
+
+// 레지스트리에 노출된 패밀리 이름 (시계열이 없는 패밀리는 빠짐)
+func gatheredNames(t *testing.T, gatherer prometheus.Gatherer) map[string]bool {
+	t.Helper()
+	families, err := gatherer.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	names := make(map[string]bool, len(families))
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	return names
+}
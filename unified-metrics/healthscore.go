@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 건강 점수 구성 요소 (og_galileo_validator_health_score_component의 component 라벨)
+const (
+	healthUptime     = "uptime"
+	healthMissStreak = "miss_streak"
+	healthStatus     = "status"
+	healthSync       = "sync"
+	healthRank       = "rank"
+	healthGovernance = "governance"
+)
+
+var healthComponents = []string{healthUptime, healthMissStreak, healthStatus, healthSync, healthRank, healthGovernance}
+
+// 기본 가중치 (HEALTH_SCORE_WEIGHTS로 일부만 바꿀 수 있음)
+var defaultHealthWeights = map[string]float64{
+	healthUptime:     40,
+	healthMissStreak: 15,
+	healthStatus:     20,
+	healthSync:       10,
+	healthRank:       10,
+	healthGovernance: 5,
+}
+
+const (
+	healthStreakLimit = 10 // 연속 누락이 이만큼이면 miss_streak 요소가 0
+	healthRankMargin  = 10 // 활성 셋 경계에서 이만큼 위면 rank 요소가 1
+)
+
+// 점수 계산 입력 (한 주기의 벨리데이터 상태, 점수는 이 값만으로 계산)
+// *Known이 false인 요소는 아직 모르는 값이라 가중치에서 빼고 나머지로 정규화
+type HealthInputs struct {
+	Uptime        float64 // 최근 서명률 0~1 (1 - 누락률 EWMA)
+	UptimeKnown   bool
+	MissStreak    int
+	Bonded        bool
+	Jailed        bool
+	StatusKnown   bool
+	NodeSynced    bool
+	Rank          int // 토큰 순위 (0이면 본딩되지 않음)
+	MaxValidators int // 활성 셋 크기 (0이면 모름)
+	Proposals     int // 투표 기간인 제안 수 (0이면 governance 요소 제외)
+	Votes         int // 그중 투표한 제안 수
+}
+
+// 요소별 0~1 값을 가중 평균해 0~100 점수로 (계산할 수 있는 요소가 없으면 ok=false)
+//
+//	uptime      Uptime
+//	miss_streak 1 - MissStreak/10 (10 이상이면 0)
+//	status      본딩 1, 본딩되지 않음 0.25, 감금 0
+//	sync        노드 동기화 1, 아니면 0
+//	rank        (MaxValidators - Rank)/10 (최대 1, 본딩되지 않았거나 셋 밖이면 0)
+//	governance  Votes/Proposals
+func computeHealthScore(in HealthInputs, weights map[string]float64) (score float64, components map[string]float64, ok bool) {
+	components = map[string]float64{
+		healthMissStreak: clamp01(1 - float64(in.MissStreak)/healthStreakLimit),
+		healthSync:       boolToFloat(in.NodeSynced),
+	}
+	if in.UptimeKnown {
+		components[healthUptime] = clamp01(in.Uptime)
+	}
+	if in.StatusKnown {
+		switch {
+		case in.Jailed:
+			components[healthStatus] = 0
+		case in.Bonded:
+			components[healthStatus] = 1
+		default:
+			components[healthStatus] = 0.25
+		}
+	}
+	if in.MaxValidators > 0 {
+		if in.Rank == 0 {
+			components[healthRank] = 0
+		} else {
+			components[healthRank] = clamp01(float64(in.MaxValidators-in.Rank) / healthRankMargin)
+		}
+	}
+	if in.Proposals > 0 {
+		components[healthGovernance] = clamp01(float64(in.Votes) / float64(in.Proposals))
+	}
+
+	var total, weightSum float64
+	for component, value := range components {
+		total += weights[component] * value
+		weightSum += weights[component]
+	}
+	if weightSum == 0 {
+		return 0, components, false
+	}
+	return 100 * total / weightSum, components, true
+}
+
+func clamp01(v float64) float64 {
+	return min(max(v, 0), 1)
+}
+
+// HEALTH_SCORE_WEIGHTS="uptime=50,governance=0" (적지 않은 요소는 기본 가중치)
+func parseHealthWeights(spec string) (map[string]float64, error) {
+	weights := make(map[string]float64, len(defaultHealthWeights))
+	for component, weight := range defaultHealthWeights {
+		weights[component] = weight
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid health weight %q (expected component=weight)", entry)
+		}
+		if _, known := defaultHealthWeights[name]; !known {
+			sorted := append([]string(nil), healthComponents...)
+			sort.Strings(sorted)
+			return nil, fmt.Errorf("unknown health component %q (known: %s)", name, strings.Join(sorted, ", "))
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight for health component %q: %q", name, value)
+		}
+		weights[name] = weight
+	}
+	var sum float64
+	for _, weight := range weights {
+		sum += weight
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("health weights must not all be zero")
+	}
+	return weights, nil
+}
+
+// 추적 벨리데이터의 현재 상태로 점수 입력 구성
+func (vt *UnifiedValidatorTracker) healthInputs(label string) HealthInputs {
+	in := HealthInputs{}
+	if rate, ok := vt.missRate.Value(label); ok {
+		in.Uptime = 1 - rate
+		in.UptimeKnown = true
+	}
+	if sample, ok := vt.history.LatestSample(label); ok {
+		in.Bonded = sample.Bonded
+		in.Jailed = sample.Jailed
+		in.Rank = sample.Rank
+		in.StatusKnown = true
+	}
+
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	in.MissStreak = vt.missStreak[label]
+	in.NodeSynced = vt.nodeSynced
+	if in.StatusKnown {
+		in.MaxValidators = vt.maxValidators
+	}
+	for id := range vt.activeProposals {
+		in.Proposals++
+		if vt.proposalVotes[id][label] {
+			in.Votes++
+		}
+	}
+	return in
+}
+
+// 매 추적 주기마다 점수와 요소별 값 갱신 (요소 값은 제외된 요소를 지워서 감사 시 혼동이 없게 함)
+func (vt *UnifiedValidatorTracker) updateHealthScores() {
+	for _, label := range vt.validators {
+		score, components, ok := computeHealthScore(vt.healthInputs(label), vt.healthWeights)
+		for _, component := range healthComponents {
+			gauge := vt.metrics.custom.healthComponentMetric
+			if value, included := components[component]; included {
+				gauge.WithLabelValues(label, component).Set(value)
+			} else {
+				gauge.DeleteLabelValues(label, component)
+			}
+		}
+		if ok {
+			vt.metrics.custom.healthScoreMetric.WithLabelValues(label).Set(score)
+		}
+	}
+}
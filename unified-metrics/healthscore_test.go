@@ -0,0 +1,126 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestComputeHealthScore(t *testing.T) {
+	healthy := HealthInputs{Uptime: 1, UptimeKnown: true, Bonded: true, StatusKnown: true, NodeSynced: true,
+		Rank: 1, MaxValidators: 100, Proposals: 2, Votes: 2}
+	tests := []struct {
+		name       string
+		in         HealthInputs
+		score      float64
+		components map[string]float64
+	}{
+		{"healthy", healthy, 100, map[string]float64{
+			healthUptime: 1, healthMissStreak: 1, healthStatus: 1, healthSync: 1, healthRank: 1, healthGovernance: 1}},
+		// 0.5*40 / (40+15+20+10+10)
+		{"jailed and out of sync", HealthInputs{Uptime: 0.5, UptimeKnown: true, MissStreak: 10, Jailed: true, Bonded: true,
+			StatusKnown: true, MaxValidators: 100}, 100 * 20.0 / 95, map[string]float64{
+			healthUptime: 0.5, healthMissStreak: 0, healthStatus: 0, healthSync: 0, healthRank: 0}},
+		// 아직 모르는 요소는 빼고 miss_streak, sync만으로: (0.7*15 + 10) / 25
+		{"only streak and sync known", HealthInputs{MissStreak: 3, NodeSynced: true}, 82, map[string]float64{
+			healthMissStreak: 0.7, healthSync: 1}},
+		{"unbonded", HealthInputs{StatusKnown: true, NodeSynced: true, MaxValidators: 100}, 100 * (15 + 0.25*20 + 10) / 55.0,
+			map[string]float64{healthMissStreak: 1, healthStatus: 0.25, healthSync: 1, healthRank: 0}},
+		{"near the set boundary", HealthInputs{Bonded: true, StatusKnown: true, NodeSynced: true, Rank: 95, MaxValidators: 100},
+			100 * (15 + 20 + 10 + 0.5*10) / 55.0, map[string]float64{
+				healthMissStreak: 1, healthStatus: 1, healthSync: 1, healthRank: 0.5}},
+		{"ranked outside the set", HealthInputs{Bonded: true, StatusKnown: true, NodeSynced: true, Rank: 120, MaxValidators: 100},
+			100 * 45 / 55.0, map[string]float64{healthMissStreak: 1, healthStatus: 1, healthSync: 1, healthRank: 0}},
+		{"partial governance", HealthInputs{NodeSynced: true, Proposals: 4, Votes: 1}, 100 * (15 + 10 + 0.25*5) / 30.0,
+			map[string]float64{healthMissStreak: 1, healthSync: 1, healthGovernance: 0.25}},
+		{"out of range inputs clamp", HealthInputs{Uptime: 1.5, UptimeKnown: true, MissStreak: 25}, 100 * 40 / 65.0,
+			map[string]float64{healthUptime: 1, healthMissStreak: 0, healthSync: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, components, ok := computeHealthScore(tt.in, defaultHealthWeights)
+			if !ok {
+				t.Fatal("score not computable")
+			}
+			if math.Abs(score-tt.score) > 1e-9 {
+				t.Errorf("score = %v, want %v", score, tt.score)
+			}
+			if len(components) != len(tt.components) {
+				t.Errorf("components = %v, want %v", components, tt.components)
+			}
+			for component, want := range tt.components {
+				if got, included := components[component]; !included || math.Abs(got-want) > 1e-9 {
+					t.Errorf("%s = %v (included %v), want %v", component, got, included, want)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeHealthScoreWeights(t *testing.T) {
+	in := HealthInputs{Uptime: 0.9, UptimeKnown: true, MissStreak: 5, NodeSynced: false}
+
+	uptimeOnly := map[string]float64{healthUptime: 1}
+	if score, _, ok := computeHealthScore(in, uptimeOnly); !ok || math.Abs(score-90) > 1e-9 {
+		t.Errorf("uptime-only score = %v, %v, want 90", score, ok)
+	}
+
+	// 가중치가 있는 요소가 모두 모르는 값이면 점수를 내지 않음
+	governanceOnly := map[string]float64{healthGovernance: 1}
+	if score, _, ok := computeHealthScore(in, governanceOnly); ok {
+		t.Errorf("score = %v computed with no weighted component known", score)
+	}
+}
+
+func TestParseHealthWeights(t *testing.T) {
+	weights, err := parseHealthWeights(" uptime = 50 , governance=0,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weights[healthUptime] != 50 || weights[healthGovernance] != 0 || weights[healthStatus] != defaultHealthWeights[healthStatus] {
+		t.Errorf("weights = %v", weights)
+	}
+	if defaultHealthWeights[healthUptime] != 40 {
+		t.Error("parseHealthWeights modified the defaults")
+	}
+
+	if weights, err := parseHealthWeights(""); err != nil || len(weights) != len(defaultHealthWeights) {
+		t.Errorf("empty spec = %v, %v", weights, err)
+	}
+
+	for spec, want := range map[string]string{
+		"uptime":    "expected component=weight",
+		"latency=5": `unknown health component "latency"`,
+		"uptime=-1": "invalid weight",
+		"uptime=x":  "invalid weight",
+		"uptime=0,miss_streak=0,status=0,sync=0,rank=0,governance=0": "must not all be zero",
+	} {
+		if _, err := parseHealthWeights(spec); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseHealthWeights(%q) error = %v, want containing %q", spec, err, want)
+		}
+	}
+}
+
+// 추적 주기마다 점수를 내고, 모르는 요소의 시리즈는 지움
+func TestUpdateHealthScores(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	h.chain.SetSigning("beta", false)
+	if err := h.advance(4); err != nil {
+		t.Fatal(err)
+	}
+	// 점수는 수집 단계에서 계산하므로 적용된 블록은 다음 주기에 반영
+	if err := h.cycle(); err != nil {
+		t.Fatal(err)
+	}
+	alpha := h.mustValue("og_galileo_validator_health_score", "validator", "alpha")
+	beta := h.mustValue("og_galileo_validator_health_score", "validator", "beta")
+	if beta >= alpha {
+		t.Errorf("beta score %v not below alpha %v after missing blocks", beta, alpha)
+	}
+	if got := h.mustValue("og_galileo_validator_health_score_component", "validator", "beta", "component", healthMissStreak); got >= 1 {
+		t.Errorf("beta miss_streak component = %v, want < 1", got)
+	}
+	if _, ok := h.value("og_galileo_validator_health_score_component", "validator", "alpha", "component", healthGovernance); ok {
+		t.Error("governance component exported without active proposals")
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHeartbeatInterval = time.Minute
+	heartbeatTimeout         = 10 * time.Second
+)
+
+// 외부 모니터(healthchecks.io 등)로 보내는 생존 신호
+// 익스포터나 호스트가 죽으면 신호가 끊겨 외부에서 알림이 발생 (정상일 때만 전송)
+// 핑 URL은 경로에 토큰이 들어 있는 경우가 많아 로그에는 호스트만 남김
+type Heartbeat struct {
+	client   *http.Client
+	url      string
+	host     string
+	method   string // GET 또는 POST
+	interval time.Duration
+}
+
+// HEARTBEAT_URL이 없으면 nil
+func heartbeatFromEnv() (*Heartbeat, error) {
+	target := getEnv("HEARTBEAT_URL", "")
+	if target == "" {
+		return nil, nil
+	}
+	method := strings.ToUpper(getEnv("HEARTBEAT_METHOD", http.MethodGet))
+	if method != http.MethodGet && method != http.MethodPost {
+		return nil, fmt.Errorf("invalid HEARTBEAT_METHOD %q (expected GET or POST)", method)
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HEARTBEAT_URL: %w", err)
+	}
+	interval := getEnvDuration("HEARTBEAT_INTERVAL", defaultHeartbeatInterval)
+	if interval <= 0 {
+		return nil, fmt.Errorf("HEARTBEAT_INTERVAL must be positive")
+	}
+	return &Heartbeat{
+		client:   &http.Client{Timeout: min(interval, heartbeatTimeout)},
+		url:      target,
+		host:     u.Host,
+		method:   method,
+		interval: interval,
+	}, nil
+}
+
+// 현재 높이를 height 쿼리 파라미터로 붙인 요청 URL (기존 쿼리는 유지)
+func (h *Heartbeat) requestURL(height int64) (string, error) {
+	u, err := url.Parse(h.url)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("height", strconv.FormatInt(height, 10))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func (h *Heartbeat) send(ctx context.Context, height int64) error {
+	target, err := h.requestURL(height)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, h.method, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		// url.Error는 요청 URL을 포함하므로 원인만 사용
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// 주기마다 준비 상태(최근 블록 처리)를 확인하고 정상일 때만 신호 전송
+// 전송 실패는 로그와 카운터에만 남기고 추적 루프에는 영향을 주지 않음
+func (vt *UnifiedValidatorTracker) StartHeartbeat(ctx context.Context, heartbeat *Heartbeat) {
+	alertsLog.Info("Starting heartbeat", "host", heartbeat.host, "method", heartbeat.method, "interval", heartbeat.interval)
+	ticker := time.NewTicker(heartbeat.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			vt.sendHeartbeat(ctx, heartbeat)
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) sendHeartbeat(ctx context.Context, heartbeat *Heartbeat) {
+	metrics := vt.metrics.exporter
+	if ready, reason := vt.Ready(vt.readyMaxStaleness); !ready {
+		alertsLog.Warn("Skipping heartbeat, exporter is not healthy", "reason", reason)
+		metrics.heartbeatsMetric.WithLabelValues("skipped").Inc()
+		return
+	}
+
+	height := vt.LastHeight()
+	if err := heartbeat.send(ctx, height); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		alertsLog.Warn("Error sending heartbeat", "host", heartbeat.host, "error", err)
+		metrics.heartbeatsMetric.WithLabelValues("failed").Inc()
+		return
+	}
+	alertsLog.Debug("Heartbeat sent", "height", height)
+	metrics.heartbeatsMetric.WithLabelValues("sent").Inc()
+	metrics.heartbeatLastSentMetric.SetToCurrentTime()
+}
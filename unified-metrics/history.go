@@ -0,0 +1,387 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// 기본 히스토리 보관 기간 (히트맵/리포트가 최대 7일을 조회)
+const defaultHistoryRetention = 7 * 24 * time.Hour
+
+// 히스토리 보관 정책 (0이면 해당 제한 없음)
+type HistoryRetention struct {
+	MaxAge  time.Duration // 이보다 오래된 기록 삭제
+	MaxRows int           // 테이블(signing, samples)별 최대 기록 수
+}
+
+// 블록별 벨리데이터 서명 기록
+type SigningRecord struct {
+	Height    int64     `json:"height"`
+	Time      time.Time `json:"time"`
+	Validator string    `json:"validator"`
+	Signed    bool      `json:"signed"`
+}
+
+// 스테이킹 정보 갱신 시점의 벨리데이터 상태 샘플
+type ValidatorSample struct {
+	Time      time.Time `json:"time"`
+	Validator string    `json:"validator"`
+	Tokens    string    `json:"tokens"`
+	Rank      int       `json:"rank"`
+	Bonded    bool      `json:"bonded"`
+	Jailed    bool      `json:"jailed"`
+}
+
+// 메시지 큐로 보낼 블록 기록 (sink가 전송을 확인하면 outbox에서 삭제)
+type BlockRecord struct {
+	ChainID           string          `json:"chain_id"`
+	Height            int64           `json:"height"`
+	Time              time.Time       `json:"time"`
+	Proposer          string          `json:"proposer"`
+	ProposerValidator string          `json:"proposer_validator,omitempty"` // 추적 벨리데이터가 제안했으면 라벨
+	TxCount           int             `json:"tx_count"`
+	Signed            map[string]bool `json:"signed"` // 라벨 -> 서명 여부 (셋에 없던 벨리데이터는 제외)
+}
+
+// 서명 기록과 벨리데이터 상태 샘플을 보관하는 메모리 저장소
+type HistoryStore struct {
+	mu         sync.RWMutex
+	retention  HistoryRetention
+	signing    []SigningRecord // 시간 순서로 추가됨
+	samples    []ValidatorSample
+	events     []Event // 이벤트 로그 (시간 순서, ID 오름차순)
+	nextID     int64
+	lastHeight map[string]int64 // validator -> 마지막으로 기록한 높이
+	outbox     []BlockRecord    // sink로 아직 전송하지 못한 블록 (높이 순서)
+	outboxMax  int              // outbox 최대 기록 수 (넘으면 오래된 것부터 삭제, 0이면 제한 없음)
+}
+
+func NewHistoryStore(retention HistoryRetention) *HistoryStore {
+	return &HistoryStore{
+		retention:  retention,
+		nextID:     1,
+		lastHeight: make(map[string]int64),
+	}
+}
+
+// 서명 기록 추가 (같은 벨리데이터의 이미 기록된 높이는 무시)
+func (hs *HistoryStore) AddSigning(records ...SigningRecord) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	for _, record := range records {
+		if record.Height <= hs.lastHeight[record.Validator] {
+			continue
+		}
+		hs.lastHeight[record.Validator] = record.Height
+		hs.signing = append(hs.signing, record)
+	}
+}
+
+// 벨리데이터별 누락 블록 시각 (시간 순서)
+func (hs *HistoryStore) MissTimes() map[string][]time.Time {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	missTimes := make(map[string][]time.Time)
+	for _, record := range hs.signing {
+		if !record.Signed {
+			missTimes[record.Validator] = append(missTimes[record.Validator], record.Time)
+		}
+	}
+	return missTimes
+}
+
+func (hs *HistoryStore) AddSample(sample ValidatorSample) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.samples = append(hs.samples, sample)
+}
+
+// 벨리데이터의 가장 최근 스테이킹 표본
+func (hs *HistoryStore) LatestSample(validator string) (ValidatorSample, bool) {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	for i := len(hs.samples) - 1; i >= 0; i-- {
+		if hs.samples[i].Validator == validator {
+			return hs.samples[i], true
+		}
+	}
+	return ValidatorSample{}, false
+}
+
+// 이벤트 로그에 추가 (ID 부여)
+func (hs *HistoryStore) AddEvent(event Event) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	event.ID = hs.nextID
+	hs.nextID++
+	hs.events = append(hs.events, event)
+}
+
+// outbox에 블록 추가 후 한도를 넘어 버린 기록 수 반환 (이미 들어 있는 높이는 무시)
+func (hs *HistoryStore) AddOutbox(record BlockRecord) (dropped int) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	if n := len(hs.outbox); n > 0 && record.Height <= hs.outbox[n-1].Height {
+		return 0
+	}
+	hs.outbox = append(hs.outbox, record)
+	if hs.outboxMax > 0 && len(hs.outbox) > hs.outboxMax {
+		dropped = len(hs.outbox) - hs.outboxMax
+		hs.outbox = hs.outbox[dropped:]
+	}
+	return dropped
+}
+
+// 전송 대기 중인 앞쪽 기록 최대 limit개
+func (hs *HistoryStore) Outbox(limit int) []BlockRecord {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	return append([]BlockRecord(nil), hs.outbox[:min(limit, len(hs.outbox))]...)
+}
+
+// 체인 리셋: 높이가 다시 시작하므로 중복 판단 기준을 비우고 이전 체인의 미전송 기록 수를 반환하며 버림
+func (hs *HistoryStore) ResetChain() (dropped int) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.lastHeight = make(map[string]int64)
+	dropped = len(hs.outbox)
+	hs.outbox = nil
+	return dropped
+}
+
+// height 이하의 기록을 전송 완료로 삭제
+func (hs *HistoryStore) AckOutbox(height int64) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	acked := sort.Search(len(hs.outbox), func(i int) bool { return hs.outbox[i].Height > height })
+	hs.outbox = hs.outbox[acked:]
+}
+
+func (hs *HistoryStore) OutboxLen() int {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	return len(hs.outbox)
+}
+
+// 스냅샷의 outbox를 합침 (복원 전에 추가된 블록은 유지, 같은 높이는 하나만)
+func (hs *HistoryStore) ImportOutbox(records []BlockRecord) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	merged := append(append([]BlockRecord(nil), records...), hs.outbox...)
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Height < merged[j].Height })
+	hs.outbox = merged[:0]
+	for _, record := range merged {
+		if n := len(hs.outbox); n > 0 && record.Height == hs.outbox[n-1].Height {
+			continue
+		}
+		hs.outbox = append(hs.outbox, record)
+	}
+}
+
+// 이벤트 로그 조회 조건 (빈 값은 조건 없음)
+type EventQuery struct {
+	Type      string
+	Validator string
+	From      time.Time
+	To        time.Time
+	Visible   func(validator string) bool // nil이 아니면 벨리데이터 이벤트 중 true인 것만 (체인 이벤트는 항상 포함)
+	After     int64                       // 이 ID 이후의 이벤트만 (페이지 커서)
+	Limit     int
+}
+
+// 조건에 맞는 이벤트를 ID 순서로 최대 Limit개 반환 (더 있으면 more=true)
+func (hs *HistoryStore) Events(query EventQuery) (events []Event, more bool) {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	start := sort.Search(len(hs.events), func(i int) bool { return hs.events[i].ID > query.After })
+	events = []Event{}
+	for _, event := range hs.events[start:] {
+		if query.Type != "" && event.Type != query.Type {
+			continue
+		}
+		if query.Validator != "" && event.Validator != query.Validator {
+			continue
+		}
+		if query.Visible != nil && event.Validator != "" && !query.Visible(event.Validator) {
+			continue
+		}
+		if !query.From.IsZero() && event.Time.Before(query.From) {
+			continue
+		}
+		if !query.To.IsZero() && !event.Time.Before(query.To) {
+			continue
+		}
+		if len(events) == query.Limit {
+			return events, true
+		}
+		events = append(events, event)
+	}
+	return events, false
+}
+
+// [from, to) 구간의 서명 기록 (validator가 비어 있으면 전체)
+func (hs *HistoryStore) Signing(validator string, from, to time.Time) []SigningRecord {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	start := sort.Search(len(hs.signing), func(i int) bool { return !hs.signing[i].Time.Before(from) })
+	var result []SigningRecord
+	for _, record := range hs.signing[start:] {
+		if !record.Time.Before(to) {
+			break
+		}
+		if validator == "" || record.Validator == validator {
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+// [from, to) 구간의 상태 샘플 (validator가 비어 있으면 전체)
+func (hs *HistoryStore) Samples(validator string, from, to time.Time) []ValidatorSample {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	var result []ValidatorSample
+	for _, sample := range hs.samples {
+		if sample.Time.Before(from) || !sample.Time.Before(to) {
+			continue
+		}
+		if validator == "" || sample.Validator == validator {
+			result = append(result, sample)
+		}
+	}
+	return result
+}
+
+// 테이블별 기록 수
+func (hs *HistoryStore) Rows() (signing, samples, events int) {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	return len(hs.signing), len(hs.samples), len(hs.events)
+}
+
+// 보관 정책을 벗어난 기록을 테이블별로 최대 limit개까지 삭제
+// (기록은 시간 순서이므로 앞부분만 잘라냄)
+func (hs *HistoryStore) PruneBatch(now time.Time, limit int) (signing, samples, events int) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	signing = hs.expiredLocked(len(hs.signing), func(i int) time.Time { return hs.signing[i].Time }, now, limit)
+	hs.signing = hs.signing[signing:]
+	samples = hs.expiredLocked(len(hs.samples), func(i int) time.Time { return hs.samples[i].Time }, now, limit)
+	hs.samples = hs.samples[samples:]
+	events = hs.expiredLocked(len(hs.events), func(i int) time.Time { return hs.events[i].Time }, now, limit)
+	hs.events = hs.events[events:]
+	return signing, samples, events
+}
+
+// 앞에서부터 삭제할 기록 수 (최대 limit)
+func (hs *HistoryStore) expiredLocked(rows int, timeAt func(int) time.Time, now time.Time, limit int) int {
+	count := 0
+	if hs.retention.MaxAge > 0 {
+		cutoff := now.Add(-hs.retention.MaxAge)
+		count = sort.Search(rows, func(i int) bool { return !timeAt(i).Before(cutoff) })
+	}
+	if hs.retention.MaxRows > 0 && rows-count > hs.retention.MaxRows {
+		count = rows - hs.retention.MaxRows
+	}
+	if limit > 0 && count > limit {
+		count = limit
+	}
+	return count
+}
+
+// 히트맵 버킷: [Start, End) 구간의 서명/누락 블록 수
+type HeatmapBucket struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Signed int       `json:"signed"`
+	Missed int       `json:"missed"`
+}
+
+// [from, to) 구간을 bucket 단위로 나눠 서명/누락 수를 집계
+func (hs *HistoryStore) Heatmap(validator string, from, to time.Time, bucket time.Duration) []HeatmapBucket {
+	from = from.Truncate(bucket)
+	count := int((to.Sub(from) + bucket - 1) / bucket)
+	buckets := make([]HeatmapBucket, count)
+	for i := range buckets {
+		buckets[i].Start = from.Add(time.Duration(i) * bucket)
+		buckets[i].End = buckets[i].Start.Add(bucket)
+	}
+
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	start := sort.Search(len(hs.signing), func(i int) bool { return !hs.signing[i].Time.Before(from) })
+	for _, record := range hs.signing[start:] {
+		if !record.Time.Before(to) {
+			break
+		}
+		if record.Validator != validator {
+			continue
+		}
+		i := int(record.Time.Sub(from) / bucket)
+		if record.Signed {
+			buckets[i].Signed++
+		} else {
+			buckets[i].Missed++
+		}
+	}
+	return buckets
+}
+
+// 전체 기록 복사본 (스냅샷용)
+func (hs *HistoryStore) Export() ([]SigningRecord, []ValidatorSample, []Event) {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	return append([]SigningRecord(nil), hs.signing...), append([]ValidatorSample(nil), hs.samples...),
+		append([]Event(nil), hs.events...)
+}
+
+// outbox 복사본 (스냅샷용)
+func (hs *HistoryStore) ExportOutbox() []BlockRecord {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	return append([]BlockRecord(nil), hs.outbox...)
+}
+
+// 스냅샷의 기록으로 저장소 내용을 교체
+func (hs *HistoryStore) Import(signing []SigningRecord, samples []ValidatorSample, events []Event) {
+	sort.SliceStable(signing, func(i, j int) bool { return signing[i].Time.Before(signing[j].Time) })
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].Time.Before(samples[j].Time) })
+	sort.SliceStable(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	hs.signing = append([]SigningRecord(nil), signing...)
+	hs.samples = append([]ValidatorSample(nil), samples...)
+	hs.events = append([]Event(nil), events...)
+	hs.nextID = 1
+	if len(hs.events) > 0 {
+		hs.nextID = hs.events[len(hs.events)-1].ID + 1
+	}
+	hs.lastHeight = make(map[string]int64)
+	for _, record := range hs.signing {
+		if record.Height > hs.lastHeight[record.Validator] {
+			hs.lastHeight[record.Validator] = record.Height
+		}
+	}
+}
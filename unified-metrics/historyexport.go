@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	historyExportChunk   = 1000    // 잠금을 잡고 한 번에 복사하는 기록 수
+	maxHistoryImportLine = 1 << 20 // NDJSON 한 줄 최대 크기
+	maxHistoryImportSize = 1 << 30 // import 요청 본문 최대 크기
+	ndjsonContentType    = "application/x-ndjson"
+)
+
+// 히스토리 export의 한 줄 (signing 또는 event 중 하나)
+// cursor는 이 줄까지 받은 뒤 이어받을 때 쓰는 값
+type HistoryExportLine struct {
+	Cursor  string         `json:"cursor"`
+	Signing *SigningRecord `json:"signing,omitempty"`
+	Event   *Event         `json:"event,omitempty"`
+}
+
+type HistoryImportResponse struct {
+	Signing        int `json:"signing"`         // 새로 추가된 서명 기록 수
+	Events         int `json:"events"`          // 새로 추가된 이벤트 수
+	SkippedSigning int `json:"skipped_signing"` // 이미 있던 (validator, height) 기록
+	SkippedEvents  int `json:"skipped_events"`  // 이미 있던 이벤트
+}
+
+// 재시작 후에도 유효하도록 높이(서명 기록)와 이벤트 ID 기준으로 위치를 표현
+// 서명 기록을 높이, 벨리데이터 순으로 모두 보낸 뒤 이벤트를 ID 순으로 보냄
+type exportCursor struct {
+	Height    int64  `json:"h,omitempty"`
+	Validator string `json:"v,omitempty"`
+	EventID   int64  `json:"e,omitempty"` // 0보다 크면 이벤트 단계
+}
+
+func (c exportCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func parseExportCursor(value string) (exportCursor, error) {
+	var cursor exportCursor
+	if value == "" {
+		return cursor, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(data, &cursor)
+	return cursor, err
+}
+
+// (height, validator) 이후의 서명 기록을 높이, 벨리데이터 순으로 최대 limit개(높이 단위로 끊음) 반환
+func (hs *HistoryStore) SigningAfter(height int64, validator string, limit int) []SigningRecord {
+	hs.mu.RLock()
+	defer hs.mu.RUnlock()
+
+	// 서명 기록은 높이 순서로 추가됨
+	i := sort.Search(len(hs.signing), func(i int) bool { return hs.signing[i].Height >= height })
+	var records []SigningRecord
+	for i < len(hs.signing) && len(records) < limit {
+		groupHeight := hs.signing[i].Height
+		j := i
+		for j < len(hs.signing) && hs.signing[j].Height == groupHeight {
+			j++
+		}
+		group := append([]SigningRecord(nil), hs.signing[i:j]...)
+		sort.Slice(group, func(a, b int) bool { return group[a].Validator < group[b].Validator })
+		for _, record := range group {
+			if record.Height == height && record.Validator <= validator {
+				continue
+			}
+			records = append(records, record)
+		}
+		i = j
+	}
+	return records
+}
+
+// 가져온 기록을 병합 (같은 벨리데이터와 높이의 서명 기록, 같은 내용의 이벤트는 건너뜀)
+// 이벤트는 시간 순으로 다시 번호를 매김
+func (hs *HistoryStore) Merge(signing []SigningRecord, events []Event) HistoryImportResponse {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	var result HistoryImportResponse
+	type signingKey struct {
+		validator string
+		height    int64
+	}
+	seenSigning := make(map[signingKey]bool, len(hs.signing))
+	for _, record := range hs.signing {
+		seenSigning[signingKey{record.Validator, record.Height}] = true
+	}
+	for _, record := range signing {
+		key := signingKey{record.Validator, record.Height}
+		if seenSigning[key] {
+			result.SkippedSigning++
+			continue
+		}
+		seenSigning[key] = true
+		hs.signing = append(hs.signing, record)
+		result.Signing++
+		if record.Height > hs.lastHeight[record.Validator] {
+			hs.lastHeight[record.Validator] = record.Height
+		}
+	}
+	sort.SliceStable(hs.signing, func(i, j int) bool { return hs.signing[i].Height < hs.signing[j].Height })
+
+	eventKey := func(event Event) string {
+		return fmt.Sprintf("%s|%d|%d|%s|%s", event.Type, event.Time.UnixNano(), event.Height, event.Validator, event.Message)
+	}
+	seenEvents := make(map[string]bool, len(hs.events))
+	for _, event := range hs.events {
+		seenEvents[eventKey(event)] = true
+	}
+	for _, event := range events {
+		key := eventKey(event)
+		if seenEvents[key] {
+			result.SkippedEvents++
+			continue
+		}
+		seenEvents[key] = true
+		hs.events = append(hs.events, event)
+		result.Events++
+	}
+	if result.Events > 0 {
+		sort.SliceStable(hs.events, func(i, j int) bool { return hs.events[i].Time.Before(hs.events[j].Time) })
+		for i := range hs.events {
+			hs.events[i].ID = int64(i + 1)
+		}
+		hs.nextID = int64(len(hs.events) + 1)
+	}
+	return result
+}
+
+// 전체 서명/이벤트 히스토리를 NDJSON으로 스트리밍 (청크 단위로 복사하므로 메모리 사용량 일정)
+func (vt *UnifiedValidatorTracker) handleHistoryExport(w http.ResponseWriter, r *http.Request) {
+	cursor, err := parseExportCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	w.Header().Set("Content-Type", ndjsonContentType)
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+	write := func(line HistoryExportLine) bool {
+		if err := encoder.Encode(line); err != nil {
+			httpLog.Debug("History export aborted", "error", err)
+			return false
+		}
+		return true
+	}
+
+	// 팀 토큰이면 그 팀의 벨리데이터 기록과 체인 이벤트만 내보냄
+	tenant := tenantFromContext(r.Context())
+	if cursor.EventID == 0 {
+		for r.Context().Err() == nil {
+			records := vt.history.SigningAfter(cursor.Height, cursor.Validator, historyExportChunk)
+			if len(records) == 0 {
+				break
+			}
+			for i := range records {
+				cursor.Height, cursor.Validator = records[i].Height, records[i].Validator
+				if !tenant.Allows(records[i].Validator) {
+					continue
+				}
+				if !write(HistoryExportLine{Cursor: cursor.encode(), Signing: &records[i]}) {
+					return
+				}
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+
+	for r.Context().Err() == nil {
+		events, more := vt.history.Events(EventQuery{After: cursor.EventID, Visible: tenant.Allows, Limit: historyExportChunk})
+		for i := range events {
+			cursor.EventID = events[i].ID
+			if !write(HistoryExportLine{Cursor: cursor.encode(), Event: &events[i]}) {
+				return
+			}
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+// export 결과를 새 인스턴스로 가져오기 (다시 실행해도 중복 기록이 생기지 않음)
+func (vt *UnifiedValidatorTracker) handleHistoryImport(w http.ResponseWriter, r *http.Request) {
+	scanner := bufio.NewScanner(http.MaxBytesReader(w, r.Body, maxHistoryImportSize))
+	scanner.Buffer(make([]byte, 64*1024), maxHistoryImportLine)
+
+	var signing []SigningRecord
+	var events []Event
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry HistoryExportLine
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			writeAPIError(w, http.StatusBadRequest, "line %d: %v", lineNumber, err)
+			return
+		}
+		switch {
+		case entry.Signing != nil:
+			signing = append(signing, *entry.Signing)
+		case entry.Event != nil:
+			events = append(events, *entry.Event)
+		default:
+			writeAPIError(w, http.StatusBadRequest, "line %d: expected signing or event", lineNumber)
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "read import: %v", err)
+		return
+	}
+
+	result := vt.history.Merge(signing, events)
+	persistenceLog.Info("Imported history", "signing", result.Signing, "events", result.Events,
+		"skipped_signing", result.SkippedSigning, "skipped_events", result.SkippedEvents)
+	writeJSON(w, http.StatusOK, result)
+}
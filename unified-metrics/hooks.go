@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	defaultHookTimeout   = 10 * time.Second
+	defaultHookQueueSize = 100
+	// 실패 로그에 남기는 명령 출력 최대 길이 (바이트, 나머지 출력은 버림)
+	hookOutputLogLimit = 512
+	// 종료 후 출력 파이프가 닫히기를 기다리는 최대 시간 (백그라운드 자식이 파이프를 잡고 있는 경우)
+	hookWaitDelay = time.Second
+)
+
+// 훅을 걸 수 있는 이벤트 종류
+var hookEventTypes = map[string]bool{
+	EventBlockProcessed:  true,
+	EventValidatorMissed: true,
+	EventJailed:          true,
+	EventProposalNew:     true,
+}
+
+// 이벤트에 묶은 외부 명령 (이벤트 JSON을 stdin으로 전달)
+// 훅마다 고루틴 하나가 큐를 순서대로 실행하므로 같은 훅이 동시에 실행되지 않음
+type HookConfig struct {
+	Name      string       `json:"name"`
+	Event     string       `json:"event"`
+	Command   []string     `json:"command"`              // 실행 파일과 인자 (셸을 거치지 않음)
+	Timeout   jsonDuration `json:"timeout,omitempty"`    // 기본 10s, 넘으면 프로세스 종료
+	QueueSize int          `json:"queue_size,omitempty"` // 기본 100, 가득 차면 새 이벤트를 버림
+}
+
+type hookRunner struct {
+	config HookConfig
+	dir    string
+	queue  chan Event
+}
+
+// HOOKS_ENABLED=true일 때만 HOOKS_FILE(JSON 파일) 또는 HOOKS(JSON 문자열)에서 훅 목록 읽기
+// 명령은 HOOKS_WORKDIR에서 최소한의 환경 변수로 실행
+func hooksFromEnv() ([]HookConfig, string, error) {
+	data := []byte(getEnv("HOOKS", ""))
+	if path := getEnv("HOOKS_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, "", err
+		}
+	}
+	if len(data) == 0 {
+		return nil, "", nil
+	}
+	if !getEnvBool("HOOKS_ENABLED", false) {
+		return nil, "", fmt.Errorf("hooks are configured but HOOKS_ENABLED is not true (hooks execute external commands and must be enabled explicitly)")
+	}
+	dir := getEnv("HOOKS_WORKDIR", "")
+	if dir == "" {
+		return nil, "", fmt.Errorf("HOOKS_WORKDIR is required when hooks are enabled")
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, "", fmt.Errorf("HOOKS_WORKDIR %q is not a directory", dir)
+	}
+
+	var hooks []HookConfig
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, "", fmt.Errorf("decode hooks: %w", err)
+	}
+	names := make(map[string]bool)
+	for i := range hooks {
+		hook := &hooks[i]
+		if hook.Name == "" || len(hook.Command) == 0 || hook.Command[0] == "" {
+			return nil, "", fmt.Errorf("hook %d: name and command are required", i)
+		}
+		if names[hook.Name] {
+			return nil, "", fmt.Errorf("duplicate hook name %q", hook.Name)
+		}
+		if !hookEventTypes[hook.Event] {
+			return nil, "", fmt.Errorf("hook %q: unsupported event %q (expected %s, %s, %s or %s)", hook.Name, hook.Event,
+				EventBlockProcessed, EventValidatorMissed, EventJailed, EventProposalNew)
+		}
+		names[hook.Name] = true
+		if hook.Timeout <= 0 {
+			hook.Timeout = jsonDuration(defaultHookTimeout)
+		}
+		if hook.QueueSize <= 0 {
+			hook.QueueSize = defaultHookQueueSize
+		}
+	}
+	return hooks, dir, nil
+}
+
+// 훅별 실행 고루틴 시작 후 이벤트 버스에 연결 (버스에서는 큐에 넣기만 하므로 추적 루프를 막지 않음)
+func (vt *UnifiedValidatorTracker) StartHooks(ctx context.Context, hooks []HookConfig, dir string) {
+	if len(hooks) == 0 {
+		return
+	}
+	runners := make(map[string][]*hookRunner)
+	for _, hook := range hooks {
+		runner := &hookRunner{config: hook, dir: dir, queue: make(chan Event, hook.QueueSize)}
+		runners[hook.Event] = append(runners[hook.Event], runner)
+		trackerLog.Warn("Hook enabled: external command will run on event", "hook", hook.Name, "event", hook.Event,
+			"command", strings.Join(hook.Command, " "), "workdir", dir, "timeout", time.Duration(hook.Timeout))
+		vt.goroutines.Go(componentHooks, func() { vt.runHook(ctx, runner) })
+	}
+
+	vt.events.AddSink(func(event Event) {
+		for _, runner := range runners[event.Type] {
+			select {
+			case runner.queue <- event:
+			default:
+				vt.metrics.exporter.hookDroppedMetric.WithLabelValues(runner.config.Name).Inc()
+				trackerLog.Warn("Hook queue full, dropping event", "hook", runner.config.Name, "event", event.Type, "height", event.Height)
+			}
+		}
+	})
+}
+
+func (vt *UnifiedValidatorTracker) runHook(ctx context.Context, runner *hookRunner) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-runner.queue:
+			result := "success"
+			if err := runner.exec(ctx, event); err != nil {
+				result = "failure"
+				if errors.Is(err, context.DeadlineExceeded) {
+					result = "timeout"
+				}
+				trackerLog.Warn("Hook failed", "hook", runner.config.Name, "event", event.Type, "height", event.Height,
+					"result", result, "error", err)
+			}
+			vt.metrics.exporter.hookRunsMetric.WithLabelValues(runner.config.Name, result).Inc()
+		}
+	}
+}
+
+// 명령 한 번 실행 (stdin: 이벤트 JSON, 작업 디렉터리: HOOKS_WORKDIR, 환경: PATH와 OG_HOOK_*만 전달)
+// 훅은 자기 프로세스 그룹에서 실행하고, 타임아웃이나 종료 시 그룹에 남은 프로세스를 모두 종료
+func (r *hookRunner) exec(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.config.Timeout))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, r.config.Command[0], r.config.Command[1:]...)
+	cmd.Dir = r.dir
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + r.dir,
+		"OG_HOOK_NAME=" + r.config.Name,
+		"OG_HOOK_EVENT=" + event.Type,
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	output := &hookOutput{limit: hookOutputLogLimit}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error { return killProcessGroup(cmd.Process.Pid) }
+	// 자식 프로세스가 출력 파이프를 잡고 있어도 Wait가 이 시간 뒤에 반환
+	cmd.WaitDelay = hookWaitDelay
+
+	start := time.Now()
+	err = cmd.Run()
+	if cmd.Process != nil {
+		// 훅이 띄우고 남긴 백그라운드 프로세스 정리 (이미 모두 끝났으면 ESRCH)
+		killProcessGroup(cmd.Process.Pid)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("killed after %s: %w", time.Duration(r.config.Timeout), context.DeadlineExceeded)
+	}
+	if errors.Is(err, exec.ErrWaitDelay) {
+		return fmt.Errorf("command exited but a background process kept its output open: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("%w (output: %q)", err, output.String())
+	}
+	trackerLog.Debug("Hook finished", "hook", r.config.Name, "event", event.Type, "height", event.Height,
+		"duration", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func killProcessGroup(pid int) error {
+	return syscall.Kill(-pid, syscall.SIGKILL)
+}
+
+// 명령 출력의 앞부분만 보관 (나머지는 버리되 쓰기는 성공으로 처리해 명령이 막히지 않게 함)
+// stdout과 stderr에 같은 값을 넘기면 exec가 Write를 동시에 호출하지 않음
+type hookOutput struct {
+	buf     []byte
+	limit   int
+	written int
+}
+
+func (o *hookOutput) Write(p []byte) (int, error) {
+	o.written += len(p)
+	// 문자 경계를 찾을 수 있도록 한 글자 분량을 더 보관
+	if room := o.limit + utf8.UTFMax - len(o.buf); room > 0 {
+		o.buf = append(o.buf, p[:min(len(p), room)]...)
+	}
+	return len(p), nil
+}
+
+// 보관한 출력 (limit 바이트 이하, 멀티바이트 문자 중간에서 자르지 않고 잘렸으면 "..." 표시)
+func (o *hookOutput) String() string {
+	out := string(o.buf)
+	truncated := o.written > o.limit
+	if truncated {
+		out = truncateUTF8(out, o.limit)
+	}
+	out = strings.TrimSpace(out)
+	if truncated {
+		out += "..."
+	}
+	return out
+}
+
+// 최대 n바이트로 자르되 UTF-8 문자 중간에서 자르지 않음
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newHookRunner(t *testing.T, timeout time.Duration, command ...string) *hookRunner {
+	t.Helper()
+	return &hookRunner{
+		config: HookConfig{Name: "test", Event: EventJailed, Command: command, Timeout: jsonDuration(timeout), QueueSize: 1},
+		dir:    t.TempDir(),
+		queue:  make(chan Event, 1),
+	}
+}
+
+// 프로세스가 살아 있는지 (회수되지 않은 좀비는 종료된 것으로 봄)
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return true
+	}
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) == 0 || fields[0] != "Z"
+}
+
+// 훅이 남긴 자식 프로세스의 PID (pid 파일이 생길 때까지 대기)
+func waitChildPID(t *testing.T, path string) int {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if data, err := os.ReadFile(path); err == nil {
+			if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+				return pid
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no pid in %s", path)
+	return 0
+}
+
+func assertProcessGone(t *testing.T, pid int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for processAlive(pid) {
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("background process %d still running", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestHooksFromEnvValidation(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name, enabled, workdir, config, err string
+	}{
+		{"not enabled", "", dir, `[{"name": "a", "event": "jailed", "command": ["true"]}]`, "HOOKS_ENABLED is not true"},
+		{"no workdir", "true", "", `[{"name": "a", "event": "jailed", "command": ["true"]}]`, "HOOKS_WORKDIR is required"},
+		{"workdir missing", "true", filepath.Join(dir, "missing"), `[{"name": "a", "event": "jailed", "command": ["true"]}]`, "is not a directory"},
+		{"no command", "true", dir, `[{"name": "a", "event": "jailed", "command": []}]`, "name and command are required"},
+		{"duplicate name", "true", dir, `[{"name": "a", "event": "jailed", "command": ["true"]}, {"name": "a", "event": "jailed", "command": ["true"]}]`, `duplicate hook name "a"`},
+		{"unsupported event", "true", dir, `[{"name": "a", "event": "chain_halt", "command": ["true"]}]`, `unsupported event "chain_halt"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOOKS_FILE", "")
+			t.Setenv("HOOKS", tt.config)
+			t.Setenv("HOOKS_ENABLED", tt.enabled)
+			t.Setenv("HOOKS_WORKDIR", tt.workdir)
+			if _, _, err := hooksFromEnv(); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error = %v, want containing %q", err, tt.err)
+			}
+		})
+	}
+
+	t.Setenv("HOOKS", `[{"name": "a", "event": "jailed", "command": ["true"]}]`)
+	t.Setenv("HOOKS_ENABLED", "true")
+	t.Setenv("HOOKS_WORKDIR", dir)
+	hooks, workdir, err := hooksFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workdir != dir || time.Duration(hooks[0].Timeout) != defaultHookTimeout || hooks[0].QueueSize != defaultHookQueueSize {
+		t.Errorf("hooks = %+v in %s", hooks, workdir)
+	}
+}
+
+// 이벤트 JSON은 stdin, 작업 디렉터리와 환경은 설정한 값만
+func TestHookExec(t *testing.T) {
+	runner := newHookRunner(t, 5*time.Second, "sh", "-c", `cat > event.json; env | sort > env.txt`)
+	event := Event{Type: EventJailed, Validator: "beta", Height: 120}
+	if err := runner.exec(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(runner.dir, "event.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Event
+	if err := json.Unmarshal(data, &got); err != nil || got.Validator != "beta" || got.Height != 120 {
+		t.Errorf("stdin event = %s (%v)", data, err)
+	}
+	env, err := os.ReadFile(filepath.Join(runner.dir, "env.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(env)), "\n") {
+		name, _, _ := strings.Cut(line, "=")
+		switch name {
+		case "PATH", "HOME", "OG_HOOK_NAME", "OG_HOOK_EVENT", "PWD", "SHLVL", "_": // 뒤의 셋은 sh가 설정
+		default:
+			t.Errorf("unexpected environment variable %q", line)
+		}
+	}
+}
+
+// 타임아웃을 넘긴 훅은 종료되고 runner는 바로 다음 이벤트로 넘어감
+func TestHookTimeout(t *testing.T) {
+	runner := newHookRunner(t, 200*time.Millisecond, "sleep", "30")
+	start := time.Now()
+	err := runner.exec(context.Background(), Event{Type: EventJailed})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("exec error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond+hookWaitDelay+time.Second {
+		t.Errorf("exec returned after %v", elapsed)
+	}
+}
+
+// 타임아웃 시 훅이 띄운 자식 프로세스까지 그룹 전체를 종료 (자식이 출력 파이프를 잡고 있어도 반환)
+func TestHookTimeoutKillsProcessGroup(t *testing.T) {
+	runner := newHookRunner(t, 300*time.Millisecond, "sh", "-c", `sleep 30 & echo $! > child.pid; wait`)
+	start := time.Now()
+	err := runner.exec(context.Background(), Event{Type: EventJailed})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("exec error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond+hookWaitDelay+time.Second {
+		t.Errorf("exec returned after %v", elapsed)
+	}
+	assertProcessGone(t, waitChildPID(t, filepath.Join(runner.dir, "child.pid")))
+}
+
+// 훅은 끝났지만 백그라운드 자식이 출력 파이프를 잡고 있으면 WaitDelay 뒤에 반환하고 자식을 정리
+func TestHookBackgroundChild(t *testing.T) {
+	runner := newHookRunner(t, 30*time.Second, "sh", "-c", `sleep 30 & echo $! > child.pid; echo started`)
+	start := time.Now()
+	err := runner.exec(context.Background(), Event{Type: EventJailed})
+	if !errors.Is(err, exec.ErrWaitDelay) {
+		t.Fatalf("exec error = %v, want ErrWaitDelay", err)
+	}
+	if elapsed := time.Since(start); elapsed > hookWaitDelay+2*time.Second {
+		t.Errorf("exec returned after %v, want about %v", elapsed, hookWaitDelay)
+	}
+	assertProcessGone(t, waitChildPID(t, filepath.Join(runner.dir, "child.pid")))
+}
+
+// 오래 걸리는 훅이 큐를 채우면 이벤트 버스는 막히지 않고 이벤트를 버림
+func TestHookQueueFullDoesNotBlock(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		tracker.goroutines.WaitIdle(10 * time.Second)
+	}()
+	hook := HookConfig{Name: "slow", Event: EventJailed, Command: []string{"sleep", "30"}, Timeout: jsonDuration(500 * time.Millisecond), QueueSize: 1}
+	tracker.StartHooks(ctx, []HookConfig{hook}, t.TempDir())
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		tracker.events.Publish(Event{Type: EventJailed, Height: int64(i)})
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("publishing took %v", elapsed)
+	}
+
+	// 실행 중 하나와 큐의 하나를 뺀 나머지는 버림
+	if dropped := testutil.ToFloat64(tracker.metrics.exporter.hookDroppedMetric.WithLabelValues("slow")); dropped < 3 {
+		t.Errorf("dropped = %v, want at least 3", dropped)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for testutil.ToFloat64(tracker.metrics.exporter.hookRunsMetric.WithLabelValues("slow", "timeout")) < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("hook run did not time out")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// 실패 로그의 출력은 크기를 제한하고 문자 경계에서 자름
+func TestHookOutputTruncation(t *testing.T) {
+	output := &hookOutput{limit: 10}
+	output.Write([]byte("가나다"))
+	output.Write([]byte("라마바")) // 18바이트, 한계 10바이트는 네 번째 글자 중간
+	if got := output.String(); got != "가나다..." {
+		t.Errorf("output = %q, want %q", got, "가나다...")
+	}
+	if len(output.buf) > output.limit+utf8.UTFMax {
+		t.Errorf("buffered %d bytes", len(output.buf))
+	}
+
+	short := &hookOutput{limit: 10}
+	short.Write([]byte(" ok \n"))
+	if got := short.String(); got != "ok" {
+		t.Errorf("short output = %q", got)
+	}
+
+	runner := newHookRunner(t, 5*time.Second, "sh", "-c", `i=0; while [ $i -lt 2000 ]; do printf '가'; i=$((i+1)); done; exit 3`)
+	err := runner.exec(context.Background(), Event{Type: EventJailed})
+	if err == nil {
+		t.Fatal("exec succeeded, want exit status 3")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "exit status 3") || !utf8.ValidString(msg) || strings.Contains(msg, `\x`) {
+		t.Errorf("error = %.80q...", msg)
+	}
+	if len(msg) > hookOutputLogLimit+100 {
+		t.Errorf("error message is %d bytes", len(msg))
+	}
+}
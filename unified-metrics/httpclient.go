@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultHTTPTimeout     = 4 * time.Second // 폴링 간격(기본 5초) 안에 끝나도록
+	defaultHTTPMaxAttempts = 3
+	httpRetryBaseDelay     = 200 * time.Millisecond // 재시도 간격은 200ms, 400ms, 800ms... (최대 2초)
+	httpRetryMaxDelay      = 2 * time.Second
+
+	// 한 주기에 같은 노드로 블록, 검증자 셋, 스테이킹 조회가 몰리므로 노드별 유휴 연결을 넉넉히 유지
+	httpMaxIdleConnsPerHost = 16
+	httpIdleConnTimeout     = 90 * time.Second
+)
+
+// fetch*가 공유하는 HTTP 클라이언트
+// 요청마다 타임아웃을 걸고 일시적 실패(네트워크 에러, 5xx)는 지터를 더한 지수 백오프로 재시도하며,
+// 시도별 소요 시간과 실패, 재시도를 {endpoint, method} 라벨로 기록
+// 4xx 응답은 재시도해도 결과가 같으므로 바로 반환 (JSON-RPC error 객체는 200 응답이라 역시 재시도하지 않음)
+type HTTPClient struct {
+	client      *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	budget      time.Duration // 재시도를 포함한 요청 하나의 시간 한도 (폴링 간격, 0이면 제한 없음)
+
+	durationMetric *prometheus.HistogramVec
+	errorsMetric   *prometheus.CounterVec
+	retriesMetric  *prometheus.CounterVec
+}
+
+func NewHTTPClient(timeout time.Duration, maxAttempts int, duration *prometheus.HistogramVec, errors, retries *prometheus.CounterVec) *HTTPClient {
+	return &HTTPClient{
+		client:         &http.Client{Timeout: timeout, Transport: newHTTPTransport()},
+		maxAttempts:    max(maxAttempts, 1),
+		baseDelay:      httpRetryBaseDelay,
+		maxDelay:       httpRetryMaxDelay,
+		durationMetric: duration,
+		errorsMetric:   errors,
+		retriesMetric:  retries,
+	}
+}
+
+// 재시도 간격: 지수 백오프(최대 maxDelay)의 절반에 나머지 절반 범위의 무작위 지터
+// (여러 노드가 동시에 실패해도 재시도가 한꺼번에 몰리지 않도록)
+func (c *HTTPClient) retryDelay(attempt int) time.Duration {
+	backoff := min(c.baseDelay<<(attempt-1), c.maxDelay)
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// 연결을 재사용하는 트랜스포트 (기본 트랜스포트는 호스트당 유휴 연결이 2개뿐)
+func newHTTPTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = httpMaxIdleConnsPerHost
+	transport.IdleConnTimeout = httpIdleConnTimeout
+	return transport
+}
+
+// JSON-RPC 배치처럼 요청 방식이 다른 클라이언트가 같은 연결 풀을 쓰도록 트랜스포트 공유
+func (c *HTTPClient) Transport() http.RoundTripper {
+	return c.client.Transport
+}
+
+// GET 요청 (method는 fetch 종류, endpoint는 라벨용 기준 URL)
+// 마지막 시도까지 5xx면 그 응답을 그대로 반환해 호출한 쪽에서 상태 코드로 분류
+// ctx가 취소되면 진행 중인 요청과 재시도 대기를 바로 중단
+func (c *HTTPClient) Get(ctx context.Context, method, endpoint, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	label := sanitizeEndpoint(endpoint)
+	deadline := time.Now().Add(c.budget)
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		resp, err := c.client.Do(req)
+		c.durationMetric.WithLabelValues(label, method).Observe(time.Since(start).Seconds())
+
+		transient := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		if err != nil || resp.StatusCode != http.StatusOK {
+			c.errorsMetric.WithLabelValues(label, method).Inc()
+		}
+		if !transient || attempt >= c.maxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+
+		delay := c.retryDelay(attempt)
+		if c.budget > 0 && time.Now().Add(delay).After(deadline) {
+			rpcLog.Debug("HTTP retry budget exhausted", "method", method, "endpoint", label, "attempt", attempt, "budget", c.budget)
+			return resp, err
+		}
+		c.retriesMetric.WithLabelValues(label, method).Inc()
+		if err != nil {
+			rpcLog.Debug("HTTP request failed, retrying", "method", method, "endpoint", label, "attempt", attempt, "delay", delay, "error", err)
+		} else {
+			rpcLog.Debug("HTTP request returned server error, retrying", "method", method, "endpoint", label, "attempt", attempt, "delay", delay, "status", resp.StatusCode)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
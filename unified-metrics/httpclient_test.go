@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestHTTPClient(timeout time.Duration, maxAttempts int) *HTTPClient {
+	client := NewHTTPClient(timeout, maxAttempts,
+		prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "duration"}, []string{"endpoint", "method"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{Name: "errors"}, []string{"endpoint", "method"}),
+		prometheus.NewCounterVec(prometheus.CounterOpts{Name: "retries"}, []string{"endpoint", "method"}),
+	)
+	client.baseDelay = time.Millisecond
+	client.maxDelay = 5 * time.Millisecond
+	return client
+}
+
+// 응답 전에 delay만큼 멈추는 서버 (클라이언트가 끊으면 바로 반환)
+func newSlowServer(t *testing.T, delay time.Duration, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case <-time.After(delay):
+			w.Write([]byte(`{}`))
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPClientTimeout(t *testing.T) {
+	var hits atomic.Int32
+	server := newSlowServer(t, 5*time.Second, &hits)
+	client := newTestHTTPClient(100*time.Millisecond, 1)
+
+	start := time.Now()
+	resp, err := client.Get(context.Background(), "block", server.URL, server.URL+"/block")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("request past the timeout succeeded")
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("error = %v, want a timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request returned after %v, want about the 100ms timeout", elapsed)
+	}
+	if got := testutil.ToFloat64(client.errorsMetric.WithLabelValues(sanitizeEndpoint(server.URL), "block")); got != 1 {
+		t.Errorf("errors = %v, want 1", got)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+// 타임아웃도 일시적 실패이므로 maxAttempts까지 재시도한 뒤 에러
+func TestHTTPClientTimeoutRetried(t *testing.T) {
+	var hits atomic.Int32
+	server := newSlowServer(t, 5*time.Second, &hits)
+	client := newTestHTTPClient(50*time.Millisecond, 3)
+
+	resp, err := client.Get(context.Background(), "block", server.URL, server.URL+"/block")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("request past the timeout succeeded")
+	}
+	if got := hits.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+	if got := testutil.ToFloat64(client.retriesMetric.WithLabelValues(sanitizeEndpoint(server.URL), "block")); got != 2 {
+		t.Errorf("retries = %v, want 2", got)
+	}
+}
+
+func TestHTTPClientRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int
+		want     int
+		hits     int32
+	}{
+		{"success", []int{200}, 3, 200, 1},
+		{"5xx then success", []int{503, 502, 200}, 3, 200, 3},
+		{"5xx until the last attempt", []int{503, 503, 503, 200}, 3, 503, 3},
+		{"4xx is not retried", []int{404, 200}, 3, 404, 1},
+		{"single attempt", []int{500, 200}, 1, 500, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(hits.Add(1)) - 1
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses)-1)])
+			}))
+			defer server.Close()
+
+			client := newTestHTTPClient(time.Second, tt.attempts)
+			resp, err := client.Get(context.Background(), "block", server.URL, server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if got := hits.Load(); got != tt.hits {
+				t.Errorf("attempts = %d, want %d", got, tt.hits)
+			}
+		})
+	}
+}
+
+// 재시도 대기가 예산(폴링 간격)을 넘으면 더 시도하지 않음
+func TestHTTPClientBudget(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestHTTPClient(time.Second, 5)
+	client.baseDelay, client.maxDelay = time.Second, time.Second
+	client.budget = 100 * time.Millisecond
+	resp, err := client.Get(context.Background(), "block", server.URL, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := hits.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestHTTPClientCancelDuringRetryWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestHTTPClient(time.Second, 5)
+	client.baseDelay, client.maxDelay = time.Minute, time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Get(ctx, "block", server.URL, server.URL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retry wait not interrupted, returned after %v", elapsed)
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// 서명 여부는 직전 블록 기준이므로 한 블록 늦게 반영됨
+func TestIntegrationMissStreak(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta", "gamma")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+
+	h.chain.SetSigning("beta", false)
+	for i := 0; i < 4; i++ {
+		if err := h.advance(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", "beta"); got != 3 {
+		t.Errorf("beta streak = %v, want 3", got)
+	}
+	for _, name := range []string{"alpha", "gamma"} {
+		if got := h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", name); got != 0 {
+			t.Errorf("%s streak = %v, want 0", name, got)
+		}
+	}
+	tip := strconv.FormatInt(h.chain.Height(), 10)
+	if got := h.mustValue("og_galileo_validator_beacon_block_signed", "validator", "beta", "block_height", tip); got != 0 {
+		t.Errorf("beta signed at %s = %v, want 0", tip, got)
+	}
+	if got := h.mustValue("og_galileo_validator_beacon_block_signed", "validator", "alpha", "block_height", tip); got != 1 {
+		t.Errorf("alpha signed at %s = %v, want 1", tip, got)
+	}
+
+	// 다시 서명하면 한 블록 뒤에 연속 누락이 끊김
+	h.chain.SetSigning("beta", true)
+	for i := 0; i < 2; i++ {
+		if err := h.advance(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", "beta"); got != 0 {
+		t.Errorf("beta streak after signing again = %v, want 0", got)
+	}
+}
+
+func TestIntegrationJail(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta", "gamma")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_validator_is_jailed", "validator", "beta"); got != 0 {
+		t.Fatalf("beta jailed before jail = %v", got)
+	}
+
+	h.chain.Jail("beta")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_validator_is_jailed", "validator", "beta"); got != 1 {
+		t.Errorf("beta is_jailed = %v, want 1", got)
+	}
+	if got := h.mustValue("og_galileo_validator_is_bonded", "validator", "beta"); got != 0 {
+		t.Errorf("beta is_bonded = %v, want 0", got)
+	}
+	if got := h.mustValue("og_galileo_validator_status", "validator", "beta"); got != 0 {
+		t.Errorf("beta status = %v, want 0 (out of the validator set)", got)
+	}
+	if got := h.mustValue("og_galileo_validator_is_jailed", "validator", "alpha"); got != 0 {
+		t.Errorf("alpha is_jailed = %v, want 0", got)
+	}
+
+	h.chain.Unjail("beta")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_validator_is_jailed", "validator", "beta"); got != 0 {
+		t.Errorf("beta is_jailed after unjail = %v, want 0", got)
+	}
+	if got := h.mustValue("og_galileo_validator_status", "validator", "beta"); got != 1 {
+		t.Errorf("beta status after unjail = %v, want 1", got)
+	}
+}
+
+// 지분 부족으로 셋에서 빠졌다가 돌아오는 경우 (제일 아님)
+func TestIntegrationValidatorSetChange(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta", "gamma")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		if got := h.mustValue("og_galileo_validator_status", "validator", name); got != 1 {
+			t.Fatalf("%s status = %v, want 1", name, got)
+		}
+	}
+
+	h.chain.SetInSet("gamma", false)
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_validator_status", "validator", "gamma"); got != 0 {
+		t.Errorf("gamma status = %v, want 0", got)
+	}
+	if got := h.mustValue("og_galileo_validator_is_bonded", "validator", "gamma"); got != 0 {
+		t.Errorf("gamma is_bonded = %v, want 0", got)
+	}
+	if got := h.mustValue("og_galileo_validator_is_jailed", "validator", "gamma"); got != 0 {
+		t.Errorf("gamma is_jailed = %v, want 0", got)
+	}
+
+	h.chain.SetInSet("gamma", true)
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_validator_status", "validator", "gamma"); got != 1 {
+		t.Errorf("gamma status after rejoining = %v, want 1", got)
+	}
+}
+
+func TestIntegrationRPCOutageAndRecovery(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	if err := h.advance(2); err != nil {
+		t.Fatal(err)
+	}
+	before := h.tracker.LastHeight()
+
+	h.chain.SetOutage(true)
+	h.chain.Advance(3)
+	if err := h.cycle(); err == nil {
+		t.Fatal("cycle during outage returned no error")
+	}
+	if got := h.tracker.LastHeight(); got != before {
+		t.Errorf("last height moved during outage: %d -> %d", before, got)
+	}
+	if got := h.mustValue("og_galileo_validator_block_height"); got != float64(before) {
+		t.Errorf("block height metric = %v during outage, want %d", got, before)
+	}
+	if got, _ := h.value("og_galileo_exporter_rpc_errors_total"); got == 0 {
+		t.Error("outage not counted in og_galileo_exporter_rpc_errors_total")
+	}
+
+	// 복구 후 장애 동안 생긴 블록을 채우고 최신 블록까지 따라잡음
+	h.chain.SetOutage(false)
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	tip := h.chain.Height()
+	if got := h.tracker.LastHeight(); got != tip {
+		t.Errorf("last height after recovery = %d, want %d", got, tip)
+	}
+	if got := h.mustValue("og_galileo_validator_block_height"); got != float64(tip) {
+		t.Errorf("block height metric after recovery = %v, want %d", got, tip)
+	}
+	for height := before + 1; height <= tip; height++ {
+		if _, ok := h.value("og_galileo_validator_beacon_block_signed", "validator", "alpha", "block_height", strconv.FormatInt(height, 10)); !ok {
+			t.Errorf("height %d missing after recovery", height)
+		}
+	}
+}
+
+// 테스트넷 리셋: 체인 ID가 바뀌고 높이가 1부터 다시 시작해도 계속 추적
+func TestIntegrationChainReset(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	h.chain.SetSigning("beta", false)
+	if err := h.advance(5); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	oldChainID := h.tracker.ChainID()
+	if h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", "beta") == 0 {
+		t.Fatal("beta streak not recorded before reset")
+	}
+
+	h.chain.SetSigning("beta", true)
+	h.chain.Reset("zgtendermint_16601-3")
+	if err := h.advance(2); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_exporter_chain_resets_total"); got != 1 {
+		t.Errorf("chain resets = %v, want 1", got)
+	}
+	if got := h.tracker.ChainID(); got == oldChainID || got != "zgtendermint_16601-3" {
+		t.Errorf("chain id after reset = %q (was %q)", got, oldChainID)
+	}
+	if got, want := h.tracker.LastHeight(), h.chain.Height(); got != want {
+		t.Errorf("last height after reset = %d, want %d", got, want)
+	}
+	if got, ok := h.value("og_galileo_validator_consecutive_missed_blocks", "validator", "beta"); ok && got != 0 {
+		t.Errorf("beta streak carried over the reset: %v", got)
+	}
+
+	// 새 체인에서도 블록이 계속 적용됨
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := h.tracker.LastHeight(), h.chain.Height(); got != want {
+		t.Errorf("last height on the new chain = %d, want %d", got, want)
+	}
+}
+
+// 가짜 노드의 /websocket 구독으로 새 블록마다 blocks 수집기가 깨어남
+func TestIntegrationWebSocketEvents(t *testing.T) {
+	h := newTestHarness(t, "alpha")
+	events := NewWebSocketTracker(h.tracker)
+	h.tracker.goroutines.Go(componentWebSocket, func() { events.Run(h.ctx) })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if got, _ := h.value("og_galileo_exporter_websocket_connected"); got == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("websocket subscription not established")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	h.chain.Advance(1)
+	select {
+	case <-events.Wake():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no wake-up after a new block")
+	}
+}
@@ -0,0 +1,413 @@
+// Package confparse는 설정 파일에 필요한 YAML/TOML 부분집합만 구현한다.
+// 값은 문자열, 목록([]interface{}) 또는 중첩 맵(map[string]interface{})이며, 숫자와 불리언도 문자열로 반환한다.
+// 앵커, 여러 줄 문자열, 인라인 맵, 중첩 목록, 여러 줄에 걸친 배열은 지원하지 않는다.
+package confparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 파싱 결과: 값은 string, []interface{} 또는 map[string]interface{}
+type Document = map[string]interface{}
+
+// 줄 번호를 포함한 파싱 에러
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string { return fmt.Sprintf("line %d: %s", e.Line, e.Msg) }
+
+// YAML 블록 스타일 맵, 목록("- " 항목)과 스칼라 (들여쓰기는 공백만 허용)
+// 목록 항목은 스칼라 또는 "- key: value"로 시작하는 맵이며, 한 줄짜리 [a, b] 목록도 허용
+func ParseYAML(data []byte) (Document, error) {
+	type frame struct {
+		indent int
+		m      Document
+		// 목록 프레임: 항목은 parent[key]에 다시 저장 (append로 슬라이스가 바뀌므로)
+		list   bool
+		items  []interface{}
+		parent Document
+		key    string
+	}
+	root := Document{}
+	stack := []frame{{indent: 0, m: root}}
+	// 값 없이 "key:"로 끝난 줄: 다음 줄이 "- "면 목록, 더 깊게 들여쓰였으면 중첩 맵, 아니면 빈 값
+	var pendingMap Document
+	var pendingKey string
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		line := strings.TrimRight(stripComment(raw), " \r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, &SyntaxError{lineNo, "tabs are not allowed for indentation"}
+		}
+		indent := len(line) - len(trimmed)
+		isItem := strings.HasPrefix(trimmed, "- ") || trimmed == "-"
+
+		if pendingMap != nil {
+			switch top := stack[len(stack)-1]; {
+			case isItem && indent >= top.indent:
+				// 목록은 키와 같은 들여쓰기로 시작해도 됨
+				pendingMap[pendingKey] = []interface{}{}
+				stack = append(stack, frame{indent: indent, list: true, items: []interface{}{}, parent: pendingMap, key: pendingKey})
+			case indent > top.indent:
+				child := Document{}
+				pendingMap[pendingKey] = child
+				stack = append(stack, frame{indent: indent, m: child})
+			default:
+				pendingMap[pendingKey] = ""
+			}
+			pendingMap = nil
+		}
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if indent < top.indent || (top.list && indent == top.indent && !isItem) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			break
+		}
+		top := &stack[len(stack)-1]
+		if indent != top.indent {
+			return nil, &SyntaxError{lineNo, "inconsistent indentation"}
+		}
+
+		content := trimmed
+		current := top.m
+		if isItem {
+			if !top.list {
+				return nil, &SyntaxError{lineNo, "list item outside a list"}
+			}
+			content = strings.TrimLeft(trimmed[1:], " ")
+			switch {
+			case content == "":
+				return nil, &SyntaxError{lineNo, "empty list items are not supported"}
+			case strings.HasPrefix(content, "- ") || content == "-":
+				return nil, &SyntaxError{lineNo, "nested lists are not supported"}
+			}
+			if _, _, ok := splitYAMLKey(content); !ok {
+				value, err := yamlValue(content)
+				if err != nil {
+					return nil, &SyntaxError{lineNo, err.Error()}
+				}
+				if _, isList := value.([]interface{}); isList {
+					return nil, &SyntaxError{lineNo, "nested lists are not supported"}
+				}
+				top.items = append(top.items, value)
+				top.parent[top.key] = top.items
+				continue
+			}
+			// "- key: value": 맵 항목, 이어지는 키는 첫 키와 같은 열에 맞춤
+			current = Document{}
+			top.items = append(top.items, current)
+			top.parent[top.key] = top.items
+			stack = append(stack, frame{indent: indent + len(trimmed) - len(content), m: current})
+		}
+
+		keyText, valueText, ok := splitYAMLKey(content)
+		if !ok {
+			return nil, &SyntaxError{lineNo, fmt.Sprintf("expected \"key: value\", got %q", content)}
+		}
+		key, err := unquote(keyText)
+		if err != nil {
+			return nil, &SyntaxError{lineNo, err.Error()}
+		}
+		if _, exists := current[key]; exists {
+			return nil, &SyntaxError{lineNo, fmt.Sprintf("duplicate key %q", key)}
+		}
+		if valueText == "" {
+			pendingMap, pendingKey = current, key
+			continue
+		}
+		value, err := yamlValue(valueText)
+		if err != nil {
+			return nil, &SyntaxError{lineNo, err.Error()}
+		}
+		current[key] = value
+	}
+	if pendingMap != nil {
+		pendingMap[pendingKey] = ""
+	}
+	return root, nil
+}
+
+// 한 줄 값: 스칼라, {} (빈 맵), 또는 [a, b] (스칼라 목록)
+func yamlValue(text string) (interface{}, error) {
+	switch {
+	case text == "{}":
+		return Document{}, nil
+	case strings.HasPrefix(text, "{"):
+		return nil, fmt.Errorf("flow-style maps are not supported")
+	case strings.HasPrefix(text, "["):
+		return parseFlowList(text)
+	}
+	return unquote(text)
+}
+
+// "[a, 'b', \"c\"]" 형식의 한 줄 목록 (마지막 쉼표 허용, 중첩 목록과 맵은 불가)
+func parseFlowList(text string) ([]interface{}, error) {
+	if !strings.HasSuffix(text, "]") {
+		return nil, fmt.Errorf("unterminated list %s (lists must fit on one line)", text)
+	}
+	rest := strings.TrimSpace(text[1 : len(text)-1])
+	items := []interface{}{}
+	for rest != "" {
+		end := indexOutsideQuotes(rest, ',')
+		part := rest
+		if end >= 0 {
+			part, rest = rest[:end], strings.TrimSpace(rest[end+1:])
+		} else {
+			rest = ""
+		}
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("empty item in list %s", text)
+		}
+		if strings.HasPrefix(part, "[") || strings.HasPrefix(part, "{") {
+			return nil, fmt.Errorf("nested lists and maps are not supported in %s", text)
+		}
+		value, err := unquote(part)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	return items, nil
+}
+
+// "key: value"에서 키와 값 분리 (따옴표 안의 콜론은 무시)
+func splitYAMLKey(line string) (string, string, bool) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ':' && (i+1 == len(line) || line[i+1] == ' '):
+			return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), i > 0
+		}
+	}
+	return "", "", false
+}
+
+// TOML 테이블([a.b]), 테이블 배열([[a]])과 "key = value" (문자열, 숫자, 불리언은 모두 문자열로 반환)
+// 배열은 한 줄짜리 스칼라 배열만 지원
+func ParseTOML(data []byte) (Document, error) {
+	root := Document{}
+	current := root
+	for i, raw := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripComment(raw))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[[") {
+			if !strings.HasSuffix(line, "]]") {
+				return nil, &SyntaxError{lineNo, "unterminated array of tables header"}
+			}
+			path, err := splitDottedKey(strings.TrimSpace(line[2 : len(line)-2]))
+			if err != nil {
+				return nil, &SyntaxError{lineNo, err.Error()}
+			}
+			if current, err = appendTable(root, path); err != nil {
+				return nil, &SyntaxError{lineNo, err.Error()}
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, &SyntaxError{lineNo, "unterminated table header"}
+			}
+			path, err := splitDottedKey(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, &SyntaxError{lineNo, err.Error()}
+			}
+			if current, err = descend(root, path); err != nil {
+				return nil, &SyntaxError{lineNo, err.Error()}
+			}
+			continue
+		}
+
+		eq := indexOutsideQuotes(line, '=')
+		if eq < 0 {
+			return nil, &SyntaxError{lineNo, fmt.Sprintf("expected \"key = value\", got %q", line)}
+		}
+		path, err := splitDottedKey(strings.TrimSpace(line[:eq]))
+		if err != nil {
+			return nil, &SyntaxError{lineNo, err.Error()}
+		}
+		valueText := strings.TrimSpace(line[eq+1:])
+		var value interface{}
+		switch {
+		case strings.HasPrefix(valueText, "{"):
+			return nil, &SyntaxError{lineNo, "inline tables are not supported"}
+		case strings.HasPrefix(valueText, "["):
+			value, err = parseFlowList(valueText)
+		default:
+			value, err = unquote(valueText)
+		}
+		if err != nil {
+			return nil, &SyntaxError{lineNo, err.Error()}
+		}
+		table, err := descend(current, path[:len(path)-1])
+		if err != nil {
+			return nil, &SyntaxError{lineNo, err.Error()}
+		}
+		key := path[len(path)-1]
+		if _, exists := table[key]; exists {
+			return nil, &SyntaxError{lineNo, fmt.Sprintf("duplicate key %q", key)}
+		}
+		table[key] = value
+	}
+	return root, nil
+}
+
+// 점으로 구분된 키 (각 부분은 따옴표로 감쌀 수 있음)
+func splitDottedKey(key string) ([]string, error) {
+	var parts []string
+	for key != "" {
+		end := indexOutsideQuotes(key, '.')
+		part := key
+		if end >= 0 {
+			part, key = key[:end], key[end+1:]
+		} else {
+			key = ""
+		}
+		part, err := unquote(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if part == "" {
+			return nil, fmt.Errorf("empty key")
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("empty key")
+	}
+	return parts, nil
+}
+
+// path를 따라 하위 테이블을 찾거나 만듦 (테이블 배열은 마지막 테이블로 내려감)
+func descend(table Document, path []string) (Document, error) {
+	for _, part := range path {
+		switch next := table[part].(type) {
+		case nil:
+			child := Document{}
+			table[part] = child
+			table = child
+		case Document:
+			table = next
+		case []interface{}:
+			last, ok := lastTable(next)
+			if !ok {
+				return nil, fmt.Errorf("key %q is already an array, not a table", part)
+			}
+			table = last
+		default:
+			return nil, fmt.Errorf("key %q is already a value, not a table", part)
+		}
+	}
+	return table, nil
+}
+
+// [[path]]: path 배열에 새 테이블을 추가
+func appendTable(root Document, path []string) (Document, error) {
+	parent, err := descend(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	var tables []interface{}
+	switch existing := parent[key].(type) {
+	case nil:
+	case []interface{}:
+		if _, ok := lastTable(existing); !ok {
+			return nil, fmt.Errorf("key %q is already an array of values", key)
+		}
+		tables = existing
+	default:
+		return nil, fmt.Errorf("key %q is already defined, not an array of tables", key)
+	}
+	table := Document{}
+	parent[key] = append(tables, table)
+	return table, nil
+}
+
+func lastTable(items []interface{}) (Document, bool) {
+	if len(items) == 0 {
+		return nil, false
+	}
+	table, ok := items[len(items)-1].(Document)
+	return table, ok
+}
+
+func indexOutsideQuotes(s string, target byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == target:
+			return i
+		}
+	}
+	return -1
+}
+
+// 따옴표 밖의 "#"부터 줄 끝까지 제거 (YAML처럼 앞이 공백이거나 줄 처음일 때만 주석)
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// 큰따옴표(이스케이프 처리), 작은따옴표(그대로), 또는 따옴표 없는 값
+func unquote(s string) (string, error) {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		value, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted string %s", s)
+		}
+		return value, nil
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1], nil
+	}
+	if strings.HasPrefix(s, "\"") || strings.HasPrefix(s, "'") {
+		return "", fmt.Errorf("unterminated string %s", s)
+	}
+	return s, nil
+}
@@ -0,0 +1,184 @@
+package confparse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type list = []interface{}
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Document
+	}{
+		{"empty", "", Document{}},
+		{"document marker and comments", "---\n# comment\nkey: value # trailing\n", Document{"key": "value"}},
+		{"scalars stay strings", "port: 8080\nenabled: true\nratio: 0.5\n", Document{"port": "8080", "enabled": "true", "ratio": "0.5"}},
+		{"quoted", "a: \"x: y # z\"\nb: '\\n kept'\nc: \"tab\\tnewline\\n\"\n", Document{"a": "x: y # z", "b": "\\n kept", "c": "tab\tnewline\n"}},
+		{"quoted key", "\"with space\": 1\n", Document{"with space": "1"}},
+		{"url value", "rpc_endpoint: http://127.0.0.1:26657\n", Document{"rpc_endpoint": "http://127.0.0.1:26657"}},
+		{"hash inside value", "color: a#b\n", Document{"color": "a#b"}},
+		{"empty value", "a:\nb: 1\n", Document{"a": "", "b": "1"}},
+		{"empty value at end", "a: 1\nb:\n", Document{"a": "1", "b": ""}},
+		{"empty map", "env: {}\n", Document{"env": Document{}}},
+		{"nested maps", "a:\n  b:\n    c: 1\n  d: 2\ne: 3\n", Document{"a": Document{"b": Document{"c": "1"}, "d": "2"}, "e": "3"}},
+		{"crlf", "a: 1\r\nb: 2\r\n", Document{"a": "1", "b": "2"}},
+		{"scalar list", "tags:\n  - a\n  - \"b c\"\n  - 'd'\n", Document{"tags": list{"a", "b c", "d"}}},
+		{"list at key indent", "tags:\n- a\n- b\nnext: 1\n", Document{"tags": list{"a", "b"}, "next": "1"}},
+		{"flow list", "tags: [a, \"b, c\", 'd']\n", Document{"tags": list{"a", "b, c", "d"}}},
+		{"flow list trailing comma", "tags: [a, b, ]\n", Document{"tags": list{"a", "b"}}},
+		{"empty flow list", "tags: []\n", Document{"tags": list{}}},
+		{
+			"list of maps",
+			"probes:\n  - name: grafana\n    url: http://grafana:3000/api/health\n  - name: prom\n    url: http://prom:9090/-/ready\n    expected_status: 200\n",
+			Document{"probes": list{
+				Document{"name": "grafana", "url": "http://grafana:3000/api/health"},
+				Document{"name": "prom", "url": "http://prom:9090/-/ready", "expected_status": "200"},
+			}},
+		},
+		{
+			"list of maps at key indent",
+			"probes:\n- name: a\n  url: http://a\n- name: b\n  url: http://b\nlisten_addr: :8080\n",
+			Document{
+				"probes":      list{Document{"name": "a", "url": "http://a"}, Document{"name": "b", "url": "http://b"}},
+				"listen_addr": ":8080",
+			},
+		},
+		{
+			"nested values inside list items",
+			"items:\n  - name: a\n    labels:\n      team: x\n    tags:\n      - t1\n    ports: [1, 2]\n  - b\n",
+			Document{"items": list{
+				Document{"name": "a", "labels": Document{"team": "x"}, "tags": list{"t1"}, "ports": list{"1", "2"}},
+				"b",
+			}},
+		},
+		{"item with empty value", "items:\n  - name:\n    url: x\n", Document{"items": list{Document{"name": "", "url": "x"}}}},
+		{"list then dedent to parent map", "a:\n  b:\n    - 1\n  c: 2\n", Document{"a": Document{"b": list{"1"}, "c": "2"}}},
+		{"colon without space is a scalar item", "hosts:\n  - 10.0.0.1:26657\n", Document{"hosts": list{"10.0.0.1:26657"}}},
+		{"quoted colon item", "items:\n  - \"a: b\"\n", Document{"items": list{"a: b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseYAML([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("ParseYAML: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseYAML =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		msg   string
+	}{
+		{"tab indentation", "a:\n\tb: 1\n", 2, "tabs"},
+		{"inconsistent indentation", "a:\n    b: 1\n  c: 2\n", 3, "inconsistent indentation"},
+		{"not key value", "a: 1\njust text\n", 2, "expected \"key: value\""},
+		{"duplicate key", "a: 1\na: 2\n", 2, "duplicate key \"a\""},
+		{"duplicate key in item", "items:\n  - a: 1\n    a: 2\n", 3, "duplicate key"},
+		{"unterminated string", "a: \"abc\n", 1, "unterminated string"},
+		{"flow map", "a: {b: 1}\n", 1, "flow-style maps"},
+		{"unterminated flow list", "a: [1, 2\n", 1, "unterminated list"},
+		{"nested flow list", "a: [[1], 2]\n", 1, "nested lists"},
+		{"empty flow item", "a: [1, , 2]\n", 1, "empty item"},
+		{"item outside list", "a: 1\n- b\n", 2, "list item outside a list"},
+		{"empty item", "a:\n  -\n", 2, "empty list items"},
+		{"nested block list", "a:\n  - - b\n", 2, "nested lists"},
+		{"nested flow list item", "a:\n  - [1, 2]\n", 2, "nested lists"},
+		{"key after list at deeper indent", "a:\n  - 1\n    b: 2\n", 3, "inconsistent indentation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseYAML([]byte(tt.input))
+			assertSyntaxError(t, err, tt.line, tt.msg)
+		})
+	}
+}
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Document
+	}{
+		{"empty", "", Document{}},
+		{"values", "a = \"x\" # comment\nb = 5\nc = true\nd = 'raw\\n'\n", Document{"a": "x", "b": "5", "c": "true", "d": "raw\\n"}},
+		{"tables", "top = 1\n[a]\nb = 2\n[a.c]\nd = 3\n", Document{"top": "1", "a": Document{"b": "2", "c": Document{"d": "3"}}}},
+		{"dotted and quoted keys", "a.b = 1\n\"x.y\" = 2\n", Document{"a": Document{"b": "1"}, "x.y": "2"}},
+		{"quoted table name", "[validators]\n\"21F5C524\" = \"validator1\"\n", Document{"validators": Document{"21F5C524": "validator1"}}},
+		{"array", "tags = [\"a\", \"b, c\", 3]\n", Document{"tags": list{"a", "b, c", "3"}}},
+		{"empty array", "tags = []\n", Document{"tags": list{}}},
+		{
+			"array of tables",
+			"listen_addr = \":8080\"\n[[probes]]\nname = \"a\"\nurl = \"http://a\"\n\n[[probes]]\nname = \"b\"\nurl = \"http://b\"\n",
+			Document{
+				"listen_addr": ":8080",
+				"probes":      list{Document{"name": "a", "url": "http://a"}, Document{"name": "b", "url": "http://b"}},
+			},
+		},
+		{
+			"subtable of the last array element",
+			"[[items]]\nname = \"a\"\n[items.labels]\nteam = \"x\"\n[[items]]\nname = \"b\"\n",
+			Document{"items": list{Document{"name": "a", "labels": Document{"team": "x"}}, Document{"name": "b"}}},
+		},
+		{"nested array of tables", "[[a.b]]\nc = 1\n", Document{"a": Document{"b": list{Document{"c": "1"}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTOML([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("ParseTOML: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTOML =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		line  int
+		msg   string
+	}{
+		{"not key value", "a = 1\njust text\n", 2, "expected \"key = value\""},
+		{"unterminated table", "[a\n", 1, "unterminated table header"},
+		{"unterminated array of tables", "[[a]\n", 1, "unterminated array of tables"},
+		{"empty key", " = 1\n", 1, "empty key"},
+		{"duplicate key", "a = 1\na = 2\n", 2, "duplicate key"},
+		{"value then table", "a = 1\n[a]\n", 2, "already a value"},
+		{"array of values then array of tables", "a = [1]\n[[a]]\n", 2, "array of values"},
+		{"table then array of tables", "[a]\n[[a]]\n", 2, "not an array of tables"},
+		{"inline table", "a = {b = 1}\n", 1, "inline tables"},
+		{"multi-line array", "a = [\n  1,\n]\n", 1, "unterminated list"},
+		{"unterminated string", "a = \"x\n", 1, "unterminated string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTOML([]byte(tt.input))
+			assertSyntaxError(t, err, tt.line, tt.msg)
+		})
+	}
+}
+
+func assertSyntaxError(t *testing.T, err error, line int, msg string) {
+	t.Helper()
+	syntaxErr, ok := err.(*SyntaxError)
+	if !ok {
+		t.Fatalf("error = %v (%T), want *SyntaxError", err, err)
+	}
+	if syntaxErr.Line != line || !strings.Contains(syntaxErr.Msg, msg) {
+		t.Errorf("error = %v, want line %d containing %q", err, line, msg)
+	}
+}
@@ -0,0 +1,144 @@
+// Package jsonpath는 REST 응답에서 숫자 하나를 꺼내는 데 필요한 JSONPath 부분집합만 구현한다.
+// $.a.b, $.list[0].c, $['key.with.dots'], 배열·객체 크기를 세는 .length() 를 지원한다.
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type stepKind int
+
+const (
+	stepField stepKind = iota
+	stepIndex
+	stepLength
+)
+
+type step struct {
+	kind  stepKind
+	field string
+	index int
+}
+
+// 컴파일된 경로
+type Path struct {
+	expr  string
+	steps []step
+}
+
+func (p *Path) String() string { return p.expr }
+
+// 경로 문법 검사 (시작 시 설정 검증용)
+func Compile(expr string) (*Path, error) {
+	rest := strings.TrimSpace(expr)
+	if !strings.HasPrefix(rest, "$") {
+		return nil, fmt.Errorf("path %q must start with $", expr)
+	}
+	rest = rest[1:]
+	path := &Path{expr: expr}
+	for rest != "" {
+		if path.hasLength() {
+			return nil, fmt.Errorf("path %q: length() must be the last step", expr)
+		}
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			if name == "" {
+				return nil, fmt.Errorf("path %q: empty field name", expr)
+			}
+			if name == "length()" {
+				path.steps = append(path.steps, step{kind: stepLength})
+			} else {
+				path.steps = append(path.steps, step{kind: stepField, field: name})
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("path %q: unterminated [", expr)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				path.steps = append(path.steps, step{kind: stepField, field: inner[1 : len(inner)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("path %q: invalid index [%s]", expr, inner)
+			}
+			path.steps = append(path.steps, step{kind: stepIndex, index: index})
+		default:
+			return nil, fmt.Errorf("path %q: unexpected %q", expr, rest[0])
+		}
+	}
+	return path, nil
+}
+
+func (p *Path) hasLength() bool {
+	return len(p.steps) > 0 && p.steps[len(p.steps)-1].kind == stepLength
+}
+
+// 디코딩한 JSON(json.Decoder.UseNumber 권장)에서 경로의 값을 숫자로 변환
+// 숫자, 숫자 문자열(Cosmos의 큰 정수와 Dec 값), 불리언(1/0)을 허용
+func (p *Path) Float(doc interface{}) (float64, error) {
+	value := doc
+	for _, s := range p.steps {
+		switch s.kind {
+		case stepField:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return 0, fmt.Errorf("%s: %q is not an object field", p.expr, s.field)
+			}
+			if value, ok = object[s.field]; !ok {
+				return 0, fmt.Errorf("%s: field %q not found", p.expr, s.field)
+			}
+		case stepIndex:
+			array, ok := value.([]interface{})
+			if !ok {
+				return 0, fmt.Errorf("%s: [%d] applied to a non-array", p.expr, s.index)
+			}
+			if s.index >= len(array) {
+				return 0, fmt.Errorf("%s: index %d out of range (length %d)", p.expr, s.index, len(array))
+			}
+			value = array[s.index]
+		case stepLength:
+			switch v := value.(type) {
+			case []interface{}:
+				return float64(len(v)), nil
+			case map[string]interface{}:
+				return float64(len(v)), nil
+			}
+			return 0, fmt.Errorf("%s: length() applied to a non-array", p.expr)
+		}
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		return strconv.ParseFloat(v.String(), 64)
+	case float64:
+		return v, nil
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s: value %q is not numeric", p.expr, v)
+		}
+		return parsed, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case nil:
+		return 0, fmt.Errorf("%s: value is null", p.expr)
+	}
+	return 0, fmt.Errorf("%s: value is not a number", p.expr)
+}
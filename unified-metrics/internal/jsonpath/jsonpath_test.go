@@ -0,0 +1,137 @@
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testdata의 응답은 0G 갈릴레오 노드 REST(LCD)가 돌려주는 형식 그대로 (Cosmos 모듈과 0G dasigners 모듈)
+func loadResponse(t *testing.T, name string) interface{} {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestExtractFromNodeResponses(t *testing.T) {
+	tests := []struct {
+		file string
+		expr string
+		want float64
+	}{
+		{"mint_inflation.json", "$.inflation", 0.13},
+		{"staking_pool.json", "$.pool.bonded_tokens", 98765432100000000000000000},
+		{"staking_pool.json", "$['pool']['not_bonded_tokens']", 1234567890000000000000},
+		{"dasigners_params.json", "$.params.epoch_blocks", 5760},
+		{"dasigners_params.json", "$.params.max_votes_per_signer", 1024},
+		{"dasigners_params.json", "$.params.length()", 5},
+		{"dasigners_epoch_number.json", "$.epoch_number", 1523},
+		{"dasigners_quorum.json", "$.quorum.signers.length()", 3},
+		{"gov_proposals.json", "$.proposals.length()", 2},
+		{"gov_proposals.json", "$.pagination.total", 2},
+		{"gov_proposals.json", "$.proposals[0].final_tally_result.yes_count", 61000000000000000000000000},
+		{"gov_proposals.json", "$.proposals[1].id", 8},
+		{"bank_supply_by_denom.json", "$.amount.amount", 1e27},
+		{"slashing_signing_info.json", "$.val_signing_info.missed_blocks_counter", 3},
+		{"slashing_signing_info.json", "$.val_signing_info.tombstoned", 0},
+		{"slashing_signing_info.json", `$["val_signing_info"].index_offset`, 4821937},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file+" "+tt.expr, func(t *testing.T) {
+			path, err := Compile(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := path.Float(loadResponse(t, tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+// 값이 없거나 숫자가 아니면 에러 (게이지를 0으로 덮어쓰지 않도록)
+func TestExtractErrors(t *testing.T) {
+	tests := []struct {
+		file string
+		expr string
+		err  string
+	}{
+		{"upgrade_current_plan.json", "$.plan", "value is null"},
+		{"upgrade_current_plan.json", "$.plan.height", "is not an object field"},
+		{"mint_inflation.json", "$.annual_provisions", `field "annual_provisions" not found`},
+		{"gov_proposals.json", "$.proposals[2].id", "index 2 out of range (length 2)"},
+		{"gov_proposals.json", "$.proposals[0].status", "is not numeric"},
+		{"gov_proposals.json", "$.proposals[0].final_tally_result", "not a number"},
+		{"gov_proposals.json", "$.pagination.next_key", "value is null"},
+		{"gov_proposals.json", "$.pagination[0]", "applied to a non-array"},
+		{"dasigners_epoch_number.json", "$.epoch_number.length()", "length() applied to a non-array"},
+		{"slashing_signing_info.json", "$.val_signing_info.address", "is not numeric"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file+" "+tt.expr, func(t *testing.T) {
+			path, err := Compile(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := path.Float(loadResponse(t, tt.file)); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("error = %v, want containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"inflation", "must start with $"},
+		{"", "must start with $"},
+		{"$..inflation", "empty field name"},
+		{"$.pool.", "empty field name"},
+		{"$.proposals[0", "unterminated ["},
+		{"$.proposals[-1]", "invalid index [-1]"},
+		{"$.proposals[*]", "invalid index [*]"},
+		{"$.proposals.length().id", "length() must be the last step"},
+		{"$pool", "unexpected 'p'"},
+	}
+	for _, tt := range tests {
+		if _, err := Compile(tt.expr); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Compile(%q) error = %v, want containing %q", tt.expr, err, tt.err)
+		}
+	}
+}
+
+func TestPathString(t *testing.T) {
+	path, err := Compile(" $.pool.bonded_tokens ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := path.String(); got != " $.pool.bonded_tokens " {
+		t.Errorf("String() = %q", got)
+	}
+	// 루트 자체가 숫자인 응답
+	root, err := Compile("$")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := root.Float(json.Number("42")); err != nil || got != 42 {
+		t.Errorf("$ on a number = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,435 @@
+// Package rpcmock는 익스포터를 실제 노드 없이 돌려 보기 위한 가짜 0G 체인이다.
+// CometBFT RPC와 Cosmos REST 중 익스포터가 쓰는 부분만 흉내 내며,
+// 블록 진행, 서명 누락, 제일(jail), 업그레이드 예약을 스크립트로 조작할 수 있다.
+package rpcmock
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"og-galileo-unified-metrics/internal/bech32"
+)
+
+const (
+	DefaultChainID  = "zgtendermint_16601-2"
+	DefaultDenom    = "ua0gi"
+	DefaultVersion  = "0.38.12"
+	DefaultAppName  = "0gchaind"
+	DefaultAppVer   = "v1.0.3"
+	defaultBlockGap = time.Second
+	// 최근 블록 보관 개수 (오래된 높이 조회는 404)
+	maxRecentBlocks = 1000
+)
+
+// 시뮬레이션 벨리데이터
+type Validator struct {
+	Name            string
+	Address         string // 합의 주소 (대문자 hex)
+	PubKey          string // base64 ed25519 공개키
+	OperatorAddress string
+	Tokens          string // 기본 단위 정수
+	VotingPower     int64
+	Commission      string // 소수 비율 ("0.05")
+	Jailed          bool
+	InSet           bool // 활성 벨리데이터 집합 포함 여부
+	Signing         bool // false면 다음 블록부터 서명 누락
+	Missed          int64
+	JailedUntil     time.Time
+	SignDelay       time.Duration // 블록 시각 대비 서명 타임스탬프 지연
+}
+
+// 예약된 업그레이드 (current_plan)
+type UpgradePlan struct {
+	Name   string
+	Height int64
+	Info   string
+}
+
+// 거버넌스 제안
+type Proposal struct {
+	ID         uint64
+	Title      string
+	Status     string // PROPOSAL_STATUS_VOTING_PERIOD 등
+	VotingEnds time.Time
+}
+
+// 슬래싱 파라미터
+type SlashingParams struct {
+	SignedBlocksWindow      int64
+	MinSignedPerWindow      string
+	DowntimeJailDuration    time.Duration
+	SlashFractionDoubleSign string
+	SlashFractionDowntime   string
+}
+
+type block struct {
+	height     int64
+	time       time.Time
+	proposer   string
+	valsHash   string
+	nextHash   string // 다음 블록이 생성되기 전까지는 현재 집합 기준
+	txs        int
+	set        []setEntry  // 이 높이의 활성 벨리데이터 집합
+	signatures []signature // 이전 높이의 커밋 서명
+}
+
+type setEntry struct {
+	address string
+	pubKey  string
+	power   int64
+}
+
+type signature struct {
+	address string
+	signed  bool
+	delay   time.Duration
+}
+
+// 스크립트로 조작하는 체인 상태 (모든 메서드는 동시 호출 안전)
+type Chain struct {
+	mu         sync.Mutex
+	chainID    string
+	height     int64
+	blockTime  time.Time
+	blockGap   time.Duration
+	validators []*Validator
+	blocks     map[int64]*block
+	slashing   SlashingParams
+	proposals  []Proposal
+	upgrade    *UpgradePlan
+	txsPerBlk  int
+	outage     bool
+	latency    time.Duration
+	proposerIx int
+	blockSubs  map[chan int64]bool // /websocket NewBlock 구독자
+}
+
+// 벨리데이터 이름으로 시작 상태 생성 (모두 서명, 동일 지분), 첫 블록은 높이 1
+func NewChain(names ...string) *Chain {
+	c := &Chain{
+		chainID:   DefaultChainID,
+		blockTime: time.Now().UTC().Truncate(time.Second),
+		blockGap:  defaultBlockGap,
+		blocks:    make(map[int64]*block),
+		slashing: SlashingParams{
+			SignedBlocksWindow:      100,
+			MinSignedPerWindow:      "0.500000000000000000",
+			DowntimeJailDuration:    10 * time.Minute,
+			SlashFractionDoubleSign: "0.050000000000000000",
+			SlashFractionDowntime:   "0.010000000000000000",
+		},
+	}
+	for _, name := range names {
+		c.addValidator(name, "")
+	}
+	c.advance(1)
+	return c
+}
+
+// 합의 주소를 직접 지정해 벨리데이터 추가 (익스포터에 하드코딩된 주소 재현용)
+func (c *Chain) AddValidator(name, address string) *Validator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.addValidator(name, address)
+}
+
+func (c *Chain) addValidator(name, address string) *Validator {
+	key := sha256.Sum256([]byte(name))
+	if address == "" {
+		sum := sha256.Sum256(key[:])
+		address = strings.ToUpper(hex.EncodeToString(sum[:20]))
+	}
+	raw, _ := hex.DecodeString(address)
+	operator, _ := bech32.Encode("0gvaloper", raw)
+	v := &Validator{
+		Name:            name,
+		Address:         strings.ToUpper(address),
+		PubKey:          base64.StdEncoding.EncodeToString(key[:]),
+		OperatorAddress: operator,
+		Tokens:          "1000000000000000000000000",
+		VotingPower:     1000000,
+		Commission:      "0.050000000000000000",
+		InSet:           true,
+		Signing:         true,
+	}
+	c.validators = append(c.validators, v)
+	return v
+}
+
+func (c *Chain) validator(name string) (*Validator, error) {
+	for _, v := range c.validators {
+		if v.Name == name || v.Address == strings.ToUpper(name) {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown validator %q", name)
+}
+
+// 벨리데이터 목록 복사본
+func (c *Chain) Validators() []Validator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]Validator, len(c.validators))
+	for i, v := range c.validators {
+		out[i] = *v
+	}
+	return out
+}
+
+func (c *Chain) Height() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.height
+}
+
+func (c *Chain) ChainID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.chainID
+}
+
+// 테스트넷 리셋 재현: 체인 ID를 바꾸고 높이 1부터 다시 시작
+// 벨리데이터와 지분은 유지하고 누락/제일, 제안, 업그레이드 계획은 초기화 (블록 시각은 계속 증가)
+func (c *Chain) Reset(chainID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.chainID = chainID
+	c.height = 0
+	c.blocks = make(map[int64]*block)
+	c.proposals = nil
+	c.upgrade = nil
+	c.proposerIx = 0
+	for _, v := range c.validators {
+		v.Missed = 0
+		v.Jailed = false
+		v.JailedUntil = time.Time{}
+	}
+	c.advance(1)
+}
+
+// 블록 n개 진행 (블록 시각은 blockGap씩 증가)
+func (c *Chain) Advance(n int) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.advance(n)
+	return c.height
+}
+
+func (c *Chain) advance(n int) {
+	for i := 0; i < n; i++ {
+		c.height++
+		if c.height > 1 {
+			c.blockTime = c.blockTime.Add(c.blockGap)
+		}
+		b := &block{height: c.height, time: c.blockTime, valsHash: c.validatorsHash(), txs: c.txsPerBlk}
+		b.nextHash = b.valsHash
+		if prev, ok := c.blocks[c.height-1]; ok {
+			prev.nextHash = b.valsHash
+		}
+		active := c.activeSet()
+		for _, v := range active {
+			b.set = append(b.set, setEntry{address: v.Address, pubKey: v.PubKey, power: v.VotingPower})
+		}
+		if len(active) > 0 {
+			b.proposer = active[c.proposerIx%len(active)].Address
+			c.proposerIx++
+		}
+		// last_commit는 이전 높이에 대한 활성 집합의 서명
+		if c.height > 1 {
+			for _, v := range active {
+				b.signatures = append(b.signatures, signature{address: v.Address, signed: v.Signing, delay: v.SignDelay})
+				if !v.Signing {
+					v.Missed++
+				} else if v.Missed > 0 && c.height%c.slashing.SignedBlocksWindow == 0 {
+					v.Missed--
+				}
+			}
+		}
+		c.blocks[c.height] = b
+		delete(c.blocks, c.height-maxRecentBlocks)
+		for sub := range c.blockSubs {
+			select {
+			case sub <- c.height:
+			default: // 느린 구독자는 이벤트를 놓침 (실제 노드도 버퍼가 차면 끊음)
+			}
+		}
+
+		// 예약된 업그레이드 높이에 도달하면 계획 제거 (실제 체인에서는 노드가 멈춤)
+		if c.upgrade != nil && c.height >= c.upgrade.Height {
+			c.upgrade = nil
+		}
+	}
+}
+
+// 제일되지 않았고 집합에 포함된 벨리데이터 (지분 내림차순)
+func (c *Chain) activeSet() []*Validator {
+	var active []*Validator
+	for _, v := range c.validators {
+		if v.InSet && !v.Jailed {
+			active = append(active, v)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool { return active[i].VotingPower > active[j].VotingPower })
+	return active
+}
+
+func (c *Chain) validatorsHash() string {
+	h := sha256.New()
+	for _, v := range c.activeSet() {
+		fmt.Fprintf(h, "%s:%d;", v.Address, v.VotingPower)
+	}
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil)))
+}
+
+// 서명 여부 전환 (다음에 생성되는 블록부터 반영)
+func (c *Chain) SetSigning(name string, signing bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, err := c.validator(name)
+	if err != nil {
+		return err
+	}
+	v.Signing = signing
+	return nil
+}
+
+// 이후 커밋 서명의 타임스탬프를 블록 시각보다 delay만큼 늦춤
+func (c *Chain) SetSignDelay(name string, delay time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, err := c.validator(name)
+	if err != nil {
+		return err
+	}
+	v.SignDelay = delay
+	return nil
+}
+
+// 제일: 활성 집합에서 빠지고 스테이킹 상태가 UNBONDING으로 바뀜
+func (c *Chain) Jail(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, err := c.validator(name)
+	if err != nil {
+		return err
+	}
+	v.Jailed = true
+	v.JailedUntil = c.blockTime.Add(c.slashing.DowntimeJailDuration)
+	return nil
+}
+
+func (c *Chain) Unjail(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, err := c.validator(name)
+	if err != nil {
+		return err
+	}
+	v.Jailed = false
+	v.Missed = 0
+	v.JailedUntil = time.Time{}
+	return nil
+}
+
+// 제일 없이 활성 집합에서 빼거나 다시 넣기 (지분 부족으로 밀려난 경우)
+func (c *Chain) SetInSet(name string, inSet bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, err := c.validator(name)
+	if err != nil {
+		return err
+	}
+	v.InSet = inSet
+	return nil
+}
+
+func (c *Chain) SetVotingPower(name string, power int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, err := c.validator(name)
+	if err != nil {
+		return err
+	}
+	v.VotingPower = power
+	return nil
+}
+
+// 업그레이드 예약 (height 도달 시 계획이 사라짐)
+func (c *Chain) ScheduleUpgrade(name string, height int64, info string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.upgrade = &UpgradePlan{Name: name, Height: height, Info: info}
+}
+
+func (c *Chain) CancelUpgrade() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.upgrade = nil
+}
+
+func (c *Chain) AddProposal(title, status string, votingEnds time.Time) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := uint64(len(c.proposals) + 1)
+	c.proposals = append(c.proposals, Proposal{ID: id, Title: title, Status: status, VotingEnds: votingEnds})
+	return id
+}
+
+func (c *Chain) SetSlashingParams(params SlashingParams) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.slashing = params
+}
+
+// 블록당 트랜잭션 수 (block_results의 수수료 이벤트도 같은 수만큼 생성)
+func (c *Chain) SetTxsPerBlock(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.txsPerBlk = n
+}
+
+func (c *Chain) SetBlockGap(gap time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.blockGap = gap
+}
+
+// 장애 모드: 모든 요청에 503 응답
+func (c *Chain) SetOutage(outage bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.outage = outage
+}
+
+// 응답 지연: 요청마다 delay만큼 기다린 뒤 응답 (클라이언트가 먼저 끊으면 응답하지 않음)
+func (c *Chain) SetLatency(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.latency = delay
+}
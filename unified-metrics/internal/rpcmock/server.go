@@ -0,0 +1,426 @@
+package rpcmock
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"og-galileo-unified-metrics/internal/bech32"
+)
+
+// CometBFT RPC 경로 (JSON-RPC 배치에서는 메서드 이름으로 사용)
+var cometMethods = map[string]bool{
+	"status": true, "block": true, "validators": true, "block_results": true,
+	"abci_info": true, "net_info": true, "num_unconfirmed_txs": true,
+}
+
+// 체인 상태를 응답하는 httptest 서버 (호출자가 Close)
+func (c *Chain) Server() *httptest.Server {
+	return httptest.NewServer(c.Handler())
+}
+
+// CometBFT URI/JSON-RPC 배치와 Cosmos REST를 한 포트에서 처리 (실제 노드는 26657/1317로 나뉨)
+func (c *Chain) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		outage, latency := c.outage, c.latency
+		c.mu.Unlock()
+		if latency > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(latency):
+			}
+		}
+		if outage {
+			http.Error(w, "simulated outage", http.StatusServiceUnavailable)
+			return
+		}
+
+		if r.URL.Path == "/websocket" {
+			c.serveWebSocket(w, r)
+			return
+		}
+		if r.Method == http.MethodPost && r.URL.Path == "/" {
+			c.serveJSONRPC(w, r)
+			return
+		}
+
+		method := strings.TrimPrefix(r.URL.Path, "/")
+		if cometMethods[method] {
+			result, status, err := c.comet(method, r.URL.Query())
+			if err != nil {
+				writeJSON(w, status, map[string]interface{}{"jsonrpc": "2.0", "id": -1,
+					"error": map[string]interface{}{"code": -32603, "message": "Internal error", "data": err.Error()}})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"jsonrpc": "2.0", "id": -1, "result": result})
+			return
+		}
+
+		result, status, err := c.rest(r.URL.Path, r.URL.Query())
+		if err != nil {
+			writeJSON(w, status, map[string]interface{}{"code": 5, "message": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// 배치(배열) 요청만 지원, 각 요청은 URI 방식과 같은 처리
+func (c *Chain) serveJSONRPC(w http.ResponseWriter, r *http.Request) {
+	var requests []struct {
+		ID     int               `json:"id"`
+		Method string            `json:"method"`
+		Params map[string]string `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+		http.Error(w, "batch requests only", http.StatusBadRequest)
+		return
+	}
+
+	responses := make([]map[string]interface{}, 0, len(requests))
+	for _, request := range requests {
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+		query := url.Values{}
+		for key, value := range request.Params {
+			query.Set(key, value)
+		}
+		if !cometMethods[request.Method] {
+			response["error"] = map[string]interface{}{"code": -32601, "message": "Method not found"}
+		} else if result, _, err := c.comet(request.Method, query); err != nil {
+			response["error"] = map[string]interface{}{"code": -32603, "message": "Internal error", "data": err.Error()}
+		} else {
+			response["result"] = result
+		}
+		responses = append(responses, response)
+	}
+	writeJSON(w, http.StatusOK, responses)
+}
+
+// height 파라미터 (없으면 최신, 보관 범위 밖이면 오류)
+func (c *Chain) blockAt(query url.Values) (*block, error) {
+	height := c.height
+	if value := query.Get("height"); value != "" {
+		parsed, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid height %q", value)
+		}
+		height = parsed
+	}
+	if height > c.height {
+		return nil, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", height, c.height)
+	}
+	b, ok := c.blocks[height]
+	if !ok {
+		return nil, fmt.Errorf("height %d is not available, lowest height is %d", height, max(c.height-maxRecentBlocks+1, 1))
+	}
+	return b, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func (c *Chain) comet(method string, query url.Values) (interface{}, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch method {
+	case "status":
+		latest := c.blocks[c.height]
+		return map[string]interface{}{
+			"node_info": map[string]interface{}{"network": c.chainID, "version": DefaultVersion, "moniker": "rpcmock"},
+			"sync_info": map[string]interface{}{
+				"latest_block_height": strconv.FormatInt(c.height, 10),
+				"latest_block_time":   formatTime(latest.time),
+				"catching_up":         false,
+			},
+		}, http.StatusOK, nil
+
+	case "block":
+		b, err := c.blockAt(query)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		signatures := make([]map[string]interface{}, 0, len(b.signatures))
+		for _, sig := range b.signatures {
+			entry := map[string]interface{}{"block_id_flag": 2, "validator_address": sig.address,
+				"timestamp": formatTime(b.time.Add(sig.delay)), "signature": fakeSignature(sig.address, b.height)}
+			if !sig.signed {
+				entry = map[string]interface{}{"block_id_flag": 1, "validator_address": "",
+					"timestamp": "0001-01-01T00:00:00Z", "signature": nil}
+			}
+			signatures = append(signatures, entry)
+		}
+		txs := make([]string, b.txs)
+		for i := range txs {
+			txs[i] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("tx-%d-%d", b.height, i)))
+		}
+		return map[string]interface{}{
+			"block": map[string]interface{}{
+				"header": map[string]interface{}{
+					"chain_id":             c.chainID,
+					"height":               strconv.FormatInt(b.height, 10),
+					"time":                 formatTime(b.time),
+					"proposer_address":     b.proposer,
+					"validators_hash":      b.valsHash,
+					"next_validators_hash": b.nextHash,
+				},
+				"data": map[string]interface{}{"txs": txs},
+				"last_commit": map[string]interface{}{
+					"height":     strconv.FormatInt(b.height-1, 10),
+					"signatures": signatures,
+				},
+			},
+		}, http.StatusOK, nil
+
+	case "validators":
+		b, err := c.blockAt(query)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		page, _ := strconv.Atoi(query.Get("page"))
+		perPage, _ := strconv.Atoi(query.Get("per_page"))
+		page = max(page, 1)
+		if perPage <= 0 {
+			perPage = 30
+		}
+		perPage = min(perPage, 100)
+		start := (page - 1) * perPage
+		if start >= len(b.set) && page > 1 {
+			return nil, http.StatusInternalServerError, fmt.Errorf("page should be within [1, %d] range, given %d",
+				(len(b.set)+perPage-1)/perPage, page)
+		}
+		end := min(start+perPage, len(b.set))
+		validators := make([]map[string]interface{}, 0, end-start)
+		for _, entry := range b.set[start:end] {
+			validators = append(validators, map[string]interface{}{
+				"address":           entry.address,
+				"pub_key":           map[string]interface{}{"type": "tendermint/PubKeyEd25519", "value": entry.pubKey},
+				"voting_power":      strconv.FormatInt(entry.power, 10),
+				"proposer_priority": "0",
+			})
+		}
+		return map[string]interface{}{
+			"block_height": strconv.FormatInt(b.height, 10),
+			"validators":   validators,
+			"count":        strconv.Itoa(len(validators)),
+			"total":        strconv.Itoa(len(b.set)),
+		}, http.StatusOK, nil
+
+	case "block_results":
+		b, err := c.blockAt(query)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		results := make([]map[string]interface{}, b.txs)
+		for i := range results {
+			fee := fmt.Sprintf("%d%s", (int64(i)%5+1)*1000000000000000, DefaultDenom)
+			results[i] = map[string]interface{}{
+				"code": 0, "gas_wanted": "200000", "gas_used": "150000",
+				"events": []map[string]interface{}{{"type": "tx", "attributes": []map[string]interface{}{
+					{"key": "fee", "value": fee, "index": true},
+				}}},
+			}
+		}
+		return map[string]interface{}{"height": strconv.FormatInt(b.height, 10), "txs_results": results}, http.StatusOK, nil
+
+	case "abci_info":
+		return map[string]interface{}{"response": map[string]interface{}{
+			"data": DefaultAppName, "version": DefaultAppVer, "last_block_height": strconv.FormatInt(c.height, 10),
+		}}, http.StatusOK, nil
+
+	case "net_info":
+		return map[string]interface{}{"listening": true, "n_peers": "0", "peers": []interface{}{}}, http.StatusOK, nil
+
+	case "num_unconfirmed_txs":
+		return map[string]interface{}{"n_txs": "0", "total": "0", "total_bytes": "0"}, http.StatusOK, nil
+	}
+	return nil, http.StatusNotFound, fmt.Errorf("unknown method %q", method)
+}
+
+// 높이와 주소에서 결정적으로 만든 서명 자리값 (검증하지 않음)
+func fakeSignature(address string, height int64) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s/%d", address, height)))
+}
+
+func (c *Chain) rest(path string, query url.Values) (interface{}, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case path == "/cosmos/staking/v1beta1/validators":
+		return c.stakingValidators(query)
+
+	case path == "/cosmos/staking/v1beta1/pool":
+		bonded, notBonded := 0.0, 0.0
+		for _, v := range c.validators {
+			tokens, _ := strconv.ParseFloat(v.Tokens, 64)
+			if v.InSet && !v.Jailed {
+				bonded += tokens
+			} else {
+				notBonded += tokens
+			}
+		}
+		return map[string]interface{}{"pool": map[string]interface{}{
+			"bonded_tokens": fmt.Sprintf("%.0f", bonded), "not_bonded_tokens": fmt.Sprintf("%.0f", notBonded),
+		}}, http.StatusOK, nil
+
+	case path == "/cosmos/staking/v1beta1/params":
+		return map[string]interface{}{"params": map[string]interface{}{
+			"unbonding_time": "1814400s", "max_validators": 125, "max_entries": 7,
+			"historical_entries": 10000, "bond_denom": DefaultDenom, "min_commission_rate": "0.000000000000000000",
+		}}, http.StatusOK, nil
+
+	case strings.HasPrefix(path, "/cosmos/distribution/v1beta1/validators/"):
+		rest := strings.TrimPrefix(path, "/cosmos/distribution/v1beta1/validators/")
+		operator, kind, _ := strings.Cut(rest, "/")
+		if c.operator(operator) == nil {
+			return nil, http.StatusNotFound, fmt.Errorf("validator %s not found", operator)
+		}
+		// 높이에 비례해 쌓이는 보상 (커미션은 그 5%)
+		rewards := []map[string]interface{}{{"denom": DefaultDenom, "amount": fmt.Sprintf("%d.000000000000000000", c.height*1000000000000)}}
+		switch kind {
+		case "outstanding_rewards":
+			return map[string]interface{}{"rewards": map[string]interface{}{"rewards": rewards}}, http.StatusOK, nil
+		case "commission":
+			commission := []map[string]interface{}{{"denom": DefaultDenom, "amount": fmt.Sprintf("%d.000000000000000000", c.height*50000000000)}}
+			return map[string]interface{}{"commission": map[string]interface{}{"commission": commission}}, http.StatusOK, nil
+		}
+
+	case path == "/cosmos/slashing/v1beta1/params":
+		return map[string]interface{}{"params": map[string]interface{}{
+			"signed_blocks_window":       strconv.FormatInt(c.slashing.SignedBlocksWindow, 10),
+			"min_signed_per_window":      c.slashing.MinSignedPerWindow,
+			"downtime_jail_duration":     fmt.Sprintf("%ds", int64(c.slashing.DowntimeJailDuration.Seconds())),
+			"slash_fraction_double_sign": c.slashing.SlashFractionDoubleSign,
+			"slash_fraction_downtime":    c.slashing.SlashFractionDowntime,
+		}}, http.StatusOK, nil
+
+	case path == "/cosmos/slashing/v1beta1/signing_infos":
+		infos := make([]map[string]interface{}, 0, len(c.validators))
+		for _, v := range c.validators {
+			infos = append(infos, c.signingInfo(v))
+		}
+		return map[string]interface{}{"info": infos, "pagination": map[string]interface{}{
+			"next_key": nil, "total": strconv.Itoa(len(infos)),
+		}}, http.StatusOK, nil
+
+	case path == "/cosmos/gov/v1/proposals":
+		status := query.Get("proposal_status")
+		proposals := make([]map[string]interface{}, 0, len(c.proposals))
+		for _, p := range c.proposals {
+			if status != "" && status != p.Status {
+				continue
+			}
+			proposals = append(proposals, map[string]interface{}{
+				"id": strconv.FormatUint(p.ID, 10), "title": p.Title, "status": p.Status,
+				"voting_end_time": formatTime(p.VotingEnds),
+			})
+		}
+		return map[string]interface{}{"proposals": proposals, "pagination": map[string]interface{}{
+			"next_key": nil, "total": strconv.Itoa(len(proposals)),
+		}}, http.StatusOK, nil
+
+	case path == "/cosmos/upgrade/v1beta1/current_plan":
+		if c.upgrade == nil {
+			return map[string]interface{}{"plan": nil}, http.StatusOK, nil
+		}
+		return map[string]interface{}{"plan": map[string]interface{}{
+			"name": c.upgrade.Name, "height": strconv.FormatInt(c.upgrade.Height, 10), "info": c.upgrade.Info,
+		}}, http.StatusOK, nil
+
+	case path == "/cosmos/base/node/v1beta1/config":
+		return map[string]interface{}{"minimum_gas_price": "0.000000000000000000" + DefaultDenom}, http.StatusOK, nil
+	}
+	return nil, http.StatusNotImplemented, fmt.Errorf("not implemented: %s", path)
+}
+
+func (c *Chain) operator(address string) *Validator {
+	for _, v := range c.validators {
+		if v.OperatorAddress == address {
+			return v
+		}
+	}
+	return nil
+}
+
+func (c *Chain) signingInfo(v *Validator) map[string]interface{} {
+	raw, _ := hex.DecodeString(v.Address)
+	consensus, _ := bech32.Encode("0gvalcons", raw)
+	jailedUntil := "1970-01-01T00:00:00Z"
+	if v.Jailed {
+		jailedUntil = formatTime(v.JailedUntil)
+	}
+	return map[string]interface{}{
+		"address":               consensus,
+		"start_height":          "1",
+		"index_offset":          strconv.FormatInt(c.height, 10),
+		"jailed_until":          jailedUntil,
+		"tombstoned":            false,
+		"missed_blocks_counter": strconv.FormatInt(v.Missed, 10),
+	}
+}
+
+// pagination.key는 다음 페이지 시작 인덱스 (base64)
+func (c *Chain) stakingValidators(query url.Values) (interface{}, int, error) {
+	limit, _ := strconv.Atoi(query.Get("pagination.limit"))
+	if limit <= 0 {
+		limit = 100
+	}
+	start := 0
+	if key := query.Get("pagination.key"); key != "" {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err == nil {
+			start, err = strconv.Atoi(string(decoded))
+		}
+		if err != nil || start < 0 || start > len(c.validators) {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid pagination key %q", key)
+		}
+	}
+	end := min(start+limit, len(c.validators))
+
+	validators := make([]map[string]interface{}, 0, end-start)
+	for _, v := range c.validators[start:end] {
+		status := "BOND_STATUS_BONDED"
+		if v.Jailed {
+			status = "BOND_STATUS_UNBONDING"
+		} else if !v.InSet {
+			status = "BOND_STATUS_UNBONDED"
+		}
+		validators = append(validators, map[string]interface{}{
+			"operator_address": v.OperatorAddress,
+			"consensus_pubkey": map[string]interface{}{"@type": "/cosmos.crypto.ed25519.PubKey", "key": v.PubKey},
+			"jailed":           v.Jailed,
+			"status":           status,
+			"tokens":           v.Tokens,
+			"delegator_shares": v.Tokens + ".000000000000000000",
+			"description":      map[string]interface{}{"moniker": v.Name},
+			"commission": map[string]interface{}{"commission_rates": map[string]interface{}{
+				"rate": v.Commission, "max_rate": "0.200000000000000000", "max_change_rate": "0.010000000000000000",
+			}},
+			"min_self_delegation": "1",
+		})
+	}
+	var nextKey interface{}
+	if end < len(c.validators) {
+		nextKey = base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+	}
+	return map[string]interface{}{"validators": validators, "pagination": map[string]interface{}{
+		"next_key": nextKey, "total": strconv.Itoa(len(c.validators)),
+	}}, http.StatusOK, nil
+}
@@ -0,0 +1,84 @@
+package rpcmock
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"og-galileo-unified-metrics/internal/websocket"
+)
+
+// 구독자 하나가 밀려 있을 수 있는 이벤트 수
+const blockEventBuffer = 16
+
+// /websocket: subscribe 요청에 빈 결과로 응답한 뒤 블록이 생길 때마다 NewBlock 이벤트 전송
+// 이벤트에는 익스포터가 쓰는 헤더 높이만 담고, 쿼리 종류는 확인하지 않음
+func (c *Chain) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	data, err := conn.ReadMessage()
+	if err != nil {
+		return
+	}
+	var request struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	if err := json.Unmarshal(data, &request); err != nil || request.Method != "subscribe" {
+		reply, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID,
+			"error": map[string]interface{}{"code": -32601, "message": "Method not found"}})
+		conn.WriteText(reply)
+		return
+	}
+
+	events := make(chan int64, blockEventBuffer)
+	c.mu.Lock()
+	if c.blockSubs == nil {
+		c.blockSubs = make(map[chan int64]bool)
+	}
+	c.blockSubs[events] = true
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.blockSubs, events)
+		c.mu.Unlock()
+	}()
+
+	reply, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": map[string]interface{}{}})
+	if err := conn.WriteText(reply); err != nil {
+		return
+	}
+
+	// 클라이언트가 끊으면 (close 프레임 또는 연결 종료) 읽기가 실패함
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-r.Context().Done():
+			return
+		case height := <-events:
+			event, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": map[string]interface{}{
+				"query": "tm.event='NewBlock'",
+				"data": map[string]interface{}{"type": "tendermint/event/NewBlock", "value": map[string]interface{}{
+					"block": map[string]interface{}{"header": map[string]interface{}{"height": strconv.FormatInt(height, 10)}},
+				}},
+			}})
+			if err := conn.WriteText(event); err != nil {
+				return
+			}
+		}
+	}
+}
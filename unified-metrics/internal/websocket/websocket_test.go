@@ -0,0 +1,389 @@
+package websocket
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 받은 메시지를 그대로 돌려주는 서버
+func newEchoServer(t *testing.T, check func(r *http.Request)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if check != nil {
+			check(r)
+		}
+		conn, err := Accept(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteText(message); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dial(t *testing.T, rawURL string) *Conn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := Dial(ctx, rawURL, nil)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// 길이 인코딩 경계(7비트, 16비트, 64비트)를 모두 지나는 왕복
+func TestEchoRoundTrip(t *testing.T) {
+	conn := dial(t, wsURL(newEchoServer(t, nil))+"/websocket")
+	conn.ReadTimeout = 5 * time.Second
+	for _, size := range []int{0, 1, 125, 126, 127, 0xFFFF, 0x10000, 200000} {
+		payload := bytes.Repeat([]byte{'a' + byte(size%26)}, size)
+		if err := conn.WriteText(payload); err != nil {
+			t.Fatalf("write %d bytes: %v", size, err)
+		}
+		got, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read %d bytes: %v", size, err)
+		}
+		if !bytes.Equal(got, payload) {
+			t.Fatalf("echo of %d bytes returned %d bytes", size, len(got))
+		}
+	}
+}
+
+func TestDialSendsPathQueryAndBasicAuth(t *testing.T) {
+	seen := make(chan *http.Request, 1)
+	server := newEchoServer(t, func(r *http.Request) { seen <- r })
+	dial(t, "ws://user:secret@"+strings.TrimPrefix(server.URL, "http://")+"/websocket?x=1")
+
+	r := <-seen
+	if r.URL.Path != "/websocket" || r.URL.RawQuery != "x=1" {
+		t.Errorf("request URL = %s", r.URL)
+	}
+	if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "secret" {
+		t.Errorf("basic auth = %q, %q, %v", user, password, ok)
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" || r.Header.Get("Sec-WebSocket-Key") == "" {
+		t.Errorf("handshake headers = %v", r.Header)
+	}
+}
+
+func TestDialHandshakeFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+	}{
+		{
+			"plain http response",
+			func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("not a websocket")) },
+			"status 200",
+		},
+		{
+			"wrong accept key",
+			func(w http.ResponseWriter, r *http.Request) {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				defer conn.Close()
+				conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+					"Sec-WebSocket-Accept: " + acceptKey("other") + "\r\n\r\n"))
+			},
+			"invalid Sec-WebSocket-Accept",
+		},
+		{
+			"missing upgrade header",
+			func(w http.ResponseWriter, r *http.Request) {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				defer conn.Close()
+				conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\n" +
+					"Sec-WebSocket-Accept: " + acceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n"))
+			},
+			"missing the upgrade header",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			_, err := Dial(context.Background(), wsURL(server), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Dial error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialUnsupportedScheme(t *testing.T) {
+	if _, err := Dial(context.Background(), "http://127.0.0.1:1/websocket", nil); err == nil {
+		t.Error("http:// accepted")
+	}
+}
+
+// 응답하지 않는 서버에서도 ctx 기한에 맞춰 핸드셰이크를 포기
+func TestDialHonoursContext(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = Dial(ctx, "ws://"+listener.Addr().String(), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Dial error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Dial took %v after the deadline", elapsed)
+	}
+}
+
+func TestAcceptRejectsPlainRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		header map[string]string
+	}{
+		{"no upgrade", http.MethodGet, nil},
+		{"wrong version", http.MethodGet, map[string]string{"Upgrade": "websocket", "Sec-WebSocket-Version": "8", "Sec-WebSocket-Key": "x"}},
+		{"missing key", http.MethodGet, map[string]string{"Upgrade": "websocket", "Sec-WebSocket-Version": "13"}},
+		{"post", http.MethodPost, map[string]string{"Upgrade": "websocket", "Sec-WebSocket-Version": "13", "Sec-WebSocket-Key": "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/websocket", nil)
+			for name, value := range tt.header {
+				r.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			if _, err := Accept(w, r); err == nil {
+				t.Fatal("Accept succeeded")
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", w.Code)
+			}
+		})
+	}
+}
+
+// RFC 6455 1.3의 예시 키
+func TestAcceptKey(t *testing.T) {
+	if got := acceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("acceptKey = %s", got)
+	}
+}
+
+// 프레임 단위 테스트: 한쪽은 Conn, 다른 쪽은 테스트가 원시 프레임을 읽고 씀
+func newPipeConn(server bool) (*Conn, net.Conn) {
+	local, remote := net.Pipe()
+	return &Conn{conn: local, br: bufio.NewReader(local), server: server, MaxMessageSize: DefaultMaxMessageSize}, remote
+}
+
+func rawFrame(final bool, opcode byte, payload []byte, masked bool) []byte {
+	first := opcode
+	if final {
+		first |= 0x80
+	}
+	maskBit := byte(0)
+	if masked {
+		maskBit = 0x80
+	}
+	frame := []byte{first}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xFFFF:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	if !masked {
+		return append(frame, payload...)
+	}
+	mask := [4]byte{1, 2, 3, 4}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	return frame
+}
+
+// 상대가 보낸 프레임 하나를 읽음 (마스킹은 해제)
+func readRawFrame(t *testing.T, r io.Reader) (opcode byte, payload []byte) {
+	t.Helper()
+	reader := &Conn{br: bufio.NewReader(r), conn: nopDeadlineConn{}, MaxMessageSize: DefaultMaxMessageSize}
+	_, opcode, payload, err := reader.readFrame()
+	if err != nil {
+		t.Fatalf("read raw frame: %v", err)
+	}
+	return opcode, payload
+}
+
+type nopDeadlineConn struct{ net.Conn }
+
+func (nopDeadlineConn) SetReadDeadline(time.Time) error { return nil }
+
+func readResult(conn *Conn) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		message, err := conn.ReadMessage()
+		if err == nil && string(message) != "hello world" {
+			err = errors.New("unexpected message " + string(message))
+		}
+		done <- err
+	}()
+	return done
+}
+
+func TestReadMessageAssemblesFragmentsAndAnswersPing(t *testing.T) {
+	conn, remote := newPipeConn(false)
+	defer remote.Close()
+	done := readResult(conn)
+
+	remote.Write(rawFrame(false, opText, []byte("hello"), false))
+	remote.Write(rawFrame(true, opPing, []byte("p1"), false))
+	// pong은 같은 페이로드로, 클라이언트 프레임이므로 마스킹됨
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(remote, head); err != nil {
+		t.Fatal(err)
+	}
+	if head[0] != 0x80|opPong || head[1] != 0x80|2 {
+		t.Fatalf("pong header = %x", head)
+	}
+	rest := make([]byte, 6)
+	io.ReadFull(remote, rest)
+	if got := []byte{rest[4] ^ rest[0], rest[5] ^ rest[1]}; string(got) != "p1" {
+		t.Errorf("pong payload = %q", got)
+	}
+
+	remote.Write(rawFrame(false, opContinuation, []byte(" "), false))
+	remote.Write(rawFrame(true, opContinuation, []byte("world"), false))
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadMessageClose(t *testing.T) {
+	conn, remote := newPipeConn(false)
+	defer remote.Close()
+	done := make(chan error, 1)
+	go func() {
+		_, err := conn.ReadMessage()
+		done <- err
+	}()
+
+	remote.Write(rawFrame(true, opClose, []byte{0x03, 0xE9, 'b', 'y', 'e'}, false))
+	opcode, payload := readRawFrame(t, remote)
+	if opcode != opClose || !bytes.Equal(payload, []byte{0x03, 0xE9}) {
+		t.Errorf("close reply = opcode %d payload %x, want the received status code", opcode, payload)
+	}
+	if err := <-done; !errors.Is(err, ErrClosed) {
+		t.Errorf("ReadMessage error = %v, want ErrClosed", err)
+	}
+}
+
+func TestReadMessageProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		server bool
+		max    int
+		frames [][]byte
+		want   string
+	}{
+		{"reserved bits", false, 0, [][]byte{{0x80 | 0x40 | opText, 0}}, "reserved bits"},
+		{"continuation first", false, 0, [][]byte{rawFrame(true, opContinuation, []byte("x"), false)}, "continuation frame without a message"},
+		{"interleaved message", false, 0, [][]byte{rawFrame(false, opText, []byte("a"), false), rawFrame(true, opText, []byte("b"), false)}, "new message before"},
+		{"fragmented control frame", false, 0, [][]byte{rawFrame(false, opPing, nil, false)}, "invalid control frame"},
+		{"long control frame", false, 0, [][]byte{rawFrame(true, opPing, bytes.Repeat([]byte{'x'}, 126), false)}, "invalid control frame"},
+		{"unknown opcode", false, 0, [][]byte{rawFrame(true, 0x3, nil, false)}, "unknown opcode"},
+		{"frame too large", false, 4, [][]byte{rawFrame(true, opText, []byte("12345"), false)}, "frame exceeds 4 bytes"},
+		{"message too large", false, 4, [][]byte{rawFrame(false, opText, []byte("123"), false), rawFrame(true, opContinuation, []byte("45"), false)}, "message exceeds 4 bytes"},
+		{"unmasked client frame", true, 0, [][]byte{rawFrame(true, opText, []byte("x"), false)}, "unmasked client frame"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, remote := newPipeConn(tt.server)
+			defer remote.Close()
+			if tt.max > 0 {
+				conn.MaxMessageSize = tt.max
+			}
+			go func() {
+				for _, frame := range tt.frames {
+					if _, err := remote.Write(frame); err != nil {
+						return
+					}
+				}
+			}()
+			_, err := conn.ReadMessage()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReadMessage error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// 서버는 마스킹된 프레임을 받아 풀고, 자신은 마스킹하지 않고 씀
+func TestServerFrameMasking(t *testing.T) {
+	conn, remote := newPipeConn(true)
+	defer remote.Close()
+	go remote.Write(rawFrame(true, opText, []byte("masked"), true))
+	message, err := conn.ReadMessage()
+	if err != nil || string(message) != "masked" {
+		t.Fatalf("ReadMessage = %q, %v", message, err)
+	}
+
+	go conn.WriteText([]byte("plain"))
+	frame := make([]byte, 7)
+	if _, err := io.ReadFull(remote, frame); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(frame, rawFrame(true, opText, []byte("plain"), false)) {
+		t.Errorf("server frame = %x", frame)
+	}
+}
+
+func TestReadTimeout(t *testing.T) {
+	conn := dial(t, wsURL(newEchoServer(t, nil)))
+	conn.ReadTimeout = 50 * time.Millisecond
+	_, err := conn.ReadMessage()
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("ReadMessage error = %v, want a timeout", err)
+	}
+}
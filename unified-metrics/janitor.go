@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultLabelRetention  = 1000 // 최근 1000개 블록의 라벨만 유지 (적용 기록 processedWindow도 같은 크기)
+	defaultJanitorInterval = time.Minute
+)
+
+// 라벨 값이 계속 늘어나는 벡터(block_height, proposal_id)를 주기적으로 정리
+func (vt *UnifiedValidatorTracker) StartJanitor(ctx context.Context, interval time.Duration) {
+	trackerLog.Info("Starting label janitor", "interval", interval, "retention_blocks", vt.labelRetention)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			trackerLog.Info("Context cancelled, stopping janitor")
+			return
+		case <-ticker.C:
+			vt.collectGarbage()
+		}
+	}
+}
+
+// 트래커 상태 기준으로 더 이상 유효하지 않은 시리즈를 삭제
+func (vt *UnifiedValidatorTracker) collectGarbage() {
+	vt.mu.Lock()
+	cutoff := vt.lastBlockHeight - vt.labelRetention
+	var staleHeights []int64
+	for height := range vt.signedHeights {
+		if height < cutoff {
+			staleHeights = append(staleHeights, height)
+			delete(vt.signedHeights, height)
+		}
+	}
+	var staleProposals []string
+	for id := range vt.proposalSeries {
+		if !vt.activeProposals[id] {
+			staleProposals = append(staleProposals, id)
+			delete(vt.proposalSeries, id)
+		}
+	}
+	vt.mu.Unlock()
+
+	deleted := 0
+	for _, height := range staleHeights {
+		deleted += vt.metrics.custom.beaconBlockSignedMetric.DeletePartialMatch(
+			prometheus.Labels{"block_height": strconv.FormatInt(height, 10)})
+	}
+	for _, id := range staleProposals {
+		deleted += vt.metrics.cosmos.proposalEndTimeMetric.DeletePartialMatch(prometheus.Labels{"proposal_id": id})
+		deleted += vt.metrics.cosmos.voteMetric.DeletePartialMatch(prometheus.Labels{"proposal_id": id})
+	}
+
+	vt.updateLiveSeries()
+
+	if deleted > 0 {
+		trackerLog.Info("Janitor removed stale series", "series", deleted, "heights", len(staleHeights), "proposals", len(staleProposals))
+	}
+}
+
+// 현재 투표 기간인 제안 목록을 갱신 (목록에 없는 제안의 시리즈는 다음 정리 때 삭제)
+// 처음 보는 제안은 proposal_new 이벤트 발행
+func (vt *UnifiedValidatorTracker) setActiveProposals(ids []string) {
+	vt.mu.Lock()
+	var added []string
+	for _, id := range ids {
+		if !vt.activeProposals[id] && !vt.proposalSeries[id] {
+			added = append(added, id)
+		}
+	}
+	vt.activeProposals = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		vt.activeProposals[id] = true
+		vt.proposalSeries[id] = true
+	}
+	height := vt.lastBlockHeight
+	vt.mu.Unlock()
+
+	for _, id := range added {
+		vt.events.Publish(Event{Type: EventProposalNew, Height: height, Message: "proposal " + id})
+	}
+}
+
+func (vt *UnifiedValidatorTracker) updateLiveSeries() {
+	families := map[string]prometheus.Collector{
+		"og_galileo_validator_beacon_block_signed":   vt.metrics.custom.beaconBlockSignedMetric,
+		"og_galileo_validator_proposal_end_time":     vt.metrics.cosmos.proposalEndTimeMetric,
+		"og_galileo_validator_vote":                  vt.metrics.cosmos.voteMetric,
+		"cometbft_consensus_validator_missed_blocks": vt.metrics.cosmos.cometbftMissedBlocksMetric,
+	}
+	for name, collector := range families {
+		vt.metrics.exporter.liveSeriesMetric.WithLabelValues(name).Set(float64(countSeries(collector)))
+	}
+}
+
+// 컬렉터가 현재 노출하는 시리즈 수
+func countSeries(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	n := 0
+	for range ch {
+		n++
+	}
+	return n
+}
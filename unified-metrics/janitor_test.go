@@ -0,0 +1,103 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// /metrics 레지스트리에서 패밀리의 시리즈별 라벨 값
+func gatherLabelValues(t *testing.T, tracker *UnifiedValidatorTracker, family, label string) map[string]bool {
+	t.Helper()
+	families, err := tracker.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]bool)
+	for _, f := range families {
+		if f.GetName() != family {
+			continue
+		}
+		for _, metric := range f.GetMetric() {
+			for _, pair := range metric.GetLabel() {
+				if pair.GetName() == label {
+					values[pair.GetValue()] = true
+				}
+			}
+		}
+	}
+	return values
+}
+
+// 보존 범위 밖 높이와 종료된 제안의 시리즈를 레지스트리에서 삭제하고 live_series 게이지를 갱신
+func TestCollectGarbage(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha", "ADDRBETA": "beta"})
+	if err := tracker.RegisterMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	tracker.labelRetention = 10
+	tracker.lastBlockHeight = 100
+
+	// 높이 81~100: cutoff 90 미만인 81~89가 정리 대상
+	for height := int64(81); height <= 100; height++ {
+		for _, validator := range []string{"alpha", "beta"} {
+			tracker.metrics.custom.beaconBlockSignedMetric.WithLabelValues(validator, strconv.FormatInt(height, 10)).Set(1)
+		}
+		tracker.signedHeights[height] = true
+	}
+	tracker.setActiveProposals([]string{"7", "8"})
+	for _, id := range []string{"7", "8"} {
+		tracker.metrics.cosmos.proposalEndTimeMetric.WithLabelValues(id).Set(1.75e9)
+		for _, validator := range []string{"alpha", "beta"} {
+			tracker.metrics.cosmos.voteMetric.WithLabelValues(validator, id).Set(1)
+		}
+	}
+	tracker.setActiveProposals([]string{"8"}) // 7번 투표 종료
+
+	tracker.updateLiveSeries()
+	live := func(family string) float64 {
+		return testutil.ToFloat64(tracker.metrics.exporter.liveSeriesMetric.WithLabelValues(family))
+	}
+	if got := live("og_galileo_validator_beacon_block_signed"); got != 40 {
+		t.Fatalf("live beacon series before = %v, want 40", got)
+	}
+	if got := live("og_galileo_validator_vote"); got != 4 {
+		t.Fatalf("live vote series before = %v, want 4", got)
+	}
+
+	tracker.collectGarbage()
+
+	heights := gatherLabelValues(t, tracker, "og_galileo_validator_beacon_block_signed", "block_height")
+	if len(heights) != 11 {
+		t.Errorf("heights after janitor = %d, want 11 (90..100)", len(heights))
+	}
+	for height := range heights {
+		if h, _ := strconv.ParseInt(height, 10, 64); h < 90 {
+			t.Errorf("stale height %d still exported", h)
+		}
+	}
+	for _, family := range []string{"og_galileo_validator_vote", "og_galileo_validator_proposal_end_time"} {
+		if ids := gatherLabelValues(t, tracker, family, "proposal_id"); ids["7"] || !ids["8"] {
+			t.Errorf("%s proposals after janitor = %v, want only 8", family, ids)
+		}
+	}
+
+	for family, want := range map[string]float64{
+		"og_galileo_validator_beacon_block_signed": 22,
+		"og_galileo_validator_vote":                2,
+		"og_galileo_validator_proposal_end_time":   1,
+	} {
+		if got := live(family); got != want {
+			t.Errorf("live %s = %v, want %v", family, got, want)
+		}
+	}
+	for height := range tracker.signedHeights {
+		if height < 90 {
+			t.Errorf("signedHeights still has %d", height)
+		}
+	}
+	if tracker.proposalSeries["7"] {
+		t.Error("proposal 7 still tracked")
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RPC 요청 방식: auto(JSON-RPC 배치 시도 후 실패 시 URI 방식), jsonrpc, uri
+const (
+	rpcModeAuto    = "auto"
+	rpcModeJSONRPC = "jsonrpc"
+	rpcModeURI     = "uri"
+)
+
+// auto 모드에서 배치 요청이 실패한 엔드포인트는 이 시간 동안 URI 방식만 사용
+const jsonRPCRetryAfter = 10 * time.Minute
+
+type rpcCall struct {
+	Method string
+	Params map[string]string
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      int               `json:"id"`
+	Method  string            `json:"method"`
+	Params  map[string]string `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// CometBFT JSON-RPC 배치 클라이언트 (POST / 에 요청 배열 전송, id로 응답 매칭)
+type JSONRPCClient struct {
+	mode   string
+	client *http.Client
+
+	mu            sync.Mutex
+	disabledUntil map[string]time.Time // endpoint -> URI 방식으로 돌아간 시각 + jsonRPCRetryAfter
+}
+
+func NewJSONRPCClient(mode string, transport http.RoundTripper) *JSONRPCClient {
+	return &JSONRPCClient{
+		mode:          mode,
+		client:        &http.Client{Timeout: 15 * time.Second, Transport: transport},
+		disabledUntil: make(map[string]time.Time),
+	}
+}
+
+// 이 엔드포인트에 배치 요청을 사용할지 여부
+func (c *JSONRPCClient) enabled(endpoint string) bool {
+	if c == nil || c.mode == rpcModeURI {
+		return false
+	}
+	if c.mode == rpcModeJSONRPC {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return time.Now().After(c.disabledUntil[endpoint])
+}
+
+func (c *JSONRPCClient) markFailed(endpoint string, err error) {
+	// 연결 실패는 JSON-RPC 지원 여부와 무관하므로 URI 방식으로 전환하지 않음
+	var transportErr *transportError
+	if c.mode != rpcModeAuto || errors.As(err, &transportErr) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.disabledUntil[endpoint] = time.Now().Add(jsonRPCRetryAfter)
+	rpcLog.Warn("JSON-RPC batch failed, falling back to URI requests", "endpoint", sanitizeEndpoint(endpoint),
+		"retry_after", jsonRPCRetryAfter, "error", err)
+}
+
+// 응답을 받기 전 연결 단계에서 실패한 요청
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string { return e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+// 배치 요청: 호출 순서대로 {"result": ...} 형태의 응답 본문을 반환 (기존 응답 타입으로 바로 디코딩 가능)
+func (c *JSONRPCClient) Batch(ctx context.Context, endpoint string, calls []rpcCall) ([][]byte, error) {
+	requests := make([]jsonRPCRequest, len(calls))
+	for i, call := range calls {
+		params := call.Params
+		if params == nil {
+			params = map[string]string{}
+		}
+		requests[i] = jsonRPCRequest{JSONRPC: "2.0", ID: i + 1, Method: call.Method, Params: params}
+	}
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, &transportError{err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []jsonRPCResponse
+	if err := json.Unmarshal(data, &responses); err != nil {
+		return nil, fmt.Errorf("decode batch response: %w", err)
+	}
+	results := make([][]byte, len(calls))
+	for _, response := range responses {
+		i := response.ID - 1
+		if i < 0 || i >= len(calls) {
+			return nil, fmt.Errorf("unexpected response id %d", response.ID)
+		}
+		if response.Error != nil {
+			return nil, fmt.Errorf("%s: %w", calls[i].Method, response.Error)
+		}
+		results[i] = []byte(`{"result":` + string(response.Result) + `}`)
+	}
+	for i, result := range results {
+		if result == nil {
+			return nil, fmt.Errorf("missing response for %s", calls[i].Method)
+		}
+	}
+	return results, nil
+}
+
+// 한 추적 주기 동안 배치로 미리 가져온 응답 (blocks 수집기 고루틴에서만 사용)
+type cycleCache struct {
+	blocks map[int64]*BlockInfo // 0은 최신 블록
+}
+
+// 주기 시작 시 최신 블록과 직전 블록을 배치로 가져옴 (실패하면 캐시 없이 URI 방식으로 진행)
+// 노드 상태는 node_status 수집기가 별도 간격으로 조회
+func (vt *UnifiedValidatorTracker) prefetchCycle(ctx context.Context) {
+	vt.cycle = nil
+	endpoint := vt.endpoints.Selected()
+	if !vt.jsonRPC.enabled(endpoint) {
+		return
+	}
+
+	start := time.Now()
+	results, err := vt.jsonRPC.Batch(ctx, endpoint, []rpcCall{{Method: "block"}})
+	vt.cycleRPCTime.Add(int64(time.Since(start)))
+	if err != nil {
+		vt.jsonRPC.markFailed(endpoint, err)
+		return
+	}
+	cache := &cycleCache{blocks: make(map[int64]*BlockInfo)}
+	var latest BlockInfo
+	err = json.Unmarshal(results[0], &latest)
+	if err == nil {
+		err = latest.validate()
+	}
+	if err != nil {
+		vt.jsonRPC.markFailed(endpoint, err)
+		return
+	}
+	if vt.archiver != nil {
+		latest.raw = results[0]
+	}
+	cache.blocks[0] = &latest
+
+	// 새 블록이면 서명 판단에 쓰는 직전 블록도 미리 조회
+	height, _ := strconv.ParseInt(string(latest.Result.Block.Header.Height), 10, 64)
+	if height > vt.LastHeight() && height > 1 {
+		params := map[string]string{"height": strconv.FormatInt(height-1, 10)}
+		start := time.Now()
+		results, err := vt.jsonRPC.Batch(ctx, endpoint, []rpcCall{{Method: "block", Params: params}})
+		vt.cycleRPCTime.Add(int64(time.Since(start)))
+		if err == nil {
+			var previous BlockInfo
+			if json.Unmarshal(results[0], &previous) == nil && previous.validate() == nil {
+				if vt.archiver != nil {
+					previous.raw = results[0]
+				}
+				cache.blocks[height-1] = &previous
+			}
+		}
+	}
+	vt.cycle = cache
+}
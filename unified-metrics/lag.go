@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultProcessedLagAlertBlocks = 20              // 팁보다 이만큼 넘게 뒤처지면
+	defaultProcessedLagAlertAfter  = 2 * time.Minute // 이 시간 동안 계속되면 경고
+)
+
+// RPC가 알려준 팁과 익스포터가 처리한 높이의 차이 감시
+// RPC 장애(팁이 갱신되지 않음)나 체인 정지(팁이 멈춤)에서는 처리 높이가 팁을 따라잡아 차이가 줄어드므로,
+// 익스포터만 멈춰 있거나 뒤처진 경우를 따로 구분
+type LagMonitor struct {
+	mu          sync.Mutex
+	threshold   int64 // 0이면 경고하지 않음 (메트릭만 갱신)
+	after       time.Duration
+	tip         int64
+	processed   int64
+	behindSince time.Time // 차이가 threshold를 넘기 시작한 시각 (넘지 않으면 zero)
+	alerting    bool
+}
+
+func NewLagMonitor(threshold int64, after time.Duration) *LagMonitor {
+	return &LagMonitor{threshold: threshold, after: after}
+}
+
+// LagMonitor.Observe 결과 (Changed가 true면 Alerting이 새 상태)
+type lagState struct {
+	Lag       int64
+	BehindFor time.Duration
+	Known     bool // 팁과 처리 높이를 모두 한 번 이상 받았는지
+	Alerting  bool
+	Changed   bool
+}
+
+// 팁 또는 처리 높이 갱신 후 상태 판단 (0은 바뀌지 않은 값)
+func (m *LagMonitor) Observe(now time.Time, tip, processed int64) lagState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tip = max(m.tip, tip)
+	m.processed = max(m.processed, processed)
+	state := lagState{Alerting: m.alerting}
+	if m.tip == 0 || m.processed == 0 {
+		return state
+	}
+	state.Known = true
+	state.Lag = max(m.tip-m.processed, 0)
+
+	if m.threshold > 0 && state.Lag > m.threshold {
+		if m.behindSince.IsZero() {
+			m.behindSince = now
+		}
+		state.BehindFor = now.Sub(m.behindSince)
+		if state.BehindFor >= m.after {
+			state.Alerting = true
+		}
+	} else {
+		m.behindSince = time.Time{}
+		state.Alerting = false
+	}
+	state.Changed = state.Alerting != m.alerting
+	m.alerting = state.Alerting
+	return state
+}
+
+// 체인 리셋 시 이전 체인 높이 기준을 버림
+func (m *LagMonitor) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tip = 0
+	m.processed = 0
+	m.behindSince = time.Time{}
+	m.alerting = false
+}
+
+// 팁 조회(fetcher)와 블록 적용(applier) 후 호출: 팁, 처리 높이 차이 메트릭 갱신과 지속 지연 경고
+func (vt *UnifiedValidatorTracker) observeProcessedLag(tip, processed int64) {
+	if tip > 0 {
+		vt.metrics.exporter.tipHeightMetric.Set(float64(tip))
+	}
+	state := vt.lag.Observe(time.Now(), tip, processed)
+	if state.Known {
+		vt.metrics.exporter.processedLagMetric.Set(float64(state.Lag))
+	}
+	if !state.Changed {
+		return
+	}
+	if !state.Alerting {
+		trackerLog.Info("Exporter caught up with the chain tip", "lag", state.Lag)
+		return
+	}
+
+	message := fmt.Sprintf("processed height is %d blocks behind the RPC tip for %s (threshold %d blocks)",
+		state.Lag, state.BehindFor.Round(time.Second), vt.lag.threshold)
+	trackerLog.Warn("Exporter is stuck behind the chain tip", "lag", state.Lag, "behind_for", state.BehindFor, "threshold", vt.lag.threshold)
+	vt.events.Publish(Event{Type: EventExporterLagging, Height: vt.LastHeight(), Message: message})
+	if len(vt.notifiers) > 0 {
+		vt.notifyAsync(Message{
+			Title:    "Exporter stuck behind chain tip",
+			Markdown: message,
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// 상태 파일에 함께 저장하는 exporter 실행 기록
+// 주기 저장은 CleanShutdown=false로, SIGTERM/SIGINT 종료 경로의 마지막 저장만 true로 기록하므로
+// 다음 시작 시 false가 남아 있으면 이전 실행이 비정상 종료된 것으로 판단
+type ExporterLifecycle struct {
+	StartedAt     time.Time  `json:"started_at"`
+	Restarts      int64      `json:"restarts"`
+	CleanShutdown bool       `json:"clean_shutdown"`
+	ShutdownAt    *time.Time `json:"shutdown_at,omitempty"`
+}
+
+// 이전 실행의 종료 정보 (실행 기록이 있는 상태 파일을 읽었을 때만 Known=true)
+type PreviousShutdown struct {
+	Known    bool      `json:"known"`
+	Time     time.Time `json:"time,omitempty"` // 비정상 종료면 마지막으로 저장된 시각
+	Graceful bool      `json:"graceful"`
+}
+
+// 상태 파일에서 이전 실행 기록을 읽어 이번 실행 기록 초기화 (체인 ID 확인 없이 시작 직후 호출, 다른 상태는 loadStateFile에서 복원)
+func (vt *UnifiedValidatorTracker) loadLifecycle(path string, startedAt time.Time) error {
+	vt.mu.Lock()
+	vt.lifecycle = ExporterLifecycle{StartedAt: startedAt}
+	vt.mu.Unlock()
+	vt.metrics.exporter.startTimestampMetric.Set(float64(startedAt.Unix()))
+
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var saved struct {
+		CreatedAt time.Time          `json:"created_at"`
+		Lifecycle *ExporterLifecycle `json:"lifecycle"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("decode state file: %w", err)
+	}
+
+	// 실행 기록이 없던 이전 버전의 상태 파일도 재시작 한 번으로 셈 (종료 방식은 알 수 없음)
+	previous := PreviousShutdown{Known: saved.Lifecycle != nil, Time: saved.CreatedAt}
+	restarts := int64(1)
+	if saved.Lifecycle != nil {
+		restarts += saved.Lifecycle.Restarts
+		previous.Graceful = saved.Lifecycle.CleanShutdown
+		if previous.Graceful && saved.Lifecycle.ShutdownAt != nil {
+			previous.Time = *saved.Lifecycle.ShutdownAt
+		}
+	}
+
+	vt.mu.Lock()
+	vt.lifecycle.Restarts = restarts
+	vt.previousShutdown = previous
+	vt.mu.Unlock()
+
+	metrics := vt.metrics.exporter
+	metrics.restartsMetric.Add(float64(restarts))
+	metrics.lastShutdownMetric.Set(float64(previous.Time.Unix()))
+	metrics.lastShutdownGracefulMetric.Set(boolToFloat(previous.Graceful))
+	if previous.Known && !previous.Graceful {
+		persistenceLog.Warn("Previous run did not shut down cleanly", "last_saved_at", previous.Time, "restarts", restarts)
+	} else {
+		persistenceLog.Info("Loaded exporter lifecycle", "previous_shutdown", previous.Time, "graceful", previous.Graceful, "restarts", restarts)
+	}
+	return nil
+}
+
+// 스냅샷에 넣을 실행 기록 (종료 중에 저장하면 정상 종료로 기록)
+func (vt *UnifiedValidatorTracker) lifecycleLocked() *ExporterLifecycle {
+	lifecycle := vt.lifecycle
+	if vt.shuttingDown.Load() {
+		lifecycle.CleanShutdown = true
+		now := time.Now().UTC()
+		lifecycle.ShutdownAt = &now
+	}
+	return &lifecycle
+}
+
+// 시작 이벤트 메시지 (이전 종료 방식 포함)
+func (vt *UnifiedValidatorTracker) startedMessage() string {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	previous := vt.previousShutdown
+	switch {
+	case !previous.Known:
+		return ""
+	case previous.Graceful:
+		return fmt.Sprintf("restart #%d after graceful shutdown at %s", vt.lifecycle.Restarts, previous.Time.UTC().Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("restart #%d after unclean shutdown (last state saved at %s)", vt.lifecycle.Restarts, previous.Time.UTC().Format(time.RFC3339))
+	}
+}
+
+// 계속 실행할 수 없는 오류로 종료 요청 (루트 컨텍스트를 err로 취소해 정상 종료 경로를 거치게 함)
+func (vt *UnifiedValidatorTracker) Stop(err error) {
+	if vt.stop != nil {
+		vt.stop(err)
+	}
+}
+
+// Stop으로 종료했으면 그 오류 (시그널로 인한 종료는 nil)
+func (vt *UnifiedValidatorTracker) stopError(ctx context.Context) error {
+	if err := context.Cause(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
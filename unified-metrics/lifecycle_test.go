@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// 한 번의 exporter 실행: 상태 파일에서 실행 기록을 읽고 블록을 처리한 뒤 주기 저장
+// clean이면 SIGTERM 경로처럼 종료 중 표시 후 마지막으로 저장, 아니면 주기 저장만 남기고 크래시
+func simulateRun(t *testing.T, path string, startedAt time.Time, clean bool) *UnifiedValidatorTracker {
+	t.Helper()
+	h := newTestHarness(t, "alpha", "beta")
+	if err := h.tracker.loadLifecycle(path, startedAt); err != nil {
+		t.Fatalf("load lifecycle: %v", err)
+	}
+	if err := h.advance(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.saveStateFile(path); err != nil {
+		t.Fatalf("periodic save: %v", err)
+	}
+	if clean {
+		h.tracker.shuttingDown.Store(true)
+		if err := h.tracker.saveStateFile(path); err != nil {
+			t.Fatalf("shutdown save: %v", err)
+		}
+	}
+	h.stop()
+	return h.tracker
+}
+
+// 다음 실행이 읽은 이전 종료 정보
+func nextRun(t *testing.T, path string) *UnifiedValidatorTracker {
+	t.Helper()
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{})
+	if err := tracker.loadLifecycle(path, time.Now().UTC()); err != nil {
+		t.Fatalf("load lifecycle: %v", err)
+	}
+	return tracker
+}
+
+func TestLifecycleFirstStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{})
+	if err := tracker.loadLifecycle(path, startedAt); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(tracker.metrics.exporter.startTimestampMetric); got != float64(startedAt.Unix()) {
+		t.Errorf("start timestamp = %v, want %d", got, startedAt.Unix())
+	}
+	if got := testutil.ToFloat64(tracker.metrics.exporter.restartsMetric); got != 0 {
+		t.Errorf("restarts = %v, want 0", got)
+	}
+	if tracker.previousShutdown.Known || tracker.startedMessage() != "" {
+		t.Errorf("previous shutdown known on first start: %+v", tracker.previousShutdown)
+	}
+}
+
+func TestLifecycleCleanShutdown(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	simulateRun(t, path, time.Now().UTC(), true)
+	stoppedAt := time.Now().UTC()
+
+	tracker := nextRun(t, path)
+	previous := tracker.previousShutdown
+	if !previous.Known || !previous.Graceful {
+		t.Fatalf("previous shutdown = %+v, want known and graceful", previous)
+	}
+	if previous.Time.After(stoppedAt) || stoppedAt.Sub(previous.Time) > time.Minute {
+		t.Errorf("shutdown time = %v, want just before %v", previous.Time, stoppedAt)
+	}
+	if got := testutil.ToFloat64(tracker.metrics.exporter.lastShutdownGracefulMetric); got != 1 {
+		t.Errorf("last shutdown graceful = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(tracker.metrics.exporter.lastShutdownMetric); got != float64(previous.Time.Unix()) {
+		t.Errorf("last shutdown timestamp = %v, want %d", got, previous.Time.Unix())
+	}
+	if got := testutil.ToFloat64(tracker.metrics.exporter.restartsMetric); got != 1 {
+		t.Errorf("restarts = %v, want 1", got)
+	}
+	if msg := tracker.startedMessage(); !strings.Contains(msg, "graceful shutdown") {
+		t.Errorf("started message = %q", msg)
+	}
+}
+
+// 주기 저장만 남은 상태 파일은 비정상 종료로 판단하고 마지막 저장 시각을 기록
+func TestLifecycleCrash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	simulateRun(t, path, time.Now().UTC(), false)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved TrackerSnapshot
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Lifecycle == nil || saved.Lifecycle.CleanShutdown || saved.Lifecycle.ShutdownAt != nil {
+		t.Fatalf("periodic save lifecycle = %+v, want no clean shutdown marker", saved.Lifecycle)
+	}
+
+	tracker := nextRun(t, path)
+	previous := tracker.previousShutdown
+	if !previous.Known || previous.Graceful {
+		t.Fatalf("previous shutdown = %+v, want known and not graceful", previous)
+	}
+	if !previous.Time.Equal(saved.CreatedAt) {
+		t.Errorf("crash time = %v, want last save %v", previous.Time, saved.CreatedAt)
+	}
+	if got := testutil.ToFloat64(tracker.metrics.exporter.lastShutdownGracefulMetric); got != 0 {
+		t.Errorf("last shutdown graceful = %v, want 0", got)
+	}
+	if msg := tracker.startedMessage(); !strings.Contains(msg, "unclean shutdown") {
+		t.Errorf("started message = %q", msg)
+	}
+}
+
+// 재시작 수는 실행마다 누적되고, 종료 방식은 직전 실행만 반영
+func TestLifecycleRestartsAccumulate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	simulateRun(t, path, time.Now().UTC(), true)
+	simulateRun(t, path, time.Now().UTC(), false)
+	simulateRun(t, path, time.Now().UTC(), true)
+
+	tracker := nextRun(t, path)
+	if got := testutil.ToFloat64(tracker.metrics.exporter.restartsMetric); got != 3 {
+		t.Errorf("restarts = %v, want 3", got)
+	}
+	if !tracker.previousShutdown.Graceful {
+		t.Error("last run shut down cleanly but previous shutdown is not graceful")
+	}
+	if msg := tracker.startedMessage(); !strings.HasPrefix(msg, "restart #3 ") {
+		t.Errorf("started message = %q", msg)
+	}
+}
+
+// 실행 기록이 없던 버전의 상태 파일: 재시작으로 세지만 종료 방식은 알 수 없음
+func TestLifecycleLegacyStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.WriteFile(path, []byte(`{"created_at":"2025-06-01T12:00:00Z","last_block_height":100}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker := nextRun(t, path)
+	previous := tracker.previousShutdown
+	if previous.Known || previous.Graceful || !previous.Time.Equal(createdAt) {
+		t.Errorf("previous shutdown = %+v", previous)
+	}
+	if got := testutil.ToFloat64(tracker.metrics.exporter.restartsMetric); got != 1 {
+		t.Errorf("restarts = %v, want 1", got)
+	}
+}
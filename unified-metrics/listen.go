@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultListenAddr = ":8080"
+	defaultSocketMode = 0o660
+	unixListenPrefix  = "unix://"
+)
+
+// 리슨 대상: TCP 주소(:8080, 0.0.0.0:8080, [::]:8080, [2001:db8::1]:8080) 또는 unix:///path.sock
+type ListenTarget struct {
+	Network string // tcp, tcp4, tcp6, unix
+	Address string
+}
+
+func (t ListenTarget) String() string {
+	if t.Network == "unix" {
+		return unixListenPrefix + t.Address
+	}
+	return t.Address
+}
+
+func parseListenTarget(value string) (ListenTarget, error) {
+	value = strings.TrimSpace(value)
+	if path, ok := strings.CutPrefix(value, unixListenPrefix); ok {
+		if !strings.HasPrefix(path, "/") {
+			return ListenTarget{}, fmt.Errorf("unix socket path must be absolute: %q", value)
+		}
+		return ListenTarget{Network: "unix", Address: path}, nil
+	}
+
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return ListenTarget{}, fmt.Errorf("invalid listen address %q (IPv6 addresses need brackets, e.g. [::]:8080): %w", value, err)
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		return ListenTarget{}, fmt.Errorf("invalid port in listen address %q", value)
+	}
+
+	// IP 리터럴이면 주소 계열을 고정 (빈 호스트는 듀얼 스택)
+	network := "tcp"
+	if host != "" {
+		ip := net.ParseIP(strings.Split(host, "%")[0])
+		switch {
+		case ip == nil:
+			// 호스트 이름은 그대로 사용
+		case ip.To4() != nil:
+			network = "tcp4"
+		default:
+			network = "tcp6"
+		}
+	}
+	return ListenTarget{Network: network, Address: value}, nil
+}
+
+// 리스너 생성 (unix 소켓은 기존 소켓 파일을 정리하고 권한을 설정)
+func (t ListenTarget) Listen(socketMode os.FileMode) (net.Listener, error) {
+	if t.Network == "unix" {
+		if info, err := os.Lstat(t.Address); err == nil {
+			if info.Mode()&os.ModeSocket == 0 {
+				return nil, fmt.Errorf("%s exists and is not a socket", t.Address)
+			}
+			if err := os.Remove(t.Address); err != nil {
+				return nil, fmt.Errorf("remove stale socket: %w", err)
+			}
+		}
+	}
+
+	listener, err := net.Listen(t.Network, t.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Network == "unix" {
+		if err := os.Chmod(t.Address, socketMode); err != nil {
+			listener.Close()
+			return nil, fmt.Errorf("chmod socket: %w", err)
+		}
+	}
+	return listener, nil
+}
+
+// 종료 후 남은 소켓 파일 삭제
+func (t ListenTarget) Cleanup() {
+	if t.Network != "unix" {
+		return
+	}
+	if err := os.Remove(t.Address); err != nil && !os.IsNotExist(err) {
+		httpLog.Warn("Failed to remove socket file", "path", t.Address, "error", err)
+	}
+}
+
+// 로컬 요청(healthcheck)용 클라이언트와 기준 URL
+func (t ListenTarget) LocalClient(timeout time.Duration) (*http.Client, string) {
+	client := &http.Client{Timeout: timeout}
+	if t.Network == "unix" {
+		client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", t.Address)
+			},
+		}
+		return client, "http://unix"
+	}
+
+	host, port, _ := net.SplitHostPort(t.Address)
+	switch {
+	case host == "" || host == "0.0.0.0":
+		host = "127.0.0.1"
+	case host == "::":
+		host = "::1"
+	}
+	return client, "http://" + net.JoinHostPort(host, port)
+}
+
+func parseSocketMode(value string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid socket mode %q (expected octal, e.g. 0660)", value)
+	}
+	return os.FileMode(mode), nil
+}
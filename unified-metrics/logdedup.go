@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultLogDedupWindow = time.Minute
+
+// 반복 오류 요약 주기 (0이면 중복 제거 비활성화), LOG_DEDUP_WINDOW로 설정
+var logDedupWindow atomic.Int64
+
+func init() {
+	logDedupWindow.Store(int64(defaultLogDedupWindow))
+}
+
+// 정상 로그에 붙이는 성공 신호 속성 (값은 복구된 오류 로그의 메시지)
+const logRecoversKey = "recovers"
+
+// 숫자(높이, 포트, 주소 등)를 지워 같은 종류의 오류를 하나로 묶음
+var errorClassDigits = regexp.MustCompile(`[0-9]+`)
+
+func errorClass(err string) string {
+	return errorClassDigits.ReplaceAllString(err, "#")
+}
+
+// 반복되는 경고/오류 로그를 창(window) 단위로 요약하는 slog 핸들러 래퍼
+// - (컴포넌트, 메시지, 오류 종류)가 같은 로그는 창 안에서 첫 번째만 기록
+// - 창이 끝나면 "last error repeated N times" 요약을 기록
+// - 오류→성공 전환(logRecoversKey 속성)이 오면 같은 컴포넌트, 같은 메시지의 오류를 즉시 요약하고 초기화
+// - 일반 Info 로그는 요약에 영향을 주지 않음
+type dedupHandler struct {
+	inner     slog.Handler
+	state     *dedupState
+	component string
+}
+
+type dedupState struct {
+	mu      sync.Mutex
+	entries map[string]*dedupEntry
+}
+
+type dedupEntry struct {
+	handler   slog.Handler
+	component string
+	level     slog.Level
+	message   string
+	lastError string
+	repeated  int
+	timer     *time.Timer
+}
+
+func newDedupHandler(inner slog.Handler) *dedupHandler {
+	return &dedupHandler{inner: inner, state: &dedupState{entries: make(map[string]*dedupEntry)}}
+}
+
+func (h *dedupHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *dedupHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithAttrs(attrs)
+	for _, attr := range attrs {
+		if attr.Key == "component" {
+			clone.component = attr.Value.String()
+		}
+	}
+	return &clone
+}
+
+func (h *dedupHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.inner = h.inner.WithGroup(name)
+	return &clone
+}
+
+func (h *dedupHandler) Handle(ctx context.Context, record slog.Record) error {
+	window := time.Duration(logDedupWindow.Load())
+	if window <= 0 {
+		return h.inner.Handle(ctx, record)
+	}
+
+	if record.Level < slog.LevelWarn {
+		record.Attrs(func(attr slog.Attr) bool {
+			if attr.Key == logRecoversKey {
+				h.state.flushMessage(h.component, attr.Value.String())
+				return false
+			}
+			return true
+		})
+		return h.inner.Handle(ctx, record)
+	}
+
+	errText := ""
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == "error" {
+			errText = attr.Value.String()
+			return false
+		}
+		return true
+	})
+	key := fmt.Sprintf("%s\x00%s\x00%s", h.component, record.Message, errorClass(errText))
+
+	h.state.mu.Lock()
+	if entry, ok := h.state.entries[key]; ok {
+		entry.repeated++
+		entry.lastError = errText
+		h.state.mu.Unlock()
+		return nil
+	}
+	entry := &dedupEntry{handler: h.inner, component: h.component, level: record.Level, message: record.Message}
+	entry.timer = time.AfterFunc(window, func() { h.state.flushKey(key) })
+	h.state.entries[key] = entry
+	h.state.mu.Unlock()
+
+	return h.inner.Handle(ctx, record)
+}
+
+// 창이 끝난 항목 요약
+func (s *dedupState) flushKey(key string) {
+	s.mu.Lock()
+	entry, ok := s.entries[key]
+	if ok {
+		delete(s.entries, key)
+	}
+	s.mu.Unlock()
+
+	if ok {
+		entry.summarize()
+	}
+}
+
+// 성공 신호: 같은 컴포넌트, 같은 메시지의 항목을 오류 종류와 무관하게 요약
+func (s *dedupState) flushMessage(component, message string) {
+	s.mu.Lock()
+	var flushed []*dedupEntry
+	for key, entry := range s.entries {
+		if entry.component == component && entry.message == message {
+			entry.timer.Stop()
+			delete(s.entries, key)
+			flushed = append(flushed, entry)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, entry := range flushed {
+		entry.summarize()
+	}
+}
+
+func (e *dedupEntry) summarize() {
+	if e.repeated == 0 {
+		return
+	}
+	record := slog.NewRecord(time.Now(), e.level, fmt.Sprintf("last error repeated %d times", e.repeated), 0)
+	record.AddAttrs(slog.String("message", e.message), slog.Int("repeated", e.repeated))
+	if e.lastError != "" {
+		record.AddAttrs(slog.String("error", e.lastError))
+	}
+	e.handler.Handle(context.Background(), record)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 내부 핸들러로 전달된 로그 기록
+type capturedLogs struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+type captureHandler struct{ logs *capturedLogs }
+
+func (h captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h captureHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h captureHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h captureHandler) Handle(_ context.Context, record slog.Record) error {
+	h.logs.mu.Lock()
+	defer h.logs.mu.Unlock()
+	h.logs.records = append(h.logs.records, record.Clone())
+	return nil
+}
+
+func (l *capturedLogs) messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	messages := make([]string, len(l.records))
+	for i, record := range l.records {
+		messages[i] = record.Message
+	}
+	return messages
+}
+
+// 마지막 요약의 repeated 값 (요약이 없으면 -1)
+func (l *capturedLogs) lastRepeated() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	repeated := int64(-1)
+	for _, record := range l.records {
+		record.Attrs(func(attr slog.Attr) bool {
+			if attr.Key == "repeated" {
+				repeated = attr.Value.Int64()
+			}
+			return true
+		})
+	}
+	return repeated
+}
+
+func newDedupTestLogger(t *testing.T, window time.Duration) (*slog.Logger, *capturedLogs) {
+	t.Helper()
+	previous := logDedupWindow.Load()
+	logDedupWindow.Store(int64(window))
+	t.Cleanup(func() { logDedupWindow.Store(previous) })
+
+	logs := &capturedLogs{}
+	return slog.New(newDedupHandler(captureHandler{logs: logs})).With("component", "tracker"), logs
+}
+
+func TestLogDedupCountsRepeats(t *testing.T) {
+	log, logs := newDedupTestLogger(t, time.Hour)
+	for height := 100; height < 105; height++ {
+		// 숫자만 다른 오류는 같은 종류
+		log.Error(latestBlockErrorMessage, "error", fmt.Errorf("height %d is not available", height))
+	}
+	if got := logs.messages(); len(got) != 1 {
+		t.Fatalf("logged %d records, want only the first: %v", len(got), got)
+	}
+
+	// 다른 종류의 오류는 따로 기록
+	log.Error(latestBlockErrorMessage, "error", errors.New("connection refused"))
+	if got := len(logs.messages()); got != 2 {
+		t.Fatalf("different error class logged %d records, want 2", got)
+	}
+}
+
+func TestLogDedupFlushesAfterWindow(t *testing.T) {
+	log, logs := newDedupTestLogger(t, 20*time.Millisecond)
+	for i := 0; i < 4; i++ {
+		log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for logs.lastRepeated() < 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no summary after the window: %v", logs.messages())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := logs.lastRepeated(); got != 3 {
+		t.Errorf("summary repeated = %d, want 3", got)
+	}
+
+	// 창이 끝난 뒤에는 다시 첫 오류로 기록
+	log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+	if got := logs.messages(); got[len(got)-1] != latestBlockErrorMessage {
+		t.Errorf("error after the window suppressed: %v", got)
+	}
+}
+
+// 같은 컴포넌트의 다른 정상 로그(예: Processed beacon block)로는 요약이 끊기지 않음
+func TestLogDedupIgnoresUnrelatedInfo(t *testing.T) {
+	log, logs := newDedupTestLogger(t, time.Hour)
+	log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+	log.Info("Processed beacon block", "height", 10)
+	log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+	log.Info("Processed beacon block", "height", 11)
+	log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+
+	want := []string{latestBlockErrorMessage, "Processed beacon block", "Processed beacon block"}
+	got := logs.messages()
+	if len(got) != len(want) {
+		t.Fatalf("records = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("records = %v, want %v", got, want)
+		}
+	}
+	if logs.lastRepeated() != -1 {
+		t.Error("unrelated info log flushed the suppressed errors")
+	}
+}
+
+func TestLogDedupFlushesOnRecovery(t *testing.T) {
+	log, logs := newDedupTestLogger(t, time.Hour)
+	log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+	log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+	log.Error("Error fetching validators", "error", errors.New("timeout"))
+	log.Error("Error fetching validators", "error", errors.New("timeout"))
+
+	// 다른 메시지의 복구 신호는 영향 없음
+	log.Info("Staking recovered", logRecoversKey, "Error fetching staking validators")
+	if logs.lastRepeated() != -1 {
+		t.Fatal("recovery of another message flushed the summary")
+	}
+
+	log.Info("Latest block fetch recovered", logRecoversKey, latestBlockErrorMessage)
+	if got := logs.lastRepeated(); got != 1 {
+		t.Fatalf("summary repeated = %d, want 1 (records %v)", got, logs.messages())
+	}
+
+	// 복구된 키는 초기화되어 다음 오류가 다시 기록되고, 다른 메시지는 계속 억제
+	before := len(logs.messages())
+	log.Error(latestBlockErrorMessage, "error", errors.New("timeout"))
+	log.Error("Error fetching validators", "error", errors.New("timeout"))
+	if got := logs.messages(); len(got) != before+1 || got[len(got)-1] != latestBlockErrorMessage {
+		t.Errorf("records after recovery = %v", got[before:])
+	}
+}
+
+// 오류 없이 복구 신호만 오면 요약을 남기지 않음
+func TestLogDedupRecoveryWithoutErrors(t *testing.T) {
+	log, logs := newDedupTestLogger(t, time.Hour)
+	log.Info("Latest block fetch recovered", logRecoversKey, latestBlockErrorMessage)
+	if got := logs.messages(); len(got) != 1 || logs.lastRepeated() != -1 {
+		t.Errorf("records = %v", got)
+	}
+}
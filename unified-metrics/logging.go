@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// 레벨을 개별 설정할 수 있는 로그 컴포넌트
+var logComponents = []string{"tracker", "rpc", "rest", "aggregator", "http", "alerts", "persistence", "sink"}
+
+// 컴포넌트별 로거 (레벨은 logLevels에서 공유되며 SIGHUP으로 변경 가능)
+var (
+	trackerLog     = componentLogger("tracker")
+	rpcLog         = componentLogger("rpc")
+	restLog        = componentLogger("rest")
+	aggregatorLog  = componentLogger("aggregator")
+	httpLog        = componentLogger("http")
+	alertsLog      = componentLogger("alerts")
+	persistenceLog = componentLogger("persistence")
+	sinkLog        = componentLogger("sink")
+)
+
+type levelRegistry struct {
+	mu     sync.Mutex
+	levels map[string]*slog.LevelVar
+}
+
+var logLevels = &levelRegistry{levels: make(map[string]*slog.LevelVar)}
+
+// --log-level (LOG_LEVEL) 기본 레벨 (비어 있으면 LOG_LEVELS의 기본 레벨, 그것도 없으면 info)
+var baseLogLevel string
+
+func (r *levelRegistry) levelVar(component string) *slog.LevelVar {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	level, ok := r.levels[component]
+	if !ok {
+		level = new(slog.LevelVar)
+		r.levels[component] = level
+	}
+	return level
+}
+
+// 설정 적용: 나열되지 않은 컴포넌트는 기본 레벨로 되돌림
+func (r *levelRegistry) apply(spec string) error {
+	defaultLevel, overrides, err := parseLogLevels(spec)
+	if err != nil {
+		return err
+	}
+	// 컴포넌트가 없는 로그(시작/설정 메시지)는 기본 레벨을 따름
+	r.levelVar("").Set(defaultLevel)
+	for _, component := range logComponents {
+		level, ok := overrides[component]
+		if !ok {
+			level = defaultLevel
+		}
+		r.levelVar(component).Set(level)
+	}
+	return nil
+}
+
+// "rpc=debug,tracker=info,http=warn" 형식 파싱 (컴포넌트 없는 항목은 전체 기본 레벨)
+func parseLogLevels(spec string) (slog.Level, map[string]slog.Level, error) {
+	defaultLevel := slog.LevelInfo
+	overrides := make(map[string]slog.Level)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		component, levelName, hasComponent := strings.Cut(entry, "=")
+		if !hasComponent {
+			levelName = component
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(levelName))); err != nil {
+			return 0, nil, fmt.Errorf("invalid log level in %q: %w", entry, err)
+		}
+
+		if !hasComponent {
+			defaultLevel = level
+			continue
+		}
+		component = strings.TrimSpace(component)
+		if !isLogComponent(component) {
+			return 0, nil, fmt.Errorf("unknown log component %q (known: %s)", component, strings.Join(logComponents, ", "))
+		}
+		overrides[component] = level
+	}
+	return defaultLevel, overrides, nil
+}
+
+func isLogComponent(name string) bool {
+	for _, component := range logComponents {
+		if component == name {
+			return true
+		}
+	}
+	return false
+}
+
+func componentLogger(component string) *slog.Logger {
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevels.levelVar(component), ReplaceAttr: redactLogAttr})
+	return slog.New(newDedupHandler(handler)).With("component", component)
+}
+
+// LOG_LEVELS_FILE이 있으면 파일 내용, 없으면 LOG_LEVELS 환경 변수
+// --log-level은 뒤에 붙여 기본 레벨만 덮어씀 (컴포넌트별 설정은 그대로 적용)
+func logLevelSpec() (string, error) {
+	spec := getEnv("LOG_LEVELS", "")
+	if path := getEnv("LOG_LEVELS_FILE", ""); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		spec = strings.TrimSpace(string(data))
+	}
+	if baseLogLevel != "" {
+		spec += "," + baseLogLevel
+	}
+	return spec, nil
+}
+
+// --log-level 값 확인 (debug, info, warn, error)
+func parseBaseLogLevel(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "debug", "info", "warn", "error":
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid log level %q (expected debug, info, warn or error)", value)
+}
+
+func configureLogLevels() error {
+	slog.SetDefault(slog.New(newDedupHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevels.levelVar(""), ReplaceAttr: redactLogAttr}))))
+	logDedupWindow.Store(int64(getEnvDuration("LOG_DEDUP_WINDOW", defaultLogDedupWindow)))
+
+	spec, err := logLevelSpec()
+	if err != nil {
+		return err
+	}
+	return logLevels.apply(spec)
+}
+
+// SIGHUP 수신 시 로그 레벨 설정 다시 읽기 (실패하면 기존 레벨 유지)
+func HandleLogLevelReload(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			if err := configureLogLevels(); err != nil {
+				slog.Error("Failed to reload log levels, keeping current levels", "error", err)
+				continue
+			}
+			spec, _ := logLevelSpec()
+			slog.Info("Reloaded log levels", "levels", spec)
+		}
+	}
+}
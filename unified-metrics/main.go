@@ -3,16 +3,25 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"html"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"os/signal"
+	"slices"
 	"strconv"
-	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -39,14 +48,59 @@ type CosmosValidatorMetrics struct {
 	slashFractionDoubleSignMetric  prometheus.Gauge
 	slashFractionDowntimeMetric    prometheus.Gauge
 	soloMissedBlocksMetric         *prometheus.GaugeVec
+	uptimeRatioMetric              *prometheus.GaugeVec
 	trackedBlocksMetric            prometheus.Counter
 	skippedBlocksMetric            prometheus.Counter
+	signingSkippedMetric           *prometheus.CounterVec
+	missedBlocksTotalMetric        *prometheus.CounterVec
 	transactionsMetric              prometheus.Counter
 	upgradePlanMetric              prometheus.Gauge
+	blocksUntilUpgradeMetric       prometheus.Gauge
 	proposalEndTimeMetric          *prometheus.GaugeVec
 	voteMetric                     *prometheus.GaugeVec
 	nodeBlockHeightMetric          *prometheus.GaugeVec
 	nodeSyncedMetric               *prometheus.GaugeVec
+	tokensDisplayMetric            *prometheus.GaugeVec
+	seatPriceDisplayMetric         prometheus.Gauge
+	bondedPoolMetric               prometheus.Gauge
+	bondedPoolDisplayMetric        prometheus.Gauge
+	rewardsMetric                  *prometheus.GaugeVec
+	rewardsDisplayMetric           *prometheus.GaugeVec
+	maxValidatorsMetric            prometheus.Gauge
+	activeSetFullnessMetric        prometheus.Gauge
+	topTokensMetric                *prometheus.GaugeVec
+	topCommissionMetric            *prometheus.GaugeVec
+	stakeGiniMetric                prometheus.Gauge
+	nakamotoMetric                 prometheus.Gauge
+	stakeTopShareMetric            *prometheus.GaugeVec
+	tokensChangeMetric             *prometheus.GaugeVec
+	rankChangeMetric               *prometheus.GaugeVec
+	changeCoverageMetric           *prometheus.GaugeVec
+	validatorInfoMetric            *prometheus.GaugeVec
+	expectedPeerMetric             *prometheus.GaugeVec
+	peersTotalMetric               prometheus.Gauge
+	outboundPeersMetric            prometheus.Gauge
+	inboundPeersMetric             prometheus.Gauge
+	nodeHealthStateMetric          *prometheus.GaugeVec
+	nodeHeightLagMetric            *prometheus.GaugeVec
+	gasPriceMetric                 *prometheus.GaugeVec
+	observedTxsMetric              prometheus.Gauge
+	minGasPriceMetric              prometheus.Gauge
+	evmGasPriceMetric              prometheus.Gauge
+	evmBaseFeeMetric               prometheus.Gauge
+	accumCommissionMetric          *prometheus.GaugeVec
+	accumCommissionDisplayMetric   *prometheus.GaugeVec
+	tokenPriceMetric               *prometheus.GaugeVec
+	priceStaleMetric               *prometheus.GaugeVec
+	priceUpdatedMetric             *prometheus.GaugeVec
+	stakeValueMetric               *prometheus.GaugeVec
+	commissionValueMetric          *prometheus.GaugeVec
+	blocksToJailMetric             *prometheus.GaugeVec
+	startHeightMetric              *prometheus.GaugeVec
+	jailedUntilMetric              *prometheus.GaugeVec
+	tombstonedMetric               *prometheus.GaugeVec
+	signingMissedBlocksMetric      *prometheus.GaugeVec
+	pubkeyRotatedMetric            *prometheus.GaugeVec
 }
 
 // 커스텀 비콘 체인 메트릭 구조체
@@ -56,25 +110,110 @@ type CustomMetrics struct {
 	mempoolSizeMetric       prometheus.Gauge
 	mempoolTotalBytesMetric prometheus.Gauge
 	mempoolTotalMetric      prometheus.Gauge
+	mempoolMaxTxsMetric     prometheus.Gauge
 	missedBlocksMetric      *prometheus.GaugeVec
 	consecutiveMissedBlocksMetric *prometheus.GaugeVec
-	totalMissedBlocksMetric *prometheus.GaugeVec
+	totalMissedBlocksMetric       *prometheus.GaugeVec
+	proposalsWindowMetric         *prometheus.GaugeVec
+	proposalsExpectedMetric       *prometheus.GaugeVec
+	proposalsRatioMetric          *prometheus.GaugeVec
+	missRateEWMAMetric            *prometheus.GaugeVec
+	healthScoreMetric             *prometheus.GaugeVec
+	healthComponentMetric         *prometheus.GaugeVec
+	missIntervalP95Metric         *prometheus.GaugeVec
+	commitLatencyMetric           *prometheus.GaugeVec
+	commitLatencyPercentileMetric *prometheus.GaugeVec
+	totalVotingPowerMetric        prometheus.Gauge
+	faultThresholdMetric          prometheus.Gauge
+	absentVotingPowerMetric       prometheus.Gauge
+	quorumMarginMetric            prometheus.Gauge
+	votingPowerMetric             *prometheus.GaugeVec
+	faultThresholdRatioMetric     *prometheus.GaugeVec
+}
+
+// exporter 자체 상태 메트릭 구조체
+type ExporterMetrics struct {
+	liveSeriesMetric             *prometheus.GaugeVec
+	lastProcessedHeightMetric    prometheus.Gauge
+	lastProcessedTimestampMetric prometheus.Gauge
+	dataStalenessMetric          prometheus.GaugeFunc
+	rejectedRequestsMetric       *prometheus.CounterVec
+	historyRowsMetric            *prometheus.GaugeVec
+	historyPrunedMetric          *prometheus.CounterVec
+	historyPruneDurationMetric   prometheus.Histogram
+	missedCoverageMetric         prometheus.Counter
+	startupGapMetric             prometheus.Gauge
+	catchUpBlocksMetric          prometheus.Counter
+	startupCatchUpMetric         *prometheus.GaugeVec
+	rpcEndpointScoreMetric       *prometheus.GaugeVec
+	rpcEndpointSelectedMetric    *prometheus.GaugeVec
+	rpcEndpointHealthyMetric     *prometheus.GaugeVec
+	rpcURLHealthyMetric          *prometheus.GaugeVec
+	rpcFailoverMetric            prometheus.Counter
+	rpcEndpointUpMetric          *prometheus.GaugeVec
+	sourceStaleMetric            *prometheus.GaugeVec
+	probeUpMetric                *prometheus.GaugeVec
+	probeLatencyMetric           *prometheus.GaugeVec
+	probeStatusCodeMetric        *prometheus.GaugeVec
+	dataDiscrepanciesMetric      prometheus.Counter
+	verificationsMetric          *prometheus.CounterVec
+	verificationLatencyMetric    prometheus.Histogram
+	cycleOverrunsMetric          *prometheus.CounterVec
+	cycleOverrunRatioMetric      prometheus.Gauge
+	tipHeightMetric              prometheus.Gauge
+	processedLagMetric           prometheus.Gauge
+	pollIntervalMetric           prometheus.Gauge
+	websocketConnectedMetric     prometheus.Gauge
+	websocketReconnectsMetric    prometheus.Counter
+	scrapeSuccessMetric          *prometheus.GaugeVec
+	rpcCompatMetric              prometheus.Gauge
+	startTimestampMetric         prometheus.Gauge
+	restartsMetric               prometheus.Counter
+	lastShutdownMetric           prometheus.Gauge
+	lastShutdownGracefulMetric   prometheus.Gauge
+	sinkPublishedMetric          prometheus.Counter
+	sinkFailuresMetric           prometheus.Counter
+	sinkDroppedMetric            prometheus.Counter
+	sinkHeightMetric             prometheus.Gauge
+	sinkBacklogMetric            prometheus.Gauge
+	collectorLastRunMetric       *prometheus.GaugeVec
+	collectorLastSuccessMetric   *prometheus.GaugeVec
+	collectorErrorMetric         *prometheus.GaugeVec
+	collectorDurationMetric      *prometheus.GaugeVec
+	collectorRunsMetric          *prometheus.CounterVec
+	heartbeatsMetric             *prometheus.CounterVec
+	heartbeatLastSentMetric      prometheus.Gauge
+	chainResetsMetric            prometheus.Counter
+	rpcErrorsMetric              *prometheus.CounterVec
+	httpDurationMetric           *prometheus.HistogramVec
+	httpErrorsMetric             *prometheus.CounterVec
+	httpRetriesMetric            *prometheus.CounterVec
+	rpcDurationMetric            *prometheus.HistogramVec
+	rpcLastSuccessMetric         *prometheus.GaugeVec
+	archivedBlocksMetric         prometheus.Counter
+	archiveFailuresMetric        prometheus.Counter
+	archiveDroppedMetric         prometheus.Counter
+	archiveBacklogMetric         prometheus.Gauge
+	hookRunsMetric               *prometheus.CounterVec
+	hookDroppedMetric            *prometheus.CounterVec
+	goroutinesMetric             *prometheus.GaugeVec
 }
 
 type UnifiedMetrics struct {
-	cosmos *CosmosValidatorMetrics
-	custom *CustomMetrics
+	cosmos   *CosmosValidatorMetrics
+	custom   *CustomMetrics
+	exporter *ExporterMetrics
 }
 
 func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 	return &CosmosValidatorMetrics{
-		blockHeightMetric: prometheus.NewGauge(
+		blockHeightMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_block_height",
 				Help: "Latest known block height",
 			},
 		),
-		activeSetMetric: prometheus.NewGauge(
+		activeSetMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_active_set",
 				Help: "Number of validators in the active set",
@@ -97,7 +236,7 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 		missedBlocksMetric: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_missed_blocks",
-				Help: "Number of missed blocks per validator",
+				Help: "Number of missed blocks per validator in the signing window (same as og_galileo_validator_missed_blocks_window)",
 			},
 			[]string{"validator"},
 		),
@@ -108,13 +247,7 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 			},
 			[]string{"validator"},
 		),
-		cometbftMissedBlocksMetric: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "cometbft_consensus_validator_missed_blocks",
-				Help: "Number of missed blocks per validator (CometBFT consensus)",
-			},
-			[]string{"validator", "chain_id"},
-		),
+		cometbftMissedBlocksMetric: newCometBFTMissedBlocksMetric(cometbftMissedBlocksName),
 		tokensMetric: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_tokens",
@@ -122,6 +255,261 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 			},
 			[]string{"validator"},
 		),
+		tokensDisplayMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_tokens_display",
+				Help: "Number of staked tokens per validator in display units",
+			},
+			[]string{"validator"},
+		),
+		rewardsMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_outstanding_rewards",
+				Help: "Outstanding (unwithdrawn) rewards per validator in base units",
+			},
+			[]string{"validator"},
+		),
+		rewardsDisplayMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_outstanding_rewards_display",
+				Help: "Outstanding (unwithdrawn) rewards per validator in display units",
+			},
+			[]string{"validator"},
+		),
+		maxValidatorsMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_staking_max_validators",
+				Help: "max_validators staking parameter (size of the active set)",
+			},
+		),
+		activeSetFullnessMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_active_set_fullness",
+				Help: "Bonded validator count divided by max_validators (1 means new validators must outbid the seat price)",
+			},
+		),
+		topTokensMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_network_top_validator_tokens",
+				Help: "Bonded tokens of the validator at each of the top N stake ranks in base units",
+			},
+			[]string{"rank"},
+		),
+		topCommissionMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_network_top_validator_commission",
+				Help: "Commission rate of the validator at each of the top N stake ranks",
+			},
+			[]string{"rank"},
+		),
+		stakeGiniMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_network_stake_gini",
+				Help: "Gini coefficient of bonded stake across the active set (0 = equal, 1 = concentrated)",
+			},
+		),
+		nakamotoMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_network_nakamoto_coefficient",
+				Help: "Minimum number of validators that together hold more than 1/3 of bonded stake",
+			},
+		),
+		stakeTopShareMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_network_stake_share_top",
+				Help: "Share of bonded stake held by the top N validators",
+			},
+			[]string{"top"},
+		),
+		tokensChangeMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_tokens_change_24h",
+				Help: "Change in bonded tokens over the last 24h (or the available span, see change_24h_coverage) in base units",
+			},
+			[]string{"validator"},
+		),
+		rankChangeMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_rank_change_24h",
+				Help: "Change in rank over the last 24h (negative = moved up; 0 rank means not bonded)",
+			},
+			[]string{"validator"},
+		),
+		changeCoverageMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_change_24h_coverage",
+				Help: "Fraction of the 24h window covered by retained samples for the change metrics (1 = full window)",
+			},
+			[]string{"validator"},
+		),
+		pubkeyRotatedMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_pubkey_rotated",
+				Help: "Last processed height when a change of the validator's consensus pubkey was detected (absent if never changed)",
+			},
+			[]string{"validator"},
+		),
+		validatorInfoMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_info",
+				Help: "Always 1; carries the sanitized on-chain moniker and operator address of a tracked validator",
+			},
+			[]string{"validator", "moniker", "operator_address"},
+		),
+		expectedPeerMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_node_expected_peer_connected",
+				Help: "Whether a configured expected peer is currently connected to the node (1=connected, 0=disconnected)",
+			},
+			[]string{"node", "peer"},
+		),
+		peersTotalMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_node_peers_total",
+				Help: "Number of peers connected to the selected RPC node (n_peers from /net_info)",
+			},
+		),
+		outboundPeersMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_node_outbound_peers",
+				Help: "Number of outbound peers (dialed by the node) of the selected RPC node",
+			},
+		),
+		inboundPeersMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_node_inbound_peers",
+				Help: "Number of inbound peers (dialed into the node) of the selected RPC node",
+			},
+		),
+		nodeHealthStateMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_node_health_state",
+				Help: "Health of a configured node by role (2=healthy, 1=unsynced, 0=down, -1=unknown: no direct RPC access)",
+			},
+			[]string{"node", "role"},
+		),
+		nodeHeightLagMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_node_height_lag",
+				Help: "Blocks a configured node is behind the highest height seen across nodes and the exporter",
+			},
+			[]string{"node", "role"},
+		),
+		gasPriceMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_fee_observed_gas_price",
+				Help: "Gas price (fee / gas_wanted, in base denom per gas) paid by transactions over the last FEE_WINDOW_BLOCKS blocks, by quantile",
+			},
+			[]string{"quantile"},
+		),
+		observedTxsMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_fee_observed_transactions",
+				Help: "Number of transactions with a fee in the gas price observation window",
+			},
+		),
+		minGasPriceMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_fee_node_min_gas_price",
+				Help: "Minimum gas price configured on the queried node, in base denom per gas (0 if unset)",
+			},
+		),
+		evmGasPriceMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_fee_evm_gas_price",
+				Help: "Gas price suggested by the EVM JSON-RPC (eth_gasPrice), in wei",
+			},
+		),
+		evmBaseFeeMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_fee_evm_base_fee",
+				Help: "Base fee per gas of the next block reported by the EVM JSON-RPC (eth_feeHistory), in wei",
+			},
+		),
+		accumCommissionMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_accumulated_commission",
+				Help: "Accumulated (unwithdrawn) commission per validator in base units",
+			},
+			[]string{"validator"},
+		),
+		accumCommissionDisplayMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_accumulated_commission_display",
+				Help: "Accumulated (unwithdrawn) commission per validator in display units",
+			},
+			[]string{"validator"},
+		),
+		tokenPriceMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_token_price",
+				Help: "Price of one display-unit token from the configured price feed (last successful value)",
+			},
+			[]string{"quote"},
+		),
+		priceStaleMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_token_price_stale",
+				Help: "1 if the last price feed poll failed and og_galileo_token_price holds an older value",
+			},
+			[]string{"quote"},
+		),
+		priceUpdatedMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_token_price_updated_timestamp",
+				Help: "Unix time of the last successful price feed poll",
+			},
+			[]string{"quote"},
+		),
+		stakeValueMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_stake_value",
+				Help: "Value of the validator's staked tokens (display units x token price) in the quote currency",
+			},
+			[]string{"validator", "quote"},
+		),
+		commissionValueMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_accumulated_commission_value",
+				Help: "Value of the validator's accumulated commission (display units x token price) in the quote currency",
+			},
+			[]string{"validator", "quote"},
+		),
+		blocksToJailMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_blocks_to_jail",
+				Help: "Additional consecutive missed blocks that would trigger downtime jailing (0 = already over the threshold)",
+			},
+			[]string{"validator"},
+		),
+		startHeightMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_start_height",
+				Help: "Height at which the validator's signing info started (slashing signing_infos start_height)",
+			},
+			[]string{"validator"},
+		),
+		jailedUntilMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_jailed_until_timestamp",
+				Help: "Unix time until which the validator is jailed (0 if it has never been jailed)",
+			},
+			[]string{"validator"},
+		),
+		tombstonedMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_tombstoned",
+				Help: "Set to 1 if the validator is tombstoned (double-signed) and can never re-enter the active set",
+			},
+			[]string{"validator"},
+		),
+		signingMissedBlocksMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_signing_missed_blocks",
+				Help: "Missed blocks in the current signing window as counted by the chain (slashing signing_infos missed_blocks_counter)",
+			},
+			[]string{"validator"},
+		),
 		rankMetric: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_rank",
@@ -157,13 +545,31 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 			},
 			[]string{"validator"},
 		),
-		seatPriceMetric: prometheus.NewGauge(
+		seatPriceMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_seat_price",
 				Help: "Min seat price to be in the active set",
 			},
 		),
-		signedBlocksWindowMetric: prometheus.NewGauge(
+		seatPriceDisplayMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_seat_price_display",
+				Help: "Min seat price to be in the active set in display units",
+			},
+		),
+		bondedPoolMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_bonded_pool_tokens",
+				Help: "Total bonded tokens in the staking pool in base units",
+			},
+		),
+		bondedPoolDisplayMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_bonded_pool_tokens_display",
+				Help: "Total bonded tokens in the staking pool in display units",
+			},
+		),
+		signedBlocksWindowMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_signed_blocks_window",
 				Help: "Number of blocks per signing window",
@@ -172,29 +578,29 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 		missedBlocksWindowMetric: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_missed_blocks_window",
-				Help: "Number of missed blocks per validator for the current signing window",
+				Help: "Number of missed blocks per validator in the last signed_blocks_window blocks observed by the exporter",
 			},
 			[]string{"validator"},
 		),
-		minSignedBlocksPerWindowMetric: prometheus.NewGauge(
+		minSignedBlocksPerWindowMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_min_signed_blocks_per_window",
 				Help: "Minimum number of blocks required to be signed per signing window",
 			},
 		),
-		downtimeJailDurationMetric: prometheus.NewGauge(
+		downtimeJailDurationMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_downtime_jail_duration",
 				Help: "Duration of the jail period for a validator in seconds",
 			},
 		),
-		slashFractionDoubleSignMetric: prometheus.NewGauge(
+		slashFractionDoubleSignMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_slash_fraction_double_sign",
 				Help: "Slash penalty for double-signing",
 			},
 		),
-		slashFractionDowntimeMetric: prometheus.NewGauge(
+		slashFractionDowntimeMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_slash_fraction_downtime",
 				Help: "Slash penalty for downtime",
@@ -203,10 +609,17 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 		soloMissedBlocksMetric: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_solo_missed_blocks",
-				Help: "Number of missed blocks per validator, unless the block is missed by many other validators",
+				Help: "Number of missed blocks per validator in the signing window, excluding blocks signed by less than 2/3 of the validator set",
 			},
 			[]string{"validator"},
 		),
+		uptimeRatioMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_uptime_ratio",
+				Help: "Fraction of evaluated blocks signed by the validator over the last window blocks (UPTIME_WINDOWS)",
+			},
+			[]string{"validator", "window"},
+		),
 		trackedBlocksMetric: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Name: "og_galileo_validator_tracked_blocks",
@@ -216,8 +629,22 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 		skippedBlocksMetric: prometheus.NewCounter(
 			prometheus.CounterOpts{
 				Name: "og_galileo_validator_skipped_blocks",
-				Help: "Number of blocks skipped since start",
+				Help: "Number of intermediate blocks skipped since start (beyond the backfill limit or failed to fetch)",
+			},
+		),
+		signingSkippedMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_validator_signing_skipped_total",
+				Help: "Blocks whose signing evaluation was skipped because the previous block could not be fetched, by reason (height_not_available, fetch_failed)",
+			},
+			[]string{"reason"},
+		),
+		missedBlocksTotalMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_validator_missed_blocks_total",
+				Help: "Number of missed blocks per validator since tracking began (kept across restarts with STATE_FILE)",
 			},
+			[]string{"validator"},
 		),
 		transactionsMetric: prometheus.NewCounter(
 			prometheus.CounterOpts{
@@ -225,10 +652,16 @@ func NewCosmosValidatorMetrics() *CosmosValidatorMetrics {
 				Help: "Number of transactions since start",
 			},
 		),
-		upgradePlanMetric: prometheus.NewGauge(
+		upgradePlanMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_upgrade_plan",
-				Help: "Block height of the upcoming upgrade",
+				Help: "Block height of the upcoming upgrade (-1 if no upgrade is planned)",
+			},
+		),
+		blocksUntilUpgradeMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_blocks_until_upgrade",
+				Help: "Blocks left until the planned upgrade height (-1 if no upgrade is planned)",
 			},
 		),
 		proposalEndTimeMetric: prometheus.NewGaugeVec(
@@ -278,72 +711,740 @@ func NewCustomMetrics() *CustomMetrics {
 			},
 			[]string{"validator", "address"},
 		),
-		mempoolSizeMetric: prometheus.NewGauge(
+		mempoolSizeMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_mempool_size",
-				Help: "Current size of the mempool in transactions",
+				Help: "Number of unconfirmed transactions in the node's mempool (/num_unconfirmed_txs n_txs)",
 			},
 		),
-		mempoolTotalBytesMetric: prometheus.NewGauge(
+		mempoolTotalBytesMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_mempool_total_bytes",
-				Help: "Total size of transactions in the mempool in bytes",
+				Help: "Total size of unconfirmed transactions in the node's mempool in bytes",
 			},
 		),
-		mempoolTotalMetric: prometheus.NewGauge(
+		mempoolTotalMetric: newLazyGauge(
 			prometheus.GaugeOpts{
 				Name: "og_galileo_validator_mempool_total",
-				Help: "Total number of transactions in the mempool",
+				Help: "Total number of unconfirmed transactions reported by the node (/num_unconfirmed_txs total)",
+			},
+		),
+		mempoolMaxTxsMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_mempool_max_txs",
+				Help: "Configured mempool capacity in transactions (MEMPOOL_MAX_TXS, the node's config.toml [mempool] size)",
+			},
+		),
+		proposalsWindowMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_proposals_window",
+				Help: "Number of blocks proposed by the validator among the last processed blocks in the window",
+			},
+			[]string{"validator"},
+		),
+		proposalsExpectedMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_proposals_expected_window",
+				Help: "Expected number of proposed blocks in the window from the validator's voting power share",
 			},
+			[]string{"validator"},
+		),
+		proposalsRatioMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_proposals_ratio",
+				Help: "Ratio of actual to expected proposed blocks in the window (1 = on schedule)",
+			},
+			[]string{"validator"},
+		),
+		missRateEWMAMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_miss_rate_ewma",
+				Help: "Exponentially weighted moving average of the per-block miss indicator",
+			},
+			[]string{"validator"},
+		),
+		healthScoreMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_health_score",
+				Help: "Weighted validator health score from 0 to 100 (see og_galileo_validator_health_score_component)",
+			},
+			[]string{"validator"},
+		),
+		healthComponentMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_health_score_component",
+				Help: "Health score component from 0 to 1 (uptime, miss_streak, status, sync, rank, governance), absent while unknown",
+			},
+			[]string{"validator", "component"},
+		),
+		missIntervalP95Metric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_miss_interval_p95_seconds",
+				Help: "95th percentile of the time between missed blocks over the history window",
+			},
+			[]string{"validator"},
+		),
+		commitLatencyMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_network_commit_latency_seconds",
+				Help: "Quantiles of commit signature delay behind the earliest signature in the last processed commit, across all signers",
+			},
+			[]string{"quantile"},
+		),
+		commitLatencyPercentileMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_commit_latency_percentile",
+				Help: "Percentage of signers in the last processed commit that signed no later than the validator (100 = slowest)",
+			},
+			[]string{"validator"},
+		),
+		totalVotingPowerMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_chain_total_voting_power",
+				Help: "Total voting power of the validator set at the last processed commit height",
+			},
+		),
+		faultThresholdMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_chain_fault_threshold_power",
+				Help: "Voting power that can be absent while blocks still reach the >2/3 commit quorum",
+			},
+		),
+		absentVotingPowerMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_chain_absent_voting_power",
+				Help: "Voting power of validators in the set that did not sign the last processed commit",
+			},
+		),
+		quorumMarginMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_chain_quorum_margin",
+				Help: "Additional voting power that could go offline before blocks stop (fault threshold minus absent power)",
+			},
+		),
+		votingPowerMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_voting_power",
+				Help: "Voting power of the validator at the last processed commit height (0 if not in the set)",
+			},
+			[]string{"validator"},
+		),
+		faultThresholdRatioMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_validator_fault_threshold_ratio",
+				Help: "Validator voting power as a fraction of the fault threshold (1 or more: going offline alone stops blocks)",
+			},
+			[]string{"validator"},
+		),
+	}
+}
+
+func NewExporterMetrics() *ExporterMetrics {
+	return &ExporterMetrics{
+		liveSeriesMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_live_series",
+				Help: "Number of live series per metric family with unbounded label values",
+			},
+			[]string{"metric"},
+		),
+		lastProcessedHeightMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_last_processed_height",
+				Help: "Height of the last block successfully processed by the exporter",
+			},
+		),
+		lastProcessedTimestampMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_last_processed_timestamp",
+				Help: "Unix timestamp of the last successful block processing",
+			},
+		),
+		rejectedRequestsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_rejected_requests_total",
+				Help: "Number of HTTP requests rejected by rate or concurrency limits",
+			},
+			[]string{"endpoint", "reason"},
+		),
+		historyRowsMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_history_rows",
+				Help: "Number of rows held in the history store per table",
+			},
+			[]string{"table"},
+		),
+		historyPrunedMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_history_rows_pruned_total",
+				Help: "Number of history rows deleted by the retention pruner per table",
+			},
+			[]string{"table"},
+		),
+		historyPruneDurationMetric: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "og_galileo_exporter_history_prune_duration_seconds",
+				Help:    "Duration of history retention pruning runs",
+				Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+			},
+		),
+		missedCoverageMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_missed_coverage_blocks_total",
+				Help: "Number of blocks never processed because the restart gap exceeded the catch-up limit",
+			},
+		),
+		startupGapMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_startup_gap_blocks",
+				Help: "Blocks between the persisted last processed height and the tip at startup",
+			},
+		),
+		catchUpBlocksMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_catchup_blocks_total",
+				Help: "Number of blocks processed by startup catch-up",
+			},
+		),
+		startupCatchUpMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_startup_catchup",
+				Help: "Startup catch-up decision (1 for the decision taken: none, caught_up, skipped)",
+			},
+			[]string{"decision"},
+		),
+		rpcEndpointScoreMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_rpc_endpoint_score",
+				Help: "RPC endpoint score from probe latency, error rate and height lag (lower is better)",
+			},
+			[]string{"endpoint"},
+		),
+		rpcEndpointSelectedMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_rpc_endpoint_selected",
+				Help: "Whether the RPC endpoint is currently used by the exporter (1=selected)",
+			},
+			[]string{"endpoint"},
+		),
+		rpcEndpointHealthyMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_rpc_endpoint_healthy",
+				Help: "Whether the RPC endpoint passed its last probe and is within the allowed height lag",
+			},
+			[]string{"endpoint"},
+		),
+		rpcURLHealthyMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_rpc_endpoint_healthy",
+				Help: "Whether the RPC endpoint is below the consecutive fetch failure threshold (0=failing, skipped for failover)",
+			},
+			[]string{"url"},
+		),
+		rpcFailoverMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_rpc_failover_total",
+				Help: "Total number of immediate switches away from an RPC endpoint after consecutive fetch failures",
+			},
+		),
+		rpcEndpointUpMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_rpc_endpoint_up",
+				Help: "Whether the last fetch or probe against the RPC endpoint succeeded (1=up)",
+			},
+			[]string{"endpoint"},
+		),
+		sourceStaleMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_source_stale",
+				Help: "Whether the data source is stale and its series have been removed (1=stale)",
+			},
+			[]string{"source"},
+		),
+		probeUpMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_probe_up",
+				Help: "Whether the probed service answered with the expected status (1=up)",
+			},
+			[]string{"name"},
+		),
+		probeLatencyMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_probe_latency_seconds",
+				Help: "Response time of the last successful probe request",
+			},
+			[]string{"name"},
+		),
+		probeStatusCodeMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_probe_status_code",
+				Help: "HTTP status code of the last probe (0 if the request failed)",
+			},
+			[]string{"name"},
+		),
+		dataDiscrepanciesMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_data_discrepancies_total",
+				Help: "Number of tracked validator signing results that differ from the verification endpoint",
+			},
+		),
+		verificationsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_verifications_total",
+				Help: "Number of sampled block verifications by result (match, discrepancy, unavailable)",
+			},
+			[]string{"result"},
+		),
+		verificationLatencyMetric: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "og_galileo_exporter_verification_latency_seconds",
+				Help:    "Time until the verification endpoint served the sampled block, including retries",
+				Buckets: prometheus.DefBuckets,
+			},
+		),
+		cycleOverrunsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_cycle_overrun_total",
+				Help: "Number of tracking cycles that took longer than the poll interval by dominant cause (rpc, processing)",
+			},
+			[]string{"reason"},
+		),
+		cycleOverrunRatioMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_cycle_overrun_ratio",
+				Help: "Fraction of tracking cycles in the last 5 minutes that overran the poll interval",
+			},
+		),
+		tipHeightMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_tip_height",
+				Help: "Latest block height reported by the RPC node (og_galileo_validator_block_height is the last processed height)",
+			},
+		),
+		processedLagMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_processed_lag_blocks",
+				Help: "Blocks between the RPC tip and the exporter's last processed height",
+			},
+		),
+		pollIntervalMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_poll_interval_seconds",
+				Help: "Configured block polling interval (POLL_INTERVAL or the blocks entry of COLLECTOR_INTERVALS)",
+			},
+		),
+		websocketConnectedMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_websocket_connected",
+				Help: "Whether the NewBlock event subscription on the RPC WebSocket is active (0 while polling)",
+			},
+		),
+		websocketReconnectsMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_websocket_reconnects_total",
+				Help: "Reconnection attempts of the RPC WebSocket subscription",
+			},
+		),
+		scrapeSuccessMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_scrape_success",
+				Help: "Whether the last scrape of an upstream metric source succeeded, with the failure reason (ok, auth, status, connection, read)",
+			},
+			[]string{"source", "reason"},
+		),
+		rpcCompatMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_rpc_compat",
+				Help: "1 if the node's CometBFT and app versions are in the built-in compatibility table, 0 if unknown or unsupported",
+			},
+		),
+		startTimestampMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_start_timestamp",
+				Help: "Unix time at which this exporter process started",
+			},
+		),
+		restartsMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_restarts_total",
+				Help: "Number of times the exporter has been restarted, carried over in the state file",
+			},
+		),
+		lastShutdownMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_previous_shutdown_timestamp",
+				Help: "Unix time of the previous run's shutdown (last state save if it did not shut down cleanly)",
+			},
+		),
+		lastShutdownGracefulMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_previous_shutdown_graceful",
+				Help: "1 if the previous run shut down through the SIGTERM/SIGINT path, 0 after a crash or kill",
+			},
+		),
+		sinkPublishedMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_sink_published_total",
+				Help: "Block records acknowledged by the message queue sink",
+			},
+		),
+		sinkFailuresMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_sink_failures_total",
+				Help: "Failed attempts to publish a block record to the message queue sink",
+			},
+		),
+		sinkDroppedMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_sink_dropped_total",
+				Help: "Unpublished block records dropped because the sink buffer (SINK_BUFFER_MAX) was full",
+			},
+		),
+		sinkHeightMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_sink_published_height",
+				Help: "Height of the last block record acknowledged by the message queue sink",
+			},
+		),
+		sinkBacklogMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_sink_backlog",
+				Help: "Block records buffered in the history store waiting to be published",
+			},
+		),
+		collectorLastRunMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_collector_last_run_timestamp",
+				Help: "Unix time of the last run of each scheduled collector",
+			},
+			[]string{"collector"},
+		),
+		collectorLastSuccessMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_collector_last_success_timestamp",
+				Help: "Unix time of the last successful run of each scheduled collector",
+			},
+			[]string{"collector"},
+		),
+		collectorErrorMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_collector_last_error",
+				Help: "1 if the last run of the collector failed, 0 if it succeeded",
+			},
+			[]string{"collector"},
+		),
+		collectorDurationMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_collector_duration_seconds",
+				Help: "Duration of the last run of each scheduled collector",
+			},
+			[]string{"collector"},
+		),
+		collectorRunsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_collector_runs_total",
+				Help: "Runs of each scheduled collector by result (success, error)",
+			},
+			[]string{"collector", "result"},
+		),
+		heartbeatsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_heartbeats_total",
+				Help: "Heartbeat attempts to the external monitor by result (sent, failed, skipped while unhealthy)",
+			},
+			[]string{"result"},
+		),
+		heartbeatLastSentMetric: newLazyGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_heartbeat_last_sent_timestamp",
+				Help: "Unix timestamp of the last heartbeat accepted by the external monitor",
+			},
+		),
+		chainResetsMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_chain_resets_total",
+				Help: "Detected chain resets (chain id change or tip far below the last processed height) that reset tracker state",
+			},
+		),
+		rpcErrorsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_rpc_errors_total",
+				Help: "Failed RPC/REST fetches by fetch method and error kind (timeout, rate_limited, not_found, http_status, decode, connection, other)",
+			},
+			[]string{"method", "reason"},
+		),
+		httpDurationMetric: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "og_galileo_http_request_duration_seconds",
+				Help:    "Duration of each RPC/REST HTTP request attempt made by fetches, including retries",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"endpoint", "method"},
+		),
+		httpErrorsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_http_request_errors_total",
+				Help: "RPC/REST HTTP request attempts that failed with a network error or non-200 status, including retried attempts",
+			},
+			[]string{"endpoint", "method"},
+		),
+		httpRetriesMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_http_request_retries_total",
+				Help: "RPC/REST HTTP requests retried after a network error or 5xx response",
+			},
+			[]string{"endpoint", "method"},
+		),
+		rpcDurationMetric: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "og_galileo_rpc_request_duration_seconds",
+				Help:    "Duration of each fetch by endpoint type (block, validators, staking_validators, mempool, node_exporter, og_node, ...), including retries and failover",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"endpoint_type"},
+		),
+		rpcLastSuccessMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_rpc_last_success_timestamp",
+				Help: "Unix time of the last successful fetch by endpoint type",
+			},
+			[]string{"endpoint_type"},
+		),
+		archivedBlocksMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_archive_blocks_total",
+				Help: "Sampled blocks written to the archive store (ARCHIVE_EVERY)",
+			},
+		),
+		archiveFailuresMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_archive_failures_total",
+				Help: "Failed attempts to write a sampled block or manifest to the archive store",
+			},
+		),
+		archiveDroppedMetric: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_archive_dropped_total",
+				Help: "Sampled blocks dropped because the archive queue (ARCHIVE_QUEUE_MAX) was full",
+			},
+		),
+		archiveBacklogMetric: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_archive_backlog",
+				Help: "Sampled blocks waiting to be written to the archive store",
+			},
+		),
+		hookRunsMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_hook_runs_total",
+				Help: "Hook command executions by hook and result (success, failure, timeout)",
+			},
+			[]string{"hook", "result"},
+		),
+		hookDroppedMetric: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "og_galileo_exporter_hook_dropped_total",
+				Help: "Events not delivered to a hook because its queue was full",
+			},
+			[]string{"hook"},
+		),
+		goroutinesMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "og_galileo_exporter_goroutines",
+				Help: "Running goroutines started by each exporter component",
+			},
+			[]string{"component"},
 		),
 	}
 }
 
 func NewUnifiedMetrics() *UnifiedMetrics {
 	return &UnifiedMetrics{
-		cosmos: NewCosmosValidatorMetrics(),
-		custom: NewCustomMetrics(),
+		cosmos:   NewCosmosValidatorMetrics(),
+		custom:   NewCustomMetrics(),
+		exporter: NewExporterMetrics(),
 	}
 }
 
-func (um *UnifiedMetrics) Register() {
+// 트래커 레지스트리 외에 셀프 테스트용 섀도 레지스트리에도 등록할 수 있음
+// 이름이 겹치는 등 등록에 실패한 메트릭은 건너뛰고 모든 에러를 모아 반환
+// 트래커 상태를 읽는 GaugeFunc(dataStalenessMetric)는 트래커 생성 시 만들어지므로 없으면 건너뜀
+func (um *UnifiedMetrics) Register(registerer prometheus.Registerer) error {
+	var errs []error
+	register := func(collector prometheus.Collector) {
+		if collector == nil {
+			return
+		}
+		if err := registerer.Register(collector); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// cosmos-validator-watcher 메트릭 등록
-	prometheus.MustRegister(um.cosmos.blockHeightMetric)
-	prometheus.MustRegister(um.cosmos.activeSetMetric)
-	prometheus.MustRegister(um.cosmos.isBondedMetric)
-	prometheus.MustRegister(um.cosmos.isJailedMetric)
-	prometheus.MustRegister(um.cosmos.missedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.consecutiveMissedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.cometbftMissedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.tokensMetric)
-	prometheus.MustRegister(um.cosmos.rankMetric)
-	prometheus.MustRegister(um.cosmos.commissionMetric)
-	prometheus.MustRegister(um.cosmos.proposedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.validatedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.emptyBlocksMetric)
-	prometheus.MustRegister(um.cosmos.seatPriceMetric)
-	prometheus.MustRegister(um.cosmos.signedBlocksWindowMetric)
-	prometheus.MustRegister(um.cosmos.missedBlocksWindowMetric)
-	prometheus.MustRegister(um.cosmos.minSignedBlocksPerWindowMetric)
-	prometheus.MustRegister(um.cosmos.downtimeJailDurationMetric)
-	prometheus.MustRegister(um.cosmos.slashFractionDoubleSignMetric)
-	prometheus.MustRegister(um.cosmos.slashFractionDowntimeMetric)
-	prometheus.MustRegister(um.cosmos.soloMissedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.trackedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.skippedBlocksMetric)
-	prometheus.MustRegister(um.cosmos.transactionsMetric)
-	prometheus.MustRegister(um.cosmos.upgradePlanMetric)
-	prometheus.MustRegister(um.cosmos.proposalEndTimeMetric)
-	prometheus.MustRegister(um.cosmos.voteMetric)
-	prometheus.MustRegister(um.cosmos.nodeBlockHeightMetric)
-	prometheus.MustRegister(um.cosmos.nodeSyncedMetric)
+	register(um.cosmos.blockHeightMetric)
+	register(um.cosmos.activeSetMetric)
+	register(um.cosmos.isBondedMetric)
+	register(um.cosmos.isJailedMetric)
+	register(um.cosmos.missedBlocksMetric)
+	register(um.cosmos.consecutiveMissedBlocksMetric)
+	register(um.cosmos.cometbftMissedBlocksMetric)
+	register(um.cosmos.tokensMetric)
+	register(um.cosmos.rankMetric)
+	register(um.cosmos.commissionMetric)
+	register(um.cosmos.proposedBlocksMetric)
+	register(um.cosmos.validatedBlocksMetric)
+	register(um.cosmos.emptyBlocksMetric)
+	register(um.cosmos.seatPriceMetric)
+	register(um.cosmos.seatPriceDisplayMetric)
+	register(um.cosmos.tokensDisplayMetric)
+	register(um.cosmos.bondedPoolMetric)
+	register(um.cosmos.bondedPoolDisplayMetric)
+	register(um.cosmos.rewardsMetric)
+	register(um.cosmos.rewardsDisplayMetric)
+	register(um.cosmos.maxValidatorsMetric)
+	register(um.cosmos.activeSetFullnessMetric)
+	register(um.cosmos.topTokensMetric)
+	register(um.cosmos.topCommissionMetric)
+	register(um.cosmos.stakeGiniMetric)
+	register(um.cosmos.nakamotoMetric)
+	register(um.cosmos.stakeTopShareMetric)
+	register(um.cosmos.tokensChangeMetric)
+	register(um.cosmos.rankChangeMetric)
+	register(um.cosmos.changeCoverageMetric)
+	register(um.cosmos.validatorInfoMetric)
+	register(um.cosmos.pubkeyRotatedMetric)
+	register(um.cosmos.expectedPeerMetric)
+	register(um.cosmos.peersTotalMetric)
+	register(um.cosmos.outboundPeersMetric)
+	register(um.cosmos.inboundPeersMetric)
+	register(um.cosmos.nodeHealthStateMetric)
+	register(um.cosmos.nodeHeightLagMetric)
+	register(um.cosmos.gasPriceMetric)
+	register(um.cosmos.observedTxsMetric)
+	register(um.cosmos.minGasPriceMetric)
+	register(um.cosmos.evmGasPriceMetric)
+	register(um.cosmos.evmBaseFeeMetric)
+	register(um.cosmos.accumCommissionMetric)
+	register(um.cosmos.accumCommissionDisplayMetric)
+	register(um.cosmos.tokenPriceMetric)
+	register(um.cosmos.priceStaleMetric)
+	register(um.cosmos.priceUpdatedMetric)
+	register(um.cosmos.stakeValueMetric)
+	register(um.cosmos.commissionValueMetric)
+	register(um.cosmos.blocksToJailMetric)
+	register(um.cosmos.startHeightMetric)
+	register(um.cosmos.jailedUntilMetric)
+	register(um.cosmos.tombstonedMetric)
+	register(um.cosmos.signingMissedBlocksMetric)
+	register(um.cosmos.signedBlocksWindowMetric)
+	register(um.cosmos.missedBlocksWindowMetric)
+	register(um.cosmos.minSignedBlocksPerWindowMetric)
+	register(um.cosmos.downtimeJailDurationMetric)
+	register(um.cosmos.slashFractionDoubleSignMetric)
+	register(um.cosmos.slashFractionDowntimeMetric)
+	register(um.cosmos.soloMissedBlocksMetric)
+	register(um.cosmos.uptimeRatioMetric)
+	register(um.cosmos.trackedBlocksMetric)
+	register(um.cosmos.skippedBlocksMetric)
+	register(um.cosmos.signingSkippedMetric)
+	register(um.cosmos.missedBlocksTotalMetric)
+	register(um.cosmos.transactionsMetric)
+	register(um.cosmos.upgradePlanMetric)
+	register(um.cosmos.blocksUntilUpgradeMetric)
+	register(um.cosmos.proposalEndTimeMetric)
+	register(um.cosmos.voteMetric)
+	register(um.cosmos.nodeBlockHeightMetric)
+	register(um.cosmos.nodeSyncedMetric)
 
 	// 커스텀 메트릭 등록
-	prometheus.MustRegister(um.custom.beaconBlockSignedMetric)
-	prometheus.MustRegister(um.custom.validatorStatusMetric)
-	prometheus.MustRegister(um.custom.mempoolSizeMetric)
-	prometheus.MustRegister(um.custom.mempoolTotalBytesMetric)
-	prometheus.MustRegister(um.custom.mempoolTotalMetric)
+	register(um.custom.beaconBlockSignedMetric)
+	register(um.custom.validatorStatusMetric)
+	register(um.custom.mempoolSizeMetric)
+	register(um.custom.mempoolTotalBytesMetric)
+	register(um.custom.mempoolTotalMetric)
+	register(um.custom.mempoolMaxTxsMetric)
+	register(um.custom.proposalsWindowMetric)
+	register(um.custom.proposalsExpectedMetric)
+	register(um.custom.proposalsRatioMetric)
+	register(um.custom.missRateEWMAMetric)
+	register(um.custom.healthScoreMetric)
+	register(um.custom.healthComponentMetric)
+	register(um.custom.missIntervalP95Metric)
+	register(um.custom.commitLatencyMetric)
+	register(um.custom.commitLatencyPercentileMetric)
+	register(um.custom.totalVotingPowerMetric)
+	register(um.custom.faultThresholdMetric)
+	register(um.custom.absentVotingPowerMetric)
+	register(um.custom.quorumMarginMetric)
+	register(um.custom.votingPowerMetric)
+	register(um.custom.faultThresholdRatioMetric)
+
+	// exporter 자체 메트릭 등록
+	register(um.exporter.liveSeriesMetric)
+	register(um.exporter.lastProcessedHeightMetric)
+	register(um.exporter.lastProcessedTimestampMetric)
+	register(um.exporter.dataStalenessMetric)
+	register(um.exporter.rejectedRequestsMetric)
+	register(um.exporter.historyRowsMetric)
+	register(um.exporter.historyPrunedMetric)
+	register(um.exporter.historyPruneDurationMetric)
+	register(um.exporter.missedCoverageMetric)
+	register(um.exporter.startupGapMetric)
+	register(um.exporter.catchUpBlocksMetric)
+	register(um.exporter.startupCatchUpMetric)
+	register(um.exporter.rpcEndpointScoreMetric)
+	register(um.exporter.rpcEndpointSelectedMetric)
+	register(um.exporter.rpcEndpointHealthyMetric)
+	register(um.exporter.rpcURLHealthyMetric)
+	register(um.exporter.rpcFailoverMetric)
+	register(um.exporter.rpcEndpointUpMetric)
+	register(um.exporter.sourceStaleMetric)
+	register(um.exporter.probeUpMetric)
+	register(um.exporter.probeLatencyMetric)
+	register(um.exporter.probeStatusCodeMetric)
+	register(um.exporter.dataDiscrepanciesMetric)
+	register(um.exporter.verificationsMetric)
+	register(um.exporter.verificationLatencyMetric)
+	register(um.exporter.cycleOverrunsMetric)
+	register(um.exporter.cycleOverrunRatioMetric)
+	register(um.exporter.tipHeightMetric)
+	register(um.exporter.processedLagMetric)
+	register(um.exporter.pollIntervalMetric)
+	register(um.exporter.websocketConnectedMetric)
+	register(um.exporter.websocketReconnectsMetric)
+	register(um.exporter.scrapeSuccessMetric)
+	register(um.exporter.rpcCompatMetric)
+	register(um.exporter.startTimestampMetric)
+	register(um.exporter.restartsMetric)
+	register(um.exporter.lastShutdownMetric)
+	register(um.exporter.lastShutdownGracefulMetric)
+	register(um.exporter.sinkPublishedMetric)
+	register(um.exporter.sinkFailuresMetric)
+	register(um.exporter.sinkDroppedMetric)
+	register(um.exporter.sinkHeightMetric)
+	register(um.exporter.sinkBacklogMetric)
+	register(um.exporter.collectorLastRunMetric)
+	register(um.exporter.collectorLastSuccessMetric)
+	register(um.exporter.collectorErrorMetric)
+	register(um.exporter.collectorDurationMetric)
+	register(um.exporter.collectorRunsMetric)
+	register(um.exporter.heartbeatsMetric)
+	register(um.exporter.heartbeatLastSentMetric)
+	register(um.exporter.chainResetsMetric)
+	register(um.exporter.rpcErrorsMetric)
+	register(um.exporter.httpDurationMetric)
+	register(um.exporter.httpErrorsMetric)
+	register(um.exporter.httpRetriesMetric)
+	register(um.exporter.rpcDurationMetric)
+	register(um.exporter.rpcLastSuccessMetric)
+	register(um.exporter.archivedBlocksMetric)
+	register(um.exporter.archiveFailuresMetric)
+	register(um.exporter.archiveDroppedMetric)
+	register(um.exporter.archiveBacklogMetric)
+	register(um.exporter.hookRunsMetric)
+	register(um.exporter.hookDroppedMetric)
+	register(um.exporter.goroutinesMetric)
+	return errors.Join(errs...)
 }
 
 // API 응답 구조체들
@@ -351,25 +1452,43 @@ type BlockInfo struct {
 	Result struct {
 		Block struct {
 			Header struct {
-				Height string `json:"height"`
+				Height             numericString `json:"height"`
+				Time               string        `json:"time"`
+				ChainID            string        `json:"chain_id"`
+				ProposerAddress    string        `json:"proposer_address"`
+				ValidatorsHash     string        `json:"validators_hash"`
+				NextValidatorsHash string        `json:"next_validators_hash"`
 			} `json:"header"`
+			Data struct {
+				Txs []string `json:"txs"`
+			} `json:"data"`
 			LastCommit struct {
+				Height     numericString `json:"height"`
 				Signatures []struct {
 					ValidatorAddress string `json:"validator_address"`
+					Timestamp        string `json:"timestamp"`
 					Signature        string `json:"signature"`
 				} `json:"signatures"`
 			} `json:"last_commit"`
 		} `json:"block"`
 	} `json:"result"`
+	Error *RPCError `json:"error"` // 정리된 높이 등을 요청하면 result 대신 옴 (validate에서 에러로 반환)
+
+	raw []byte // 노드 응답 원문 (블록 보관이 켜져 있을 때만 유지)
 }
 
 type ValidatorInfo struct {
-	Validators []struct {
-		Address string `json:"address"`
-		PubKey  struct {
-			Value string `json:"value"`
-		} `json:"pub_key"`
-	} `json:"validators"`
+	Result struct {
+		Validators []struct {
+			Address string `json:"address"`
+			PubKey  struct {
+				Value string `json:"value"`
+			} `json:"pub_key"`
+			VotingPower numericString `json:"voting_power"`
+		} `json:"validators"`
+		Total numericString `json:"total"`
+	} `json:"result"`
+	Error *RPCError `json:"error"` // 정리된 높이는 result 대신 옴 (validate에서 에러로 반환)
 }
 
 type ValidatorResponse struct {
@@ -380,7 +1499,7 @@ type ValidatorResponse struct {
 		} `json:"consensus_pubkey"`
 		Jailed      bool    `json:"jailed"`
 		Status      string  `json:"status"`
-		Tokens      string  `json:"tokens"`
+		Tokens      numericString  `json:"tokens"`
 		DelegatorShares string `json:"delegator_shares"`
 		Description struct {
 			Moniker string `json:"moniker"`
@@ -392,188 +1511,596 @@ type ValidatorResponse struct {
 		} `json:"commission"`
 		MinSelfDelegation string `json:"min_self_delegation"`
 	} `json:"validators"`
+	Pagination struct {
+		NextKey string `json:"next_key"`
+	} `json:"pagination"`
+}
+
+// 스테이킹 풀 (본딩/언본딩 토큰 총량)
+type StakingPoolResponse struct {
+	Pool struct {
+		BondedTokens    string `json:"bonded_tokens"`
+		NotBondedTokens string `json:"not_bonded_tokens"`
+	} `json:"pool"`
+}
+
+type StakingParamsResponse struct {
+	Params struct {
+		MaxValidators int    `json:"max_validators"`
+		BondDenom     string `json:"bond_denom"`
+	} `json:"params"`
+}
+
+// 벨리데이터 미수령 보상 (DecCoin 소수 금액)
+type OutstandingRewardsResponse struct {
+	Rewards struct {
+		Rewards []DecCoin `json:"rewards"`
+	} `json:"rewards"`
+}
+
+type ValidatorCommissionResponse struct {
+	Commission struct {
+		Commission []DecCoin `json:"commission"`
+	} `json:"commission"`
 }
 
-// MempoolResponse represents the response from the mempool endpoint
+// MempoolResponse represents the response from the CometBFT /num_unconfirmed_txs endpoint
 type MempoolResponse struct {
 	Result struct {
-		NTxs       string `json:"n_txs"`
-		Total      string `json:"total"`
-		TotalBytes string `json:"total_bytes"`
+		NTxs       numericString `json:"n_txs"`
+		Total      numericString `json:"total"`
+		TotalBytes numericString `json:"total_bytes"`
+	} `json:"result"`
+}
+
+// StatusResponse represents the response from the CometBFT /status endpoint
+type StatusResponse struct {
+	Result struct {
+		NodeInfo struct {
+			Network string `json:"network"`
+			Version string `json:"version"`
+			Moniker string `json:"moniker"`
+		} `json:"node_info"`
+		SyncInfo struct {
+			LatestBlockHeight numericString `json:"latest_block_height"`
+			LatestBlockTime   string        `json:"latest_block_time"`
+			CatchingUp        bool          `json:"catching_up"`
+		} `json:"sync_info"`
 	} `json:"result"`
 }
 
 type UnifiedValidatorTracker struct {
-	rpcEndpoint     string
-	validators      map[string]string // address -> label
+	endpoints       *EndpointPool     // RPC 엔드포인트 (여러 개면 점수 기반 선택)
+	restEndpoint    string            // REST(LCD) 엔드포인트 (REST_ENDPOINT, 비어 있으면 선택된 RPC 엔드포인트)
+	validators      map[string]string // address -> label (생성 후 변경하지 않음, 핸들러는 Validators() 사용)
 	metrics         *UnifiedMetrics
+	registry        *prometheus.Registry // /metrics로 노출하는 레지스트리 (트래커마다 별도라 여러 개를 만들어도 충돌하지 않음)
 	lastBlockHeight int64
-	processedBlocks map[int64]bool
+	processed       *ProcessedHeights // 최근 적용한 블록 높이 (같은 높이 중복 적용 방지)
+
+	// 아래 필드는 janitor 고루틴, HTTP 핸들러와 공유되므로 mu로 보호 (읽기만 하는 접근자는 RLock)
+	mu              sync.RWMutex
+	signedHeights   map[int64]bool             // beacon_block_signed 시리즈가 노출된 높이
+	activeProposals map[string]bool            // 현재 투표 기간인 제안 ID
+	proposalSeries  map[string]bool            // proposal_id 라벨로 노출된 제안 ID
+	labelRetention  int64                      // block_height 라벨을 유지할 블록 수
+	lastProcessedAt time.Time                  // 마지막으로 블록 처리를 완료한 시각
+	proposalVotes   map[string]map[string]bool // proposal id -> validator -> 투표 여부
+	proposalPeriods map[string]ProposalPeriod  // proposal id -> 투표 기간 (기간별 리포트용)
+
+	readyOnce    sync.Once   // 첫 블록 조회 성공 시 systemd READY 통지
+	shuttingDown atomic.Bool // 종료 중에는 준비되지 않은 것으로 응답
+
+	readyMaxStaleness time.Duration // 준비 상태 판단 기준
+
+	history *HistoryStore // 서명 기록 및 상태 샘플
+	events  *EventBus     // SSE, 알림 등이 구독하는 이벤트 버스
+
+	// 상태 변화 이벤트 감지용 (prevJailed, prevBonded, chainID는 mu로 보호)
+	prevJailed       map[string]bool
+	prevBonded       map[string]bool
+	consensusPubkeys map[string]string // validator -> 마지막으로 본 합의 공개키 (상태 파일에 저장)
+	chainID          string
+	endpointStatus   map[string]*EndpointStatus // fetch 종류별 마지막 성공/실패
+	missStreak       map[string]int             // validator -> 연속 누락 블록 수
+	signedTotal      map[string]int             // validator -> 추적 시작 이후 서명 블록 수 (상태 파일에 저장)
+	missedTotal      map[string]int             // validator -> 추적 시작 이후 누락 블록 수 (상태 파일에 저장)
+	configSummary    map[string]string          // 진단 덤프용 설정 요약 (비밀 값 가림)
+	nodeSynced       bool
+	lastSeenTip      int64
+	lastHeightChange time.Time
+	chainHalted      bool
+	chainHaltWindow  time.Duration
+	proposers        *ProposerWindow    // 최근 블록의 제안자 (mu로 보호)
+	votingShare      map[string]float64 // validator -> 투표력 비율 (mu로 보호)
+	denom            DenomMetadata      // 토큰 단위 환산
+	sourceStaleAfter time.Duration      // 소스 데이터 신선도 기준
+	staleSources     map[string]bool    // 시리즈를 삭제한 소스 (mu로 보호)
+	probes           []ProbeConfig      // 주변 서비스 HTTP 프로브
+	peerChecks       []PeerCheckConfig  // 노드별 기대 피어 연결 확인
+	nodes            []NodeConfig       // 역할별 노드 상태 확인 (NODES)
+	metricSources    []*MetricSource    // /all-metrics에 합치는 업스트림 메트릭 소스
+	notifiers        []Notifier         // 설정된 알림 채널
+	verifier         *BlockVerifier     // 보조 엔드포인트 교차 검증 (nil이면 비활성화)
+	jsonRPC          *JSONRPCClient     // JSON-RPC 배치 요청 (nil이면 URI 방식만 사용)
+	cycle            *cycleCache        // 현재 추적 주기에 미리 가져온 응답
+	validatorSets    *ValidatorSetCache // 높이별 벨리데이터 셋 캐시
+	maxValidators    int                // 스테이킹 파라미터 max_validators (0이면 아직 모름, mu로 보호)
+	bondedCount      int                // 마지막으로 관측한 본딩 벨리데이터 수 (mu로 보호)
+	missRate         *MissRateEWMA      // 블록별 누락 지표의 지수 가중 이동 평균
+	cycles           *CycleMonitor      // 추적 주기 지연(overrun) 감시
+	lag              *LagMonitor        // RPC 팁과 처리 높이 차이 감시
+	cycleRPCTime     atomic.Int64       // 현재 주기에서 RPC/REST 요청에 쓴 시간 (ns)
+	upgradeHeight    atomic.Int64       // 예약된 업그레이드 높이 (-1이면 예약 없음, 0이면 아직 조회하지 않음)
+	dryRun           bool               // 알림 전송과 파일 쓰기 없이 동작
+	nodeVersions     NodeVersions       // 시작 시 확인한 노드 버전과 호환성 (mu로 보호)
+	rpcCompatMode    string             // 지원하지 않는 노드 버전일 때 동작 (degraded, refuse)
+	stop             func(error)        // 계속 실행할 수 없을 때 루트 컨텍스트 취소 (main에서 설정)
+	networkTopN      int                // 네트워크 개요에 노출하는 상위 벨리데이터 수
+	changes          *ChangeWindow      // 24시간 토큰/순위 변화량 샘플
+	blockQueue       chan blockSummary  // fetcher → applier 블록 큐 (StartApplier에서 생성)
+	lastQueued       int64              // 마지막으로 큐에 넣은 높이 (fetcher 고루틴에서만 사용)
+	latestFailing    bool               // 직전 주기의 최신 블록 조회 실패 여부 (fetcher 고루틴에서만 사용)
+	tenants          *TenantRegistry    // 팀 토큰별로 볼 수 있는 벨리데이터 (비어 있으면 전체 공개)
+	lifecycle        ExporterLifecycle  // 이번 실행의 시작 시각과 누적 재시작 수 (mu로 보호)
+	previousShutdown PreviousShutdown   // 상태 파일로 확인한 이전 실행의 종료 방식 (mu로 보호)
+	gasPrices        *GasPriceWindow    // 최근 블록의 트랜잭션 가스 가격 (nil이면 block_results 조회 안 함)
+	sink             Sink               // 블록 기록을 내보내는 메시지 큐 (nil이면 비활성화)
+	sinkWake         chan struct{}      // outbox에 블록이 추가되면 sink를 깨움
+	sinkPublished    int64              // 브로커가 확인한 마지막 높이 (상태 파일에 저장, mu로 보호)
+	archiver         *BlockArchiver     // 블록 표본 보관 (nil이면 비활성화)
+	priceFeeds       []PriceFeedConfig  // 토큰 가격 소스 (비어 있으면 환산 가치 메트릭 없음)
+	valuation        *TokenValuation    // 가격과 벨리데이터별 보유량
+	scheduler        *Scheduler         // 수집기별 간격 실행
+	jailCountdown    *JailCountdown     // 슬래싱 파라미터와 누락 카운터로 계산하는 제일까지 남은 블록
+	missedWindow     *MissedBlockWindow // 서명 윈도우 안의 벨리데이터별 누락 수 (상태 파일에 저장)
+	uptime           *UptimeTracker     // UPTIME_WINDOWS 윈도우별 서명 비율 (상태 파일에 저장)
+	catalog          *MetricCatalog     // 등록된 메트릭 정의 (/api/metrics/catalog)
+	stateFile        string             // 상태 파일 경로 (체인 리셋 시 이전 체인 ID를 붙여 보관)
+	resetHeightDrop  int64              // 팁이 이만큼 넘게 낮아지면 체인 리셋으로 판단 (0이면 비활성화)
+	backfillLimit    int64              // 한 주기에 처리하는 최대 중간 블록 수 (0이면 중간 블록을 건너뜀)
+	blockEvents      *WebSocketTracker  // NewBlock 이벤트 구독 (--poll-mode면 nil)
+
+	// address -> 알려진 주소 형식과 모니커 (mu로 보호)
+	identities map[string]*ValidatorIdentity
+
+	goroutines *GoroutineTracker // 구성 요소별 고루틴 수
+	http       *HTTPClient       // fetch*가 공유하는 타임아웃/재시도 HTTP 클라이언트
+
+	// VALIDATORS_FILE에 적은 운영자 주소, 모니커 -> 라벨 (시작 시에만 설정)
+	operatorLabels map[string]string
+	monikerLabels  map[string]string
+	monikers       map[string]string // 라벨 -> 설정한 모니커 (moniker 라벨 값)
+
+	// 상태 파일 복원 시 옮길 이전 라벨 (LABEL_RENAMES, 시작 시에만 설정)
+	labelRenames []LabelRename
+
+	// 노드 이름 -> 마지막 상태 (mu로 보호)
+	nodeHealth map[string]NodeStatusEntry
+
+	// 건강 점수 요소 -> 가중치 (HEALTH_SCORE_WEIGHTS, 시작 시에만 설정)
+	healthWeights map[string]float64
 }
 
-func NewUnifiedValidatorTracker(rpcEndpoint string, validators map[string]string) *UnifiedValidatorTracker {
-	return &UnifiedValidatorTracker{
-		rpcEndpoint:     rpcEndpoint,
-		validators:      validators,
-		metrics:         NewUnifiedMetrics(),
-		processedBlocks: make(map[int64]bool),
+// rpcEndpoints는 설정 순서대로 장애 시 시도할 RPC 엔드포인트 (첫 번째부터 사용)
+func NewUnifiedValidatorTracker(rpcEndpoints []string, validators map[string]string) *UnifiedValidatorTracker {
+	vt := &UnifiedValidatorTracker{
+		endpoints:         NewEndpointPool(rpcEndpoints, "", defaultEndpointMaxLag),
+		validators:        validators,
+		metrics:           NewUnifiedMetrics(),
+		registry:          prometheus.NewRegistry(),
+		processed:         NewProcessedHeights(processedWindow),
+		signedHeights:     make(map[int64]bool),
+		activeProposals:   make(map[string]bool),
+		proposalSeries:    make(map[string]bool),
+		labelRetention:    defaultLabelRetention,
+		proposalVotes:     make(map[string]map[string]bool),
+		proposalPeriods:   make(map[string]ProposalPeriod),
+		history:           NewHistoryStore(HistoryRetention{MaxAge: defaultHistoryRetention}),
+		events:            NewEventBus(defaultMaxSubscribers),
+		prevJailed:        make(map[string]bool),
+		consensusPubkeys:  make(map[string]string),
+		prevBonded:        make(map[string]bool),
+		endpointStatus:    make(map[string]*EndpointStatus),
+		missStreak:        make(map[string]int),
+		signedTotal:       make(map[string]int),
+		missedTotal:       make(map[string]int),
+		nodeSynced:        true,
+		chainHaltWindow:   defaultChainHaltWindow,
+		readyMaxStaleness: defaultReadyMaxStaleness,
+		proposers:         NewProposerWindow(defaultProposerWindow),
+		votingShare:       make(map[string]float64),
+		denom:             galileoDenom,
+		sourceStaleAfter:  defaultSourceStaleAfter,
+		staleSources:      make(map[string]bool),
+		nodeHealth:        make(map[string]NodeStatusEntry),
+		validatorSets:     NewValidatorSetCache(),
+		missRate:          NewMissRateEWMA(alphaFromHalfLife(defaultMissRateHalfLife)),
+		healthWeights:     defaultHealthWeights,
+		cycles:            NewCycleMonitor(defaultPollInterval, defaultCycleOverrunAlertRatio),
+		lag:               NewLagMonitor(defaultProcessedLagAlertBlocks, defaultProcessedLagAlertAfter),
+		networkTopN:       defaultNetworkTopN,
+		changes:           NewChangeWindow(),
+		identities:        make(map[string]*ValidatorIdentity),
+		tenants:           &TenantRegistry{},
+		sinkWake:          make(chan struct{}, 1),
+		valuation:         NewTokenValuation(),
+		jailCountdown:     NewJailCountdown(),
+		missedWindow:      NewMissedBlockWindow(defaultSignedBlocksWindow),
+		uptime:            NewUptimeTracker([]int64{defaultUptimeWindow}),
+		catalog:           NewMetricCatalog(),
+		resetHeightDrop:   defaultChainResetHeightDrop,
+		backfillLimit:     defaultBackfillMaxBlocks,
 	}
+
+	vt.goroutines = NewGoroutineTracker(vt.metrics.exporter.goroutinesMetric)
+	vt.http = NewHTTPClient(defaultHTTPTimeout, defaultHTTPMaxAttempts, vt.metrics.exporter.httpDurationMetric,
+		vt.metrics.exporter.httpErrorsMetric, vt.metrics.exporter.httpRetriesMetric)
+	vt.events.AddSink(vt.logEvent)
+
+	// 데이터 신선도는 스크레이프 시점 기준으로 계산
+	vt.metrics.exporter.dataStalenessMetric = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Name: "og_galileo_exporter_data_staleness_seconds",
+			Help: "Seconds since the exporter last successfully processed a block (-1 before the first block)",
+		},
+		func() float64 {
+			staleness, ok := vt.dataStaleness()
+			if !ok {
+				return -1
+			}
+			return staleness.Seconds()
+		},
+	)
+	return vt
 }
 
-func (vt *UnifiedValidatorTracker) RegisterMetrics() {
-	vt.metrics.Register()
+// 트래커 메트릭과 Go 런타임, 프로세스 메트릭을 트래커 레지스트리에 등록 (기본 레지스트리와 같은 출력)
+func (vt *UnifiedValidatorTracker) RegisterMetrics() error {
+	return errors.Join(
+		vt.registry.Register(collectors.NewGoCollector()),
+		vt.registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})),
+		vt.metrics.Register(vt.catalog.Wrap(vt.registry)),
+	)
 }
 
-func (vt *UnifiedValidatorTracker) fetchBlock(height int64) (*BlockInfo, error) {
+func (vt *UnifiedValidatorTracker) fetchBlock(ctx context.Context, height int64) (result *BlockInfo, err error) {
+	defer func(start time.Time) { vt.recordFetch("block", time.Since(start), err) }(time.Now())
+
+	if vt.cycle != nil && vt.cycle.blocks[height] != nil {
+		return vt.cycle.blocks[height], nil
+	}
+
+	err = vt.endpoints.Try(func(endpoint string) error {
+		result, err = vt.fetchBlockFrom(ctx, endpoint, height)
+		return err
+	})
+	return result, err
+}
+
+func (vt *UnifiedValidatorTracker) fetchBlockFrom(ctx context.Context, endpoint string, height int64) (*BlockInfo, error) {
 	var url string
 	if height == 0 {
 		// 최신 블록을 가져오기 위해 /block 엔드포인트 사용 (height 파라미터 없이)
-		url = fmt.Sprintf("%s/block", vt.rpcEndpoint)
+		url = fmt.Sprintf("%s/block", endpoint)
 	} else {
-		url = fmt.Sprintf("%s/block?height=%d", vt.rpcEndpoint, height)
+		url = fmt.Sprintf("%s/block?height=%d", endpoint, height)
 	}
-	
-	log.Printf("Fetching block from: %s", url)
-	resp, err := http.Get(url)
+
+	rpcLog.Debug("Fetching block", "url", sanitizeURL(url))
+	resp, err := vt.http.Get(ctx, "block", endpoint, url)
 	if err != nil {
-		return nil, err
+		return nil, newFetchError("block", endpoint, transportFailure(err))
 	}
 	defer resp.Body.Close()
 
 	// 응답 본문을 읽어서 로그에 출력
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		return nil, newFetchError("block", endpoint, transportFailure(err))
+	}
+
+	rpcLog.Debug("RPC response", "url", sanitizeURL(url), "body", string(body))
+	if resp.StatusCode != http.StatusOK {
+		return nil, newFetchError("block", endpoint, httpStatusError(resp, body))
+	}
+
+	var blockInfo BlockInfo
+	err = json.Unmarshal(body, &blockInfo)
+	if err == nil {
+		err = blockInfo.validate()
+	}
+	if err != nil {
+		err := newFetchError("block", endpoint, responseFailure(err))
+		if errors.Is(err, ErrHeightNotAvailable) {
+			rpcLog.Warn("Block height not available on node", "url", sanitizeURL(url), "error", err)
+		} else {
+			rpcLog.Error("JSON parsing error", "url", sanitizeURL(url), "error", err)
+		}
 		return nil, err
 	}
-	
-	log.Printf("RPC Response: %s", string(body))
+	if vt.archiver != nil {
+		blockInfo.raw = body
+	}
+
+	return &blockInfo, nil
+}
+
+// height가 0이면 최신 벨리데이터 셋 조회
+func (vt *UnifiedValidatorTracker) fetchValidators(ctx context.Context, height int64) (result *ValidatorInfo, err error) {
+	defer func(start time.Time) { vt.recordFetch("validators", time.Since(start), err) }(time.Now())
+
+	// 투표력 비율 계산을 위해 전체 벨리데이터 셋을 페이지 단위로 조회 (모든 페이지를 같은 엔드포인트에서)
+	var validatorInfo ValidatorInfo
+	err = vt.endpoints.Try(func(endpoint string) error {
+		validatorInfo = ValidatorInfo{}
+		for page := 1; ; page++ {
+			url := fmt.Sprintf("%s/validators?page=%d&per_page=%d", endpoint, page, validatorsPerPage)
+			if height > 0 {
+				url += fmt.Sprintf("&height=%d", height)
+			}
+			// 프루닝된 높이 등은 에러 상태 코드로 응답
+			var pageInfo ValidatorInfo
+			if err := vt.getJSON(ctx, "validators", endpoint, url, &pageInfo); err != nil {
+				return fmt.Errorf("validators at height %d: %w", height, err)
+			}
 
-	var blockInfo BlockInfo
-	if err := json.Unmarshal(body, &blockInfo); err != nil {
-		log.Printf("JSON parsing error: %v", err)
+			validatorInfo.Result.Validators = append(validatorInfo.Result.Validators, pageInfo.Result.Validators...)
+			validatorInfo.Result.Total = pageInfo.Result.Total
+			total, _ := strconv.Atoi(string(pageInfo.Result.Total))
+			if len(pageInfo.Result.Validators) == 0 || len(validatorInfo.Result.Validators) >= total {
+				return nil
+			}
+		}
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &blockInfo, nil
+	return &validatorInfo, nil
 }
 
-func (vt *UnifiedValidatorTracker) fetchValidators() (*ValidatorInfo, error) {
-	url := fmt.Sprintf("%s/validators", vt.rpcEndpoint)
-	resp, err := http.Get(url)
+func (vt *UnifiedValidatorTracker) fetchStakingValidators(ctx context.Context) (result *ValidatorResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("staking_validators", time.Since(start), err) }(time.Now())
+
+	// 순위와 분산도 계산을 위해 전체 목록을 next_key 기준으로 페이지 단위 조회
+	var validatorResponse ValidatorResponse
+	err = vt.tryREST(func(endpoint string) error {
+		validatorResponse = ValidatorResponse{}
+		nextKey := ""
+		for {
+			url := fmt.Sprintf("%s/cosmos/staking/v1beta1/validators?pagination.limit=%d", endpoint, stakingValidatorsPerPage)
+			if nextKey != "" {
+				url += "&pagination.key=" + neturl.QueryEscape(nextKey)
+			}
+			var page ValidatorResponse
+			if err := vt.getJSON(ctx, "staking_validators", endpoint, url, &page); err != nil {
+				return err
+			}
+
+			validatorResponse.Validators = append(validatorResponse.Validators, page.Validators...)
+			nextKey = page.Pagination.NextKey
+			if nextKey == "" || len(page.Validators) == 0 {
+				return nil
+			}
+		}
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var validatorInfo ValidatorInfo
-	if err := json.NewDecoder(resp.Body).Decode(&validatorInfo); err != nil {
+	return &validatorResponse, nil
+}
+
+func (vt *UnifiedValidatorTracker) fetchStakingPool(ctx context.Context) (result *StakingPoolResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("staking_pool", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/staking/v1beta1/pool", endpoint)
+	var poolResponse StakingPoolResponse
+	if err := vt.getJSON(ctx, "staking_pool", endpoint, url, &poolResponse); err != nil {
 		return nil, err
 	}
 
-	return &validatorInfo, nil
+	return &poolResponse, nil
 }
 
-func (vt *UnifiedValidatorTracker) fetchStakingValidators() (*ValidatorResponse, error) {
-	url := fmt.Sprintf("%s/cosmos/staking/v1beta1/validators", vt.rpcEndpoint)
-	resp, err := http.Get(url)
-	if err != nil {
+func (vt *UnifiedValidatorTracker) fetchOutstandingRewards(ctx context.Context, operatorAddress string) (result *OutstandingRewardsResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("outstanding_rewards", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/distribution/v1beta1/validators/%s/outstanding_rewards", endpoint, operatorAddress)
+	var rewardsResponse OutstandingRewardsResponse
+	if err := vt.getJSON(ctx, "outstanding_rewards", endpoint, url, &rewardsResponse); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var validatorResponse ValidatorResponse
-	if err := json.NewDecoder(resp.Body).Decode(&validatorResponse); err != nil {
+	return &rewardsResponse, nil
+}
+
+func (vt *UnifiedValidatorTracker) fetchValidatorCommission(ctx context.Context, operatorAddress string) (result *ValidatorCommissionResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("validator_commission", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/distribution/v1beta1/validators/%s/commission", endpoint, operatorAddress)
+	var commissionResponse ValidatorCommissionResponse
+	if err := vt.getJSON(ctx, "validator_commission", endpoint, url, &commissionResponse); err != nil {
 		return nil, err
 	}
 
-	return &validatorResponse, nil
+	return &commissionResponse, nil
 }
 
-func (vt *UnifiedValidatorTracker) fetchMempool() (*MempoolResponse, error) {
-	url := fmt.Sprintf("%s/mempool", vt.rpcEndpoint)
-	resp, err := http.Get(url)
-	if err != nil {
+func (vt *UnifiedValidatorTracker) fetchStakingParams(ctx context.Context) (result *StakingParamsResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("staking_params", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/staking/v1beta1/params", endpoint)
+	var paramsResponse StakingParamsResponse
+	if err := vt.getJSON(ctx, "staking_params", endpoint, url, &paramsResponse); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
+	return &paramsResponse, nil
+}
+
+func (vt *UnifiedValidatorTracker) fetchMempool(ctx context.Context) (result *MempoolResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("mempool", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.endpoints.Selected()
+	url := fmt.Sprintf("%s/num_unconfirmed_txs", endpoint)
 	var mempoolResponse MempoolResponse
-	if err := json.NewDecoder(resp.Body).Decode(&mempoolResponse); err != nil {
+	if err := vt.getJSON(ctx, "mempool", endpoint, url, &mempoolResponse); err != nil {
 		return nil, err
 	}
 
 	return &mempoolResponse, nil
 }
 
+func (vt *UnifiedValidatorTracker) fetchStatus(ctx context.Context) (result *StatusResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("status", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.endpoints.Selected()
+	url := fmt.Sprintf("%s/status", endpoint)
+	var statusResponse StatusResponse
+	if err := vt.getJSON(ctx, "status", endpoint, url, &statusResponse); err != nil {
+		return nil, err
+	}
+
+	return &statusResponse, nil
+}
+
 // 비콘 체인용: -1 블록 이전을 조회하여 서명/누락 판단
-func (vt *UnifiedValidatorTracker) updateBeaconBlockMetrics(currentBlockInfo *BlockInfo) {
-	log.Printf("=== updateBeaconBlockMetrics called ===")
-	log.Printf("Current block height: %s", currentBlockInfo.Result.Block.Header.Height)
-	currentHeight, _ := strconv.ParseInt(currentBlockInfo.Result.Block.Header.Height, 10, 64)
+// 직전 블록은 fetcher 단계에서 함께 조회해 전달 (조회 실패 시 nil이면 서명 판단 생략)
+// 평가한 서명 기록의 복사본 반환 (sink 메시지용)
+func (vt *UnifiedValidatorTracker) updateBeaconBlockMetrics(ctx context.Context, currentBlockInfo, previousBlockInfo *BlockInfo) []SigningRecord {
+	currentHeight, _ := strconv.ParseInt(string(currentBlockInfo.Result.Block.Header.Height), 10, 64)
 	previousHeight := currentHeight - 1
-	log.Printf("Previous height: %d", previousHeight)
+	trackerLog.Debug("Updating beacon block metrics", "height", currentHeight, "previous_height", previousHeight)
+	if previousBlockInfo == nil {
+		trackerLog.Warn("Previous block unavailable, skipping signing evaluation", "height", currentHeight)
+		return nil
+	}
 
-	// 이전 블록 정보 조회
-	previousBlockInfo, err := vt.fetchBlock(previousHeight)
+	// 서명 대상 높이의 벨리데이터 셋 기준으로 판단 (셋에 없던 벨리데이터는 미서명으로 보지 않음)
+	vt.recordValidatorsHashes(currentBlockInfo)
+	vt.recordValidatorsHashes(previousBlockInfo)
+	commitHeight, err := strconv.ParseInt(string(previousBlockInfo.Result.Block.LastCommit.Height), 10, 64)
 	if err != nil {
-		log.Printf("Error fetching previous block %d: %v", previousHeight, err)
-		return
+		commitHeight = previousHeight - 1
+	}
+	scan := acquireSignatureScan()
+	defer scan.release()
+	validatorSet, err := vt.validatorSetAt(ctx, commitHeight)
+	if err != nil {
+		rpcLog.Warn("Error fetching validator set, evaluating against all tracked validators", "height", commitHeight, "error", err)
+	} else {
+		scan.loadValidatorSet(validatorSet)
 	}
 
-	// 이전 블록의 서명 정보로 현재 블록의 서명 상태 판단
-	signedValidators := make(map[string]bool)
-	for _, sig := range previousBlockInfo.Result.Block.LastCommit.Signatures {
-		if sig.Signature != "" {
-			signedValidators[sig.ValidatorAddress] = true
-		}
+	// 이전 블록의 서명 정보로 현재 블록의 서명 상태 판단 (블록당 한 번만 집합 구성)
+	scan.loadSignatures(previousBlockInfo)
+	vt.updateCommitLatencyMetrics(scan)
+	if validatorSet != nil {
+		vt.updateQuorumMetrics(validatorSet, scan)
 	}
 
 	// 디버깅을 위한 로그 추가
-	log.Printf("Previous block %d signatures: %v", previousHeight, signedValidators)
-	log.Printf("Tracking validators: %v", vt.validators)
+	trackerLog.Debug("Previous block signatures", "height", previousHeight, "signed", len(scan.signed), "tracking", len(vt.validators))
+
+	// 히스토리 기록 시각은 블록 헤더 시각 기준 (파싱 실패 시 현재 시각)
+	blockTime, err := time.Parse(time.RFC3339Nano, currentBlockInfo.Result.Block.Header.Time)
+	if err != nil {
+		blockTime = time.Now()
+	}
+	heightLabel := string(currentBlockInfo.Result.Block.Header.Height)
 
 	// 현재 블록 높이에 대해 이전 블록의 서명 정보로 메트릭 업데이트
 	for address, label := range vt.validators {
-		signed := 0.0
-		if signedValidators[address] {
-			signed = 1.0
+		normalized := normalizeAddress(address)
+		if !scan.expected(normalized) {
+			trackerLog.Debug("Validator not in set at commit height, skipping", "validator", label, "height", commitHeight)
+			continue
 		}
-		
-		log.Printf("Validator %s (%s): signed=%v", address, label, signed)
-		
+		signed := scan.signed[normalized]
+		trackerLog.Debug("Validator signing status", "validator", label, "address", address, "signed", signed)
+
 		// 비콘 체인 메트릭 업데이트
-		vt.metrics.custom.beaconBlockSignedMetric.WithLabelValues(label, currentBlockInfo.Result.Block.Header.Height).Set(signed)
-		
-		// CometBFT consensus missed blocks metric 업데이트
-		// 서명하지 않았으면 missed blocks로 카운트
-		missedBlocks := 0.0
-		if !signedValidators[address] {
-			missedBlocks = 1.0
+		// CometBFT consensus missed blocks metric 업데이트: 서명하지 않았으면 missed blocks로 카운트
+		vt.metrics.custom.beaconBlockSignedMetric.WithLabelValues(label, heightLabel).Set(boolToFloat(signed))
+		vt.metrics.cosmos.cometbftMissedBlocksMetric.WithLabelValues(label, "0g-galileo").Set(boolToFloat(!signed))
+
+		scan.records = append(scan.records, SigningRecord{Height: currentHeight, Time: blockTime, Validator: label, Signed: signed})
+	}
+	records := append([]SigningRecord(nil), scan.records...)
+	vt.history.AddSigning(records...)
+	vt.maybeVerifySigning(ctx, previousHeight, scan)
+
+	// 이미 기록된 높이(중복 호출)에는 이벤트를 다시 발행하지 않음
+	vt.mu.Lock()
+	alreadyRecorded := vt.signedHeights[currentHeight]
+	vt.mu.Unlock()
+	if !alreadyRecorded {
+		soloMiss := scan.participation(previousBlockInfo) >= soloMissParticipation
+		statuses := make(map[string]windowStatus, len(records))
+		for _, record := range records {
+			streak := vt.recordSigning(record.Validator, record.Signed)
+			vt.metrics.cosmos.consecutiveMissedBlocksMetric.WithLabelValues(record.Validator).Set(float64(streak))
+			rate := vt.missRate.Observe(record.Validator, !record.Signed)
+			vt.observeUptime(record.Validator, currentHeight, record.Signed)
+			vt.metrics.custom.missRateEWMAMetric.WithLabelValues(record.Validator).Set(rate)
+			switch {
+			case record.Signed:
+				statuses[record.Validator] = windowSigned
+			case soloMiss:
+				statuses[record.Validator] = windowSoloMissed
+			default:
+				statuses[record.Validator] = windowMissed
+			}
+			if !record.Signed {
+				vt.metrics.cosmos.missedBlocksTotalMetric.WithLabelValues(record.Validator).Inc()
+				vt.events.Publish(Event{Type: EventValidatorMissed, Height: currentHeight, Validator: record.Validator})
+			}
+		}
+		if vt.missedWindow.Observe(currentHeight, statuses) {
+			vt.updateMissedWindowMetrics()
 		}
-		vt.metrics.cosmos.cometbftMissedBlocksMetric.WithLabelValues(label, "0g-galileo").Set(missedBlocks)
 	}
 
-	log.Printf("Updated beacon block metrics for block %d based on previous block %d", currentHeight, previousHeight)
+	vt.mu.Lock()
+	vt.signedHeights[currentHeight] = true
+	vt.mu.Unlock()
+
+	trackerLog.Debug("Updated beacon block metrics", "height", currentHeight, "previous_height", previousHeight)
+	return records
 }
 
-func (vt *UnifiedValidatorTracker) updateCosmosMetrics() {
+// staking 수집기: 스테이킹 벨리데이터 상태와 보상, 네트워크 개요 갱신
+func (vt *UnifiedValidatorTracker) updateCosmosMetrics(ctx context.Context) error {
 	// 스테이킹 벨리데이터 정보 조회
-	stakingValidators, err := vt.fetchStakingValidators()
+	stakingValidators, err := vt.fetchStakingValidators(ctx)
 	if err != nil {
-		log.Printf("Error fetching staking validators: %v", err)
-		return
+		restLog.Error("Error fetching staking validators", "error", err)
+		return err
 	}
 
+	// 토큰 기준 본딩 벨리데이터 순위
+	ranks := rankByTokens(stakingValidators)
+	now := time.Now()
+
 	// 벨리데이터 정보 업데이트
 	for _, validator := range stakingValidators.Validators {
+		vt.recordStakingIdentity(validator.ConsensusPubkey.Key, validator.OperatorAddress, validator.Description.Moniker)
+
 		// 주소를 hex 형식으로 변환 (필요한 경우)
 		address := validator.OperatorAddress
 		
-		// 추적 중인 벨리데이터인지 확인
-		label, exists := vt.validators[address]
+		// 추적 중인 벨리데이터인지 확인 (운영자 주소, 모니커, 합의 공개키로 맞춤)
+		label, exists := vt.stakingLabel(address, validator.Description.Moniker, validator.ConsensusPubkey.Key)
 		if !exists {
 			continue
 		}
+		vt.checkPubkeyRotation(label, validator.ConsensusPubkey.Key)
 
 		// 본딩 상태
 		isBonded := 0.0
@@ -581,6 +2108,7 @@ func (vt *UnifiedValidatorTracker) updateCosmosMetrics() {
 			isBonded = 1.0
 		}
 		vt.metrics.cosmos.isBondedMetric.WithLabelValues(label).Set(isBonded)
+		vt.publishStateChange(label, validator.Status == "BOND_STATUS_BONDED", validator.Jailed)
 
 		// 감금 상태
 		isJailed := 0.0
@@ -589,9 +2117,31 @@ func (vt *UnifiedValidatorTracker) updateCosmosMetrics() {
 		}
 		vt.metrics.cosmos.isJailedMetric.WithLabelValues(label).Set(isJailed)
 
-		// 토큰 수량
-		if tokens, err := strconv.ParseFloat(validator.Tokens, 64); err == nil {
-			vt.metrics.cosmos.tokensMetric.WithLabelValues(label).Set(tokens)
+		// 토큰 수량 (raw base 단위와 display 단위)
+		vt.denom.setGauges(vt.metrics.cosmos.tokensMetric.WithLabelValues(label),
+			vt.metrics.cosmos.tokensDisplayMetric.WithLabelValues(label), string(validator.Tokens))
+		if stake, ok := vt.denom.ToDisplay(string(validator.Tokens)); ok {
+			vt.valuation.setStake(label, stake)
+		}
+
+		// 미수령 보상
+		if rewards, err := vt.fetchOutstandingRewards(ctx, validator.OperatorAddress); err != nil {
+			restLog.Warn("Error fetching outstanding rewards", "validator", label, "error", err)
+		} else {
+			vt.denom.setGauges(vt.metrics.cosmos.rewardsMetric.WithLabelValues(label),
+				vt.metrics.cosmos.rewardsDisplayMetric.WithLabelValues(label), vt.denom.amountOf(rewards.Rewards.Rewards))
+		}
+
+		// 누적 커미션
+		if commission, err := vt.fetchValidatorCommission(ctx, validator.OperatorAddress); err != nil {
+			restLog.Warn("Error fetching validator commission", "validator", label, "error", err)
+		} else {
+			amount := vt.denom.amountOf(commission.Commission.Commission)
+			vt.denom.setGauges(vt.metrics.cosmos.accumCommissionMetric.WithLabelValues(label),
+				vt.metrics.cosmos.accumCommissionDisplayMetric.WithLabelValues(label), amount)
+			if display, ok := vt.denom.ToDisplay(amount); ok {
+				vt.valuation.setCommission(label, display)
+			}
 		}
 
 		// 커미션
@@ -603,30 +2153,56 @@ func (vt *UnifiedValidatorTracker) updateCosmosMetrics() {
 		// 기존 missed blocks 정보를 사용하여 CometBFT 형식으로도 노출
 		// 실제 구현에서는 더 정확한 데이터가 필요할 수 있음
 		vt.metrics.cosmos.cometbftMissedBlocksMetric.WithLabelValues(label, "0g-galileo").Set(0.0) // 기본값
+
+		// 순위 (본딩되지 않은 벨리데이터는 0)
+		vt.metrics.cosmos.rankMetric.WithLabelValues(label).Set(float64(ranks[address]))
+
+		vt.updateChangeMetrics(label, now, string(validator.Tokens), ranks[address])
+
+		vt.history.AddSample(ValidatorSample{
+			Time:      now,
+			Validator: label,
+			Tokens:    string(validator.Tokens),
+			Rank:      ranks[address],
+			Bonded:    validator.Status == "BOND_STATUS_BONDED",
+			Jailed:    validator.Jailed,
+		})
 	}
 
 	// 기본 메트릭 설정 (예시 값들)
 	vt.metrics.cosmos.activeSetMetric.Set(float64(len(stakingValidators.Validators)))
-	vt.metrics.cosmos.seatPriceMetric.Set(1000000.0) // 예시 값
-	vt.metrics.cosmos.signedBlocksWindowMetric.Set(100.0) // 예시 값
-	vt.metrics.cosmos.minSignedBlocksPerWindowMetric.Set(50.0) // 예시 값
-	vt.metrics.cosmos.downtimeJailDurationMetric.Set(600.0) // 예시 값
-	vt.metrics.cosmos.slashFractionDoubleSignMetric.Set(0.05) // 예시 값
-	vt.metrics.cosmos.slashFractionDowntimeMetric.Set(0.01) // 예시 값
+	vt.setBondedCount(len(ranks))
+	vt.updateNetworkOverview(stakingValidators)
+	vt.updateValueMetrics()
+	if price := seatPrice(stakingValidators); price != "" {
+		vt.denom.setGauges(vt.metrics.cosmos.seatPriceMetric, vt.metrics.cosmos.seatPriceDisplayMetric, price)
+	}
+	if pool, err := vt.fetchStakingPool(ctx); err != nil {
+		restLog.Warn("Error fetching staking pool", "error", err)
+	} else {
+		vt.denom.setGauges(vt.metrics.cosmos.bondedPoolMetric, vt.metrics.cosmos.bondedPoolDisplayMetric, pool.Pool.BondedTokens)
+	}
+	vt.updateSigningInfos(ctx)
+	return nil
 }
 
-func (vt *UnifiedValidatorTracker) updateValidatorStatus() {
-	validatorInfo, err := vt.fetchValidators()
+// height 시점의 벨리데이터 셋 기준으로 활성 상태 갱신
+func (vt *UnifiedValidatorTracker) updateValidatorStatus(ctx context.Context, height int64) {
+	validatorInfo, err := vt.validatorSetAt(ctx, height)
 	if err != nil {
-		log.Printf("Error fetching validators: %v", err)
+		rpcLog.Error("Error fetching validators", "error", err)
 		return
 	}
 
 	// Create a map of active validators
 	activeValidators := make(map[string]bool)
-	for _, validator := range validatorInfo.Validators {
+	for _, validator := range validatorInfo.Result.Validators {
 		activeValidators[validator.Address] = true
+		if _, tracked := vt.validators[validator.Address]; tracked {
+			vt.recordConsensusPubKey(validator.Address, validator.PubKey.Value)
+		}
 	}
+	vt.setVotingPowerShares(validatorInfo)
 
 	// Update status for each tracked validator
 	for address, label := range vt.validators {
@@ -639,261 +2215,544 @@ func (vt *UnifiedValidatorTracker) updateValidatorStatus() {
 	}
 }
 
-func (vt *UnifiedValidatorTracker) updateMempoolMetrics() {
-	// 0G 갈릴레오는 mempool API를 제공하지 않으므로
-	// 현재 블록의 트랜잭션 정보를 사용하여 mempool 상태를 추정
-	
-	// 최신 블록 정보 가져오기
-	blockInfo, err := vt.fetchBlock(0) // 0 means latest block
+// node_status 수집기: 노드 동기화 상태와 체인 ID 갱신
+func (vt *UnifiedValidatorTracker) updateNodeStatus(ctx context.Context) error {
+	endpoint := sanitizeEndpoint(vt.endpoints.Selected())
+	status, err := vt.fetchStatus(ctx)
 	if err != nil {
-		log.Printf("Error fetching latest block for mempool estimation: %v", err)
-		return
+		rpcLog.Error("Error fetching node status", "error", err)
+		return err
 	}
 
-	// 블록 높이 파싱
-	height, err := strconv.ParseInt(blockInfo.Result.Block.Header.Height, 10, 64)
-	if err != nil {
-		log.Printf("Error parsing block height: %v", err)
-		return
+	synced := !status.Result.SyncInfo.CatchingUp
+	syncedValue := 0.0
+	if synced {
+		syncedValue = 1.0
 	}
-
-	// 이전 블록과 비교하여 트랜잭션 변화 추정
-	// 실제로는 더 정확한 방법이 필요하지만, 현재로서는 기본값 설정
-	estimatedMempoolSize := float64(0) // 기본값
-	estimatedTotalBytes := float64(0)  // 기본값
-	estimatedTotal := float64(0)       // 기본값
-
-	// 블록 높이가 증가했는지 확인하여 네트워크 활동 추정
-	if height > vt.lastBlockHeight {
-		// 네트워크가 활성화되어 있다고 가정
-		estimatedMempoolSize = 10.0 // 추정값
-		estimatedTotalBytes = 1024.0 // 추정값 (1KB)
-		estimatedTotal = 5.0 // 추정값
-	} else {
-		// 네트워크가 비활성화되어 있다고 가정
-		estimatedMempoolSize = 0.0
-		estimatedTotalBytes = 0.0
-		estimatedTotal = 0.0
+	vt.metrics.cosmos.nodeSyncedMetric.WithLabelValues(endpoint).Set(syncedValue)
+	vt.updateNetInfo(ctx)
+	if height, err := strconv.ParseInt(string(status.Result.SyncInfo.LatestBlockHeight), 10, 64); err == nil {
+		vt.metrics.cosmos.nodeBlockHeightMetric.WithLabelValues(endpoint).Set(float64(height))
 	}
 
-	// 메트릭 업데이트
-	vt.metrics.custom.mempoolSizeMetric.Set(estimatedMempoolSize)
-	vt.metrics.custom.mempoolTotalBytesMetric.Set(estimatedTotalBytes)
-	vt.metrics.custom.mempoolTotalMetric.Set(estimatedTotal)
+	vt.mu.Lock()
+	// 체인 ID가 바뀌면 blocks 수집기가 리셋을 처리하며 새 값으로 바꿈
+	previousChainID := vt.chainID
+	if previousChainID == "" {
+		vt.chainID = status.Result.NodeInfo.Network
+	}
+	wasSynced := vt.nodeSynced
+	vt.nodeSynced = synced
+	vt.mu.Unlock()
 
-	log.Printf("Updated estimated mempool metrics - Size: %.0f, Total: %.0f, TotalBytes: %.0f (Block: %d)", 
-		estimatedMempoolSize, estimatedTotal, estimatedTotalBytes, height)
+	if previousChainID != "" && previousChainID != status.Result.NodeInfo.Network {
+		rpcLog.Warn("Node reports a different chain id, waiting for block tracking to reset", "tracked", previousChainID,
+			"node", status.Result.NodeInfo.Network)
+	}
+	if wasSynced && !synced {
+		vt.events.Publish(Event{Type: EventNodeUnsynced, Message: fmt.Sprintf("%s %s is catching up", vt.nodeDescription(vt.endpoints.Selected()), endpoint)})
+	}
+	return nil
 }
 
-func (vt *UnifiedValidatorTracker) updateBlockMetrics(blockInfo *BlockInfo) {
-	height, _ := strconv.ParseInt(blockInfo.Result.Block.Header.Height, 10, 64)
-	log.Printf("Updating block metrics for height: %d", height)
-	vt.metrics.cosmos.blockHeightMetric.Set(float64(height))
-	log.Printf("Set block height metric to: %d", height)
+// 선택된 노드의 미확인 트랜잭션 수와 크기 (조회 실패 시 이전 값 유지)
+func (vt *UnifiedValidatorTracker) updateMempoolMetrics(ctx context.Context) {
+	mempool, err := vt.fetchMempool(ctx)
+	if err != nil {
+		rpcLog.Error("Error fetching mempool", "error", err)
+		return
+	}
+	nTxs, _ := strconv.ParseFloat(string(mempool.Result.NTxs), 64)
+	total, _ := strconv.ParseFloat(string(mempool.Result.Total), 64)
+	totalBytes, _ := strconv.ParseFloat(string(mempool.Result.TotalBytes), 64)
+
+	vt.metrics.custom.mempoolSizeMetric.Set(nTxs)
+	vt.metrics.custom.mempoolTotalBytesMetric.Set(totalBytes)
+	vt.metrics.custom.mempoolTotalMetric.Set(total)
 
-	// 비콘 체인용 메트릭 업데이트
-	vt.updateBeaconBlockMetrics(blockInfo)
-	
-	// cosmos-validator-watcher 메트릭 업데이트
-	vt.updateCosmosMetrics()
-	
-	// 카운터 메트릭 업데이트
-	vt.metrics.cosmos.trackedBlocksMetric.Inc()
+	trackerLog.Debug("Updated mempool metrics", "n_txs", nTxs, "total", total, "total_bytes", totalBytes)
 }
 
+// 블록 추적 시작 (캐치업 후 호출, 간격은 POLL_INTERVAL 또는 COLLECTOR_INTERVALS의 blocks)
+// WebSocket 구독이 있으면 NewBlock 이벤트마다 바로 실행하고, 간격은 이벤트가 없을 때의 폴링으로만 사용
 func (vt *UnifiedValidatorTracker) StartTracking(ctx context.Context) {
-	log.Printf("StartTracking: Initializing block tracking with 5-second intervals")
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	log.Printf("StartTracking: Starting tracking loop")
-	for {
-		select {
-		case <-ctx.Done():
-			log.Printf("StartTracking: Context cancelled, stopping tracking")
-			return
-		case <-ticker.C:
-			log.Printf("StartTracking: Tick received, calling trackLatestBlock")
-			vt.trackLatestBlock()
+	collector := Collector{Name: collectorBlocks, Interval: vt.cycles.interval, Run: vt.collectBlocks}
+	if vt.blockEvents != nil {
+		collector.Wake = vt.blockEvents.Wake()
+		vt.goroutines.Go(componentWebSocket, func() { vt.blockEvents.Run(ctx) })
+	}
+	vt.scheduler.Register(collector)
+	vt.scheduler.Start(ctx)
+}
+
+// blocks 수집기: 한 추적 주기
+func (vt *UnifiedValidatorTracker) collectBlocks(ctx context.Context) error {
+	if !vt.versionsDetected() {
+		if err := vt.checkRPCCompat(ctx); err != nil {
+			trackerLog.Error("Stopping: node versions are not supported", "error", err)
+			vt.Stop(err)
+			return err
 		}
 	}
+	start := time.Now()
+	vt.cycleRPCTime.Store(0)
+	err := vt.trackLatestBlock(ctx)
+	vt.observeCycle(start, time.Since(start), time.Duration(vt.cycleRPCTime.Load()))
+	vt.updateHealthScores()
+	vt.checkSourceFreshness(time.Now())
+	vt.notifyWatchdog()
+	return err
 }
 
-func (vt *UnifiedValidatorTracker) trackLatestBlock() {
+const latestBlockErrorMessage = "Error fetching latest block"
+
+// fetcher 단계: 최신 블록과 직전 블록을 조회해 큐에 넣음 (적용은 applier가 높이 순서대로 수행)
+func (vt *UnifiedValidatorTracker) trackLatestBlock(ctx context.Context) error {
+	// JSON-RPC 배치로 이번 주기에 필요한 응답을 한 번에 조회
+	vt.prefetchCycle(ctx)
+	defer func() { vt.cycle = nil }()
+
 	// Fetch latest block
-	log.Printf("Attempting to fetch latest block from RPC endpoint: %s", vt.rpcEndpoint)
-	blockInfo, err := vt.fetchBlock(0) // 0 means latest block
+	trackerLog.Debug("Fetching latest block", "endpoint", sanitizeEndpoint(vt.endpoints.Selected()))
+	blockInfo, err := vt.fetchBlock(ctx, 0) // 0 means latest block
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
 	if err != nil {
-		log.Printf("Error fetching latest block: %v", err)
+		trackerLog.Error(latestBlockErrorMessage, "endpoint", sanitizeEndpoint(vt.endpoints.Selected()), "error", err)
+		vt.latestFailing = true
 		// RPC 연결 실패 시에도 기본 메트릭은 계속 제공
-		return
+		return err
 	}
 
-	height, _ := strconv.ParseInt(blockInfo.Result.Block.Header.Height, 10, 64)
-	log.Printf("Successfully fetched block height: %d", height)
-	
-	// Only process if this is a new block and hasn't been processed
-	if height > vt.lastBlockHeight && !vt.processedBlocks[height] {
-		log.Printf("Processing new block: %d (previous: %d)", height, vt.lastBlockHeight)
-		vt.updateBlockMetrics(blockInfo)
-		log.Printf("About to call updateBeaconBlockMetrics for block %d", height)
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("Panic in updateBeaconBlockMetrics: %v", r)
-				}
-			}()
-			vt.updateBeaconBlockMetrics(blockInfo) // Add beacon block metrics update
-		}()
-		log.Printf("Finished calling updateBeaconBlockMetrics for block %d", height)
-		vt.updateValidatorStatus()
-		vt.updateMempoolMetrics() // Add this line to update mempool metrics
-		vt.lastBlockHeight = height
-		vt.processedBlocks[height] = true
-		
-		// 메모리 관리를 위해 오래된 블록 정보 정리 (최근 1000개 블록만 유지)
-		if len(vt.processedBlocks) > 1000 {
-			for oldHeight := range vt.processedBlocks {
-				if oldHeight < height-1000 {
-					delete(vt.processedBlocks, oldHeight)
-				}
-			}
+	// 높이를 읽을 수 없는 응답으로 블록 높이 메트릭이 0으로 떨어지거나 체인 리셋으로 오인하지 않도록 여기서 중단
+	height, err := strconv.ParseInt(string(blockInfo.Result.Block.Header.Height), 10, 64)
+	if err != nil || height <= 0 {
+		err := newFetchError("block", vt.endpoints.Selected(), decodeFailure(fmt.Errorf("invalid latest block height %q", blockInfo.Result.Block.Header.Height)))
+		trackerLog.Error(latestBlockErrorMessage, "error", err)
+		vt.latestFailing = true
+		vt.metrics.exporter.rpcErrorsMetric.WithLabelValues("block", fetchReasonDecode).Inc()
+		return err
+	}
+	trackerLog.Debug("Fetched latest block", "height", height)
+	if vt.latestFailing {
+		// 억제된 조회 오류 요약을 바로 기록
+		trackerLog.Info("Latest block fetch recovered", "height", height, logRecoversKey, latestBlockErrorMessage)
+		vt.latestFailing = false
+	}
+	vt.notifyReady()
+	// 테스트넷 리셋이면 이전 체인 상태를 비운 뒤 새 체인의 블록부터 처리
+	if reset := vt.detectChainReset(blockInfo.Result.Block.Header.ChainID, height); reset != nil {
+		if !vt.resetChain(ctx, reset) {
+			return ctx.Err()
 		}
-		
-		log.Printf("Successfully processed beacon block %d", height)
-	} else {
-		log.Printf("Block %d already processed or not new (last: %d)", height, vt.lastBlockHeight)
 	}
-}
+	vt.checkChainHalt(height)
+	vt.observeProcessedLag(height, vt.LastHeight())
 
-// NodeExporterMetrics represents Node Exporter metrics
-type NodeExporterMetrics struct {
-	client *http.Client
-	url    string
+	// 이미 큐에 넣었거나 적용한 높이는 다시 넣지 않음
+	if height <= vt.lastQueued || height <= vt.LastHeight() {
+		trackerLog.Debug("Block already processed or not new", "height", height, "last_queued", vt.lastQueued)
+		return nil
+	}
+	// 주기 사이에 생성된 중간 블록을 먼저 높이 순서대로 처리 (BACKFILL_MAX_BLOCKS를 넘는 블록은 건너뛴 수로 기록)
+	var previous *BlockInfo
+	if last := max(vt.lastQueued, vt.LastHeight()); last > 0 && height > last+1 {
+		previous = vt.backfillBlocks(ctx, last+1, height-1)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	trackerLog.Debug("Queueing new block", "height", height, "queued", len(vt.blockQueue))
+	vt.enqueueBlock(ctx, vt.newBlockSummary(ctx, height, blockInfo, previous, true))
+	return nil
 }
 
-func NewNodeExporterMetrics(url string) *NodeExporterMetrics {
-	return &NodeExporterMetrics{
-		client: &http.Client{Timeout: 10 * time.Second},
-		url:    url,
+func main() {
+	// healthcheck 서브커맨드 (Docker HEALTHCHECK용, curl 불필요)
+	if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
+		os.Exit(runHealthcheck())
+	}
+	// mockchain 서브커맨드 (로컬 개발과 통합 테스트용 가짜 체인)
+	if len(os.Args) > 1 && os.Args[1] == "mockchain" {
+		os.Exit(runMockChain(os.Args[2:]))
 	}
-}
 
-func (nem *NodeExporterMetrics) fetchMetrics() (string, error) {
-	resp, err := nem.client.Get(nem.url)
+	// 설정 파일 (--config=config.yaml, 환경 변수보다 우선)
+	configPath := configPathFromArgs(os.Args[1:])
+	var config *Config
+	if configPath != "" {
+		var err error
+		if config, err = loadConfigFile(configPath); err != nil {
+			slog.Error("Invalid config file", "error", err)
+			os.Exit(1)
+		}
+		fileSettings = config.settings()
+		slog.Info("Loaded config file", "path", configPath, "keys", config.keys())
+	}
+	flag.String("config", configPath, "YAML or TOML config file (values override environment variables)")
+	validateConfig := flag.Bool("validate-config", false, "print the parsed config file and effective settings, then exit")
+
+	// 히스토리 보관 정책 (--history-retention=30d)
+	historyRetention := flag.String("history-retention", getEnv("HISTORY_RETENTION", "7d"),
+		"maximum age of signing history and samples (e.g. 30d, 72h)")
+	historyMaxRows := flag.Int("history-max-rows", int(getEnvInt64("HISTORY_MAX_ROWS", 0)),
+		"maximum rows kept per history table (0 = unlimited)")
+	listenAddr := flag.String("listen", getEnv("LISTEN_ADDR", defaultListenAddr),
+		"listen address: host:port, [ipv6]:port or unix:///path.sock")
+	socketModeValue := flag.String("socket-mode", getEnv("LISTEN_SOCKET_MODE", "0660"),
+		"permissions of the unix socket file (octal)")
+	dryRun := flag.Bool("dry-run", getEnvBool("DRY_RUN", false),
+		"fetch and compute as usual, but simulate notifications and skip state and diagnostic file writes")
+	pollMode := flag.Bool("poll-mode", getEnvBool("POLL_MODE", false),
+		"poll for new blocks every POLL_INTERVAL instead of subscribing to NewBlock events on the RPC WebSocket")
+	logLevel := flag.String("log-level", getEnv("LOG_LEVEL", ""),
+		"default log level: debug, info, warn or error (per-component levels in LOG_LEVELS still apply)")
+	flag.Parse()
+
+	listenTarget, err := parseListenTarget(*listenAddr)
 	if err != nil {
-		return "", err
+		slog.Error("Invalid listen address", "error", err)
+		os.Exit(1)
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	socketMode, err := parseSocketMode(*socketModeValue)
 	if err != nil {
-		return "", err
+		slog.Error("Invalid socket mode", "error", err)
+		os.Exit(1)
 	}
 
-	return string(body), nil
-}
+	// 컴포넌트별 로그 레벨 (LOG_LEVELS=rpc=debug,tracker=info,http=warn), 기본 레벨은 --log-level
+	baseLogLevel, err = parseBaseLogLevel(*logLevel)
+	if err != nil {
+		slog.Error("Invalid log level", "error", err)
+		os.Exit(1)
+	}
+	if err := configureLogLevels(); err != nil {
+		slog.Error("Invalid log level configuration", "error", err)
+		os.Exit(1)
+	}
+	// 공백이나 스킴이 빠진 엔드포인트는 첫 요청에서 알기 어려운 에러가 되므로 시작 시 확인
+	if err := validateEndpointEnv(); err != nil {
+		slog.Error("Invalid endpoint configuration", "error", err)
+		os.Exit(1)
+	}
 
-func main() {
 	// 0G 체인 갈릴레오 설정 (비콘 체인)
-	rpcEndpoint := os.Getenv("RPC_ENDPOINT")
-	if rpcEndpoint == "" {
-		rpcEndpoint = "http://57.129.73.24:50657" // 기본값
-	}
+	rpcEndpoint := getEnv("RPC_ENDPOINT", "http://57.129.73.24:50657")
 	
 	// 추적할 벨리데이터 (실제 0G 노드 벨리데이터 주소 사용)
+	// 설정 파일의 validators, VALIDATORS_FILE, VALIDATORS 중 하나가 있으면 그 목록
 	validators := map[string]string{
 		"21F5C524FCA565DD50841FF4B92A7220AA5B0BDD": "validator1",
 	}
+	validatorConfigs, err := validatorConfigsFromEnv(config)
+	if err != nil {
+		slog.Error("Invalid validator configuration", "error", err)
+		os.Exit(1)
+	}
+	if len(validatorConfigs) > 0 {
+		validators = validatorLabels(validatorConfigs)
+	}
+
+	if *validateConfig {
+		printConfig(config, validators)
+		os.Exit(0)
+	}
+
+	// 역할별 노드 (센트리만 접근 가능한 구성 등)
+	nodes, err := nodesFromEnv()
+	if err != nil {
+		slog.Error("Invalid node configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// 여러 RPC 엔드포인트 (RPC_ENDPOINTS=url1,url2), RPC_ENDPOINT_PIN으로 수동 고정
+	// 없으면 NODES 중 RPC가 있는 노드에서 서명 데이터를 읽음 (동기화된 노드를 선택)
+	defaultEndpoints := []string{rpcEndpoint}
+	if nodeEndpoints := nodeRPCEndpoints(nodes); len(nodeEndpoints) > 0 {
+		defaultEndpoints = nodeEndpoints
+	}
+	rpcEndpoints := getEnvList("RPC_ENDPOINTS", defaultEndpoints)
+	pinnedEndpoint := getEnv("RPC_ENDPOINT_PIN", "")
+	if pinnedEndpoint != "" && !slices.Contains(rpcEndpoints, pinnedEndpoint) {
+		slog.Error("RPC_ENDPOINT_PIN must be one of RPC_ENDPOINTS", "pin", sanitizeEndpoint(pinnedEndpoint))
+		os.Exit(1)
+	}
 
-	log.Printf("Initializing unified metrics tracker with RPC endpoint: %s", rpcEndpoint)
-	log.Printf("Tracking validators: %v", validators)
+	slog.Info("Initializing unified metrics tracker", "endpoints", sanitizeEndpoints(rpcEndpoints), "validators", validators)
 
-	tracker := NewUnifiedValidatorTracker(rpcEndpoint, validators)
-	tracker.RegisterMetrics()
-	log.Printf("Metrics registered successfully")
+	tracker := NewUnifiedValidatorTracker(rpcEndpoints, validators)
+	tracker.setValidatorConfigs(validatorConfigs)
+	tracker.restEndpoint = getEnv("REST_ENDPOINT", "")
+	// RPC/REST 요청별 타임아웃과 일시적 실패(네트워크 에러, 5xx) 재시도 횟수
+	httpAttempts := getEnvInt64("HTTP_MAX_ATTEMPTS", defaultHTTPMaxAttempts)
+	if httpAttempts < 1 {
+		slog.Error("HTTP_MAX_ATTEMPTS must be at least 1", "value", httpAttempts)
+		os.Exit(1)
+	}
+	tracker.http = NewHTTPClient(getEnvDuration("HTTP_TIMEOUT", defaultHTTPTimeout), int(httpAttempts),
+		tracker.metrics.exporter.httpDurationMetric, tracker.metrics.exporter.httpErrorsMetric, tracker.metrics.exporter.httpRetriesMetric)
+	tracker.endpoints.maxLag = getEnvInt64("RPC_ENDPOINT_MAX_LAG", defaultEndpointMaxLag)
+	tracker.endpoints.Pin(pinnedEndpoint)
+	tracker.endpoints.scoreMetric = tracker.metrics.exporter.rpcEndpointScoreMetric
+	tracker.endpoints.selectedMetric = tracker.metrics.exporter.rpcEndpointSelectedMetric
+	tracker.endpoints.healthyMetric = tracker.metrics.exporter.rpcEndpointHealthyMetric
+	tracker.endpoints.liveMetric = tracker.metrics.exporter.rpcURLHealthyMetric
+	tracker.endpoints.failoverMetric = tracker.metrics.exporter.rpcFailoverMetric
+	tracker.endpoints.upMetric = tracker.metrics.exporter.rpcEndpointUpMetric
+	// 선택된 엔드포인트가 연속 N번 실패하면 평가 주기를 기다리지 않고 다음 엔드포인트로 전환 (0이면 비활성화)
+	tracker.endpoints.SetFailoverThreshold(int(getEnvInt64("RPC_FAILOVER_THRESHOLD", defaultFailoverThreshold)))
+	tracker.labelRetention = getEnvInt64("LABEL_RETENTION_BLOCKS", defaultLabelRetention)
+	// 라벨 변경 (LABEL_RENAMES="old=new", 상태 파일의 이전 라벨 상태와 히스토리를 새 라벨로 옮김)
+	if tracker.labelRenames, err = labelRenamesFromEnv(validators); err != nil {
+		slog.Error("Invalid LABEL_RENAMES", "error", err)
+		os.Exit(1)
+	}
+	tracker.denom = DenomMetadata{
+		Base:     getEnv("DENOM_BASE", galileoDenom.Base),
+		Display:  getEnv("DENOM_DISPLAY", galileoDenom.Display),
+		Exponent: int(getEnvInt64("DENOM_EXPONENT", int64(galileoDenom.Exponent))),
+	}
+	probes, err := probesFromEnv()
+	if err != nil {
+		slog.Error("Invalid probe configuration", "error", err)
+		os.Exit(1)
+	}
+	tracker.probes = probes
+	peerChecks, err := peerChecksFromEnv()
+	if err != nil {
+		slog.Error("Invalid peer check configuration", "error", err)
+		os.Exit(1)
+	}
+	tracker.peerChecks = peerChecks
+	tracker.nodes = nodes
+	appQueries, err := appQueriesFromEnv()
+	if err != nil {
+		slog.Error("Invalid app query configuration", "error", err)
+		os.Exit(1)
+	}
+	hooks, hooksDir, err := hooksFromEnv()
+	if err != nil {
+		slog.Error("Invalid hook configuration", "error", err)
+		os.Exit(1)
+	}
+	priceFeeds, err := priceFeedsFromEnv()
+	if err != nil {
+		slog.Error("Invalid price feed configuration", "error", err)
+		os.Exit(1)
+	}
+	tracker.priceFeeds = priceFeeds
+	if feeWindow := getEnvInt64("FEE_WINDOW_BLOCKS", defaultFeeWindowBlocks); feeWindow > 0 {
+		tracker.gasPrices = NewGasPriceWindow(int(feeWindow))
+	}
+	switch rpcMode := getEnv("RPC_MODE", rpcModeAuto); rpcMode {
+	case rpcModeAuto, rpcModeJSONRPC:
+		tracker.jsonRPC = NewJSONRPCClient(rpcMode, tracker.http.Transport())
+	case rpcModeURI:
+	default:
+		slog.Error("Invalid RPC_MODE (expected auto, jsonrpc or uri)", "value", rpcMode)
+		os.Exit(1)
+	}
+	if verifyEndpoint := getEnv("VERIFY_ENDPOINT", ""); verifyEndpoint != "" {
+		tracker.verifier = NewBlockVerifier(verifyEndpoint, getEnvInt64("VERIFY_SAMPLE_RATE", defaultVerifySampleRate))
+	}
+	tracker.sourceStaleAfter = getEnvDuration("SOURCE_STALE_AFTER", defaultSourceStaleAfter)
+	tracker.proposers = NewProposerWindow(int(getEnvInt64("PROPOSER_WINDOW", defaultProposerWindow)))
+	missRateAlpha, err := missRateAlphaFromEnv()
+	if err != nil {
+		slog.Error("Invalid miss rate EWMA settings", "error", err)
+		os.Exit(1)
+	}
+	tracker.missRate = NewMissRateEWMA(missRateAlpha)
+	// 건강 점수 요소별 가중치 (HEALTH_SCORE_WEIGHTS="uptime=50,governance=0")
+	if tracker.healthWeights, err = parseHealthWeights(getEnv("HEALTH_SCORE_WEIGHTS", "")); err != nil {
+		slog.Error("Invalid HEALTH_SCORE_WEIGHTS", "error", err)
+		os.Exit(1)
+	}
+	tracker.networkTopN = int(getEnvInt64("NETWORK_TOP_N", defaultNetworkTopN))
+	// 수집기별 간격 (COLLECTOR_INTERVALS="staking=2m,node_status=15s", 없으면 기존 *_INTERVAL 설정과 기본값)
+	collectorIntervals, err := parseCollectorIntervals(getEnv("COLLECTOR_INTERVALS", ""))
+	if err != nil {
+		slog.Error("Invalid COLLECTOR_INTERVALS", "error", err)
+		os.Exit(1)
+	}
+	tracker.scheduler = NewScheduler(tracker.metrics.exporter, collectorIntervals, tracker.goroutines)
+	pollInterval, err := pollIntervalFromEnv()
+	if err != nil {
+		slog.Error("Invalid poll interval", "error", err)
+		os.Exit(1)
+	}
+	pollInterval = tracker.scheduler.IntervalFor(collectorBlocks, pollInterval)
+	if err := validatePollInterval(pollInterval); err != nil {
+		slog.Error("Invalid blocks collector interval", "error", err)
+		os.Exit(1)
+	}
+	tracker.metrics.exporter.pollIntervalMetric.Set(pollInterval.Seconds())
+	if !*pollMode {
+		tracker.blockEvents = NewWebSocketTracker(tracker)
+	}
+	// 재시도를 포함해도 한 주기 안에 끝나도록
+	tracker.http.budget = pollInterval
+	tracker.cycles = NewCycleMonitor(pollInterval, getEnvFloat("CYCLE_OVERRUN_ALERT_RATIO", defaultCycleOverrunAlertRatio))
+	tracker.lag = NewLagMonitor(getEnvInt64("PROCESSED_LAG_ALERT_BLOCKS", defaultProcessedLagAlertBlocks),
+		getEnvDuration("PROCESSED_LAG_ALERT_AFTER", defaultProcessedLagAlertAfter))
+	retentionAge, err := parseRetention(*historyRetention)
+	if err != nil {
+		slog.Error("Invalid history retention", "value", *historyRetention, "error", err)
+		os.Exit(1)
+	}
+	tracker.history = NewHistoryStore(HistoryRetention{MaxAge: retentionAge, MaxRows: *historyMaxRows})
+	// 블록 기록 메시지 큐 (SINK_URL, 전송 전 블록은 히스토리 저장소의 outbox에 보관)
+	if tracker.sink, err = sinkFromEnv(); err != nil {
+		slog.Error("Invalid sink configuration", "error", err)
+		os.Exit(1)
+	}
+	tracker.history.outboxMax = int(getEnvInt64("SINK_BUFFER_MAX", defaultSinkBufferMax))
+	// 서명 기록 증빙용 블록 표본 보관 (ARCHIVE_EVERY)
+	if tracker.archiver, err = archiverFromEnv(); err != nil {
+		slog.Error("Invalid archive configuration", "error", err)
+		os.Exit(1)
+	}
+	// 노드와 같은 이름의 메트릭 처리 방식 (rename이면 우리 메트릭 이름을 바꿔 등록)
+	dedupMode, err := parseMetricDedupMode(getEnv("METRIC_DEDUP_MODE", metricDedupBoth))
+	if err != nil {
+		slog.Error("Invalid metric deduplication mode", "error", err)
+		os.Exit(1)
+	}
+	if dedupMode == metricDedupRename {
+		tracker.metrics.cosmos.cometbftMissedBlocksMetric = newCometBFTMissedBlocksMetric(renamedMissedBlocksName)
+	}
+	if err := tracker.RegisterMetrics(); err != nil {
+		slog.Error("Error registering metrics", "error", err)
+		os.Exit(1)
+	}
+	if err := tracker.RegisterAppQueries(appQueries); err != nil {
+		slog.Error("Invalid app query configuration", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Metrics registered successfully")
 
 	// Node Exporter 메트릭 수집기 초기화
-	nodeExporterURL := os.Getenv("NODE_EXPORTER_URL")
+	nodeExporterURL := getEnv("NODE_EXPORTER_URL", "")
 	if nodeExporterURL == "" {
 		nodeExporterURL = "http://57.129.73.24:9200/metrics" // 기본값
 	}
-	nodeExporter := NewNodeExporterMetrics(nodeExporterURL)
-	log.Printf("Node Exporter metrics collector initialized")
+	slog.Info("Node Exporter metrics collector initialized", "url", sanitizeEndpoint(nodeExporterURL))
+
+	// 0G 노드 메트릭 (CometBFT)
+	ogNodeURL := getEnv("OG_NODE_METRICS_URL", "")
+	if ogNodeURL == "" {
+		ogNodeURL = "http://57.129.73.24:50660/metrics" // 기본값
+	}
+	// 업스트림 소스별 인증/TLS (METRIC_SOURCES_AUTH)
+	sourceConfigs, err := sourceConfigsFromEnv([]string{"node_exporter", "og_node"})
+	if err != nil {
+		slog.Error("Invalid metric source configuration", "error", err)
+		os.Exit(1)
+	}
+	nodeExporter, err := NewMetricSource("node_exporter", nodeExporterURL, sourceConfigs["node_exporter"])
+	if err != nil {
+		slog.Error("Invalid metric source configuration", "error", err)
+		os.Exit(1)
+	}
+	ogNode, err := NewMetricSource("og_node", ogNodeURL, sourceConfigs["og_node"])
+	if err != nil {
+		slog.Error("Invalid metric source configuration", "error", err)
+		os.Exit(1)
+	}
+	tracker.metricSources = []*MetricSource{nodeExporter, ogNode}
+	tracker.notifiers = notifiersFromEnv()
+	if *dryRun {
+		tracker.dryRun = true
+		tracker.notifiers = dryRunNotifiers(tracker.notifiers)
+		slog.Warn("Dry-run mode: notifications are logged instead of sent and no state or diagnostic files are written")
+	}
+	heartbeat, err := heartbeatFromEnv()
+	if err != nil {
+		slog.Error("Invalid heartbeat configuration", "error", err)
+		os.Exit(1)
+	}
+	if heartbeat != nil && tracker.dryRun {
+		// 드라이런 인스턴스가 운영 인스턴스의 생존 신호를 대신 보내지 않도록 함
+		slog.Warn("Dry-run mode: heartbeat is disabled")
+		heartbeat = nil
+	}
+	if tracker.archiver != nil && tracker.dryRun {
+		slog.Warn("Dry-run mode: block archiving is disabled")
+		tracker.archiver = nil
+	}
+	if len(hooks) > 0 && tracker.dryRun {
+		slog.Warn("Dry-run mode: hooks are disabled")
+		hooks = nil
+	}
+
+	// 팀별 토큰 (TENANTS/TENANTS_FILE, SIGHUP으로 다시 읽음)
+	adminToken := getEnv("ADMIN_TOKEN", "")
+	if err := tracker.loadTenants(adminToken); err != nil {
+		slog.Error("Invalid tenant configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// HTTP 서버 설정 (테넌시가 켜져 있으면 팀의 벨리데이터 시리즈만 노출)
+	// 설정한 모니커는 validator 라벨이 있는 시리즈에 moniker 라벨로 붙임
+	var gatherer prometheus.Gatherer = tracker.registry
+	if len(tracker.monikers) > 0 {
+		gatherer = monikerGatherer{gatherer: gatherer, monikers: tracker.monikers}
+	}
+	metricsHandler := promhttp.InstrumentMetricHandler(tracker.registry, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	http.Handle("/metrics", tracker.tenantMetricsHandler(adminToken, metricsHandler, gatherer, promhttp.HandlerOpts{}))
+
+	// 무거운 엔드포인트 요청 제한 (기본값은 모두 비활성화)
+	limiter := NewRequestLimiter(
+		int(getEnvInt64("ALL_METRICS_MAX_INFLIGHT", 0)),
+		getEnvFloat("RATE_LIMIT_RPS", 0),
+		int(getEnvInt64("RATE_LIMIT_BURST", 10)),
+		tracker.metrics.exporter.rejectedRequestsMetric,
+	)
 
-	// HTTP 서버 설정
-	http.Handle("/metrics", promhttp.Handler())
-	
 	// 통합 메트릭 엔드포인트 (모든 메트릭 포함)
-	http.HandleFunc("/all-metrics", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
-		
-		// 1. Prometheus 메트릭 (cosmos-validator-watcher + 커스텀 메트릭)
-		promResp, err := http.Get("http://localhost:8080/metrics")
-		if err == nil {
-			defer promResp.Body.Close()
-			io.Copy(w, promResp.Body)
-		} else {
-			log.Printf("Warning: Failed to fetch local metrics: %v", err)
-		}
-		
-		// 2. Node Exporter 메트릭 추가 (시스템 메트릭만)
-		nodeMetrics, err := nodeExporter.fetchMetrics()
-		if err == nil {
-			w.Write([]byte("\n# Node Exporter Metrics\n"))
-			w.Write([]byte(nodeMetrics))
-		} else {
-			log.Printf("Warning: Failed to fetch Node Exporter metrics: %v", err)
-		}
-		
-		// 3. 0G 노드 메트릭 추가 (CometBFT 메트릭만, 중복 제거)
-		ogNodeURL := os.Getenv("OG_NODE_METRICS_URL")
-		if ogNodeURL == "" {
-			ogNodeURL = "http://57.129.73.24:50660/metrics" // 기본값
-		}
-		log.Printf("Attempting to fetch 0G node metrics from %s", ogNodeURL)
-		ogClient := &http.Client{Timeout: 15 * time.Second}
-		ogResp, err := ogClient.Get(ogNodeURL)
-		if err == nil {
-			defer ogResp.Body.Close()
-			body, err := io.ReadAll(ogResp.Body)
-			if err == nil {
-				// CometBFT 메트릭만 필터링하여 중복 제거
-				lines := strings.Split(string(body), "\n")
-				w.Write([]byte("\n# 0G Galileo Node Metrics (CometBFT)\n"))
-				for _, line := range lines {
-					line = strings.TrimSpace(line)
-					if line != "" && !strings.HasPrefix(line, "#") {
-						// 이미 로컬 메트릭에 있는 메트릭은 제외
-						if !strings.Contains(line, "og_galileo_") && 
-						   !strings.Contains(line, "cosmos_validator_") &&
-						   !strings.Contains(line, "go_") &&
-						   !strings.Contains(line, "process_") {
-							w.Write([]byte(line + "\n"))
-						}
-					} else if strings.HasPrefix(line, "#") {
-						// 헬프 텍스트는 유지
-						w.Write([]byte(line + "\n"))
-					}
-				}
-			}
-			log.Printf("Successfully fetched 0G node metrics (status: %d)", ogResp.StatusCode)
-		} else {
-			log.Printf("Warning: Failed to fetch 0G node metrics: %v", err)
-			// 에러가 발생해도 기본 메트릭은 계속 제공
-			w.Write([]byte("\n# 0G Galileo Node Metrics (CometBFT) - UNAVAILABLE\n"))
-			w.Write([]byte("# Error: Unable to connect to 0G node metrics endpoint\n"))
-		}
-	})
-	
+	http.HandleFunc("/all-metrics", limiter.Wrap("all-metrics", true, tracker.requireTenant(adminToken,
+		tracker.allMetricsHandler(gatherer, dedupMode, nodeExporter, ogNode))))
+
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
+
+	tracker.configSummary = configSummaryFromEnv()
+	tracker.readyMaxStaleness = getEnvDuration("READY_MAX_STALENESS", defaultReadyMaxStaleness)
+
+	// JSON API
+	tracker.events.maxSubscribers = int(getEnvInt64("SSE_MAX_SUBSCRIBERS", defaultMaxSubscribers))
+	tracker.chainHaltWindow = getEnvDuration("CHAIN_HALT_WINDOW", defaultChainHaltWindow)
+	cors, err := corsConfigFromEnv()
+	if err != nil {
+		slog.Error("Invalid CORS configuration", "error", err)
+		os.Exit(1)
+	}
+	tracker.RegisterAPI(http.DefaultServeMux, APIOptions{
+		AdminToken: adminToken,
+		Limiter:    limiter,
+		CORS:       cors,
+	})
+
+	// 준비 상태: 최근에 블록을 처리했을 때만 OK
+	http.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		ready, reason := tracker.Ready(tracker.readyMaxStaleness)
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("NOT READY: " + reason))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("READY"))
+	})
 	
+	// 루트 페이지에는 실제 리슨 주소를 표시 (LISTEN_ADDR 또는 --listen, 기본값 :8080)
+	listenInfo := html.EscapeString(listenTarget.String())
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(`
+		fmt.Fprintf(w, `
 <!DOCTYPE html>
 <html>
 <head>
@@ -913,6 +2772,7 @@ func main() {
     <div class="container">
         <h1>0G Galileo Beacon Chain Unified Metrics</h1>
         <p>Unified metrics collector - provides cosmos-validator-watcher, custom beacon chain metrics, and system metrics from a single port.</p>
+        <p>Listening on <code>%s</code> (set with <code>LISTEN_ADDR</code> or <code>--listen</code>, default <code>%s</code>)</p>
         
         <div class="metric">
             <h3>📊 Metrics Endpoints</h3>
@@ -923,6 +2783,23 @@ func main() {
         <div class="metric">
             <h3>🏥 Health Check</h3>
             <p><a href="/health">/health</a> - Service status check</p>
+            <p><a href="/ready">/ready</a> - Readiness check (recent block processed)</p>
+        </div>
+
+        <div class="metric">
+            <h3>🧾 JSON API</h3>
+            <p><a href="/api/openapi.json">/api/openapi.json</a> - OpenAPI document for the JSON API</p>
+            <p><a href="/api/status">/api/status</a> - Exporter and validator status</p>
+            <p><a href="/api/targets">/api/targets</a> - Chain, endpoints, validators and sources monitored by this instance</p>
+            <p><a href="/api/metrics/catalog">/api/metrics/catalog</a> - Every metric this exporter can emit with type, labels, help, unit and collector</p>
+            <p>/api/events?type=jailed&amp;from=2006-01-02&amp;validator=label&amp;limit=100&amp;cursor= - Persisted event log</p>
+            <p>/api/heatmap?validator=label&amp;days=7&amp;bucket=1h - Signed/missed block counts per time bucket</p>
+            <p>/api/report?from=2006-01-02&amp;to=2006-01-31&amp;validator=label&amp;format=markdown - Uptime report with per-day breakdown</p>
+            <p>/api/events/stream - Server-sent events of validator and chain events</p>
+            <p>/api/history/export?cursor= - Full signing and event history as NDJSON (resumable)</p>
+            <p>/api/history/import - Load a history export into this instance (admin)</p>
+            <p>/api/state/snapshot, /api/state/restore - Tracker state snapshot and restore (admin)</p>
+            <p>/api/selftest - Run a synthetic block through a shadow tracker and show the metric changes (admin)</p>
         </div>
         
         <div class="metric">
@@ -942,7 +2819,7 @@ func main() {
                 <li><strong>og_galileo_validator_block_height</strong> - Current block height</li>
                 <li><strong>og_galileo_validator_is_bonded</strong> - Validator bonding status</li>
                 <li><strong>og_galileo_validator_missed_blocks</strong> - Number of missed blocks</li>
-                <li><strong>og_galileo_validator_mempool_size</strong> - Mempool size (estimated)</li>
+                <li><strong>og_galileo_validator_mempool_size</strong> - Unconfirmed transactions in the mempool</li>
                 <li><strong>node_cpu_seconds_total</strong> - CPU usage</li>
                 <li><strong>node_memory_MemTotal_bytes</strong> - Memory usage</li>
                 <li><strong>node_filesystem_size_bytes</strong> - Disk usage</li>
@@ -967,17 +2844,174 @@ func main() {
     </div>
 </body>
 </html>
-		`))
+		`, listenInfo, defaultListenAddr)
 	})
 
-	// 백그라운드에서 블록 추적 시작
-	log.Printf("Starting block tracking in background...")
-	ctx, cancel := context.WithCancel(context.Background())
+	// 추적을 시작하기 전에 바인딩해서 포트가 이미 쓰이고 있으면 바로 종료
+	listener, err := listenTarget.Listen(socketMode)
+	if err != nil {
+		slog.Error("Cannot bind listen address (set LISTEN_ADDR or --listen to a free address)",
+			"network", listenTarget.Network, "addr", listenTarget.Address, "error", err)
+		os.Exit(1)
+	}
+	defer listenTarget.Cleanup()
+
+	// 백그라운드에서 블록 추적 시작 (SIGINT/SIGTERM 시 정상 종료)
+	signalCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
-	
-	go tracker.StartTracking(ctx)
-	log.Printf("Block tracking started successfully")
+	// 시작을 거부하는 경우에도 같은 종료 경로(상태 저장 등)를 거친 뒤 0이 아닌 코드로 종료
+	ctx, stop := context.WithCancelCause(signalCtx)
+	defer stop(nil)
+	tracker.stop = stop
+
+	// 상태 파일이 있으면 복원 후 재시작 동안 놓친 블록을 따라잡고 실시간 추적 시작
+	stateFile := getEnv("STATE_FILE", "")
+	tracker.stateFile = stateFile
+	tracker.resetHeightDrop = getEnvInt64("CHAIN_RESET_HEIGHT_DROP", defaultChainResetHeightDrop)
+	if err := tracker.loadLifecycle(stateFile, time.Now().UTC()); err != nil {
+		persistenceLog.Error("Failed to read exporter lifecycle from state file", "path", stateFile, "error", err)
+	}
+	catchUpMaxBlocks := getEnvInt64("CATCHUP_MAX_BLOCKS", defaultCatchUpMaxBlocks)
+	tracker.backfillLimit = getEnvInt64("BACKFILL_MAX_BLOCKS", defaultBackfillMaxBlocks)
+	// 노드 RPC와 설정 조회 API 모두 mempool 용량을 알려주지 않으므로 config.toml [mempool] size 값을 설정으로 받음
+	if maxTxs := getEnvInt64("MEMPOOL_MAX_TXS", 0); maxTxs > 0 {
+		tracker.metrics.custom.mempoolMaxTxsMetric.Set(float64(maxTxs))
+	}
+	uptimeWindows, err := parseUptimeWindows(getEnv("UPTIME_WINDOWS", strconv.Itoa(defaultUptimeWindow)))
+	if err != nil || len(uptimeWindows) == 0 {
+		slog.Error("Invalid UPTIME_WINDOWS (expected comma-separated block counts)", "error", err)
+		os.Exit(1)
+	}
+	tracker.uptime = NewUptimeTracker(uptimeWindows)
+	tracker.rpcCompatMode = getEnv("RPC_COMPAT_MODE", rpcCompatModeDegraded)
+	if tracker.rpcCompatMode != rpcCompatModeDegraded && tracker.rpcCompatMode != rpcCompatModeRefuse {
+		slog.Error("Invalid RPC_COMPAT_MODE (expected degraded or refuse)", "value", tracker.rpcCompatMode)
+		os.Exit(1)
+	}
+	tracker.StartApplier(ctx)
+	// 이벤트별 외부 명령 (HOOKS, 추적 시작 전에 이벤트 버스에 연결)
+	tracker.StartHooks(ctx, hooks, hooksDir)
+	// 블록 외 수집기는 바로 시작 (blocks 수집기는 캐치업 후 StartTracking에서 시작)
+	jitter := getEnvFloat("COLLECTOR_JITTER", defaultCollectorJitter)
+	tracker.scheduler.Register(Collector{Name: collectorNodeStatus, Interval: defaultNodeStatusInterval, Jitter: jitter,
+		Run: func(ctx context.Context) error { return tracker.updateNodeStatus(ctx) }})
+	tracker.scheduler.Register(Collector{Name: collectorStaking, Interval: defaultStakingInterval, Jitter: jitter,
+		Run: func(ctx context.Context) error { return tracker.updateCosmosMetrics(ctx) }})
+	tracker.scheduler.Register(Collector{Name: collectorParams, Interval: getEnvDuration("STAKING_PARAMS_INTERVAL", defaultParamsRefreshInterval),
+		Retry: paramsRetryInterval, Jitter: jitter, Run: func(ctx context.Context) error { return tracker.refreshStakingParams(ctx) }})
+	tracker.scheduler.Register(Collector{Name: collectorSlashing, Interval: getEnvDuration("SLASHING_PARAMS_INTERVAL", defaultSlashingParamsInterval),
+		Retry: paramsRetryInterval, Jitter: jitter, Run: func(ctx context.Context) error { return tracker.refreshSlashingParams(ctx) }})
+	tracker.scheduler.Register(Collector{Name: collectorGovernance, Interval: getEnvDuration("GOVERNANCE_INTERVAL", defaultGovernanceInterval),
+		Jitter: jitter, Run: func(ctx context.Context) error {
+			return errors.Join(tracker.refreshUpgradePlan(ctx), tracker.refreshGovernance(ctx))
+		}})
+	tracker.scheduler.Register(Collector{Name: collectorEconomics, Interval: getEnvDuration("FEE_REFRESH_INTERVAL", defaultFeeRefreshInterval),
+		Jitter: jitter, Run: tracker.economicsCollector(getEnv("EVM_RPC_ENDPOINT", ""))})
+	tracker.StartAppQueries(appQueries)
+	tracker.scheduler.Start(ctx)
+	tracker.goroutines.Go(componentTracker, func() {
+		// 노드 버전 호환성 확인 (refuse 모드에서 지원하지 않는 버전이면 종료)
+		if err := tracker.checkRPCCompat(ctx); err != nil {
+			slog.Error("Refusing to start", "error", err)
+			tracker.Stop(err)
+			return
+		}
+		if stateFile != "" {
+			tracker.updateNodeStatus(ctx) // 복원 전 체인 ID 확인
+			if err := tracker.loadStateFile(stateFile); err != nil {
+				persistenceLog.Error("Failed to restore state file", "path", stateFile, "error", err)
+			}
+			tracker.CatchUp(ctx, catchUpMaxBlocks)
+		}
+		tracker.events.Publish(Event{Type: EventExporterStarted, Height: tracker.LastHeight(), Message: tracker.startedMessage()})
+		tracker.StartTracking(ctx)
+	})
+	tracker.StartProbes(ctx, tracker.probes)
+	tracker.StartPriceFeeds(ctx, tracker.priceFeeds)
+	tracker.StartPeerChecks(ctx, tracker.peerChecks, getEnvDuration("PEER_DISCONNECT_ALERT_AFTER", defaultPeerDisconnectAlert))
+	tracker.StartNodeHealth(ctx, tracker.nodes, getEnvInt64("NODE_MAX_LAG", defaultEndpointMaxLag))
+	if len(rpcEndpoints) > 1 {
+		tracker.goroutines.Go(componentEndpointSelector, func() {
+			tracker.StartEndpointSelector(ctx, getEnvDuration("RPC_ENDPOINT_EVAL_INTERVAL", defaultEndpointEvalInterval))
+		})
+	}
+	if stateFile != "" {
+		tracker.goroutines.Go(componentPersister, func() {
+			tracker.StartStatePersister(ctx, stateFile, getEnvDuration("STATE_SAVE_INTERVAL", defaultStateSaveInterval))
+		})
+	}
+
+	// SIGHUP 수신 시 로그 레벨 다시 읽기
+	tracker.goroutines.Go(componentSignals, func() { HandleLogLevelReload(ctx) })
+	tracker.goroutines.Go(componentSignals, func() { tracker.HandleTenantReload(ctx, adminToken) })
+
+	// SIGUSR1 수신 시 진단 상태 덤프
+	tracker.goroutines.Go(componentSignals, func() { tracker.HandleDiagnosticSignals(ctx, getEnv("DIAG_DUMP_DIR", "")) })
+
+	// 일일 리포트 (REPORT_SCHEDULE=HH:MM, UTC)
+	if schedule := getEnv("REPORT_SCHEDULE", ""); schedule != "" {
+		if len(tracker.notifiers) == 0 {
+			alertsLog.Warn("REPORT_SCHEDULE is set but no notifier channels are configured")
+		}
+		reportFormat := getEnv("REPORT_FORMAT", "markdown")
+		tracker.goroutines.Go(componentReporter, func() { tracker.StartDailyReporter(ctx, schedule, reportFormat, tracker.notifiers) })
+	}
+
+	// 오래된 라벨 값 정리 (proposal_id, block_height)
+	janitorInterval := getEnvDuration("JANITOR_INTERVAL", defaultJanitorInterval)
+	pruneInterval := getEnvDuration("HISTORY_PRUNE_INTERVAL", defaultPruneInterval)
+	tracker.goroutines.Go(componentJanitor, func() { tracker.StartJanitor(ctx, janitorInterval) })
+	tracker.goroutines.Go(componentJanitor, func() { tracker.StartHistoryPruner(ctx, pruneInterval) })
+	// 외부 모니터로 생존 신호 (HEARTBEAT_URL)
+	if heartbeat != nil {
+		tracker.goroutines.Go(componentHeartbeat, func() { tracker.StartHeartbeat(ctx, heartbeat) })
+	}
+	if tracker.sink != nil {
+		sinkRetry := getEnvDuration("SINK_RETRY_INTERVAL", defaultSinkRetryInterval)
+		tracker.goroutines.Go(componentSink, func() { tracker.StartSink(ctx, sinkRetry) })
+	}
+	if tracker.archiver != nil {
+		archiveRetry := getEnvDuration("ARCHIVE_RETRY_INTERVAL", defaultArchiveRetryInterval)
+		tracker.goroutines.Go(componentArchiver, func() { tracker.StartArchiver(ctx, archiveRetry) })
+	}
+
+	server := &http.Server{}
+	serverErr := make(chan error, 1)
+	tracker.goroutines.Go(componentHTTPServer, func() {
+		slog.Info("Starting 0G Galileo unified metrics server", "network", listenTarget.Network, "addr", listener.Addr().String())
+		serverErr <- server.Serve(listener)
+	})
+
+	select {
+	case err := <-serverErr:
+		slog.Error("HTTP server failed", "error", err)
+		listenTarget.Cleanup()
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down gracefully")
+	tracker.shuttingDown.Store(true)
+	sdNotify("STOPPING=1")
 
-	log.Println("Starting 0G Galileo unified metrics server on :8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
-}
\ No newline at end of file
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("HTTP server shutdown failed", "error", err)
+	}
+	if stateFile != "" {
+		if err := tracker.saveStateFile(stateFile); err != nil {
+			persistenceLog.Error("Failed to save state file", "path", stateFile, "error", err)
+		}
+	}
+	// 컨텍스트 취소 후에도 남은 구성 요소 고루틴은 누수로 보고 (알림 전송은 자체 타임아웃으로 끝남)
+	if remaining := tracker.goroutines.WaitIdle(goroutineExitTimeout, componentNotifier); remaining != nil {
+		slog.Warn("Goroutines still running after shutdown", "components", formatGoroutineCounts(remaining))
+	}
+	if err := tracker.stopError(ctx); err != nil {
+		slog.Error("Exiting after a fatal error", "error", err)
+		listenTarget.Cleanup()
+		os.Exit(1)
+	}
+	slog.Info("Shutdown complete")
+}
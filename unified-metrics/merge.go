@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// 노드도 같은 이름으로 노출하는 메트릭을 /all-metrics에서 합치는 방식 (METRIC_DEDUP_MODE)
+const (
+	metricDedupNode   = "node"   // 우리 메트릭은 빼고 노드 메트릭만 전달
+	metricDedupRename = "rename" // 우리 메트릭을 og_galileo 이름으로 등록 (/metrics에도 적용)
+	metricDedupBoth   = "both"   // 한 패밀리로 합치고 source 라벨(exporter, node)로 구분
+)
+
+const (
+	cometbftMissedBlocksName = "cometbft_consensus_validator_missed_blocks"
+	renamedMissedBlocksName  = "og_galileo_consensus_validator_missed_blocks"
+	metricSourceLabel        = "source"
+)
+
+// 노드 메트릭 중 로컬에도 있는 패밀리 (go/process 런타임, 우리 네임스페이스)
+var localMetricPrefixes = []string{"og_galileo_", "cosmos_validator_", "go_", "process_", "promhttp_"}
+
+func parseMetricDedupMode(mode string) (string, error) {
+	switch mode {
+	case metricDedupNode, metricDedupRename, metricDedupBoth:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid METRIC_DEDUP_MODE %q (expected node, rename or both)", mode)
+}
+
+// cometbft_consensus_validator_missed_blocks (rename 모드에서는 og_galileo_ 이름)
+func newCometBFTMissedBlocksMetric(name string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name,
+			Help: "Number of missed blocks per validator (CometBFT consensus)",
+		},
+		[]string{"validator", "chain_id"},
+	)
+}
+
+// /all-metrics의 로컬 부분에서 노드 쪽과 합칠 패밀리를 빼는 Gatherer
+type excludeGatherer struct {
+	gatherer prometheus.Gatherer
+	exclude  string
+}
+
+func (g excludeGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	filtered := families[:0]
+	for _, family := range families {
+		if family.GetName() != g.exclude {
+			filtered = append(filtered, family)
+		}
+	}
+	return filtered, err
+}
+
+// /all-metrics의 로컬 부분 Gatherer (node, both 모드에서는 중복 패밀리를 노드 메트릭 쪽에서만 출력)
+func allMetricsLocalGatherer(gatherer prometheus.Gatherer, mode string) prometheus.Gatherer {
+	if mode == metricDedupRename {
+		return gatherer
+	}
+	return excludeGatherer{gatherer: gatherer, exclude: cometbftMissedBlocksName}
+}
+
+// /all-metrics의 로컬 부분을 text 형식으로 씀 (수집 에러가 있어도 모은 패밀리는 출력)
+func writeLocalMetrics(w io.Writer, gatherer prometheus.Gatherer) error {
+	families, err := gatherer.Gather()
+	encoder := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, family := range families {
+		if encodeErr := encoder.Encode(family); encodeErr != nil {
+			return encodeErr
+		}
+	}
+	return err
+}
+
+// 노드 메트릭 본문을 파싱해 로컬과 겹치는 패밀리를 빼고, 중복 패밀리는 모드에 따라 정리해 text 형식으로 씀
+// local은 both 모드에서 합칠 우리 쪽 패밀리를 가져올 Gatherer (팀 필터가 적용된 것)
+func writeNodeMetrics(w io.Writer, body []byte, mode string, local prometheus.Gatherer) error {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("parse node metrics: %w", err)
+	}
+
+	names := make([]string, 0, len(families))
+	for name, family := range families {
+		if hasLocalPrefix(name) {
+			delete(families, name)
+			continue
+		}
+		sanitizeFamilyLabels(family)
+		names = append(names, name)
+	}
+
+	if mode == metricDedupBoth {
+		merged, err := mergeSourceFamilies(families[cometbftMissedBlocksName], local)
+		if err != nil {
+			return err
+		}
+		if merged != nil {
+			if families[cometbftMissedBlocksName] == nil {
+				names = append(names, cometbftMissedBlocksName)
+			}
+			families[cometbftMissedBlocksName] = merged
+		}
+	}
+
+	return encodeSortedFamilies(w, families, names)
+}
+
+// 업스트림 메트릭 본문을 파싱해 정렬된 순서로 다시 씀 (파싱에 실패하면 본문 그대로)
+func writeSortedMetrics(w io.Writer, body []byte) error {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		w.Write(body)
+		return fmt.Errorf("parse metrics: %w", err)
+	}
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	return encodeSortedFamilies(w, families, names)
+}
+
+// 배포 전후 스냅샷을 diff할 수 있도록 패밀리는 이름 순, 샘플은 라벨 순으로 출력
+// 상태가 같으면 출력도 바이트 단위로 같고, 달라지는 것은 값(카운터, 시각, 업스트림이 바꾼 값)뿐
+func encodeSortedFamilies(w io.Writer, families map[string]*dto.MetricFamily, names []string) error {
+	sort.Strings(names)
+	encoder := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, name := range names {
+		family := families[name]
+		sortFamilyMetrics(family)
+		if err := encoder.Encode(family); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 각 샘플의 라벨을 이름 순으로, 샘플을 라벨 값 순으로 정렬 (같으면 타임스탬프 순)
+func sortFamilyMetrics(family *dto.MetricFamily) {
+	for _, metric := range family.Metric {
+		sort.Slice(metric.Label, func(i, j int) bool { return metric.Label[i].GetName() < metric.Label[j].GetName() })
+	}
+	sort.SliceStable(family.Metric, func(i, j int) bool {
+		a, b := family.Metric[i].Label, family.Metric[j].Label
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k].GetName() != b[k].GetName() {
+				return a[k].GetName() < b[k].GetName()
+			}
+			if a[k].GetValue() != b[k].GetValue() {
+				return a[k].GetValue() < b[k].GetValue()
+			}
+		}
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return family.Metric[i].GetTimestampMs() < family.Metric[j].GetTimestampMs()
+	})
+}
+
+func hasLocalPrefix(name string) bool {
+	for _, prefix := range localMetricPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// 체인에서 온 라벨 값(피어 모니커 등) 정리
+func sanitizeFamilyLabels(family *dto.MetricFamily) {
+	for _, metric := range family.Metric {
+		for _, pair := range metric.Label {
+			if pair.Value != nil {
+				value := sanitizeOptionalLabel(pair.GetValue())
+				pair.Value = &value
+			}
+		}
+	}
+}
+
+// 노드 패밀리와 로컬 패밀리를 source 라벨로 구분해 하나로 합침 (타입이 다르면 노드 쪽만 유지)
+func mergeSourceFamilies(node *dto.MetricFamily, local prometheus.Gatherer) (*dto.MetricFamily, error) {
+	localFamilies, err := local.Gather()
+	if err != nil {
+		return nil, err
+	}
+	var ours *dto.MetricFamily
+	for _, family := range localFamilies {
+		if family.GetName() == cometbftMissedBlocksName {
+			ours = family
+			break
+		}
+	}
+
+	switch {
+	case node == nil && ours == nil:
+		return nil, nil
+	case node == nil:
+		node = &dto.MetricFamily{Name: ours.Name, Help: ours.Help, Type: ours.Type}
+	case ours != nil && ours.GetType() != node.GetType():
+		aggregatorLog.Debug("Metric type differs from node, keeping node family only", "metric", cometbftMissedBlocksName,
+			"local", ours.GetType(), "node", node.GetType())
+		ours = nil
+	}
+
+	merged := &dto.MetricFamily{Name: node.Name, Help: node.Help, Type: node.Type}
+	for _, metric := range node.Metric {
+		merged.Metric = append(merged.Metric, withSourceLabel(metric, "node"))
+	}
+	if ours != nil {
+		for _, metric := range ours.Metric {
+			merged.Metric = append(merged.Metric, withSourceLabel(metric, "exporter"))
+		}
+	}
+	return merged, nil
+}
+
+// source 라벨을 추가한 새 메트릭 (값은 원본과 공유, 라벨은 이름 순, 이미 source가 있으면 덮어씀)
+func withSourceLabel(metric *dto.Metric, source string) *dto.Metric {
+	labels := make([]*dto.LabelPair, 0, len(metric.Label)+1)
+	for _, pair := range metric.Label {
+		if pair.GetName() != metricSourceLabel {
+			labels = append(labels, pair)
+		}
+	}
+	name := metricSourceLabel
+	labels = append(labels, &dto.LabelPair{Name: &name, Value: &source})
+	sort.Slice(labels, func(i, j int) bool { return labels[i].GetName() < labels[j].GetName() })
+	return &dto.Metric{
+		Label:       labels,
+		Gauge:       metric.Gauge,
+		Counter:     metric.Counter,
+		Summary:     metric.Summary,
+		Untyped:     metric.Untyped,
+		Histogram:   metric.Histogram,
+		TimestampMs: metric.TimestampMs,
+	}
+}
+
+// /all-metrics: 로컬 메트릭 뒤에 Node Exporter와 0G 노드 메트릭을 이어 붙임 (팀이면 로컬 부분은 팀의 시리즈만)
+// 로컬 메트릭은 리스너 종류(unix 소켓 포함)와 무관하게 프로세스 내에서 직접 수집
+func (vt *UnifiedValidatorTracker) allMetricsHandler(gatherer prometheus.Gatherer, dedupMode string, nodeExporter, ogNode *MetricSource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		// 1. Prometheus 메트릭 (cosmos-validator-watcher + 커스텀 메트릭, 레지스트리가 이름과 라벨 순으로 정렬)
+		// 뒤에 text 형식 본문을 이어 붙이므로 Accept 헤더(protobuf 등)와 무관하게 항상 text로 씀
+		localGatherer := allMetricsLocalGatherer(gatherer, dedupMode)
+		if tenant := tenantFromContext(r.Context()); tenant != nil {
+			localGatherer = tenantGatherer{gatherer: localGatherer, tenant: tenant}
+		}
+		if err := writeLocalMetrics(w, localGatherer); err != nil {
+			aggregatorLog.Warn("Error gathering local metrics, writing the families gathered so far", "error", err)
+		}
+
+		// 2. Node Exporter 메트릭 추가 (시스템 메트릭만)
+		nodeMetrics, err := vt.scrapeSource(r.Context(), nodeExporter)
+		if err == nil {
+			w.Write([]byte("\n# Node Exporter Metrics\n"))
+			if err := writeSortedMetrics(w, nodeMetrics); err != nil {
+				aggregatorLog.Warn("Failed to parse Node Exporter metrics, passing through unsorted", "endpoint", sanitizeEndpoint(nodeExporter.URL), "error", err)
+			}
+		} else {
+			aggregatorLog.Warn("Failed to fetch Node Exporter metrics", "endpoint", sanitizeEndpoint(nodeExporter.URL), "error", err)
+		}
+
+		// 3. 0G 노드 메트릭 추가 (CometBFT 메트릭만, 로컬과 겹치는 패밀리 제거)
+		// both 모드에서 합칠 우리 쪽 메트릭도 팀 필터를 따름
+		local := gatherer
+		if tenant := tenantFromContext(r.Context()); tenant != nil {
+			local = tenantGatherer{gatherer: local, tenant: tenant}
+		}
+		aggregatorLog.Debug("Fetching 0G node metrics", "endpoint", sanitizeEndpoint(ogNode.URL))
+		body, err := vt.scrapeSource(r.Context(), ogNode)
+		if err == nil {
+			w.Write([]byte("\n# 0G Galileo Node Metrics (CometBFT)\n"))
+			if err := writeNodeMetrics(w, body, dedupMode, local); err != nil {
+				aggregatorLog.Warn("Failed to merge 0G node metrics", "endpoint", sanitizeEndpoint(ogNode.URL), "error", err)
+			}
+			aggregatorLog.Debug("Fetched 0G node metrics", "endpoint", sanitizeEndpoint(ogNode.URL), "bytes", len(body))
+		} else {
+			aggregatorLog.Warn("Failed to fetch 0G node metrics", "endpoint", sanitizeEndpoint(ogNode.URL), "error", err)
+			// 에러가 발생해도 기본 메트릭은 계속 제공
+			w.Write([]byte("\n# 0G Galileo Node Metrics (CometBFT) - UNAVAILABLE\n"))
+			w.Write([]byte("# Error: Unable to connect to 0G node metrics endpoint\n"))
+			// both 모드에서는 노드 쪽 없이 우리 메트릭만 source=exporter로 출력
+			if dedupMode == metricDedupBoth {
+				writeNodeMetrics(w, nil, dedupMode, local)
+			}
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// 로컬 부분은 노드 메트릭 본문과 이어 붙으므로 항상 text 형식이어야 함
+func TestWriteLocalMetricsText(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	signed := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "og_galileo_validator_status", Help: "status"}, []string{"validator"})
+	reg.MustRegister(signed)
+	signed.WithLabelValues("alpha").Set(1)
+	signed.WithLabelValues("beta").Set(0)
+
+	var out bytes.Buffer
+	if err := writeLocalMetrics(&out, reg); err != nil {
+		t.Fatal(err)
+	}
+	out.WriteString("\n# 0G Galileo Node Metrics (CometBFT)\ncometbft_consensus_height 42\n")
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(out.String()))
+	if err != nil {
+		t.Fatalf("combined body is not valid text exposition: %v\n%s", err, out.String())
+	}
+	if got := len(families["og_galileo_validator_status"].GetMetric()); got != 2 {
+		t.Errorf("og_galileo_validator_status series = %d, want 2", got)
+	}
+	if families["cometbft_consensus_height"] == nil {
+		t.Error("node metrics lost after the local section")
+	}
+}
+
+func TestWriteLocalMetricsExcludesDuplicateFamily(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	missed := newCometBFTMissedBlocksMetric(cometbftMissedBlocksName)
+	reg.MustRegister(missed)
+	missed.WithLabelValues("alpha", "zgtendermint_16601-2").Set(3)
+
+	var out bytes.Buffer
+	if err := writeLocalMetrics(&out, excludeGatherer{gatherer: reg, exclude: cometbftMissedBlocksName}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), cometbftMissedBlocksName) {
+		t.Errorf("excluded family written:\n%s", out.String())
+	}
+}
+
+// 노드가 보내는 본문 (로컬과 겹치는 런타임, 네임스페이스 패밀리와 중복 후보인 missed_blocks 포함)
+const nodeMetricsBody = `# HELP cometbft_consensus_height Height of the chain.
+# TYPE cometbft_consensus_height gauge
+cometbft_consensus_height{chain_id="zgtendermint_16601-2"} 42
+# HELP cometbft_consensus_validator_missed_blocks Total missed blocks for a validator.
+# TYPE cometbft_consensus_validator_missed_blocks gauge
+cometbft_consensus_validator_missed_blocks{chain_id="zgtendermint_16601-2",validator_address="ABCD"} 7
+# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 12
+# HELP og_galileo_validator_status Node side copy.
+# TYPE og_galileo_validator_status gauge
+og_galileo_validator_status{validator="alpha"} 5
+`
+
+// /all-metrics 핸들러와 같은 순서로 로컬 부분과 노드 부분을 씀 (노드 본문이 nil이면 노드 조회 실패)
+func writeAllMetricsForTest(t *testing.T, mode string, node []byte) string {
+	t.Helper()
+	missedName := cometbftMissedBlocksName
+	if mode == metricDedupRename {
+		missedName = renamedMissedBlocksName
+	}
+	reg := prometheus.NewRegistry()
+	missed := newCometBFTMissedBlocksMetric(missedName)
+	status := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "og_galileo_validator_status", Help: "status"}, []string{"validator"})
+	reg.MustRegister(missed, status, prometheus.NewGoCollector())
+	missed.WithLabelValues("alpha", "zgtendermint_16601-2").Set(3)
+	status.WithLabelValues("alpha").Set(1)
+
+	var out bytes.Buffer
+	if err := writeLocalMetrics(&out, allMetricsLocalGatherer(reg, mode)); err != nil {
+		t.Fatal(err)
+	}
+	out.WriteString("\n# 0G Galileo Node Metrics (CometBFT)\n")
+	if node != nil || mode == metricDedupBoth {
+		if err := writeNodeMetrics(&out, node, mode, reg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return out.String()
+}
+
+// 모드마다 각 패밀리는 본문 전체에서 정확히 한 번만 정의되어야 함 (두 번이면 Prometheus가 스크레이프를 거부)
+func TestAllMetricsSingleFamilyDefinition(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   string
+		node   []byte
+		want   map[string]int // 패밀리별 샘플 수
+		absent []string
+	}{
+		{"node", metricDedupNode, []byte(nodeMetricsBody),
+			map[string]int{cometbftMissedBlocksName: 1, "cometbft_consensus_height": 1, "og_galileo_validator_status": 1},
+			[]string{renamedMissedBlocksName}},
+		{"rename", metricDedupRename, []byte(nodeMetricsBody),
+			map[string]int{cometbftMissedBlocksName: 1, renamedMissedBlocksName: 1, "cometbft_consensus_height": 1, "og_galileo_validator_status": 1},
+			nil},
+		{"both", metricDedupBoth, []byte(nodeMetricsBody),
+			map[string]int{cometbftMissedBlocksName: 2, "cometbft_consensus_height": 1, "og_galileo_validator_status": 1},
+			[]string{renamedMissedBlocksName}},
+		{"both without node", metricDedupBoth, nil,
+			map[string]int{cometbftMissedBlocksName: 1, "og_galileo_validator_status": 1},
+			[]string{renamedMissedBlocksName}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := writeAllMetricsForTest(t, tt.mode, tt.node)
+
+			definitions := make(map[string]int)
+			for _, line := range strings.Split(body, "\n") {
+				if fields := strings.Fields(line); len(fields) >= 3 && fields[0] == "#" && fields[1] == "TYPE" {
+					definitions[fields[2]]++
+				}
+			}
+			for name, count := range definitions {
+				if count != 1 {
+					t.Errorf("%s defined %d times", name, count)
+				}
+			}
+
+			var parser expfmt.TextParser
+			families, err := parser.TextToMetricFamilies(strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("body is not valid text exposition: %v\n%s", err, body)
+			}
+			for name, samples := range tt.want {
+				if got := len(families[name].GetMetric()); got != samples {
+					t.Errorf("%s samples = %d, want %d", name, got, samples)
+				}
+			}
+			for _, name := range tt.absent {
+				if families[name] != nil {
+					t.Errorf("%s exported in %s mode", name, tt.mode)
+				}
+			}
+			// 로컬과 겹치는 노드 쪽 값은 빠짐
+			for _, metric := range families["og_galileo_validator_status"].GetMetric() {
+				if metric.GetGauge().GetValue() != 1 {
+					t.Errorf("node copy of og_galileo_validator_status leaked: %v", metric)
+				}
+			}
+			if tt.mode == metricDedupBoth {
+				sources := make(map[string]bool)
+				for _, metric := range families[cometbftMissedBlocksName].GetMetric() {
+					for _, pair := range metric.GetLabel() {
+						if pair.GetName() == metricSourceLabel {
+							sources[pair.GetValue()] = true
+						}
+					}
+				}
+				if !sources["exporter"] || (tt.node != nil) != sources["node"] {
+					t.Errorf("source labels = %v", sources)
+				}
+			}
+		})
+	}
+}
+
+// 같은 샘플을 다른 순서로 내보내는 업스트림 본문 두 개 (node_exporter는 수집기 순서에 따라 순서가 바뀜)
+const (
+	unsortedNodeExporterBody = `# HELP node_load1 1m load average.
+# TYPE node_load1 gauge
+node_load1 0.5
+# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+# TYPE node_cpu_seconds_total counter
+node_cpu_seconds_total{mode="user",cpu="1"} 20
+node_cpu_seconds_total{cpu="0",mode="user"} 10
+node_cpu_seconds_total{cpu="0",mode="idle"} 100
+`
+	reorderedNodeExporterBody = `# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+# TYPE node_cpu_seconds_total counter
+node_cpu_seconds_total{cpu="0",mode="idle"} 100
+node_cpu_seconds_total{cpu="1",mode="user"} 20
+node_cpu_seconds_total{mode="user",cpu="0"} 10
+# HELP node_load1 1m load average.
+# TYPE node_load1 gauge
+node_load1 0.5
+`
+	sortedNodeExporterGolden = `# HELP node_cpu_seconds_total Seconds the CPUs spent in each mode.
+# TYPE node_cpu_seconds_total counter
+node_cpu_seconds_total{cpu="0",mode="idle"} 100
+node_cpu_seconds_total{cpu="0",mode="user"} 10
+node_cpu_seconds_total{cpu="1",mode="user"} 20
+# HELP node_load1 1m load average.
+# TYPE node_load1 gauge
+node_load1 0.5
+`
+)
+
+func TestWriteSortedMetricsGolden(t *testing.T) {
+	for _, body := range []string{unsortedNodeExporterBody, reorderedNodeExporterBody} {
+		for i := 0; i < 2; i++ {
+			var out bytes.Buffer
+			if err := writeSortedMetrics(&out, []byte(body)); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != sortedNodeExporterGolden {
+				t.Fatalf("sorted output differs from golden:\n%s", out.String())
+			}
+		}
+	}
+}
+
+// 상태가 같으면 /all-metrics 본문 전체가 바이트 단위로 같아야 함
+// 남는 비결정성은 값 자체뿐: 카운터 증가, 수집 시각, go_/process_ 런타임 메트릭, 업스트림이 바꾼 값
+// 그래서 이 테스트는 런타임 수집기 없이 고정된 값만 등록함
+func TestAllMetricsDeterministic(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	missed := newCometBFTMissedBlocksMetric(cometbftMissedBlocksName)
+	signed := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "og_galileo_validator_beacon_block_signed", Help: "signed"},
+		[]string{"validator", "block_height"})
+	reg.MustRegister(missed, signed)
+	for _, name := range []string{"gamma", "alpha", "beta"} {
+		missed.WithLabelValues(name, "zgtendermint_16601-2").Set(1)
+		for _, height := range []string{"12", "10", "11"} {
+			signed.WithLabelValues(name, height).Set(1)
+		}
+	}
+
+	write := func(node string) string {
+		var out bytes.Buffer
+		if err := writeLocalMetrics(&out, allMetricsLocalGatherer(reg, metricDedupBoth)); err != nil {
+			t.Fatal(err)
+		}
+		out.WriteString("\n# Node Exporter Metrics\n")
+		if err := writeSortedMetrics(&out, []byte(node)); err != nil {
+			t.Fatal(err)
+		}
+		out.WriteString("\n# 0G Galileo Node Metrics (CometBFT)\n")
+		if err := writeNodeMetrics(&out, []byte(nodeMetricsBody), metricDedupBoth, reg); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	first := write(unsortedNodeExporterBody)
+	for i := 0; i < 5; i++ {
+		if got := write(reorderedNodeExporterBody); got != first {
+			t.Fatalf("output changed between gathers:\n--- first\n%s\n--- now\n%s", first, got)
+		}
+	}
+}
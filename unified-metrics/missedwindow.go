@@ -0,0 +1,228 @@
+package main
+
+import (
+	"sort"
+	"sync"
+)
+
+// 슬래싱 파라미터를 받기 전에 쓰는 서명 윈도우 크기 (x/slashing 기본값)
+const defaultSignedBlocksWindow = 100
+
+// 셋의 2/3 이상이 서명한 블록에서 누락하면 solo 누락 (네트워크 전체 문제가 아닌 누락)
+const soloMissParticipation = 2.0 / 3
+
+// 윈도우에 기록하는 블록별 서명 상태
+type windowStatus uint8
+
+const (
+	windowSigned windowStatus = iota + 1
+	windowMissed
+	windowSoloMissed
+)
+
+// 윈도우의 한 블록 (height가 0이면 빈 슬롯)
+type windowSlot struct {
+	height   int64
+	statuses map[string]windowStatus
+}
+
+// 상태 파일에 저장하는 윈도우 (블록은 높이 순)
+type MissedWindowSnapshot struct {
+	Size   int                 `json:"size"`
+	Blocks []MissedWindowBlock `json:"blocks"`
+}
+
+type MissedWindowBlock struct {
+	Height int64    `json:"height"`
+	Signed []string `json:"signed,omitempty"`
+	Missed []string `json:"missed,omitempty"`
+	Solo   []string `json:"solo,omitempty"` // Missed 중 solo 누락
+}
+
+// 최근 signed_blocks_window 높이의 벨리데이터별 서명/누락 수
+// 슬롯은 height % size로 정하므로 건너뛴 높이는 빈 슬롯으로 남고, 캐치업처럼 순서가 뒤바뀐 높이도 윈도우 안이면 반영
+type MissedBlockWindow struct {
+	mu     sync.Mutex
+	slots  []windowSlot
+	newest int64
+	signed map[string]int
+	missed map[string]int
+	solo   map[string]int
+}
+
+func NewMissedBlockWindow(size int) *MissedBlockWindow {
+	w := &MissedBlockWindow{}
+	w.reset(size)
+	return w
+}
+
+func (w *MissedBlockWindow) reset(size int) {
+	w.slots = make([]windowSlot, max(size, 1))
+	w.newest = 0
+	w.signed = make(map[string]int)
+	w.missed = make(map[string]int)
+	w.solo = make(map[string]int)
+}
+
+func (w *MissedBlockWindow) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.slots)
+}
+
+// 한 블록의 결과 반영 (윈도우보다 오래됐거나 이미 반영한 높이면 false)
+func (w *MissedBlockWindow) Observe(height int64, statuses map[string]windowStatus) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.observeLocked(height, statuses)
+}
+
+func (w *MissedBlockWindow) observeLocked(height int64, statuses map[string]windowStatus) bool {
+	size := int64(len(w.slots))
+	if height <= 0 || height <= w.newest-size {
+		return false
+	}
+	if height > w.newest {
+		// 윈도우에서 밀려나는 높이의 슬롯 비움
+		for h := max(w.newest+1, height-size+1); h <= height; h++ {
+			w.evict(&w.slots[h%size])
+		}
+		w.newest = height
+	}
+	slot := &w.slots[height%size]
+	if slot.height == height {
+		return false
+	}
+	w.evict(slot)
+	slot.height = height
+	slot.statuses = statuses
+	for validator, status := range statuses {
+		w.count(validator, status, 1)
+	}
+	return true
+}
+
+func (w *MissedBlockWindow) evict(slot *windowSlot) {
+	for validator, status := range slot.statuses {
+		w.count(validator, status, -1)
+	}
+	*slot = windowSlot{}
+}
+
+func (w *MissedBlockWindow) count(validator string, status windowStatus, delta int) {
+	switch status {
+	case windowSigned:
+		w.signed[validator] += delta
+	case windowSoloMissed:
+		w.solo[validator] += delta
+		w.missed[validator] += delta
+	case windowMissed:
+		w.missed[validator] += delta
+	}
+}
+
+// 윈도우 크기 변경 (슬래싱 파라미터 갱신 시, 새 윈도우에 들어가는 최근 블록은 유지)
+func (w *MissedBlockWindow) Resize(size int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if size <= 0 || size == len(w.slots) {
+		return
+	}
+	slots := w.slots
+	w.reset(size)
+	for _, slot := range slots {
+		if slot.height > 0 {
+			w.observeLocked(slot.height, slot.statuses)
+		}
+	}
+}
+
+// validator의 윈도우 내 서명, 누락, solo 누락 수 (윈도우에 기록이 없으면 ok=false)
+func (w *MissedBlockWindow) Counts(validator string) (signed, missed, solo int, ok bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	signed, missed, solo = w.signed[validator], w.missed[validator], w.solo[validator]
+	return signed, missed, solo, signed+missed > 0
+}
+
+func (w *MissedBlockWindow) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.reset(len(w.slots))
+}
+
+func (w *MissedBlockWindow) Export() *MissedWindowSnapshot {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	snapshot := &MissedWindowSnapshot{Size: len(w.slots), Blocks: []MissedWindowBlock{}}
+	for _, slot := range w.slots {
+		if slot.height == 0 {
+			continue
+		}
+		block := MissedWindowBlock{Height: slot.height}
+		for validator, status := range slot.statuses {
+			switch status {
+			case windowSigned:
+				block.Signed = append(block.Signed, validator)
+			case windowSoloMissed:
+				block.Solo = append(block.Solo, validator)
+				block.Missed = append(block.Missed, validator)
+			case windowMissed:
+				block.Missed = append(block.Missed, validator)
+			}
+		}
+		sort.Strings(block.Signed)
+		sort.Strings(block.Missed)
+		sort.Strings(block.Solo)
+		snapshot.Blocks = append(snapshot.Blocks, block)
+	}
+	sort.Slice(snapshot.Blocks, func(i, j int) bool { return snapshot.Blocks[i].Height < snapshot.Blocks[j].Height })
+	return snapshot
+}
+
+// 저장된 윈도우로 교체 (크기는 슬래싱 파라미터를 다시 받을 때까지 저장된 값 사용)
+func (w *MissedBlockWindow) Import(snapshot *MissedWindowSnapshot) {
+	if snapshot == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	size := snapshot.Size
+	if size <= 0 {
+		size = len(w.slots)
+	}
+	w.reset(size)
+	for _, block := range snapshot.Blocks {
+		statuses := make(map[string]windowStatus, len(block.Signed)+len(block.Missed))
+		for _, validator := range block.Signed {
+			statuses[validator] = windowSigned
+		}
+		for _, validator := range block.Missed {
+			statuses[validator] = windowMissed
+		}
+		for _, validator := range block.Solo {
+			statuses[validator] = windowSoloMissed
+		}
+		w.observeLocked(block.Height, statuses)
+	}
+}
+
+// 추적 벨리데이터의 윈도우 메트릭 갱신 (윈도우에 기록이 없는 벨리데이터는 건너뜀)
+func (vt *UnifiedValidatorTracker) updateMissedWindowMetrics() {
+	for _, label := range vt.validators {
+		_, missed, solo, ok := vt.missedWindow.Counts(label)
+		if !ok {
+			continue
+		}
+		vt.metrics.cosmos.missedBlocksMetric.WithLabelValues(label).Set(float64(missed))
+		vt.metrics.cosmos.missedBlocksWindowMetric.WithLabelValues(label).Set(float64(missed))
+		vt.metrics.cosmos.soloMissedBlocksMetric.WithLabelValues(label).Set(float64(solo))
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 기본 반감기: 100블록 전의 누락은 현재 누락의 절반 가중치
+const defaultMissRateHalfLife = 100
+
+// 반감기(블록 수)를 EWMA 계수로 변환
+func alphaFromHalfLife(halfLife float64) float64 {
+	return 1 - math.Pow(0.5, 1/halfLife)
+}
+
+// MISS_RATE_EWMA_ALPHA가 있으면 우선, 없으면 MISS_RATE_EWMA_HALF_LIFE(블록)로 계산
+func missRateAlphaFromEnv() (float64, error) {
+	if alpha := getEnvFloat("MISS_RATE_EWMA_ALPHA", 0); alpha != 0 {
+		if alpha <= 0 || alpha > 1 {
+			return 0, fmt.Errorf("MISS_RATE_EWMA_ALPHA must be in (0, 1], got %g", alpha)
+		}
+		return alpha, nil
+	}
+	halfLife := getEnvFloat("MISS_RATE_EWMA_HALF_LIFE", defaultMissRateHalfLife)
+	if halfLife <= 0 {
+		return 0, fmt.Errorf("MISS_RATE_EWMA_HALF_LIFE must be positive, got %g", halfLife)
+	}
+	return alphaFromHalfLife(halfLife), nil
+}
+
+// 벨리데이터별 블록 누락 지표(누락 1, 서명 0)의 지수 가중 이동 평균
+type MissRateEWMA struct {
+	mu     sync.Mutex
+	alpha  float64
+	values map[string]float64
+}
+
+func NewMissRateEWMA(alpha float64) *MissRateEWMA {
+	return &MissRateEWMA{alpha: alpha, values: make(map[string]float64)}
+}
+
+// 한 블록의 결과를 반영하고 갱신된 값을 반환 (첫 관측 전 값은 0으로 간주)
+func (m *MissRateEWMA) Observe(validator string, missed bool) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	x := 0.0
+	if missed {
+		x = 1
+	}
+	value := m.values[validator] + m.alpha*(x-m.values[validator])
+	m.values[validator] = value
+	return value
+}
+
+// 현재 값 (관측한 적이 없으면 ok=false)
+func (m *MissRateEWMA) Value(validator string) (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value, ok := m.values[validator]
+	return value, ok
+}
+
+// 정렬된 값의 nearest-rank 백분위수
+func percentileDuration(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// 연속된 누락 사이 간격 (누락 시각은 시간 순서)
+func missIntervals(missTimes []time.Time) []time.Duration {
+	if len(missTimes) < 2 {
+		return nil
+	}
+	intervals := make([]time.Duration, 0, len(missTimes)-1)
+	for i := 1; i < len(missTimes); i++ {
+		intervals = append(intervals, missTimes[i].Sub(missTimes[i-1]))
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	return intervals
+}
+
+// 히스토리 보관 기간의 누락 간격 p95 갱신 (간격이 없으면 시리즈 삭제)
+func (vt *UnifiedValidatorTracker) updateMissIntervals() {
+	missTimes := vt.history.MissTimes()
+	for _, label := range vt.validators {
+		intervals := missIntervals(missTimes[label])
+		if len(intervals) == 0 {
+			vt.metrics.custom.missIntervalP95Metric.DeleteLabelValues(label)
+			continue
+		}
+		p95 := percentileDuration(intervals, 0.95)
+		vt.metrics.custom.missIntervalP95Metric.WithLabelValues(label).Set(p95.Seconds())
+	}
+}
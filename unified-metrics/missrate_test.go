@@ -0,0 +1,188 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMissRateEWMAScripted(t *testing.T) {
+	ewma := NewMissRateEWMA(0.5)
+	if _, ok := ewma.Value("alpha"); ok {
+		t.Fatal("value reported before the first observation")
+	}
+
+	// 누락 1, 서명 0: v += 0.5 × (x - v)
+	script := []struct {
+		missed bool
+		want   float64
+	}{
+		{true, 0.5},
+		{true, 0.75},
+		{false, 0.375},
+		{true, 0.6875},
+		{false, 0.34375},
+		{false, 0.171875},
+	}
+	for i, step := range script {
+		if got := ewma.Observe("alpha", step.missed); got != step.want {
+			t.Fatalf("step %d: Observe = %v, want %v", i, got, step.want)
+		}
+	}
+	if got, ok := ewma.Value("alpha"); !ok || got != 0.171875 {
+		t.Errorf("Value = %v, %v", got, ok)
+	}
+
+	// 벨리데이터별로 독립
+	if got := ewma.Observe("beta", false); got != 0 {
+		t.Errorf("beta after one signed block = %v, want 0", got)
+	}
+}
+
+// 반감기만큼 서명하면 누락 지표가 절반으로 줄어듦
+func TestMissRateHalfLife(t *testing.T) {
+	for _, halfLife := range []float64{1, 10, 100} {
+		ewma := NewMissRateEWMA(alphaFromHalfLife(halfLife))
+		for i := 0; i < 50; i++ {
+			ewma.Observe("alpha", true)
+		}
+		start, _ := ewma.Value("alpha")
+		var got float64
+		for i := 0; i < int(halfLife); i++ {
+			got = ewma.Observe("alpha", false)
+		}
+		if math.Abs(got-start/2) > 1e-12 {
+			t.Errorf("half-life %v: %v after %v signed blocks, want %v", halfLife, got, halfLife, start/2)
+		}
+	}
+}
+
+func TestMissRateAlphaFromEnv(t *testing.T) {
+	tests := []struct {
+		alpha, halfLife string
+		want            float64
+		wantErr         bool
+	}{
+		{"", "", alphaFromHalfLife(defaultMissRateHalfLife), false},
+		{"", "20", alphaFromHalfLife(20), false},
+		{"0.2", "20", 0.2, false}, // 계수가 반감기보다 우선
+		{"1", "", 1, false},
+		{"1.5", "", 0, true},
+		{"-0.1", "", 0, true},
+		{"", "0", 0, true},
+		{"", "-5", 0, true},
+	}
+	for _, tt := range tests {
+		t.Setenv("MISS_RATE_EWMA_ALPHA", tt.alpha)
+		t.Setenv("MISS_RATE_EWMA_HALF_LIFE", tt.halfLife)
+		got, err := missRateAlphaFromEnv()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("alpha=%q half-life=%q: %v, %v; want %v, error %v", tt.alpha, tt.halfLife, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMissIntervalsAndPercentile(t *testing.T) {
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	var missTimes []time.Time
+	offset := time.Duration(0)
+	// 간격 1s ~ 20s를 섞인 순서로
+	for _, gap := range []int{5, 1, 20, 3, 9, 2, 14, 7, 11, 4, 18, 6, 16, 8, 13, 10, 19, 12, 17, 15} {
+		offset += time.Duration(gap) * time.Second
+		missTimes = append(missTimes, base.Add(offset))
+	}
+	intervals := missIntervals(append([]time.Time{base}, missTimes...))
+	if len(intervals) != 20 || intervals[0] != time.Second || intervals[19] != 20*time.Second {
+		t.Fatalf("intervals = %v, want 1s..20s sorted", intervals)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0.95, 19 * time.Second}, // nearest-rank: ceil(0.95×20) = 19번째
+		{0.5, 10 * time.Second},
+		{1, 20 * time.Second},
+		{0, time.Second},
+	}
+	for _, tt := range tests {
+		if got := percentileDuration(intervals, tt.p); got != tt.want {
+			t.Errorf("p%v = %v, want %v", tt.p*100, got, tt.want)
+		}
+	}
+
+	if got := percentileDuration(nil, 0.95); got != 0 {
+		t.Errorf("percentile of no intervals = %v", got)
+	}
+	if got := missIntervals(missTimes[:1]); got != nil {
+		t.Errorf("single miss intervals = %v, want none", got)
+	}
+}
+
+func TestUpdateMissIntervals(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha", "ADDRBETA": "beta"})
+	base := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	var height int64
+	record := func(validator string, seconds int, signed bool) {
+		height++
+		tracker.history.AddSigning(SigningRecord{Height: height, Time: base.Add(time.Duration(seconds) * time.Second), Validator: validator, Signed: signed})
+	}
+	// alpha: 누락 간격 10s, 30s, 60s / beta: 누락 한 번
+	record("alpha", 0, false)
+	record("alpha", 5, true)
+	record("alpha", 10, false)
+	record("alpha", 40, false)
+	record("alpha", 100, false)
+	record("beta", 100, false)
+
+	tracker.updateMissIntervals()
+	if got := testutil.ToFloat64(tracker.metrics.custom.missIntervalP95Metric.WithLabelValues("alpha")); got != 60 {
+		t.Errorf("alpha p95 = %v, want 60", got)
+	}
+	if n := testutil.CollectAndCount(tracker.metrics.custom.missIntervalP95Metric); n != 1 {
+		t.Errorf("series = %d, want only alpha (beta has a single miss)", n)
+	}
+}
+
+// 수집 경로: 스크립트로 정한 서명/누락 순서가 게이지에 그대로 반영
+func TestMissRateEWMAMetric(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	h.tracker.missRate = NewMissRateEWMA(0.5)
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	// 첫 블록은 서명이 없는 제네시스 커밋이라 모두 누락으로 기록되므로 한 블록 진행한 뒤 시작
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	script := []bool{false, true, true, false, true}
+	for _, missed := range script {
+		h.chain.SetSigning("beta", !missed)
+		if err := h.advance(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// 제네시스 다음 블록(누락으로 기록), 준비 블록(서명), 스크립트 순서
+	var pattern []bool
+	for _, record := range h.tracker.history.Signing("beta", time.Time{}, time.Now().Add(time.Hour)) {
+		pattern = append(pattern, !record.Signed)
+	}
+	if want := append([]bool{true, false}, script...); !reflect.DeepEqual(pattern, want) {
+		t.Fatalf("recorded miss pattern = %v, want %v", pattern, want)
+	}
+
+	// beta: 0.5 → 0.25 → 0.125 → 0.5625 → 0.78125 → 0.390625 → 0.6953125
+	// alpha: 0.5 이후 여섯 블록 연속 서명 → 0.5/64
+	for validator, want := range map[string]float64{"beta": 0.6953125, "alpha": 0.0078125} {
+		if got := h.mustValue("og_galileo_validator_miss_rate_ewma", "validator", validator); got != want {
+			t.Errorf("%s miss rate = %v, want %v", validator, got, want)
+		}
+	}
+}
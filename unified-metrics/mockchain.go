@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"og-galileo-unified-metrics/internal/rpcmock"
+)
+
+// mockchain 서브커맨드: 노드 없이 익스포터를 돌려 볼 수 있도록 가짜 체인을 RPC/REST로 제공
+//
+//	og-galileo-unified-metrics mockchain -listen 127.0.0.1:26657 -validators validator1=21F5...,val2 -miss val2=5
+func runMockChain(args []string) int {
+	fs := flag.NewFlagSet("mockchain", flag.ContinueOnError)
+	listen := fs.String("listen", "127.0.0.1:26657", "listen address (serves CometBFT RPC and Cosmos REST on the same port)")
+	validators := fs.String("validators", "validator1=21F5C524FCA565DD50841FF4B92A7220AA5B0BDD,validator2,validator3",
+		"comma-separated validators, each name or name=CONSENSUS_ADDRESS")
+	blockTime := fs.Duration("block-time", time.Second, "interval between simulated blocks")
+	txs := fs.Int("txs", 2, "transactions per block (fee events in block_results)")
+	miss := fs.String("miss", "", "comma-separated name=N: the validator misses every Nth block")
+	resetAfter := fs.Int64("reset-after", 0, "reset the chain (next chain id revision, height restarts from 1) whenever it reaches this height (0 = never)")
+	signDelay := fs.String("sign-delay", "", "comma-separated name=DURATION: commit signature timestamp lag behind the block time")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	chain := rpcmock.NewChain()
+	for _, entry := range strings.Split(*validators, ",") {
+		name, address, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if name == "" {
+			continue
+		}
+		v := chain.AddValidator(name, strings.TrimSpace(address))
+		slog.Info("Simulated validator", "name", v.Name, "address", v.Address, "operator", v.OperatorAddress)
+	}
+	missEvery := make(map[string]int64)
+	for _, entry := range strings.Split(*miss, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		var every int64
+		if _, err := fmt.Sscanf(value, "%d", &every); err != nil || every <= 0 {
+			fmt.Fprintf(os.Stderr, "invalid -miss entry %q\n", entry)
+			return 2
+		}
+		missEvery[name] = every
+	}
+	for _, entry := range strings.Split(*signDelay, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		delay, err := time.ParseDuration(value)
+		if err != nil || delay < 0 {
+			fmt.Fprintf(os.Stderr, "invalid -sign-delay entry %q\n", entry)
+			return 2
+		}
+		if err := chain.SetSignDelay(name, delay); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -sign-delay entry %q: %v\n", entry, err)
+			return 2
+		}
+	}
+	chain.SetTxsPerBlock(*txs)
+	chain.SetBlockGap(*blockTime)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{Addr: *listen, Handler: chain.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		ticker := time.NewTicker(*blockTime)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				server.Close()
+				return
+			case <-ticker.C:
+				// 다음 블록의 last_commit에 반영될 서명 여부를 먼저 정함
+				next := chain.Height() + 1
+				for name, every := range missEvery {
+					if err := chain.SetSigning(name, next%every != 0); err != nil {
+						slog.Warn("Unknown validator in -miss", "name", name)
+						delete(missEvery, name)
+					}
+				}
+				if *resetAfter > 0 && chain.Height() >= *resetAfter {
+					chainID := nextChainID(chain.ChainID())
+					slog.Info("Resetting simulated chain", "height", chain.Height(), "chain_id", chainID)
+					chain.Reset(chainID)
+					continue
+				}
+				chain.Advance(1)
+			}
+		}
+	}()
+
+	slog.Info("Serving simulated chain", "listen", *listen, "chain_id", chain.ChainID(), "block_time", *blockTime)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		slog.Error("Mock chain server failed", "error", err)
+		return 1
+	}
+	return 0
+}
+
+// 리셋 후 체인 ID: 끝의 리비전 번호를 하나 올림 (zgtendermint_16601-2 → zgtendermint_16601-3)
+func nextChainID(chainID string) string {
+	if i := strings.LastIndex(chainID, "-"); i >= 0 {
+		if n, err := strconv.Atoi(chainID[i+1:]); err == nil {
+			return fmt.Sprintf("%s-%d", chainID[:i], n+1)
+		}
+	}
+	return chainID + "-1"
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	stakingValidatorsPerPage = 200 // 스테이킹 벨리데이터 목록 페이지 크기
+	defaultNetworkTopN       = 10  // 개요 메트릭으로 노출하는 상위 벨리데이터 수
+)
+
+// 상위 N개 지분 비율을 노출하는 N 목록
+var stakeShareTops = []int{5, 10, 20}
+
+// 본딩 지분 분포 지표
+type StakeDistribution struct {
+	Gini     float64         // 0(완전 균등) ~ 1(한 벨리데이터 독점)
+	Nakamoto int             // 합쳐서 지분 1/3을 넘기는 최소 벨리데이터 수
+	TopShare map[int]float64 // 상위 N개가 가진 지분 비율
+}
+
+// 지분 분포 계산 (big.Int로 합산해 정밀도 손실 없이 비율만 float으로 변환)
+func stakeDistribution(tokens []*big.Int) StakeDistribution {
+	dist := StakeDistribution{TopShare: make(map[int]float64, len(stakeShareTops))}
+	n := len(tokens)
+	total := new(big.Int)
+	for _, t := range tokens {
+		total.Add(total, t)
+	}
+	if n == 0 || total.Sign() == 0 {
+		return dist
+	}
+
+	// 내림차순: 나카모토 계수와 상위 지분
+	sorted := append([]*big.Int(nil), tokens...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) > 0 })
+
+	cumulative := new(big.Int)
+	threshold := new(big.Int)
+	for i, t := range sorted {
+		cumulative.Add(cumulative, t)
+		// cumulative > total/3  <=>  3*cumulative > total
+		if dist.Nakamoto == 0 && threshold.Mul(cumulative, big.NewInt(3)).Cmp(total) > 0 {
+			dist.Nakamoto = i + 1
+		}
+		for _, top := range stakeShareTops {
+			if i+1 == top || (i+1 == n && n < top) {
+				dist.TopShare[top], _ = new(big.Rat).SetFrac(cumulative, total).Float64()
+			}
+		}
+	}
+
+	// 지니 계수 (오름차순 i=1..n): sum((2i - n - 1) * x_i) / (n * sum(x))
+	numerator := new(big.Int)
+	term := new(big.Int)
+	for i := n - 1; i >= 0; i-- {
+		rank := int64(n - i) // 오름차순 순위
+		term.Mul(sorted[i], big.NewInt(2*rank-int64(n)-1))
+		numerator.Add(numerator, term)
+	}
+	denominator := new(big.Int).Mul(total, big.NewInt(int64(n)))
+	dist.Gini, _ = new(big.Rat).SetFrac(numerator, denominator).Float64()
+	return dist
+}
+
+// 본딩 벨리데이터 상위 N개의 토큰/커미션과 지분 분포 지표 갱신 (라벨은 순위만 사용)
+func (vt *UnifiedValidatorTracker) updateNetworkOverview(resp *ValidatorResponse) {
+	type entry struct {
+		tokens     *big.Int
+		commission string
+	}
+	var bonded []entry
+	var tokens []*big.Int
+	for _, validator := range resp.Validators {
+		if validator.Status != "BOND_STATUS_BONDED" {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(string(validator.Tokens), 10)
+		if !ok {
+			amount = new(big.Int)
+		}
+		bonded = append(bonded, entry{tokens: amount, commission: validator.Commission.CommissionRates.Rate})
+		tokens = append(tokens, amount)
+	}
+	sort.SliceStable(bonded, func(i, j int) bool { return bonded[i].tokens.Cmp(bonded[j].tokens) > 0 })
+
+	metrics := vt.metrics.cosmos
+	metrics.topTokensMetric.Reset()
+	metrics.topCommissionMetric.Reset()
+	for i := 0; i < len(bonded) && i < vt.networkTopN; i++ {
+		rank := strconv.Itoa(i + 1)
+		tokens, _ := new(big.Float).SetInt(bonded[i].tokens).Float64()
+		metrics.topTokensMetric.WithLabelValues(rank).Set(tokens)
+		if rate, err := strconv.ParseFloat(strings.TrimSpace(bonded[i].commission), 64); err == nil {
+			metrics.topCommissionMetric.WithLabelValues(rank).Set(rate)
+		}
+	}
+
+	dist := stakeDistribution(tokens)
+	metrics.stakeGiniMetric.Set(dist.Gini)
+	metrics.nakamotoMetric.Set(float64(dist.Nakamoto))
+	for top, share := range dist.TopShare {
+		metrics.stakeTopShareMetric.WithLabelValues(strconv.Itoa(top)).Set(share)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func bigTokens(amounts ...string) []*big.Int {
+	tokens := make([]*big.Int, len(amounts))
+	for i, amount := range amounts {
+		tokens[i], _ = new(big.Int).SetString(amount, 10)
+	}
+	return tokens
+}
+
+func equalTokens(n int, amount string) []string {
+	amounts := make([]string, n)
+	for i := range amounts {
+		amounts[i] = amount
+	}
+	return amounts
+}
+
+func TestStakeDistribution(t *testing.T) {
+	tests := []struct {
+		name     string
+		tokens   []string
+		gini     float64
+		nakamoto int
+		top      map[int]float64
+	}{
+		{"empty", nil, 0, 0, map[int]float64{}},
+		{"all zero", []string{"0", "0"}, 0, 0, map[int]float64{}},
+		{"single", []string{"5"}, 0, 1, map[int]float64{5: 1, 10: 1, 20: 1}},
+		{"equal", []string{"1", "1", "1", "1"}, 0, 2, map[int]float64{5: 1, 10: 1, 20: 1}},
+		{"one holds all", []string{"0", "0", "0", "1"}, 0.75, 1, map[int]float64{5: 1, 10: 1, 20: 1}},
+		{"linear", []string{"1", "2", "3", "4"}, 0.25, 1, map[int]float64{5: 1, 10: 1, 20: 1}},
+		// 정확히 1/3은 넘긴 것이 아님
+		{"exactly a third", []string{"1", "1", "1"}, 0, 2, map[int]float64{5: 1, 10: 1, 20: 1}},
+		{"just over a third", []string{"34", "33", "33"}, 1.0 / 150, 1, map[int]float64{5: 1, 10: 1, 20: 1}},
+		{"input order", []string{"10", "40", "20", "30"}, 0.25, 1, map[int]float64{5: 1, 10: 1, 20: 1}},
+		{"25 equal", equalTokens(25, "1000000000000000000000"), 0, 9, map[int]float64{5: 0.2, 10: 0.4, 20: 0.8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dist := stakeDistribution(bigTokens(tt.tokens...))
+			if math.Abs(dist.Gini-tt.gini) > 1e-12 {
+				t.Errorf("gini = %v, want %v", dist.Gini, tt.gini)
+			}
+			if dist.Nakamoto != tt.nakamoto {
+				t.Errorf("nakamoto = %d, want %d", dist.Nakamoto, tt.nakamoto)
+			}
+			if len(dist.TopShare) != len(tt.top) {
+				t.Errorf("top shares = %v, want %v", dist.TopShare, tt.top)
+			}
+			for top, want := range tt.top {
+				if got := dist.TopShare[top]; math.Abs(got-want) > 1e-12 {
+					t.Errorf("top %d share = %v, want %v", top, got, want)
+				}
+			}
+		})
+	}
+}
+
+// 18자리 토큰에서 1 단위 차이도 사라지지 않음 (float64 합산이면 지니 계수가 0이 됨)
+func TestStakeDistributionPrecision(t *testing.T) {
+	dist := stakeDistribution(bigTokens("1000000000000000000000001", "1000000000000000000000000"))
+	if dist.Gini <= 0 {
+		t.Errorf("gini = %v, want a tiny positive value", dist.Gini)
+	}
+	// 정확한 값 1 / (2 * (2x+1))
+	want, _ := new(big.Rat).SetFrac(big.NewInt(1), bigTokens("4000000000000000000000002")[0]).Float64()
+	if dist.Gini != want {
+		t.Errorf("gini = %v, want %v", dist.Gini, want)
+	}
+}
+
+func TestUpdateNetworkOverview(t *testing.T) {
+	h := newTestHarness(t, "alpha")
+	h.tracker.networkTopN = 2
+
+	var validators []string
+	for i, v := range []struct{ status, tokens, rate string }{
+		{"BOND_STATUS_BONDED", "3000000000000000000000", "0.05"},
+		{"BOND_STATUS_BONDED", "1000000000000000000000", "0.10"},
+		{"BOND_STATUS_UNBONDED", "9000000000000000000000", "0.01"}, // 본딩되지 않은 지분은 제외
+		{"BOND_STATUS_BONDED", "2000000000000000000000", "bad"},
+	} {
+		validators = append(validators, fmt.Sprintf(
+			`{"operator_address":"0gvaloper%d","status":%q,"tokens":%q,"commission":{"commission_rates":{"rate":%q}}}`,
+			i, v.status, v.tokens, v.rate))
+	}
+	var resp ValidatorResponse
+	if err := json.Unmarshal([]byte(`{"validators":[`+strings.Join(validators, ",")+`]}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	h.tracker.updateNetworkOverview(&resp)
+
+	if got := h.mustValue("og_galileo_network_top_validator_tokens", "rank", "1"); got != 3e21 {
+		t.Errorf("rank 1 tokens = %v", got)
+	}
+	if got := h.mustValue("og_galileo_network_top_validator_tokens", "rank", "2"); got != 2e21 {
+		t.Errorf("rank 2 tokens = %v", got)
+	}
+	if _, ok := h.value("og_galileo_network_top_validator_tokens", "rank", "3"); ok {
+		t.Error("rank beyond NETWORK_TOP_N exported")
+	}
+	if got := h.mustValue("og_galileo_network_top_validator_commission", "rank", "1"); got != 0.05 {
+		t.Errorf("rank 1 commission = %v", got)
+	}
+	// 파싱할 수 없는 커미션은 건너뜀
+	if _, ok := h.value("og_galileo_network_top_validator_commission", "rank", "2"); ok {
+		t.Error("unparsable commission exported")
+	}
+	if got := h.mustValue("og_galileo_network_nakamoto_coefficient"); got != 1 {
+		t.Errorf("nakamoto = %v, want 1", got)
+	}
+	if got := h.mustValue("og_galileo_network_stake_gini"); math.Abs(got-2.0/9) > 1e-12 {
+		t.Errorf("gini = %v, want 2/9", got)
+	}
+	if got := h.mustValue("og_galileo_network_stake_share_top", "top", "5"); got != 1 {
+		t.Errorf("top 5 share = %v, want 1", got)
+	}
+}
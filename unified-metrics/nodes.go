@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultNodeHealthInterval = 30 * time.Second
+
+// 노드 역할: 서명 데이터는 체인 데이터라 동기화된 아무 노드에서나 읽지만,
+// 노드 상태 메트릭과 알림의 심각도는 역할에 따라 다름
+const (
+	NodeRoleValidator = "validator" // 서명하는 노드 (방화벽 뒤라 RPC가 없을 수 있음)
+	NodeRoleSentry    = "sentry"    // 벨리데이터 앞단 노드
+	NodeRoleReference = "reference" // 비교용 외부/공용 노드
+)
+
+// 노드 상태 (og_galileo_node_health_state 값)
+const (
+	NodeStateHealthy  = "healthy"
+	NodeStateUnsynced = "unsynced" // 응답하지만 따라잡는 중이거나 최고 높이보다 뒤처짐
+	NodeStateDown     = "down"     // /status 조회 실패
+	NodeStateUnknown  = "unknown"  // 직접 접근할 수 없어 확인 불가 (정상과 구분)
+)
+
+var nodeStateValues = map[string]float64{
+	NodeStateHealthy:  2,
+	NodeStateUnsynced: 1,
+	NodeStateDown:     0,
+	NodeStateUnknown:  -1,
+}
+
+// 멀티 노드 구성의 노드 하나
+type NodeConfig struct {
+	Name     string       `json:"name"`
+	Role     string       `json:"role"`
+	RPC      string       `json:"rpc,omitempty"` // 벨리데이터는 생략 가능 (상태 unknown)
+	Interval jsonDuration `json:"interval,omitempty"`
+}
+
+// 노드별 마지막 확인 결과 (/api/status)
+type NodeStatusEntry struct {
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	State     string `json:"state"`
+	Severity  string `json:"severity,omitempty"` // 정상이 아닐 때 역할 기준 심각도
+	Reason    string `json:"reason,omitempty"`
+	Height    int64  `json:"height,omitempty"`
+	HeightLag int64  `json:"height_lag,omitempty"`
+	CheckedAt string `json:"checked_at,omitempty"`
+}
+
+// 역할별 심각도: 벨리데이터 호스트 문제는 critical, 센트리는 warning, 참조 노드는 info
+// (서명 중단 알림은 체인 데이터 기준이라 역할과 관계없이 critical)
+func nodeSeverity(role, state string) string {
+	if state == NodeStateHealthy || state == NodeStateUnknown {
+		return ""
+	}
+	switch role {
+	case NodeRoleValidator:
+		return "critical"
+	case NodeRoleSentry:
+		return "warning"
+	}
+	return "info"
+}
+
+// NODES_FILE(JSON 파일) 또는 NODES(JSON 문자열)에서 노드 목록 읽기
+func nodesFromEnv() ([]NodeConfig, error) {
+	data := []byte(getEnv("NODES", ""))
+	if path := getEnv("NODES_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var nodes []NodeConfig
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf("decode nodes: %w", err)
+	}
+	names := make(map[string]bool)
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Name == "" || node.Role == "" {
+			return nil, fmt.Errorf("node %d: name and role are required", i)
+		}
+		if names[node.Name] {
+			return nil, fmt.Errorf("duplicate node name %q", node.Name)
+		}
+		names[node.Name] = true
+		switch node.Role {
+		case NodeRoleValidator, NodeRoleSentry, NodeRoleReference:
+		default:
+			return nil, fmt.Errorf("node %q: role must be validator, sentry or reference", node.Name)
+		}
+		if node.RPC == "" {
+			if node.Role != NodeRoleValidator {
+				return nil, fmt.Errorf("node %q: rpc is required for role %s", node.Name, node.Role)
+			}
+		} else if err := validateEndpointURL(node.RPC); err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.Name, err)
+		}
+		node.RPC = strings.TrimRight(node.RPC, "/")
+		if node.Interval <= 0 {
+			node.Interval = jsonDuration(defaultNodeHealthInterval)
+		}
+	}
+	return nodes, nil
+}
+
+// RPC에 접근할 수 있는 노드 (RPC_ENDPOINTS가 없을 때 서명 데이터를 읽을 엔드포인트)
+func nodeRPCEndpoints(nodes []NodeConfig) []string {
+	var endpoints []string
+	for _, node := range nodes {
+		if node.RPC != "" {
+			endpoints = append(endpoints, node.RPC)
+		}
+	}
+	return endpoints
+}
+
+// 노드별 고루틴으로 주기적으로 /status 확인 (RPC가 없는 노드는 unknown으로 한 번만 기록)
+func (vt *UnifiedValidatorTracker) StartNodeHealth(ctx context.Context, nodes []NodeConfig, maxLag int64) {
+	for _, node := range nodes {
+		node := node
+		if node.RPC == "" {
+			trackerLog.Info("Node has no direct RPC access, host health is unknown", "node", node.Name, "role", node.Role)
+			vt.setNodeHealth(node, NodeStatusEntry{State: NodeStateUnknown, Reason: "no direct RPC access"})
+			continue
+		}
+		vt.goroutines.Go(componentNodeHealth, func() { vt.runNodeHealth(ctx, node, maxLag) })
+	}
+}
+
+func (vt *UnifiedValidatorTracker) runNodeHealth(ctx context.Context, node NodeConfig, maxLag int64) {
+	trackerLog.Info("Starting node health check", "node", node.Name, "role", node.Role,
+		"rpc", sanitizeEndpoint(node.RPC), "interval", time.Duration(node.Interval))
+	ticker := time.NewTicker(time.Duration(node.Interval))
+	defer ticker.Stop()
+
+	for {
+		vt.checkNodeOnce(ctx, node, maxLag)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) checkNodeOnce(ctx context.Context, node NodeConfig, maxLag int64) {
+	height, catchingUp, err := probeEndpoint(ctx, node.RPC)
+	if ctx.Err() != nil {
+		return
+	}
+
+	entry := NodeStatusEntry{State: NodeStateHealthy, Height: height}
+	switch {
+	case err != nil:
+		entry.State = NodeStateDown
+		entry.Reason = fetchErrorReason(err)
+		rpcLog.Debug("Node status check failed", "node", node.Name, "error", err)
+	case catchingUp:
+		entry.State = NodeStateUnsynced
+		entry.Reason = "catching up"
+	default:
+		// 다른 노드와 exporter가 본 최고 높이 기준
+		entry.HeightLag = max(vt.maxNodeHeight(), vt.LastHeight()) - height
+		if entry.HeightLag > maxLag {
+			entry.State = NodeStateUnsynced
+			entry.Reason = fmt.Sprintf("%d blocks behind", entry.HeightLag)
+		}
+		entry.HeightLag = max(entry.HeightLag, 0)
+	}
+	vt.setNodeHealth(node, entry)
+}
+
+// 노드 상태 기록 후 상태가 바뀌면 메트릭, 이벤트, 알림 (info 심각도는 이벤트만)
+func (vt *UnifiedValidatorTracker) setNodeHealth(node NodeConfig, entry NodeStatusEntry) {
+	entry.Name = node.Name
+	entry.Role = node.Role
+	entry.Severity = nodeSeverity(node.Role, entry.State)
+	entry.CheckedAt = time.Now().UTC().Format(time.RFC3339)
+
+	vt.mu.Lock()
+	previous, seen := vt.nodeHealth[node.Name]
+	vt.nodeHealth[node.Name] = entry
+	vt.mu.Unlock()
+
+	vt.metrics.cosmos.nodeHealthStateMetric.WithLabelValues(node.Name, node.Role).Set(nodeStateValues[entry.State])
+	if node.RPC != "" {
+		vt.metrics.cosmos.nodeHeightLagMetric.WithLabelValues(node.Name, node.Role).Set(float64(entry.HeightLag))
+	}
+
+	// 첫 확인이 정상이면 알리지 않음
+	if (!seen && entry.State == NodeStateHealthy) || (seen && previous.State == entry.State) {
+		return
+	}
+	severity := entry.Severity
+	if entry.State == NodeStateHealthy {
+		severity = nodeSeverity(node.Role, previous.State) // 회복 알림은 직전 장애의 심각도로
+	}
+	message := fmt.Sprintf("%s node %s is %s", node.Role, node.Name, entry.State)
+	if entry.Reason != "" {
+		message += " (" + entry.Reason + ")"
+	}
+	trackerLog.Info("Node health changed", "node", node.Name, "role", node.Role, "state", entry.State,
+		"previous", previous.State, "severity", severity, "reason", entry.Reason)
+	vt.events.Publish(Event{Type: EventNodeHealthChanged, Height: vt.LastHeight(), Message: message})
+	if entry.State == NodeStateUnknown || severity == "" || severity == "info" || len(vt.notifiers) == 0 {
+		return
+	}
+	vt.notifyAsync(Message{Title: fmt.Sprintf("[%s] Node %s %s", severity, node.Name, entry.State), Markdown: message})
+}
+
+// 이벤트 메시지용: 엔드포인트가 NODES의 노드이면 "sentry node sentry-1 at", 아니면 "node"
+func (vt *UnifiedValidatorTracker) nodeDescription(rpc string) string {
+	for _, node := range vt.nodes {
+		if node.RPC != "" && node.RPC == strings.TrimRight(rpc, "/") {
+			return fmt.Sprintf("%s node %s at", node.Role, node.Name)
+		}
+	}
+	return "node"
+}
+
+func (vt *UnifiedValidatorTracker) maxNodeHeight() int64 {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	var height int64
+	for _, entry := range vt.nodeHealth {
+		height = max(height, entry.Height)
+	}
+	return height
+}
+
+// 노드 상태 목록 (이름순)
+func (vt *UnifiedValidatorTracker) nodeHealthSnapshot() []NodeStatusEntry {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	entries := make([]NodeStatusEntry, 0, len(vt.nodeHealth))
+	for _, entry := range vt.nodeHealth {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
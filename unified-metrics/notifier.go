@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// Discord 메시지 본문 최대 길이 (바이트가 아닌 글자 수)
+const discordMaxContent = 2000
+
+// 알림 메시지 (채널별로 마크다운 또는 JSON 페이로드를 선택해 전송)
+type Message struct {
+	Title    string      `json:"title"`
+	Markdown string      `json:"text"`
+	Payload  interface{} `json:"payload,omitempty"`
+}
+
+// 알림 채널 인터페이스
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, msg Message) error
+}
+
+// 일반 웹훅: 메시지 전체를 JSON으로 POST
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+// Discord 웹훅: 마크다운 본문을 content로 전송
+type DiscordNotifier struct {
+	client *http.Client
+	url    string
+}
+
+// Slack 웹훅: 마크다운 본문을 text로 전송
+type SlackNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{client: &http.Client{Timeout: 10 * time.Second}, url: url}
+}
+
+func NewDiscordNotifier(url string) *DiscordNotifier {
+	return &DiscordNotifier{client: &http.Client{Timeout: 10 * time.Second}, url: url}
+}
+
+func NewSlackNotifier(url string) *SlackNotifier {
+	return &SlackNotifier{client: &http.Client{Timeout: 10 * time.Second}, url: url}
+}
+
+func (n *WebhookNotifier) Name() string { return "webhook" }
+func (n *DiscordNotifier) Name() string { return "discord" }
+func (n *SlackNotifier) Name() string   { return "slack" }
+
+func (n *WebhookNotifier) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.client, n.url, msg)
+}
+
+func (n *DiscordNotifier) Notify(ctx context.Context, msg Message) error {
+	content := fmt.Sprintf("**%s**\n%s", msg.Title, msg.Markdown)
+	// 한글 등 멀티바이트 문자 중간에서 자르지 않도록 rune 단위로 자름
+	if utf8.RuneCountInString(content) > discordMaxContent {
+		content = string([]rune(content)[:discordMaxContent-3]) + "..."
+	}
+	return postJSON(ctx, n.client, n.url, map[string]string{"content": content})
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, n.client, n.url, map[string]string{"text": fmt.Sprintf("*%s*\n%s", msg.Title, msg.Markdown)})
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, body interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from %s notifier", resp.StatusCode, url)
+	}
+	return nil
+}
+
+// 환경 변수로 설정된 알림 채널 목록
+func notifiersFromEnv() []Notifier {
+	var notifiers []Notifier
+	if url := getEnv("NOTIFY_WEBHOOK_URL", ""); url != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(url))
+	}
+	if url := getEnv("NOTIFY_DISCORD_WEBHOOK_URL", ""); url != "" {
+		notifiers = append(notifiers, NewDiscordNotifier(url))
+	}
+	if url := getEnv("NOTIFY_SLACK_WEBHOOK_URL", ""); url != "" {
+		notifiers = append(notifiers, NewSlackNotifier(url))
+	}
+	return notifiers
+}
+
+// 모든 채널로 전송 (채널별 실패는 로그만 남김)
+func notifyAll(ctx context.Context, notifiers []Notifier, msg Message) {
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(ctx, msg); err != nil {
+			alertsLog.Error("Error sending notification", "title", msg.Title, "notifier", notifier.Name(), "error", err)
+			continue
+		}
+		alertsLog.Info("Sent notification", "title", msg.Title, "notifier", notifier.Name())
+	}
+}
+
+// 추적 루프를 막지 않도록 알림을 별도 고루틴으로 전송
+func (vt *UnifiedValidatorTracker) notifyAsync(message Message) {
+	vt.goroutines.Go(componentNotifier, func() { notifyAll(context.Background(), vt.notifiers, message) })
+}
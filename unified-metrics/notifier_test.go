@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// 받은 JSON 본문을 기록하는 웹훅 서버
+func newWebhookServer(t *testing.T) (*httptest.Server, *[]map[string]interface{}) {
+	t.Helper()
+	var received []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode webhook body: %v", err)
+		}
+		received = append(received, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+// 긴 한글 리포트도 글자 단위로 잘라 올바른 UTF-8로 전송
+func TestDiscordNotifierTruncatesOnRuneBoundary(t *testing.T) {
+	server, received := newWebhookServer(t)
+	notifier := NewDiscordNotifier(server.URL)
+
+	for _, markdown := range []string{
+		strings.Repeat("가", discordMaxContent),
+		"a" + strings.Repeat("가", discordMaxContent), // 바이트 위치가 문자 경계와 어긋나도록
+	} {
+		if err := notifier.Notify(context.Background(), Message{Title: "일일 리포트", Markdown: markdown}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, body := range *received {
+		content, _ := body["content"].(string)
+		if !utf8.ValidString(content) {
+			t.Errorf("message %d: content is not valid UTF-8", i)
+		}
+		if n := utf8.RuneCountInString(content); n != discordMaxContent {
+			t.Errorf("message %d: content length = %d characters, want %d", i, n, discordMaxContent)
+		}
+		if !strings.HasPrefix(content, "**일일 리포트**\n") || !strings.HasSuffix(content, "가...") {
+			t.Errorf("message %d: content = %q...%q", i, content[:40], content[len(content)-12:])
+		}
+	}
+}
+
+// 한도 이내의 멀티바이트 본문은 자르지 않음 (바이트 수는 한도를 넘어도)
+func TestDiscordNotifierKeepsShortContent(t *testing.T) {
+	server, received := newWebhookServer(t)
+	markdown := strings.Repeat("가", 1000)
+	if err := NewDiscordNotifier(server.URL).Notify(context.Background(), Message{Title: "t", Markdown: markdown}); err != nil {
+		t.Fatal(err)
+	}
+	if got := (*received)[0]["content"]; got != "**t**\n"+markdown {
+		t.Errorf("content truncated: %d characters", utf8.RuneCountInString(got.(string)))
+	}
+}
+
+func TestNotifierPayloads(t *testing.T) {
+	server, received := newWebhookServer(t)
+	msg := Message{Title: "report", Markdown: "- Uptime: 99.00%", Payload: map[string]int{"signed": 99}}
+
+	if err := NewWebhookNotifier(server.URL).Notify(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewSlackNotifier(server.URL).Notify(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+
+	webhook, slack := (*received)[0], (*received)[1]
+	if webhook["title"] != "report" || webhook["text"] != msg.Markdown || webhook["payload"].(map[string]interface{})["signed"] != float64(99) {
+		t.Errorf("webhook body = %v", webhook)
+	}
+	if slack["text"] != "*report*\n- Uptime: 99.00%" {
+		t.Errorf("slack body = %v", slack)
+	}
+}
+
+func TestNotifierErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := NewDiscordNotifier(server.URL).Notify(context.Background(), Message{Title: "t"})
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Errorf("Notify error = %v, want status 429", err)
+	}
+}
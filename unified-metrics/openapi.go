@@ -0,0 +1,277 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// API 라우트 정의 (핸들러 등록과 OpenAPI 문서가 같은 표를 사용)
+type apiRoute struct {
+	Path        string
+	Method      string
+	Summary     string
+	Admin       bool
+	Params      []apiParam
+	RequestBody interface{} // 요청 본문 타입의 zero value (없으면 nil)
+	RequestType string      // 요청 Content-Type (기본 application/json)
+	Response    interface{} // 응답 타입의 zero value
+	ContentType string      // 응답 Content-Type (기본 application/json)
+	Handler     http.HandlerFunc
+}
+
+type apiParam struct {
+	Name        string
+	Type        string // string, integer, boolean
+	Required    bool
+	Description string
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func (vt *UnifiedValidatorTracker) apiRoutes() []apiRoute {
+	return []apiRoute{
+		{
+			Path: "/api/status", Method: http.MethodGet,
+			Summary:  "Exporter and tracked validator status",
+			Response: StatusSummary{},
+			Handler:  vt.handleStatus,
+		},
+		{
+			Path: "/api/targets", Method: http.MethodGet,
+			Summary:  "Everything this instance monitors: chain, endpoints, validators, sources, probes and notifiers",
+			Response: TargetsResponse{},
+			Handler:  vt.handleTargets,
+		},
+		{
+			Path: "/api/metrics/catalog", Method: http.MethodGet,
+			Summary:  "Every metric this exporter can emit with its type, labels, help text, unit and producing collector",
+			Response: MetricCatalogResponse{},
+			Handler:  vt.handleMetricCatalog,
+		},
+		{
+			Path: "/api/heatmap", Method: http.MethodGet,
+			Summary: "Signed and missed block counts per time bucket",
+			Params: []apiParam{
+				{Name: "validator", Type: "string", Required: true, Description: "Validator label"},
+				{Name: "days", Type: "integer", Description: "Number of days to cover (1-7, default 7)"},
+				{Name: "bucket", Type: "string", Description: "Bucket size as a Go duration (default 1h, min 1m)"},
+			},
+			Response: HeatmapResponse{},
+			Handler:  vt.handleHeatmap,
+		},
+		{
+			Path: "/api/report", Method: http.MethodGet,
+			Summary: "Uptime report with per-day breakdown, jail periods and governance participation",
+			Params: []apiParam{
+				{Name: "from", Type: "string", Description: "Start date (YYYY-MM-DD or RFC3339, default 7 days before to)"},
+				{Name: "to", Type: "string", Description: "End date, exclusive (RFC3339, or YYYY-MM-DD to include that whole day; default now)"},
+				{Name: "validator", Type: "string", Description: "Validator label (default all tracked validators)"},
+				{Name: "format", Type: "string", Description: "json (default) or markdown"},
+			},
+			Response: UptimeReport{},
+			Handler:  vt.handleUptimeReport,
+		},
+		{
+			Path: "/api/events", Method: http.MethodGet,
+			Summary: "Persisted event log (jails, bond changes, halts, restarts) with pagination",
+			Params: []apiParam{
+				{Name: "type", Type: "string", Description: "Event type filter"},
+				{Name: "validator", Type: "string", Description: "Validator label filter"},
+				{Name: "from", Type: "string", Description: "Start time (YYYY-MM-DD or RFC3339)"},
+				{Name: "to", Type: "string", Description: "End time, exclusive (RFC3339, or YYYY-MM-DD to include that whole day)"},
+				{Name: "limit", Type: "integer", Description: "Page size (1-1000, default 100)"},
+				{Name: "cursor", Type: "string", Description: "next_cursor from the previous page"},
+			},
+			Response: EventPage{},
+			Handler:  vt.handleEvents,
+		},
+		{
+			Path: "/api/events/stream", Method: http.MethodGet,
+			Summary:     "Server-sent events of validator and chain events",
+			Response:    Event{},
+			ContentType: "text/event-stream",
+			Handler:     vt.handleEventStream,
+		},
+		{
+			Path: "/api/history/export", Method: http.MethodGet,
+			Summary: "Entire signing and event history as NDJSON, resumable with the cursor of the last received line",
+			Params: []apiParam{
+				{Name: "cursor", Type: "string", Description: "Cursor of the last received line"},
+			},
+			Response:    HistoryExportLine{},
+			ContentType: ndjsonContentType,
+			Handler:     vt.handleHistoryExport,
+		},
+		{
+			Path: "/api/history/import", Method: http.MethodPost, Admin: true,
+			Summary:     "Load an NDJSON history export (records that already exist are skipped)",
+			RequestBody: HistoryExportLine{},
+			RequestType: ndjsonContentType,
+			Response:    HistoryImportResponse{},
+			Handler:     vt.handleHistoryImport,
+		},
+		{
+			Path: "/api/state/snapshot", Method: http.MethodGet, Admin: true,
+			Summary:  "Versioned snapshot of all tracker state",
+			Response: TrackerSnapshot{},
+			Handler:  vt.handleSnapshot,
+		},
+		{
+			Path: "/api/state/restore", Method: http.MethodPost, Admin: true,
+			Summary:     "Restore tracker state from a snapshot",
+			RequestBody: TrackerSnapshot{},
+			Response:    RestoreResponse{},
+			Handler:     vt.handleRestore,
+		},
+		{
+			Path: "/api/labels/rename", Method: http.MethodPost, Admin: true,
+			Summary:     "Move a validator label's persisted state and history to a new label and delete the old label's series",
+			RequestBody: LabelRename{},
+			Response:    LabelRenameResponse{},
+			Handler:     vt.handleLabelRename,
+		},
+		{
+			Path: "/api/selftest", Method: http.MethodPost, Admin: true,
+			Summary:     "Apply a synthetic block to a shadow copy of the tracker and return the resulting metric changes (real metrics are untouched)",
+			RequestBody: SelfTestRequest{},
+			Response:    SelfTestResponse{},
+			Handler:     vt.handleSelfTest,
+		},
+		{
+			Path: "/api/openapi.json", Method: http.MethodGet,
+			Summary:  "OpenAPI 3 document describing this API",
+			Response: map[string]interface{}{},
+			Handler:  vt.handleOpenAPI,
+		},
+	}
+}
+
+// 라우트 표로부터 OpenAPI 3 문서 생성
+func (vt *UnifiedValidatorTracker) openAPIDocument() map[string]interface{} {
+	paths := make(map[string]interface{})
+	for _, route := range vt.apiRoutes() {
+		contentType := route.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+
+		operation := map[string]interface{}{
+			"summary": route.Summary,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+					"content":     map[string]interface{}{contentType: map[string]interface{}{"schema": jsonSchema(reflect.TypeOf(route.Response))}},
+				},
+				"default": map[string]interface{}{
+					"description": "Error",
+					"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": jsonSchema(reflect.TypeOf(apiError{}))}},
+				},
+			},
+		}
+		if route.Admin {
+			operation["security"] = []interface{}{map[string]interface{}{"adminToken": []string{}}}
+		}
+		if len(route.Params) > 0 {
+			var params []interface{}
+			for _, param := range route.Params {
+				params = append(params, map[string]interface{}{
+					"name":        param.Name,
+					"in":          "query",
+					"required":    param.Required,
+					"description": param.Description,
+					"schema":      map[string]interface{}{"type": param.Type},
+				})
+			}
+			operation["parameters"] = params
+		}
+		if route.RequestBody != nil {
+			requestType := route.RequestType
+			if requestType == "" {
+				requestType = "application/json"
+			}
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  map[string]interface{}{requestType: map[string]interface{}{"schema": jsonSchema(reflect.TypeOf(route.RequestBody))}},
+			}
+		}
+
+		item, _ := paths[route.Path].(map[string]interface{})
+		if item == nil {
+			item = make(map[string]interface{})
+			paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "0G Galileo Unified Metrics API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"adminToken": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Go 타입을 json 태그 기준 JSON Schema로 변환
+func jsonSchema(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = jsonSchema(field.Type)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	}
+	return map[string]interface{}{}
+}
+
+// GET /api/openapi.json
+func (vt *UnifiedValidatorTracker) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, vt.openAPIDocument())
+}
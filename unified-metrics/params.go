@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// 스테이킹 파라미터는 거버넌스로만 바뀌므로 느린 주기로 갱신 (실패하면 짧은 간격으로 재시도)
+const (
+	defaultParamsRefreshInterval = 10 * time.Minute
+	paramsRetryInterval          = 30 * time.Second
+
+	// 슬래싱 파라미터는 업그레이드 때나 바뀌므로 더 느리게
+	defaultSlashingParamsInterval = time.Hour
+
+	// 투표 기간은 보통 며칠이므로 제안 목록과 투표 여부는 이 정도 주기로 충분
+	defaultGovernanceInterval = 10 * time.Minute
+)
+
+// params 수집기
+func (vt *UnifiedValidatorTracker) refreshStakingParams(ctx context.Context) error {
+	params, err := vt.fetchStakingParams(ctx)
+	if err != nil {
+		restLog.Error("Error fetching staking params", "error", err)
+		return err
+	}
+	maxValidators := params.Params.MaxValidators
+	vt.metrics.cosmos.maxValidatorsMetric.Set(float64(maxValidators))
+
+	vt.mu.Lock()
+	changed := vt.maxValidators != maxValidators
+	vt.maxValidators = maxValidators
+	bondedCount := vt.bondedCount
+	vt.mu.Unlock()
+
+	if changed {
+		restLog.Info("Staking params updated", "max_validators", maxValidators)
+	}
+	vt.updateActiveSetFullness(bondedCount, maxValidators)
+	return nil
+}
+
+func (vt *UnifiedValidatorTracker) setBondedCount(bondedCount int) {
+	vt.mu.Lock()
+	vt.bondedCount = bondedCount
+	maxValidators := vt.maxValidators
+	vt.mu.Unlock()
+
+	vt.updateActiveSetFullness(bondedCount, maxValidators)
+}
+
+// 본딩 수 / max_validators (둘 중 하나라도 아직 모르면 갱신하지 않음)
+func (vt *UnifiedValidatorTracker) updateActiveSetFullness(bondedCount, maxValidators int) {
+	if bondedCount == 0 || maxValidators == 0 {
+		return
+	}
+	vt.metrics.cosmos.activeSetFullnessMetric.Set(float64(bondedCount) / float64(maxValidators))
+}
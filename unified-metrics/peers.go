@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPeerCheckInterval   = 30 * time.Second
+	defaultPeerDisconnectAlert = 2 * time.Minute
+)
+
+// 노드별로 항상 연결되어 있어야 하는 피어 (센트리 구성의 private peer 등)
+type PeerCheckConfig struct {
+	Node          string       `json:"node"`
+	RPC           string       `json:"rpc"`
+	ExpectedPeers []string     `json:"expected_peers"` // "nodeid@host:port" 또는 "nodeid"
+	Interval      jsonDuration `json:"interval,omitempty"`
+	Timeout       jsonDuration `json:"timeout,omitempty"`
+
+	peerIDs []string // ExpectedPeers에서 ID 부분만 (소문자)
+}
+
+type NetInfoResponse struct {
+	Result struct {
+		NPeers numericString `json:"n_peers"`
+		Peers  []struct {
+			NodeInfo struct {
+				ID string `json:"id"`
+			} `json:"node_info"`
+			IsOutbound bool `json:"is_outbound"`
+		} `json:"peers"`
+	} `json:"result"`
+}
+
+// "nodeid@host:port"에서 ID 부분만 비교에 사용 (net_info는 ID만 보장)
+func peerID(peer string) string {
+	id, _, _ := strings.Cut(strings.TrimSpace(peer), "@")
+	return strings.ToLower(id)
+}
+
+// PEER_CHECKS_FILE(JSON 파일) 또는 PEER_CHECKS(JSON 문자열)에서 노드별 기대 피어 목록 읽기
+func peerChecksFromEnv() ([]PeerCheckConfig, error) {
+	data := []byte(getEnv("PEER_CHECKS", ""))
+	if path := getEnv("PEER_CHECKS_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var checks []PeerCheckConfig
+	if err := json.Unmarshal(data, &checks); err != nil {
+		return nil, fmt.Errorf("decode peer checks: %w", err)
+	}
+	nodes := make(map[string]bool)
+	for i := range checks {
+		check := &checks[i]
+		if check.Node == "" || check.RPC == "" {
+			return nil, fmt.Errorf("peer check %d: node and rpc are required", i)
+		}
+		if nodes[check.Node] {
+			return nil, fmt.Errorf("duplicate peer check node %q", check.Node)
+		}
+		if err := validateEndpointURL(check.RPC); err != nil {
+			return nil, fmt.Errorf("peer check %q: %w", check.Node, err)
+		}
+		nodes[check.Node] = true
+		if len(check.ExpectedPeers) == 0 {
+			return nil, fmt.Errorf("peer check %q: expected_peers is empty", check.Node)
+		}
+		for _, peer := range check.ExpectedPeers {
+			id := peerID(peer)
+			if id == "" {
+				return nil, fmt.Errorf("peer check %q: invalid peer %q", check.Node, peer)
+			}
+			check.peerIDs = append(check.peerIDs, id)
+		}
+		check.RPC = strings.TrimRight(check.RPC, "/")
+		if check.Interval <= 0 {
+			check.Interval = jsonDuration(defaultPeerCheckInterval)
+		}
+		if check.Timeout <= 0 {
+			check.Timeout = jsonDuration(defaultProbeTimeout)
+		}
+	}
+	return checks, nil
+}
+
+// 노드별 고루틴으로 주기적으로 net_info 확인
+func (vt *UnifiedValidatorTracker) StartPeerChecks(ctx context.Context, checks []PeerCheckConfig, alertAfter time.Duration) {
+	for _, check := range checks {
+		check := check
+		vt.goroutines.Go(componentPeerChecks, func() { vt.runPeerCheck(ctx, check, alertAfter) })
+	}
+}
+
+func (vt *UnifiedValidatorTracker) runPeerCheck(ctx context.Context, check PeerCheckConfig, alertAfter time.Duration) {
+	trackerLog.Info("Starting peer check", "node", check.Node, "rpc", sanitizeEndpoint(check.RPC),
+		"expected_peers", len(check.peerIDs), "interval", time.Duration(check.Interval))
+	client := &http.Client{Timeout: time.Duration(check.Timeout)}
+	ticker := time.NewTicker(time.Duration(check.Interval))
+	defer ticker.Stop()
+
+	// 피어 ID -> 연결이 끊긴 것을 처음 본 시각 (연결 중이면 없음)
+	disconnectedSince := make(map[string]time.Time)
+	alerted := make(map[string]bool)
+	for {
+		vt.checkPeersOnce(ctx, client, check, alertAfter, disconnectedSince, alerted)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) checkPeersOnce(ctx context.Context, client *http.Client, check PeerCheckConfig,
+	alertAfter time.Duration, disconnectedSince map[string]time.Time, alerted map[string]bool) {
+	netInfo, err := fetchNetInfoFrom(ctx, client, check.RPC)
+	if err != nil {
+		// 노드 자체에 접근할 수 없는 경우는 노드 상태 메트릭이 다루므로 피어 상태는 그대로 둠
+		if ctx.Err() == nil {
+			rpcLog.Warn("Error fetching net_info", "node", check.Node, "rpc", sanitizeEndpoint(check.RPC), "error", err)
+		}
+		return
+	}
+
+	connected := make(map[string]bool, len(netInfo.Result.Peers))
+	for _, peer := range netInfo.Result.Peers {
+		connected[strings.ToLower(peer.NodeInfo.ID)] = true
+	}
+
+	now := time.Now()
+	for _, id := range check.peerIDs {
+		up := connected[id]
+		vt.metrics.cosmos.expectedPeerMetric.WithLabelValues(check.Node, id).Set(boolToFloat(up))
+
+		if up {
+			if alerted[id] {
+				message := fmt.Sprintf("node %s reconnected to expected peer %s after %s", check.Node, id, now.Sub(disconnectedSince[id]).Round(time.Second))
+				trackerLog.Info("Expected peer reconnected", "node", check.Node, "peer", id)
+				vt.publishPeerEvent(EventPeerReconnected, "Expected peer reconnected", message)
+			}
+			delete(disconnectedSince, id)
+			delete(alerted, id)
+			continue
+		}
+
+		since, ok := disconnectedSince[id]
+		if !ok {
+			disconnectedSince[id] = now
+			trackerLog.Debug("Expected peer not connected", "node", check.Node, "peer", id)
+			continue
+		}
+		if alerted[id] || now.Sub(since) < alertAfter {
+			continue
+		}
+		alerted[id] = true
+		message := fmt.Sprintf("node %s has been disconnected from expected peer %s for %s", check.Node, id, now.Sub(since).Round(time.Second))
+		trackerLog.Warn("Expected peer disconnected", "node", check.Node, "peer", id, "since", since.UTC())
+		vt.publishPeerEvent(EventPeerDisconnected, "Expected peer disconnected", message)
+	}
+}
+
+func (vt *UnifiedValidatorTracker) publishPeerEvent(eventType, title, message string) {
+	vt.events.Publish(Event{Type: eventType, Height: vt.LastHeight(), Message: message})
+	if len(vt.notifiers) > 0 {
+		vt.notifyAsync(Message{Title: title, Markdown: message})
+	}
+}
+
+func fetchNetInfoFrom(ctx context.Context, client *http.Client, rpc string) (*NetInfoResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rpc+"/net_info", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var netInfo NetInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&netInfo); err != nil {
+		return nil, err
+	}
+	return &netInfo, nil
+}
+
+// 선택된 RPC 노드의 피어 목록 (node_status 수집기에서 사용)
+func (vt *UnifiedValidatorTracker) fetchNetInfo(ctx context.Context) (result *NetInfoResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("net_info", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.endpoints.Selected()
+	var netInfo NetInfoResponse
+	if err := vt.getJSON(ctx, "net_info", endpoint, endpoint+"/net_info", &netInfo); err != nil {
+		return nil, err
+	}
+	return &netInfo, nil
+}
+
+// 피어 수와 방향별 피어 수 갱신 (피어가 줄면 누락 블록보다 먼저 네트워크 고립을 알 수 있음)
+// 실패해도 노드 상태 수집은 계속 (net_info를 막아 둔 공개 RPC도 있음)
+func (vt *UnifiedValidatorTracker) updateNetInfo(ctx context.Context) {
+	netInfo, err := vt.fetchNetInfo(ctx)
+	if err != nil {
+		rpcLog.Warn("Error fetching net_info", "endpoint", sanitizeEndpoint(vt.endpoints.Selected()), "error", err)
+		return
+	}
+	outbound := 0
+	for _, peer := range netInfo.Result.Peers {
+		if peer.IsOutbound {
+			outbound++
+		}
+	}
+	total := len(netInfo.Result.Peers)
+	if n, err := strconv.Atoi(string(netInfo.Result.NPeers)); err == nil {
+		total = n
+	}
+	vt.metrics.cosmos.peersTotalMetric.Set(float64(total))
+	vt.metrics.cosmos.outboundPeersMetric.Set(float64(outbound))
+	vt.metrics.cosmos.inboundPeersMetric.Set(float64(len(netInfo.Result.Peers) - outbound))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const defaultStateSaveInterval = time.Minute
+
+// 상태 파일에서 스냅샷 복원 (파일이 없으면 새로 시작)
+func (vt *UnifiedValidatorTracker) loadStateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		persistenceLog.Info("No state file found, starting fresh", "path", path)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var snapshot TrackerSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		// 손상된 파일은 다음 저장에 덮어써지지 않도록 옮겨 두고 새로 시작
+		return vt.quarantineStateFile(path, err)
+	}
+	// 정지 중에 체인이 리셋됐으면 이전 체인 상태는 보관하고 새로 시작
+	if chainID := vt.ChainID(); chainID != "" && snapshot.ChainID != "" && snapshot.ChainID != chainID {
+		persistenceLog.Error("State file belongs to a previous chain, starting fresh", "path", path,
+			"state_chain_id", snapshot.ChainID, "chain_id", chainID, "last_block_height", snapshot.LastBlockHeight)
+		vt.metrics.exporter.chainResetsMetric.Inc()
+		return vt.archiveStateFile(snapshot.ChainID)
+	}
+	renames := vt.applyLabelRenames(&snapshot)
+	if err := vt.Restore(snapshot); err != nil {
+		return err
+	}
+	persistenceLog.Info("Restored state file", "path", path, "last_block_height", snapshot.LastBlockHeight,
+		"saved_at", snapshot.CreatedAt)
+	for i := range renames {
+		vt.completeLabelRename(&renames[i])
+	}
+	return nil
+}
+
+// 읽을 수 없는 상태 파일을 <path>.corrupt-<시각>으로 옮김 (원인 조사용으로 보관)
+func (vt *UnifiedValidatorTracker) quarantineStateFile(path string, cause error) error {
+	quarantined := path + ".corrupt-" + time.Now().UTC().Format("20060102T150405Z")
+	if vt.dryRun {
+		persistenceLog.Warn("Dry run: corrupt state file ignored, starting fresh", "path", path, "error", cause)
+		return nil
+	}
+	if err := os.Rename(path, quarantined); err != nil {
+		return fmt.Errorf("decode state file: %w (moving it aside failed: %v)", cause, err)
+	}
+	persistenceLog.Warn("State file is corrupt, starting fresh", "path", path, "moved_to", quarantined, "error", cause)
+	return nil
+}
+
+// 스냅샷을 임시 파일에 쓴 뒤 rename으로 교체 (중간에 종료돼도 이전 파일 유지)
+func (vt *UnifiedValidatorTracker) saveStateFile(path string) error {
+	snapshot := vt.Snapshot()
+	if snapshot.LastBlockHeight == 0 {
+		// 아직 처리한 블록이 없으면 기존 파일을 덮어쓰지 않음
+		return nil
+	}
+	if vt.dryRun {
+		persistenceLog.Info("Dry run: state file not written", "path", path, "last_block_height", snapshot.LastBlockHeight)
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(snapshot); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// 주기적으로 상태 파일 저장 (종료 시 저장은 main에서 서버 종료 후 수행)
+func (vt *UnifiedValidatorTracker) StartStatePersister(ctx context.Context, path string, interval time.Duration) {
+	persistenceLog.Info("Starting state persister", "path", path, "interval", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := vt.saveStateFile(path); err != nil {
+				persistenceLog.Error("Failed to save state file", "path", path, "error", err)
+			}
+		}
+	}
+}
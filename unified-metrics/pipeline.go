@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"errors"
+)
+
+// fetcher가 applier보다 앞서 나갈 수 있는 최대 블록 수 (가득 차면 fetcher가 대기)
+const blockQueueSize = 32
+
+// fetcher 단계에서 조회/파싱을 마친 블록
+type blockSummary struct {
+	height   int64
+	block    *BlockInfo
+	previous *BlockInfo // 서명 판단에 쓰는 직전 블록 (조회 실패 시 nil)
+	prevErr  error      // 직전 블록 조회 에러 (서명 판단을 건너뛴 이유)
+	live     bool       // 실시간 추적 블록이면 현재 상태 메트릭도 갱신, false면 캐치업 블록
+
+	gasPrices    []float64 // 블록 트랜잭션의 가스 가격
+	feesObserved bool      // block_results 조회에 성공했는지 (실패하면 가스 가격 창에 반영하지 않음)
+
+	reset *chainReset // nil이 아니면 블록 대신 체인 리셋 적용 (height는 0)
+}
+
+// 블록 적용 단계 시작 (추적/캐치업보다 먼저 호출)
+func (vt *UnifiedValidatorTracker) StartApplier(ctx context.Context) {
+	vt.blockQueue = make(chan blockSummary, blockQueueSize)
+	vt.goroutines.Go(componentApplier, func() { vt.runApplier(ctx) })
+}
+
+// 큐에 들어온 순서(높이 순서)대로 하나씩 적용
+func (vt *UnifiedValidatorTracker) runApplier(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case summary := <-vt.blockQueue:
+			vt.applyBlock(ctx, summary)
+		}
+	}
+}
+
+// 블록을 큐에 넣음: 큐가 가득 차면 자리가 날 때까지 대기하고 버리지 않음 (종료 시에만 false)
+func (vt *UnifiedValidatorTracker) enqueueBlock(ctx context.Context, summary blockSummary) bool {
+	select {
+	case vt.blockQueue <- summary:
+		vt.lastQueued = summary.height
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// 블록에 직전 블록을 붙여 적용 단위로 만듦 (직전 블록을 이미 갖고 있으면 재사용)
+func (vt *UnifiedValidatorTracker) newBlockSummary(ctx context.Context, height int64, block, previous *BlockInfo, live bool) blockSummary {
+	var prevErr error
+	if previous == nil && height > 1 {
+		var err error
+		if previous, err = vt.fetchBlock(ctx, height-1); err != nil {
+			if errors.Is(err, ErrHeightNotAvailable) {
+				trackerLog.Warn("Previous block pruned on node, skipping signing evaluation", "height", height-1, "error", err)
+			} else {
+				trackerLog.Error("Error fetching previous block", "height", height-1, "error", err)
+			}
+			previous = nil
+			prevErr = err
+		}
+	}
+	summary := blockSummary{height: height, block: block, previous: previous, prevErr: prevErr, live: live}
+	summary.gasPrices, summary.feesObserved = vt.observeGasPrices(ctx, height)
+	return summary
+}
+
+// 블록 하나를 메트릭과 상태에 반영 (applier 고루틴에서만 호출)
+func (vt *UnifiedValidatorTracker) applyBlock(ctx context.Context, summary blockSummary) {
+	if summary.reset != nil {
+		vt.applyChainReset(summary.reset)
+		return
+	}
+	height := summary.height
+	if vt.processed.IsProcessed(height) {
+		trackerLog.Debug("Block already applied, skipping", "height", height)
+		return
+	}
+	if height <= vt.LastHeight() {
+		trackerLog.Debug("Block older than the processed height, skipping", "height", height, "last_height", vt.LastHeight())
+		return
+	}
+	trackerLog.Debug("Applying block", "height", height, "live", summary.live, "queued", len(vt.blockQueue))
+
+	if summary.live {
+		vt.metrics.cosmos.blockHeightMetric.Set(float64(height))
+	}
+	var records []SigningRecord
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				trackerLog.Error("Panic in updateBeaconBlockMetrics", "height", height, "panic", r)
+			}
+		}()
+		records = vt.updateBeaconBlockMetrics(ctx, summary.block, summary.previous)
+	}()
+	if summary.previous == nil && height > 1 {
+		// 빈 블록으로 판단하면 모든 벨리데이터가 누락으로 기록되므로 건너뛴 수만 기록
+		reason := "fetch_failed"
+		if errors.Is(summary.prevErr, ErrHeightNotAvailable) {
+			reason = "height_not_available"
+		}
+		vt.metrics.cosmos.signingSkippedMetric.WithLabelValues(reason).Inc()
+	}
+	vt.recordProposer(summary.block.Result.Block.Header.ProposerAddress)
+	vt.queueBlockRecord(height, summary.block, records)
+	vt.archiveBlock(height, summary.block, summary.previous, records)
+	if summary.feesObserved {
+		vt.applyGasPrices(height, summary.gasPrices)
+	}
+
+	// 현재 상태(셋 포함 여부, mempool)는 실시간 블록에서만 갱신 (스테이킹은 staking 수집기가 별도 간격으로 갱신)
+	if summary.live {
+		vt.metrics.cosmos.trackedBlocksMetric.Inc()
+		vt.updateValidatorStatus(ctx, height)
+		vt.updateMempoolMetrics(ctx)
+		vt.updateBlocksUntilUpgrade(height)
+	}
+	vt.markProcessed(height)
+	vt.observeProcessedLag(0, height)
+
+	vt.processed.MarkProcessed(height)
+
+	if summary.live {
+		trackerLog.Info("Processed beacon block", "height", height)
+	} else {
+		vt.metrics.exporter.catchUpBlocksMetric.Inc()
+	}
+}
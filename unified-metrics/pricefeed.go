@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPriceFeedInterval = 5 * time.Minute
+	defaultPriceFeedTimeout  = 10 * time.Second
+	// 가격 응답 본문 최대 크기
+	maxPriceFeedBytes = 1 << 20
+)
+
+// 토큰 가격 HTTP JSON 소스 설정 (예: CoinGecko simple/price)
+type PriceFeedConfig struct {
+	Quote    string            `json:"quote"` // 가격 단위 (usd, krw 등)
+	URL      string            `json:"url"`
+	Path     string            `json:"path"`              // 응답에서 가격 위치 (예: $["zero-gravity"].usd, data[0].price)
+	Headers  map[string]string `json:"headers,omitempty"` // 값 또는 env:NAME, file:/path (API 키)
+	Interval jsonDuration      `json:"interval,omitempty"`
+	Timeout  jsonDuration      `json:"timeout,omitempty"`
+
+	steps []jsonPathStep
+}
+
+// PRICE_FEEDS_FILE(JSON 파일) 또는 PRICE_FEEDS(JSON 문자열)에서 가격 소스 목록 읽기
+func priceFeedsFromEnv() ([]PriceFeedConfig, error) {
+	data := []byte(getEnv("PRICE_FEEDS", ""))
+	if path := getEnv("PRICE_FEEDS_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var feeds []PriceFeedConfig
+	if err := json.Unmarshal(data, &feeds); err != nil {
+		return nil, fmt.Errorf("decode price feeds: %w", err)
+	}
+	quotes := make(map[string]bool)
+	for i := range feeds {
+		feed := &feeds[i]
+		feed.Quote = strings.ToLower(strings.TrimSpace(feed.Quote))
+		if feed.Quote == "" || feed.URL == "" || feed.Path == "" {
+			return nil, fmt.Errorf("price feed %d: quote, url and path are required", i)
+		}
+		if quotes[feed.Quote] {
+			return nil, fmt.Errorf("duplicate price feed quote %q", feed.Quote)
+		}
+		quotes[feed.Quote] = true
+		if err := validateEndpointURL(feed.URL); err != nil {
+			return nil, fmt.Errorf("price feed %q: %w", feed.Quote, err)
+		}
+		steps, err := parseJSONPath(feed.Path)
+		if err != nil {
+			return nil, fmt.Errorf("price feed %q: invalid path %q: %w", feed.Quote, feed.Path, err)
+		}
+		feed.steps = steps
+		for name, value := range feed.Headers {
+			if feed.Headers[name], err = resolveSecret(value); err != nil {
+				return nil, fmt.Errorf("price feed %q: header %s: %w", feed.Quote, name, err)
+			}
+		}
+		if feed.Interval <= 0 {
+			feed.Interval = jsonDuration(defaultPriceFeedInterval)
+		}
+		if feed.Timeout <= 0 {
+			feed.Timeout = jsonDuration(defaultPriceFeedTimeout)
+		}
+	}
+	return feeds, nil
+}
+
+// JSONPath 부분 집합의 한 단계: 객체 키 또는 배열 인덱스
+type jsonPathStep struct {
+	key   string
+	index int // key가 비어 있을 때만 사용
+}
+
+// "$" 루트(생략 가능), ".key", "[0]", ["key"] / ['key'] 단계만 지원 (와일드카드, 필터 없음)
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	var steps []jsonPathStep
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			fallthrough
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key")
+			}
+			steps = append(steps, jsonPathStep{key: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed bracket")
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0] {
+				if len(inner) == 2 {
+					return nil, fmt.Errorf("empty key")
+				}
+				steps = append(steps, jsonPathStep{key: inner[1 : len(inner)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index %q", inner)
+			}
+			steps = append(steps, jsonPathStep{index: index})
+		}
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("path selects the whole document")
+	}
+	return steps, nil
+}
+
+// 응답에서 가격 추출 (숫자 또는 숫자 문자열, 0 이하나 NaN은 오류)
+func extractPrice(body io.Reader, steps []jsonPathStep) (float64, error) {
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return 0, fmt.Errorf("decode response: %w", err)
+	}
+
+	for i, step := range steps {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			if step.key == "" {
+				return 0, fmt.Errorf("step %d: index %d on an object", i, step.index)
+			}
+			child, ok := node[step.key]
+			if !ok {
+				return 0, fmt.Errorf("step %d: key %q not found", i, step.key)
+			}
+			value = child
+		case []interface{}:
+			if step.key != "" {
+				return 0, fmt.Errorf("step %d: key %q on an array", i, step.key)
+			}
+			if step.index >= len(node) {
+				return 0, fmt.Errorf("step %d: index %d out of range (length %d)", i, step.index, len(node))
+			}
+			value = node[step.index]
+		default:
+			return 0, fmt.Errorf("step %d: cannot descend into %T", i, value)
+		}
+	}
+
+	var price float64
+	var err error
+	switch v := value.(type) {
+	case json.Number:
+		price, err = v.Float64()
+	case string:
+		price, err = strconv.ParseFloat(strings.TrimSpace(v), 64)
+	default:
+		return 0, fmt.Errorf("value is %T, not a number", value)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if price <= 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return 0, fmt.Errorf("invalid price %v", price)
+	}
+	return price, nil
+}
+
+// 가격과 벨리데이터별 display 단위 보유량 (가격 × 보유량으로 환산 가치 계산)
+type TokenValuation struct {
+	mu         sync.Mutex
+	prices     map[string]float64 // quote -> 마지막으로 성공한 가격
+	stake      map[string]float64 // validator -> 스테이킹 토큰 (display 단위)
+	commission map[string]float64 // validator -> 누적 커미션 (display 단위)
+}
+
+func NewTokenValuation() *TokenValuation {
+	return &TokenValuation{
+		prices:     make(map[string]float64),
+		stake:      make(map[string]float64),
+		commission: make(map[string]float64),
+	}
+}
+
+func (tv *TokenValuation) setPrice(quote string, price float64) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	tv.prices[quote] = price
+}
+
+func (tv *TokenValuation) setStake(validator string, amount float64) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	tv.stake[validator] = amount
+}
+
+func (tv *TokenValuation) setCommission(validator string, amount float64) {
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	tv.commission[validator] = amount
+}
+
+// 가격을 아는 quote와 보유량을 아는 벨리데이터 조합만 환산 가치 게이지에 설정
+func (vt *UnifiedValidatorTracker) updateValueMetrics() {
+	tv := vt.valuation
+	tv.mu.Lock()
+	defer tv.mu.Unlock()
+
+	for quote, price := range tv.prices {
+		for validator, amount := range tv.stake {
+			vt.metrics.cosmos.stakeValueMetric.WithLabelValues(validator, quote).Set(amount * price)
+		}
+		for validator, amount := range tv.commission {
+			vt.metrics.cosmos.commissionValueMetric.WithLabelValues(validator, quote).Set(amount * price)
+		}
+	}
+}
+
+// 가격 소스별 고루틴으로 주기적 조회
+func (vt *UnifiedValidatorTracker) StartPriceFeeds(ctx context.Context, feeds []PriceFeedConfig) {
+	for _, feed := range feeds {
+		feed := feed
+		vt.goroutines.Go(componentPriceFeeds, func() { vt.runPriceFeed(ctx, feed) })
+	}
+}
+
+func (vt *UnifiedValidatorTracker) runPriceFeed(ctx context.Context, feed PriceFeedConfig) {
+	restLog.Info("Starting price feed", "quote", feed.Quote, "url", sanitizeEndpoint(feed.URL),
+		"interval", time.Duration(feed.Interval))
+	client := &http.Client{Timeout: time.Duration(feed.Timeout)}
+	ticker := time.NewTicker(time.Duration(feed.Interval))
+	defer ticker.Stop()
+
+	for {
+		vt.pollPriceFeed(ctx, client, feed)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// 실패하면 마지막 가격을 유지하고 stale로 표시 (가격을 0으로 만들지 않음)
+func (vt *UnifiedValidatorTracker) pollPriceFeed(ctx context.Context, client *http.Client, feed PriceFeedConfig) {
+	metrics := vt.metrics.cosmos
+	price, err := fetchPrice(ctx, client, feed)
+	if err != nil {
+		if ctx.Err() == nil {
+			restLog.Warn("Price feed failed, keeping last price", "quote", feed.Quote, "error", err)
+		}
+		metrics.priceStaleMetric.WithLabelValues(feed.Quote).Set(1)
+		return
+	}
+
+	vt.valuation.setPrice(feed.Quote, price)
+	metrics.tokenPriceMetric.WithLabelValues(feed.Quote).Set(price)
+	metrics.priceStaleMetric.WithLabelValues(feed.Quote).Set(0)
+	metrics.priceUpdatedMetric.WithLabelValues(feed.Quote).Set(float64(time.Now().Unix()))
+	vt.updateValueMetrics()
+	restLog.Debug("Updated token price", "quote", feed.Quote, "price", price)
+}
+
+func fetchPrice(ctx context.Context, client *http.Client, feed PriceFeedConfig) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, value := range feed.Headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return extractPrice(io.LimitReader(resp.Body, maxPriceFeedBytes), feed.steps)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultProbeInterval = 30 * time.Second
+	defaultProbeTimeout  = 5 * time.Second
+)
+
+// 주변 서비스 HTTP(S) 프로브 설정
+type ProbeConfig struct {
+	Name               string       `json:"name"`
+	URL                string       `json:"url"`
+	ExpectedStatus     int          `json:"expected_status,omitempty"` // 기본 200
+	Interval           jsonDuration `json:"interval,omitempty"`
+	Timeout            jsonDuration `json:"timeout,omitempty"`
+	InsecureSkipVerify bool         `json:"insecure_skip_verify,omitempty"`
+}
+
+// "30s" 형식 문자열로 표현되는 duration
+type jsonDuration time.Duration
+
+func (d *jsonDuration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string like \"30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = jsonDuration(parsed)
+	return nil
+}
+
+func (d jsonDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonDurationString(d))
+}
+
+func jsonDurationString(d jsonDuration) string {
+	return time.Duration(d).String()
+}
+
+// PROBES_FILE(JSON 파일) 또는 PROBES(JSON 문자열)에서 프로브 목록 읽기
+func probesFromEnv() ([]ProbeConfig, error) {
+	data := []byte(getEnv("PROBES", ""))
+	if path := getEnv("PROBES_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var probes []ProbeConfig
+	if err := json.Unmarshal(data, &probes); err != nil {
+		return nil, fmt.Errorf("decode probes: %w", err)
+	}
+	names := make(map[string]bool)
+	for i := range probes {
+		probe := &probes[i]
+		if probe.Name == "" || probe.URL == "" {
+			return nil, fmt.Errorf("probe %d: name and url are required", i)
+		}
+		if names[probe.Name] {
+			return nil, fmt.Errorf("duplicate probe name %q", probe.Name)
+		}
+		if err := validateEndpointURL(probe.URL); err != nil {
+			return nil, fmt.Errorf("probe %q: %w", probe.Name, err)
+		}
+		names[probe.Name] = true
+		if probe.ExpectedStatus == 0 {
+			probe.ExpectedStatus = http.StatusOK
+		}
+		if probe.Interval <= 0 {
+			probe.Interval = jsonDuration(defaultProbeInterval)
+		}
+		if probe.Timeout <= 0 {
+			probe.Timeout = jsonDuration(defaultProbeTimeout)
+		}
+	}
+	return probes, nil
+}
+
+// 프로브별 고루틴으로 주기적 실행
+func (vt *UnifiedValidatorTracker) StartProbes(ctx context.Context, probes []ProbeConfig) {
+	for _, probe := range probes {
+		probe := probe
+		vt.goroutines.Go(componentProbes, func() { vt.runProbe(ctx, probe) })
+	}
+}
+
+func (vt *UnifiedValidatorTracker) runProbe(ctx context.Context, probe ProbeConfig) {
+	trackerLog.Info("Starting probe", "name", probe.Name, "url", sanitizeEndpoint(probe.URL),
+		"interval", time.Duration(probe.Interval))
+	client, err := newOutboundClient(time.Duration(probe.Timeout), OutboundTLS{InsecureSkipVerify: probe.InsecureSkipVerify})
+	if err != nil {
+		trackerLog.Error("Invalid probe client configuration", "name", probe.Name, "error", err)
+		return
+	}
+	ticker := time.NewTicker(time.Duration(probe.Interval))
+	defer ticker.Stop()
+
+	for {
+		vt.probeOnce(ctx, client, probe)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) probeOnce(ctx context.Context, client *http.Client, probe ProbeConfig) {
+	metrics := vt.metrics.exporter
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probe.URL, nil)
+	if err != nil {
+		trackerLog.Warn("Invalid probe request", "name", probe.Name, "error", err)
+		metrics.probeUpMetric.WithLabelValues(probe.Name).Set(0)
+		return
+	}
+	resp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		if ctx.Err() == nil {
+			trackerLog.Warn("Probe failed", "name", probe.Name, "error", err)
+		}
+		metrics.probeUpMetric.WithLabelValues(probe.Name).Set(0)
+		metrics.probeStatusCodeMetric.WithLabelValues(probe.Name).Set(0)
+		return
+	}
+	resp.Body.Close()
+
+	up := resp.StatusCode == probe.ExpectedStatus
+	metrics.probeUpMetric.WithLabelValues(probe.Name).Set(boolToFloat(up))
+	metrics.probeLatencyMetric.WithLabelValues(probe.Name).Set(latency.Seconds())
+	metrics.probeStatusCodeMetric.WithLabelValues(probe.Name).Set(float64(resp.StatusCode))
+	if !up {
+		trackerLog.Warn("Probe returned unexpected status", "name", probe.Name,
+			"status", resp.StatusCode, "expected", probe.ExpectedStatus)
+	}
+}
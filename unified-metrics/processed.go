@@ -0,0 +1,62 @@
+package main
+
+import "sync"
+
+// 적용 기록을 유지하는 최근 블록 수 (janitor의 라벨 보존 기준과 같음)
+const processedWindow = defaultLabelRetention
+
+// 적용한 블록 높이 기록: 최고 높이와 최근 processedWindow 높이의 링 버퍼 (메모리 사용량 고정)
+// 블록은 높이 순으로 적용되므로 링은 재시도나 캐치업이 같은 높이를 다시 넣는 경우만 막으면 됨
+type ProcessedHeights struct {
+	mu      sync.Mutex
+	highest int64
+	ring    []int64 // height % len(ring) 위치에 그 높이 (0이면 빈 슬롯)
+}
+
+func NewProcessedHeights(size int) *ProcessedHeights {
+	return &ProcessedHeights{ring: make([]int64, max(size, 1))}
+}
+
+func (p *ProcessedHeights) MarkProcessed(height int64) {
+	if height <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.ring[height%int64(len(p.ring))] = height
+	p.highest = max(p.highest, height)
+}
+
+// 최근 윈도우 안에서 적용한 높이인지 (윈도우보다 오래된 높이는 기록이 없으므로 false)
+func (p *ProcessedHeights) IsProcessed(height int64) bool {
+	if height <= 0 {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.ring[height%int64(len(p.ring))] == height
+}
+
+// 최근 윈도우 안에서 적용한 블록 수 (진단용)
+func (p *ProcessedHeights) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := 0
+	for _, height := range p.ring {
+		if height > 0 && height > p.highest-int64(len(p.ring)) {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *ProcessedHeights) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clear(p.ring)
+	p.highest = 0
+}
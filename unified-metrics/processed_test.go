@@ -0,0 +1,94 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestProcessedHeightsWindow(t *testing.T) {
+	p := NewProcessedHeights(4)
+	for height := int64(1); height <= 10; height++ {
+		p.MarkProcessed(height)
+	}
+	// 링에는 최근 4개 높이만 남음
+	for height := int64(1); height <= 10; height++ {
+		if got, want := p.IsProcessed(height), height > 6; got != want {
+			t.Errorf("IsProcessed(%d) = %v, want %v", height, got, want)
+		}
+	}
+	if got := p.Len(); got != 4 {
+		t.Errorf("Len = %d, want 4", got)
+	}
+	for _, height := range []int64{11, 14, 100} {
+		if p.IsProcessed(height) {
+			t.Errorf("IsProcessed(%d) = true for a height never marked", height)
+		}
+	}
+}
+
+func TestProcessedHeightsRemark(t *testing.T) {
+	p := NewProcessedHeights(8)
+	p.MarkProcessed(5)
+	p.MarkProcessed(5)
+	if !p.IsProcessed(5) || p.Len() != 1 {
+		t.Errorf("after marking 5 twice: IsProcessed = %v, Len = %d", p.IsProcessed(5), p.Len())
+	}
+	// 같은 슬롯의 다른 높이는 이전 높이를 밀어냄
+	p.MarkProcessed(13)
+	if p.IsProcessed(5) || !p.IsProcessed(13) {
+		t.Errorf("slot collision: IsProcessed(5) = %v, IsProcessed(13) = %v", p.IsProcessed(5), p.IsProcessed(13))
+	}
+}
+
+func TestProcessedHeightsInvalid(t *testing.T) {
+	p := NewProcessedHeights(0) // 최소 한 칸
+	for _, height := range []int64{0, -1} {
+		p.MarkProcessed(height)
+		if p.IsProcessed(height) {
+			t.Errorf("IsProcessed(%d) = true", height)
+		}
+	}
+	if got := p.Len(); got != 0 {
+		t.Errorf("Len = %d, want 0", got)
+	}
+	p.MarkProcessed(3)
+	if !p.IsProcessed(3) {
+		t.Error("IsProcessed(3) = false with a one-slot ring")
+	}
+}
+
+func TestProcessedHeightsReset(t *testing.T) {
+	p := NewProcessedHeights(4)
+	p.MarkProcessed(100)
+	p.MarkProcessed(101)
+	p.Reset()
+	if p.IsProcessed(100) || p.IsProcessed(101) || p.Len() != 0 {
+		t.Error("heights still recorded after Reset")
+	}
+	// 체인 리셋 후 낮은 높이부터 다시 기록
+	p.MarkProcessed(1)
+	if !p.IsProcessed(1) || p.Len() != 1 {
+		t.Errorf("after Reset: IsProcessed(1) = %v, Len = %d", p.IsProcessed(1), p.Len())
+	}
+}
+
+func TestProcessedHeightsConcurrent(t *testing.T) {
+	p := NewProcessedHeights(processedWindow)
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for height := int64(w*100 + 1); height <= int64(w*100+100); height++ {
+				p.MarkProcessed(height)
+				p.IsProcessed(height - 1)
+			}
+		}(w)
+	}
+	wg.Wait()
+	for height := int64(1); height <= 400; height++ {
+		if !p.IsProcessed(height) {
+			t.Fatalf("IsProcessed(%d) = false", height)
+		}
+	}
+}
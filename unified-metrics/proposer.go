@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math/big"
+)
+
+const (
+	defaultProposerWindow = 1000 // 제안 비율을 계산할 최근 블록 수
+	validatorsPerPage     = 100  // CometBFT /validators 최대 페이지 크기
+)
+
+// 최근 N개 블록의 제안자 주소를 보관하는 링 버퍼
+type ProposerWindow struct {
+	proposers []string
+	next      int
+	size      int
+}
+
+func NewProposerWindow(capacity int) *ProposerWindow {
+	if capacity <= 0 {
+		capacity = defaultProposerWindow
+	}
+	return &ProposerWindow{proposers: make([]string, capacity)}
+}
+
+// 윈도우에 담을 수 있는 블록 수
+func (pw *ProposerWindow) Capacity() int {
+	return len(pw.proposers)
+}
+
+func (pw *ProposerWindow) Add(proposer string) {
+	pw.proposers[pw.next] = proposer
+	pw.next = (pw.next + 1) % len(pw.proposers)
+	if pw.size < len(pw.proposers) {
+		pw.size++
+	}
+}
+
+// 윈도우 내 블록 수와 제안자별 제안 횟수
+func (pw *ProposerWindow) Counts() (blocks int, counts map[string]int) {
+	counts = make(map[string]int)
+	for i := 0; i < pw.size; i++ {
+		counts[pw.proposers[i]]++
+	}
+	return pw.size, counts
+}
+
+// 처리한 블록의 제안자를 기록하고 제안 비율 메트릭 갱신 (주소는 RPC와 설정의 대소문자가 달라도 맞도록 정규화)
+func (vt *UnifiedValidatorTracker) recordProposer(proposer string) {
+	if proposer == "" {
+		return
+	}
+
+	vt.mu.Lock()
+	vt.proposers.Add(normalizeAddress(proposer))
+	blocks, counts := vt.proposers.Counts()
+	shares := make(map[string]float64, len(vt.votingShare))
+	for label, share := range vt.votingShare {
+		shares[label] = share
+	}
+	vt.mu.Unlock()
+
+	for address, label := range vt.validators {
+		proposed := float64(counts[normalizeAddress(address)])
+		vt.metrics.custom.proposalsWindowMetric.WithLabelValues(label).Set(proposed)
+
+		share, ok := shares[label]
+		if !ok {
+			continue
+		}
+		expected := share * float64(blocks)
+		vt.metrics.custom.proposalsExpectedMetric.WithLabelValues(label).Set(expected)
+		if expected > 0 {
+			vt.metrics.custom.proposalsRatioMetric.WithLabelValues(label).Set(proposed / expected)
+		}
+	}
+}
+
+// 벨리데이터 셋의 투표력으로 추적 대상 벨리데이터의 투표력 비율 계산
+func (vt *UnifiedValidatorTracker) setVotingPowerShares(validatorInfo *ValidatorInfo) {
+	total := new(big.Int)
+	powers := make(map[string]*big.Int)
+	for _, validator := range validatorInfo.Result.Validators {
+		power, ok := new(big.Int).SetString(string(validator.VotingPower), 10)
+		if !ok {
+			continue
+		}
+		total.Add(total, power)
+		powers[normalizeAddress(validator.Address)] = power
+	}
+	if total.Sign() == 0 {
+		return
+	}
+
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	for address, label := range vt.validators {
+		power, ok := powers[normalizeAddress(address)]
+		if !ok {
+			// 활성 셋에 없으면 제안 차례가 오지 않음
+			vt.votingShare[label] = 0
+			continue
+		}
+		share, _ := new(big.Rat).SetFrac(power, total).Float64()
+		vt.votingShare[label] = share
+	}
+}
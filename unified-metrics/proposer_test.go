@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestProposerWindow(t *testing.T) {
+	pw := NewProposerWindow(3)
+	if blocks, counts := pw.Counts(); blocks != 0 || len(counts) != 0 {
+		t.Errorf("empty window = %d, %v", blocks, counts)
+	}
+
+	pw.Add("A")
+	pw.Add("B")
+	if blocks, counts := pw.Counts(); blocks != 2 || !reflect.DeepEqual(counts, map[string]int{"A": 1, "B": 1}) {
+		t.Errorf("partial window = %d, %v", blocks, counts)
+	}
+
+	// 가득 차면 가장 오래된 제안자부터 덮어씀
+	for _, proposer := range []string{"A", "C", "C"} {
+		pw.Add(proposer)
+	}
+	if blocks, counts := pw.Counts(); blocks != 3 || !reflect.DeepEqual(counts, map[string]int{"A": 1, "C": 2}) {
+		t.Errorf("wrapped window = %d, %v", blocks, counts)
+	}
+
+	if got := NewProposerWindow(0).Capacity(); got != defaultProposerWindow {
+		t.Errorf("capacity for 0 = %d, want %d", got, defaultProposerWindow)
+	}
+}
+
+// 체인 리셋은 설정한 크기(PROPOSER_WINDOW)를 유지한 채 제안 기록만 비움
+func TestChainResetKeepsProposerWindowCapacity(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha"})
+	tracker.proposers = NewProposerWindow(5)
+	tracker.recordProposer("ADDRALPHA")
+	tracker.recordProposer("ADDRALPHA")
+
+	tracker.applyChainReset(&chainReset{oldChainID: "zgtendermint_16600-1", newChainID: "zgtendermint_16601-2", done: make(chan struct{})})
+
+	if got := tracker.proposers.Capacity(); got != 5 {
+		t.Errorf("capacity after reset = %d, want 5", got)
+	}
+	if blocks, _ := tracker.proposers.Counts(); blocks != 0 {
+		t.Errorf("blocks after reset = %d, want 0", blocks)
+	}
+}
+
+// RPC가 소문자 주소를 보내도 설정한 대문자 주소의 벨리데이터로 집계
+func TestRecordProposerNormalizesAddress(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha", " addrbeta ": "beta"})
+	var set ValidatorInfo
+	if err := json.Unmarshal([]byte(`{"result":{"validators":[
+		{"address":"addralpha","voting_power":"1"},
+		{"address":"ADDRBETA","voting_power":"3"}]}}`), &set); err != nil {
+		t.Fatal(err)
+	}
+	tracker.setVotingPowerShares(&set)
+	if got := tracker.votingShare["alpha"]; got != 0.25 {
+		t.Errorf("alpha share = %v, want 0.25", got)
+	}
+	if got := tracker.votingShare["beta"]; got != 0.75 {
+		t.Errorf("beta share = %v, want 0.75", got)
+	}
+
+	for _, proposer := range []string{"addralpha", "ADDRALPHA", "ADDRBETA", "addrbeta"} {
+		tracker.recordProposer(proposer)
+	}
+	for label, want := range map[string]float64{"alpha": 2, "beta": 2} {
+		if got := testutil.ToFloat64(tracker.metrics.custom.proposalsWindowMetric.WithLabelValues(label)); got != want {
+			t.Errorf("%s proposals = %v, want %v", label, got, want)
+		}
+	}
+	if got := testutil.ToFloat64(tracker.metrics.custom.proposalsExpectedMetric.WithLabelValues("alpha")); got != 1 {
+		t.Errorf("alpha expected = %v, want 1 (0.25 × 4 blocks)", got)
+	}
+}
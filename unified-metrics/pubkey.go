@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+)
+
+// 스테이킹 목록의 합의 공개키가 이전 관측과 달라졌는지 확인 (첫 관측은 기준값으로만 기록)
+// 키 교체이든 운영자 키 탈취이든 즉시 확인해야 하므로 메트릭, 이벤트, 알림을 모두 발생
+func (vt *UnifiedValidatorTracker) checkPubkeyRotation(label, pubkey string) {
+	if pubkey == "" {
+		return
+	}
+	vt.mu.Lock()
+	previous, seen := vt.consensusPubkeys[label]
+	vt.consensusPubkeys[label] = pubkey
+	height := vt.lastBlockHeight
+	vt.mu.Unlock()
+	if !seen || previous == pubkey {
+		return
+	}
+
+	vt.metrics.cosmos.pubkeyRotatedMetric.WithLabelValues(label).Set(float64(height))
+	trackerLog.Error("Consensus pubkey changed", "validator", label, "height", height, "previous", previous, "current", pubkey)
+	message := fmt.Sprintf("consensus pubkey of %s changed at height %d: %s -> %s", label, height, previous, pubkey)
+	vt.events.Publish(Event{Type: EventPubkeyRotated, Height: height, Validator: label, Message: message})
+	if len(vt.notifiers) > 0 {
+		vt.notifyAsync(Message{
+			Title:    fmt.Sprintf("CRITICAL: consensus pubkey changed for %s", label),
+			Markdown: message + "\nIf this rotation was not planned, the operator key may be compromised.",
+		})
+	}
+}
+
+func copyStringMap(src map[string]string) map[string]string {
+	dst := make(map[string]string, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
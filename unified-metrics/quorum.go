@@ -0,0 +1,75 @@
+package main
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// 커밋 하나의 투표력 집계 (합은 big.Int로 누적해 오버플로 없음)
+type quorumPower struct {
+	total  *big.Int
+	absent *big.Int
+}
+
+// 커밋에 필요한 최소 투표력은 total*2/3 초과이므로, 빠져도 되는 최대 투표력은 total - (floor(2*total/3) + 1)
+func (q quorumPower) faultThreshold() *big.Int {
+	quorum := new(big.Int).Mul(q.total, big.NewInt(2))
+	quorum.Quo(quorum, big.NewInt(3))
+	quorum.Add(quorum, big.NewInt(1))
+	return quorum.Sub(q.total, quorum)
+}
+
+// 셋의 투표력과 커밋에 서명하지 않은 투표력 합산 (파싱할 수 없는 투표력은 건너뜀)
+func sumQuorumPower(validatorSet *ValidatorInfo, signed map[string]bool) quorumPower {
+	q := quorumPower{total: new(big.Int), absent: new(big.Int)}
+	power := new(big.Int)
+	for _, validator := range validatorSet.Result.Validators {
+		value, err := strconv.ParseInt(string(validator.VotingPower), 10, 64)
+		if err != nil || value < 0 {
+			continue
+		}
+		power.SetInt64(value)
+		q.total.Add(q.total, power)
+		if !signed[normalizeAddress(validator.Address)] {
+			q.absent.Add(q.absent, power)
+		}
+	}
+	return q
+}
+
+func bigToFloat(n *big.Int) float64 {
+	value, _ := new(big.Float).SetInt(n).Float64()
+	return value
+}
+
+// 서명 대상 높이의 셋과 커밋으로 쿼럼 여유와 추적 벨리데이터의 비중 갱신
+func (vt *UnifiedValidatorTracker) updateQuorumMetrics(validatorSet *ValidatorInfo, scan *signatureScan) {
+	q := sumQuorumPower(validatorSet, scan.signed)
+	if q.total.Sign() == 0 {
+		return
+	}
+	threshold := q.faultThreshold()
+	margin := new(big.Int).Sub(threshold, q.absent)
+
+	metrics := vt.metrics.custom
+	metrics.totalVotingPowerMetric.Set(bigToFloat(q.total))
+	metrics.faultThresholdMetric.Set(bigToFloat(threshold))
+	metrics.absentVotingPowerMetric.Set(bigToFloat(q.absent))
+	metrics.quorumMarginMetric.Set(bigToFloat(margin))
+
+	powers := make(map[string]int64, len(vt.validators))
+	for _, validator := range validatorSet.Result.Validators {
+		if value, err := strconv.ParseInt(string(validator.VotingPower), 10, 64); err == nil {
+			powers[normalizeAddress(validator.Address)] = value
+		}
+	}
+	for address, label := range vt.validators {
+		power := powers[normalizeAddress(address)]
+		metrics.votingPowerMetric.WithLabelValues(label).Set(float64(power))
+		// 셋이 아주 작으면 임계값이 0일 수 있음 (한 명만 빠져도 멈춤)
+		if threshold.Sign() > 0 {
+			ratio, _ := new(big.Rat).SetFrac(big.NewInt(power), threshold).Float64()
+			metrics.faultThresholdRatioMetric.WithLabelValues(label).Set(ratio)
+		}
+	}
+}
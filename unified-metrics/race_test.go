@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 추적 고루틴이 상태를 갱신하는 동안 HTTP 핸들러와 접근자가 읽음 (go test -race로 실행)
+func TestConcurrentReadsDuringTracking(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta", "gamma")
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	read := func(fn func()) {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				case <-time.After(time.Millisecond):
+					fn()
+				}
+			}
+		}()
+	}
+	read(func() {
+		h.tracker.handleStatus(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/status", nil))
+	})
+	read(func() {
+		h.tracker.Snapshot()
+		h.tracker.DiagnosticState()
+	})
+	read(func() {
+		h.tracker.registry.Gather()
+		h.tracker.LastHeight()
+		h.tracker.ChainID()
+		h.tracker.Validators()
+		h.tracker.healthInputs("beta")
+	})
+
+	for i := 0; i < 20; i++ {
+		switch i {
+		case 5:
+			h.chain.SetSigning("beta", false)
+		case 10:
+			h.chain.Jail("gamma")
+		case 15:
+			h.chain.SetSigning("beta", true)
+		}
+		if err := h.advance(1); err != nil {
+			t.Fatal(err)
+		}
+		if i%5 == 0 {
+			if err := h.tracker.updateCosmosMetrics(h.ctx); err != nil {
+				t.Fatal(err)
+			}
+			if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	close(done)
+	readers.Wait()
+
+	if got, want := h.tracker.LastHeight(), h.chain.Height(); got != want {
+		t.Errorf("last height = %d, want %d", got, want)
+	}
+}
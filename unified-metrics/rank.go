@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math/big"
+	"sort"
+)
+
+// 본딩된 벨리데이터를 토큰 내림차순으로 정렬한 순위 (operator address -> 1부터 시작)
+func rankByTokens(resp *ValidatorResponse) map[string]int {
+	type entry struct {
+		address string
+		tokens  *big.Int
+	}
+
+	var bonded []entry
+	for _, validator := range resp.Validators {
+		if validator.Status != "BOND_STATUS_BONDED" {
+			continue
+		}
+		tokens, ok := new(big.Int).SetString(string(validator.Tokens), 10)
+		if !ok {
+			tokens = new(big.Int)
+		}
+		bonded = append(bonded, entry{address: validator.OperatorAddress, tokens: tokens})
+	}
+
+	sort.SliceStable(bonded, func(i, j int) bool { return bonded[i].tokens.Cmp(bonded[j].tokens) > 0 })
+
+	ranks := make(map[string]int, len(bonded))
+	for i, e := range bonded {
+		ranks[e.address] = i + 1
+	}
+	return ranks
+}
+
+// 활성 셋 최소 토큰 (본딩 벨리데이터 중 가장 적은 토큰, 없으면 빈 문자열)
+func seatPrice(resp *ValidatorResponse) string {
+	var lowest *big.Int
+	for _, validator := range resp.Validators {
+		if validator.Status != "BOND_STATUS_BONDED" {
+			continue
+		}
+		tokens, ok := new(big.Int).SetString(string(validator.Tokens), 10)
+		if !ok {
+			continue
+		}
+		if lowest == nil || tokens.Cmp(lowest) < 0 {
+			lowest = tokens
+		}
+	}
+	if lowest == nil {
+		return ""
+	}
+	return lowest.String()
+}
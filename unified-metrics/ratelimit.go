@@ -0,0 +1,115 @@
+package main
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 이 시간 동안 요청이 없던 클라이언트 버킷은 정리
+const clientBucketIdleTimeout = 10 * time.Minute
+
+// 무거운 엔드포인트용 동시 실행 제한 + 클라이언트 IP별 요청 속도 제한 (0이면 비활성화)
+type RequestLimiter struct {
+	inflight chan struct{} // nil이면 동시 실행 제한 없음
+	rate     float64       // 초당 허용 요청 수 (0이면 속도 제한 없음)
+	burst    float64
+	rejected *prometheus.CounterVec
+
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func NewRequestLimiter(maxInflight int, ratePerSecond float64, burst int, rejected *prometheus.CounterVec) *RequestLimiter {
+	limiter := &RequestLimiter{
+		rate:     ratePerSecond,
+		burst:    math.Max(float64(burst), 1),
+		rejected: rejected,
+		buckets:  make(map[string]*tokenBucket),
+	}
+	if maxInflight > 0 {
+		limiter.inflight = make(chan struct{}, maxInflight)
+	}
+	return limiter
+}
+
+// 클라이언트 버킷에서 토큰 하나를 사용 (부족하면 다음 토큰까지 대기 시간 반환)
+func (l *RequestLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	if l.rate <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastCleanup) > clientBucketIdleTimeout {
+		for key, bucket := range l.buckets {
+			if now.Sub(bucket.last) > clientBucketIdleTimeout {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = bucket
+	}
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// 요청 제한 미들웨어 (limitInflight가 false면 속도 제한만 적용)
+func (l *RequestLimiter) Wrap(endpoint string, limitInflight bool, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if ok, wait := l.allow(clientIP(r), time.Now()); !ok {
+			l.reject(w, endpoint, "rate_limited", wait)
+			return
+		}
+
+		if limitInflight && l.inflight != nil {
+			select {
+			case l.inflight <- struct{}{}:
+				defer func() { <-l.inflight }()
+			default:
+				l.reject(w, endpoint, "concurrency", time.Second)
+				return
+			}
+		}
+
+		next(w, r)
+	}
+}
+
+func (l *RequestLimiter) reject(w http.ResponseWriter, endpoint, reason string, retryAfter time.Duration) {
+	l.rejected.WithLabelValues(endpoint, reason).Inc()
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	http.Error(w, "too many requests", http.StatusTooManyRequests)
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 트래커마다 별도 레지스트리라 한 프로세스에서 여러 개를 만들어도 충돌하지 않고 값도 섞이지 않음
+func TestTrackersOnSeparateRegistries(t *testing.T) {
+	first := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha"})
+	second := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:2"}, map[string]string{"ADDRALPHA": "alpha"})
+	for _, tracker := range []*UnifiedValidatorTracker{first, second} {
+		if err := tracker.RegisterMetrics(); err != nil {
+			t.Fatalf("register metrics: %v", err)
+		}
+	}
+
+	first.metrics.cosmos.blockHeightMetric.Set(100)
+	first.metrics.cosmos.missedBlocksTotalMetric.WithLabelValues("alpha").Add(3)
+	second.metrics.cosmos.blockHeightMetric.Set(200)
+
+	if got, _ := gatherValue(t, first.registry, "og_galileo_validator_block_height"); got != 100 {
+		t.Errorf("first block height = %v, want 100", got)
+	}
+	if got, _ := gatherValue(t, second.registry, "og_galileo_validator_block_height"); got != 200 {
+		t.Errorf("second block height = %v, want 200", got)
+	}
+	if got, ok := gatherValue(t, first.registry, "og_galileo_validator_missed_blocks_total", "validator", "alpha"); !ok || got != 3 {
+		t.Errorf("first missed = %v, %v; want 3", got, ok)
+	}
+	if _, ok := gatherValue(t, second.registry, "og_galileo_validator_missed_blocks_total", "validator", "alpha"); ok {
+		t.Error("second tracker exports the first tracker's missed counter")
+	}
+}
+
+// 이름이 겹치는 컬렉터가 이미 있으면 패닉 없이 에러를 반환하고 나머지 메트릭은 등록
+func TestRegisterReturnsClashError(t *testing.T) {
+	tests := []struct {
+		name, metric string
+		clash        prometheus.Collector
+		duplicate    bool // 같은 디스크립터면 AlreadyRegisteredError, 아니면 이름이 들어간 불일치 에러
+	}{
+		{"same descriptor", "og_galileo_validator_block_height",
+			prometheus.NewGauge(prometheus.GaugeOpts{Name: "og_galileo_validator_block_height", Help: "Latest known block height"}), true},
+		{"different help", "og_galileo_validator_block_height",
+			prometheus.NewGauge(prometheus.GaugeOpts{Name: "og_galileo_validator_block_height", Help: "something else"}), false},
+		{"different labels", "og_galileo_validator_missed_blocks_total",
+			prometheus.NewCounterVec(prometheus.CounterOpts{Name: "og_galileo_validator_missed_blocks_total", Help: "x"}, []string{"node"}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			registry.MustRegister(tt.clash)
+
+			metrics := NewUnifiedMetrics()
+			var err error
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("Register panicked: %v", r)
+					}
+				}()
+				err = metrics.Register(registry)
+			}()
+			if err == nil {
+				t.Fatal("Register succeeded despite a clashing collector")
+			}
+			if tt.duplicate {
+				if !errors.As(err, new(prometheus.AlreadyRegisteredError)) {
+					t.Errorf("error = %v, want AlreadyRegisteredError", err)
+				}
+			} else if !strings.Contains(err.Error(), tt.metric) {
+				t.Errorf("error does not name %s: %v", tt.metric, err)
+			}
+
+			// 겹치지 않는 메트릭은 그대로 등록되어 노출
+			metrics.cosmos.trackedBlocksMetric.Inc()
+			if got, ok := gatherValue(t, registry, "og_galileo_validator_tracked_blocks"); !ok || got != 1 {
+				t.Errorf("tracked blocks = %v, %v after a partial registration", got, ok)
+			}
+		})
+	}
+
+	// 같은 메트릭 묶음을 두 번 등록해도 패닉 없이 에러
+	registry := prometheus.NewRegistry()
+	metrics := NewUnifiedMetrics()
+	if err := metrics.Register(registry); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewUnifiedMetrics().Register(registry); err == nil {
+		t.Error("second set of metrics registered on the same registry")
+	}
+
+	// 트래커 단위에서도 같은 에러를 RegisterMetrics로 돌려받음
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha"})
+	tracker.registry.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "og_galileo_exporter_data_staleness_seconds", Help: "other"}))
+	if err := tracker.RegisterMetrics(); err == nil || !strings.Contains(err.Error(), "og_galileo_exporter_data_staleness_seconds") {
+		t.Errorf("RegisterMetrics with a clashing collector = %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 라벨 변경 요청 본문 최대 크기
+const maxLabelRenameBytes = 4 << 10
+
+var (
+	errLabelNotFound  = errors.New("label has no state to migrate")
+	errLabelCollision = errors.New("target label already has state")
+)
+
+// 벨리데이터 라벨 변경 (이전 라벨의 상태와 히스토리를 새 라벨로 옮김)
+type LabelRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// /api/labels/rename 응답
+type LabelRenameResponse struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Rows          int    `json:"rows"`           // 새 라벨로 옮긴 히스토리 행과 상태 항목 수
+	SeriesDeleted int    `json:"series_deleted"` // 삭제한 이전 라벨 시계열 수
+}
+
+func (r LabelRename) validate() error {
+	if r.From == "" || r.To == "" {
+		return fmt.Errorf("from and to are required")
+	}
+	if r.From == r.To {
+		return fmt.Errorf("label %q renamed to itself", r.From)
+	}
+	for _, label := range []string{r.From, r.To} {
+		if sanitizeLabel(label) != label {
+			return fmt.Errorf("invalid label %q", label)
+		}
+	}
+	return nil
+}
+
+// LABEL_RENAMES="old=new,old2=new2" (설정 파일에서는 renames 표)
+// 상태 파일 복원 시 적용하며, 이미 옮긴 라벨은 건너뛰므로 설정에 남겨 둬도 됨
+func parseLabelRenames(spec string) ([]LabelRename, error) {
+	var renames []LabelRename
+	sources := make(map[string]bool)
+	targets := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rename %q (expected old=new)", entry)
+		}
+		rename := LabelRename{From: strings.TrimSpace(from), To: strings.TrimSpace(to)}
+		if err := rename.validate(); err != nil {
+			return nil, err
+		}
+		if sources[rename.From] {
+			return nil, fmt.Errorf("label %q renamed more than once", rename.From)
+		}
+		if targets[rename.To] {
+			return nil, fmt.Errorf("more than one label renamed to %q", rename.To)
+		}
+		sources[rename.From] = true
+		targets[rename.To] = true
+		renames = append(renames, rename)
+	}
+	// 연쇄 변경(a=b,b=c)은 적용 순서에 따라 결과가 달라지므로 거부
+	for _, rename := range renames {
+		if sources[rename.To] {
+			return nil, fmt.Errorf("chained rename through %q (rename directly to the final label)", rename.To)
+		}
+	}
+	return renames, nil
+}
+
+// 이전 라벨이 아직 validators에 있으면 옮긴 뒤에도 이전 라벨로 계속 기록되므로 거부
+func labelRenamesFromEnv(validators map[string]string) ([]LabelRename, error) {
+	renames, err := parseLabelRenames(getEnv("LABEL_RENAMES", ""))
+	if err != nil {
+		return nil, err
+	}
+	for _, rename := range renames {
+		for _, label := range validators {
+			if label == rename.From {
+				return nil, fmt.Errorf("label %q is still configured in validators", rename.From)
+			}
+		}
+	}
+	return renames, nil
+}
+
+// 설정 파일 renames 표 -> LABEL_RENAMES 값
+func formatLabelRenames(renames map[string]string) string {
+	entries := make([]string, 0, len(renames))
+	for from, to := range renames {
+		entries = append(entries, from+"="+to)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// 스냅샷에서 라벨 변경 (상태가 없으면 errLabelNotFound, 새 라벨에 이미 상태가 있으면 errLabelCollision)
+// 반환값은 옮긴 항목 수
+func renameSnapshotLabel(snapshot *TrackerSnapshot, from, to string) (int, error) {
+	if !snapshotHasLabel(snapshot, from) {
+		return 0, errLabelNotFound
+	}
+	if snapshotHasLabel(snapshot, to) {
+		return 0, errLabelCollision
+	}
+
+	rows := 0
+	for i := range snapshot.Signing {
+		if snapshot.Signing[i].Validator == from {
+			snapshot.Signing[i].Validator = to
+			rows++
+		}
+	}
+	for i := range snapshot.Samples {
+		if snapshot.Samples[i].Validator == from {
+			snapshot.Samples[i].Validator = to
+			rows++
+		}
+	}
+	for i := range snapshot.Events {
+		if snapshot.Events[i].Validator == from {
+			snapshot.Events[i].Validator = to
+			rows++
+		}
+	}
+	for i := range snapshot.Outbox {
+		record := &snapshot.Outbox[i]
+		if record.ProposerValidator == from {
+			record.ProposerValidator = to
+		}
+		if renameKey(record.Signed, from, to) {
+			rows++
+		}
+	}
+	for _, moved := range []bool{
+		renameKey(snapshot.Jailed, from, to),
+		renameKey(snapshot.Bonded, from, to),
+		renameKey(snapshot.Pubkeys, from, to),
+		renameKey(snapshot.ChangeSamples, from, to),
+		renameKey(snapshot.SignedTotals, from, to),
+		renameKey(snapshot.MissedTotals, from, to),
+		renameKey(snapshot.MissStreaks, from, to),
+	} {
+		if moved {
+			rows++
+		}
+	}
+	for _, votes := range snapshot.ProposalVotes {
+		if renameKey(votes, from, to) {
+			rows++
+		}
+	}
+	for _, window := range snapshot.UptimeWindows {
+		if renameKey(window.Validators, from, to) {
+			rows++
+		}
+	}
+	if snapshot.MissedWindow != nil {
+		for i := range snapshot.MissedWindow.Blocks {
+			block := &snapshot.MissedWindow.Blocks[i]
+			moved := false
+			for _, labels := range [][]string{block.Signed, block.Missed, block.Solo} {
+				moved = renameInList(labels, from, to) || moved
+			}
+			if moved {
+				rows++
+			}
+		}
+	}
+	return rows, nil
+}
+
+func snapshotHasLabel(snapshot *TrackerSnapshot, label string) bool {
+	for _, record := range snapshot.Signing {
+		if record.Validator == label {
+			return true
+		}
+	}
+	for _, sample := range snapshot.Samples {
+		if sample.Validator == label {
+			return true
+		}
+	}
+	for _, record := range snapshot.Outbox {
+		if _, ok := record.Signed[label]; ok {
+			return true
+		}
+	}
+	_, jailed := snapshot.Jailed[label]
+	_, bonded := snapshot.Bonded[label]
+	_, pubkey := snapshot.Pubkeys[label]
+	_, changes := snapshot.ChangeSamples[label]
+	_, signed := snapshot.SignedTotals[label]
+	_, missed := snapshot.MissedTotals[label]
+	_, streak := snapshot.MissStreaks[label]
+	if snapshot.MissedWindow != nil {
+		for _, block := range snapshot.MissedWindow.Blocks {
+			if slices.Contains(block.Signed, label) || slices.Contains(block.Missed, label) {
+				return true
+			}
+		}
+	}
+	for _, window := range snapshot.UptimeWindows {
+		if _, ok := window.Validators[label]; ok {
+			return true
+		}
+	}
+	for _, votes := range snapshot.ProposalVotes {
+		if _, ok := votes[label]; ok {
+			return true
+		}
+	}
+	return jailed || bonded || pubkey || changes || signed || missed || streak
+}
+
+func renameInList(labels []string, from, to string) bool {
+	i := slices.Index(labels, from)
+	if i < 0 {
+		return false
+	}
+	labels[i] = to
+	return true
+}
+
+func renameKey[V any](m map[string]V, from, to string) bool {
+	value, ok := m[from]
+	if !ok {
+		return false
+	}
+	delete(m, from)
+	m[to] = value
+	return true
+}
+
+// 상태 파일 복원 전에 설정된 라벨 변경 적용 (충돌하는 변경은 건너뛰고 나머지는 계속)
+func (vt *UnifiedValidatorTracker) applyLabelRenames(snapshot *TrackerSnapshot) []LabelRenameResponse {
+	var applied []LabelRenameResponse
+	for _, rename := range vt.labelRenames {
+		rows, err := renameSnapshotLabel(snapshot, rename.From, rename.To)
+		switch {
+		case errors.Is(err, errLabelNotFound):
+			persistenceLog.Debug("Label rename already applied or nothing to migrate", "from", rename.From, "to", rename.To)
+		case err != nil:
+			persistenceLog.Error("Rejected label rename", "from", rename.From, "to", rename.To, "error", err)
+		default:
+			applied = append(applied, LabelRenameResponse{From: rename.From, To: rename.To, Rows: rows})
+		}
+	}
+	return applied
+}
+
+// 복원 후: 이전 라벨 시계열 삭제, 로그와 이벤트로 변경 기록
+func (vt *UnifiedValidatorTracker) completeLabelRename(result *LabelRenameResponse) {
+	result.SeriesDeleted = vt.catalog.DeleteSeries(prometheus.Labels{"validator": result.From})
+	persistenceLog.Info("Renamed validator label", "from", result.From, "to", result.To,
+		"rows", result.Rows, "series_deleted", result.SeriesDeleted)
+	vt.events.Publish(Event{Type: EventLabelRenamed, Height: vt.LastHeight(), Validator: result.To,
+		Message: fmt.Sprintf("validator label %s renamed to %s (%d state entries migrated)", result.From, result.To, result.Rows)})
+}
+
+// POST /api/labels/rename
+// 상태 복원과 같은 방식(스냅샷 -> 변경 -> 복원)이라 그 사이에 처리된 블록 기록은 덮어씀
+func (vt *UnifiedValidatorTracker) handleLabelRename(w http.ResponseWriter, r *http.Request) {
+	var rename LabelRename
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxLabelRenameBytes)).Decode(&rename); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid request: %v", err)
+		return
+	}
+	if err := rename.validate(); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if vt.isTrackedLabel(rename.From) {
+		writeAPIError(w, http.StatusConflict, "label %q is still configured in validators", rename.From)
+		return
+	}
+
+	snapshot := vt.Snapshot()
+	rows, err := renameSnapshotLabel(&snapshot, rename.From, rename.To)
+	switch {
+	case errors.Is(err, errLabelNotFound):
+		writeAPIError(w, http.StatusNotFound, "label %q: %v", rename.From, err)
+		return
+	case err != nil:
+		writeAPIError(w, http.StatusConflict, "label %q: %v", rename.To, err)
+		return
+	}
+	if err := vt.Restore(snapshot); err != nil {
+		writeAPIError(w, http.StatusConflict, "%v", err)
+		return
+	}
+
+	result := LabelRenameResponse{From: rename.From, To: rename.To, Rows: rows}
+	vt.completeLabelRename(&result)
+	writeJSON(w, http.StatusOK, result)
+}
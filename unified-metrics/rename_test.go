@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 스냅샷의 모든 영역에 old와 keep 라벨 상태를 넣음
+func seedRenameSnapshot() *TrackerSnapshot {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &TrackerSnapshot{
+		Version:         snapshotVersion,
+		ChainID:         "zgtendermint_16601-1",
+		LastBlockHeight: 120,
+		Signing: []SigningRecord{
+			{Height: 119, Time: now, Validator: "old", Signed: true},
+			{Height: 119, Time: now, Validator: "keep", Signed: true},
+			{Height: 120, Time: now, Validator: "old", Signed: false},
+		},
+		Samples: []ValidatorSample{{Time: now, Validator: "old", Tokens: "1000", Rank: 3, Bonded: true}},
+		Events:  []Event{{Type: EventJailed, Height: 100, Validator: "old"}, {Type: EventChainHalt, Height: 110}},
+		Outbox: []BlockRecord{
+			{Height: 120, ProposerValidator: "old", Signed: map[string]bool{"old": false, "keep": true}},
+		},
+		Jailed:        map[string]bool{"old": false, "keep": false},
+		Bonded:        map[string]bool{"old": true, "keep": true},
+		Pubkeys:       map[string]string{"old": "pubkey-old", "keep": "pubkey-keep"},
+		ChangeSamples: map[string][]ChangeSample{"old": {{Time: now, Tokens: "1000", Rank: 3}}},
+		SignedTotals:  map[string]int{"old": 118, "keep": 119},
+		MissedTotals:  map[string]int{"old": 2, "keep": 1},
+		MissStreaks:   map[string]int{"old": 1},
+		ProposalVotes: map[string]map[string]bool{"7": {"old": true, "keep": false}, "8": {"old": false}},
+		MissedWindow: &MissedWindowSnapshot{Size: 100, Blocks: []MissedWindowBlock{
+			{Height: 119, Signed: []string{"keep", "old"}},
+			{Height: 120, Signed: []string{"keep"}, Missed: []string{"old"}, Solo: []string{"old"}},
+		}},
+		UptimeWindows: []UptimeWindowSnapshot{{Window: 10000, Validators: map[string]UptimeBitmap{
+			"old":  {Newest: 120, Observed: "Aw==", Signed: "AQ=="},
+			"keep": {Newest: 120, Observed: "Aw==", Signed: "Aw=="},
+		}}},
+	}
+}
+
+func TestRenameSnapshotLabel(t *testing.T) {
+	snapshot := seedRenameSnapshot()
+	keepBefore, _ := json.Marshal(seedRenameSnapshot())
+
+	rows, err := renameSnapshotLabel(snapshot, "old", "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 서명 2 + 샘플 1 + 이벤트 1 + outbox 1 + 맵 7 + 제안 투표 2 + 업타임 1 + 누락 윈도우 블록 2
+	if rows != 17 {
+		t.Errorf("rows = %d, want 17", rows)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"old"`) {
+		t.Errorf("old label left in snapshot: %s", data)
+	}
+
+	if snapshot.Signing[0].Validator != "new" || snapshot.Signing[2].Validator != "new" || snapshot.Signing[1].Validator != "keep" {
+		t.Errorf("signing = %+v", snapshot.Signing)
+	}
+	if snapshot.Samples[0].Validator != "new" || snapshot.Events[0].Validator != "new" || snapshot.Events[1].Validator != "" {
+		t.Errorf("samples = %+v, events = %+v", snapshot.Samples, snapshot.Events)
+	}
+	if record := snapshot.Outbox[0]; record.ProposerValidator != "new" || !reflect.DeepEqual(record.Signed, map[string]bool{"new": false, "keep": true}) {
+		t.Errorf("outbox = %+v", record)
+	}
+	if snapshot.SignedTotals["new"] != 118 || snapshot.MissedTotals["new"] != 2 || snapshot.MissStreaks["new"] != 1 ||
+		snapshot.Pubkeys["new"] != "pubkey-old" || !snapshot.Bonded["new"] || len(snapshot.ChangeSamples["new"]) != 1 {
+		t.Errorf("state maps not moved: %s", data)
+	}
+	if _, ok := snapshot.Jailed["new"]; !ok {
+		t.Error("jailed state not moved")
+	}
+	if !reflect.DeepEqual(snapshot.ProposalVotes, map[string]map[string]bool{"7": {"new": true, "keep": false}, "8": {"new": false}}) {
+		t.Errorf("proposal votes = %v", snapshot.ProposalVotes)
+	}
+	if bitmap := snapshot.UptimeWindows[0].Validators["new"]; bitmap.Signed != "AQ==" {
+		t.Errorf("uptime bitmap = %+v", bitmap)
+	}
+	blocks := snapshot.MissedWindow.Blocks
+	if !reflect.DeepEqual(blocks[0].Signed, []string{"keep", "new"}) || !reflect.DeepEqual(blocks[1].Missed, []string{"new"}) ||
+		!reflect.DeepEqual(blocks[1].Solo, []string{"new"}) {
+		t.Errorf("missed window = %+v", blocks)
+	}
+
+	// 다른 라벨의 상태는 그대로
+	if snapshot.SignedTotals["keep"] != 119 || snapshot.Pubkeys["keep"] != "pubkey-keep" {
+		t.Errorf("keep state changed: %s (was %s)", data, keepBefore)
+	}
+}
+
+func TestRenameSnapshotLabelErrors(t *testing.T) {
+	if _, err := renameSnapshotLabel(seedRenameSnapshot(), "missing", "new"); !errors.Is(err, errLabelNotFound) {
+		t.Errorf("rename of unknown label = %v, want errLabelNotFound", err)
+	}
+
+	// 새 라벨에 이미 상태가 있으면 어느 영역이든 거부하고 스냅샷은 바꾸지 않음
+	collisions := map[string]func(*TrackerSnapshot){
+		"signing":       func(s *TrackerSnapshot) {},
+		"signed totals": func(s *TrackerSnapshot) { s.Signing = s.Signing[1:2]; s.SignedTotals = map[string]int{"old": 1} },
+		"proposal votes": func(s *TrackerSnapshot) {
+			s.Signing = s.Signing[:1]
+			s.ProposalVotes = map[string]map[string]bool{"7": {"old": true, "keep": true}}
+		},
+		"uptime window": func(s *TrackerSnapshot) {
+			s.Signing = s.Signing[:1]
+			s.UptimeWindows = []UptimeWindowSnapshot{{Window: 10000, Validators: map[string]UptimeBitmap{"keep": {}}}}
+		},
+	}
+	for name, setup := range collisions {
+		t.Run(name, func(t *testing.T) {
+			snapshot := seedRenameSnapshot()
+			*snapshot = TrackerSnapshot{Version: snapshot.Version, Signing: snapshot.Signing}
+			setup(snapshot)
+			before, _ := json.Marshal(snapshot)
+			if _, err := renameSnapshotLabel(snapshot, "old", "keep"); !errors.Is(err, errLabelCollision) {
+				t.Fatalf("rename onto existing label = %v, want errLabelCollision", err)
+			}
+			if after, _ := json.Marshal(snapshot); string(after) != string(before) {
+				t.Errorf("snapshot changed by rejected rename:\n%s\n%s", before, after)
+			}
+		})
+	}
+}
+
+// POST /api/labels/rename: 상태를 옮기고 이전 라벨 시계열을 모든 벡터에서 삭제, 이벤트 발행
+func TestHandleLabelRename(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRKEEP": "keep", "ADDRNEW": "new"})
+	if err := tracker.RegisterMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	tracker.chainID = "zgtendermint_16601-1"
+	if err := tracker.Restore(*seedRenameSnapshot()); err != nil {
+		t.Fatal(err)
+	}
+	// 재시작 전 프로세스가 남긴 것과 같은 이전 라벨 시계열
+	tracker.metrics.cosmos.isJailedMetric.WithLabelValues("old").Set(0)
+	tracker.metrics.custom.beaconBlockSignedMetric.WithLabelValues("old", "120").Set(0)
+	if n := len(seriesWithValidator(t, tracker, "old")); n == 0 {
+		t.Fatal("no old-label series before rename")
+	}
+	var events []Event
+	tracker.events.AddSink(func(event Event) { events = append(events, event) })
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		tracker.handleLabelRename(rec, httptest.NewRequest(http.MethodPost, "/api/labels/rename", strings.NewReader(body)))
+		return rec
+	}
+	if rec := post(`{"from": "old", "to": "keep"}`); rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), errLabelCollision.Error()) {
+		t.Errorf("rename onto keep = %d %s, want 409 collision", rec.Code, rec.Body)
+	}
+	if rec := post(`{"from": "keep", "to": "other"}`); rec.Code != http.StatusConflict {
+		t.Errorf("rename of a configured label = %d, want 409", rec.Code)
+	}
+	if rec := post(`{"from": "missing", "to": "other"}`); rec.Code != http.StatusNotFound {
+		t.Errorf("rename of unknown label = %d, want 404", rec.Code)
+	}
+	if len(events) != 0 {
+		t.Fatalf("events after rejected renames: %+v", events)
+	}
+
+	rec := post(`{"from": "old", "to": "new"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("rename = %d %s", rec.Code, rec.Body)
+	}
+	var response LabelRenameResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Rows == 0 || response.SeriesDeleted == 0 {
+		t.Errorf("response = %+v", response)
+	}
+
+	if series := seriesWithValidator(t, tracker, "old"); len(series) != 0 {
+		t.Errorf("old-label series left after rename: %v", series)
+	}
+	snapshot := tracker.Snapshot()
+	if snapshotHasLabel(&snapshot, "old") {
+		t.Error("tracker state still has the old label")
+	}
+	if snapshot.SignedTotals["new"] != 118 || snapshot.MissedTotals["new"] != 2 || snapshot.SignedTotals["keep"] != 119 {
+		t.Errorf("totals after rename: signed %v missed %v", snapshot.SignedTotals, snapshot.MissedTotals)
+	}
+	if got := tracker.history.Signing("new", time.Time{}, time.Now()); len(got) != 2 {
+		t.Errorf("signing history for new = %d records, want 2", len(got))
+	}
+	if len(events) != 1 || events[0].Type != EventLabelRenamed || events[0].Validator != "new" {
+		t.Errorf("events = %+v, want one label_renamed for new", events)
+	}
+}
+
+// 레지스트리에서 validator 라벨이 label인 시리즈 이름
+func seriesWithValidator(t *testing.T, tracker *UnifiedValidatorTracker, label string) []string {
+	t.Helper()
+	families, err := tracker.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if metricHasLabels(metric, []string{"validator", label}) {
+				names = append(names, family.GetName())
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 리포트 생성에 필요한 입력 (저장된 히스토리와 트래커 상태 스냅샷)
+type ReportInput struct {
+	From       time.Time
+	To         time.Time
+	Validators []string // 리포트 대상 벨리데이터 라벨
+	Signing    []SigningRecord
+	Samples    []ValidatorSample
+	Proposals  map[string]map[string]bool // proposal id -> validator -> 투표 여부
+}
+
+type ValidatorSummary struct {
+	Validator        string   `json:"validator"`
+	BlocksSigned     int      `json:"blocks_signed"`
+	BlocksMissed     int      `json:"blocks_missed"`
+	UptimePercent    float64  `json:"uptime_percent"`
+	RankStart        int      `json:"rank_start"`
+	RankEnd          int      `json:"rank_end"`
+	RankChange       int      `json:"rank_change"`
+	TokensStart      string   `json:"tokens_start"`
+	TokensEnd        string   `json:"tokens_end"`
+	TokensChange     string   `json:"tokens_change"`
+	ProposalsVoted   []string `json:"proposals_voted"`
+	ProposalsPending []string `json:"proposals_pending"`
+	JailEvents       int      `json:"jail_events"`
+}
+
+type DailyReport struct {
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Validators []ValidatorSummary `json:"validators"`
+}
+
+// 히스토리로부터 리포트 계산 (부수 효과 없는 순수 함수)
+func BuildDailyReport(in ReportInput) DailyReport {
+	report := DailyReport{From: in.From, To: in.To}
+
+	for _, validator := range in.Validators {
+		summary := ValidatorSummary{
+			Validator:        validator,
+			ProposalsVoted:   []string{},
+			ProposalsPending: []string{},
+		}
+
+		for _, record := range in.Signing {
+			if record.Validator != validator {
+				continue
+			}
+			if record.Signed {
+				summary.BlocksSigned++
+			} else {
+				summary.BlocksMissed++
+			}
+		}
+		if total := summary.BlocksSigned + summary.BlocksMissed; total > 0 {
+			summary.UptimePercent = float64(summary.BlocksSigned) / float64(total) * 100
+		}
+
+		var first, last *ValidatorSample
+		wasJailed := false
+		for i := range in.Samples {
+			sample := &in.Samples[i]
+			if sample.Validator != validator {
+				continue
+			}
+			if first == nil {
+				first = sample
+			} else if sample.Jailed && !wasJailed {
+				summary.JailEvents++
+			}
+			wasJailed = sample.Jailed
+			last = sample
+		}
+		if first != nil {
+			summary.RankStart, summary.RankEnd = first.Rank, last.Rank
+			// 순위는 작을수록 좋으므로 상승을 양수로 표시
+			summary.RankChange = first.Rank - last.Rank
+			summary.TokensStart, summary.TokensEnd = first.Tokens, last.Tokens
+			summary.TokensChange = tokenDelta(first.Tokens, last.Tokens)
+		}
+
+		ids := make([]string, 0, len(in.Proposals))
+		for id := range in.Proposals {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			if in.Proposals[id][validator] {
+				summary.ProposalsVoted = append(summary.ProposalsVoted, id)
+			} else {
+				summary.ProposalsPending = append(summary.ProposalsPending, id)
+			}
+		}
+
+		report.Validators = append(report.Validators, summary)
+	}
+
+	return report
+}
+
+// 큰 정수 문자열 간 차이 (파싱 불가 시 빈 문자열)
+func tokenDelta(from, to string) string {
+	start, ok1 := new(big.Int).SetString(from, 10)
+	end, ok2 := new(big.Int).SetString(to, 10)
+	if !ok1 || !ok2 {
+		return ""
+	}
+	delta := new(big.Int).Sub(end, start)
+	if delta.Sign() > 0 {
+		return "+" + delta.String()
+	}
+	return delta.String()
+}
+
+func RenderReportMarkdown(report DailyReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Period: %s ~ %s (UTC)\n", report.From.UTC().Format("2006-01-02 15:04"), report.To.UTC().Format("2006-01-02 15:04"))
+	for _, v := range report.Validators {
+		fmt.Fprintf(&b, "\n**%s**\n", v.Validator)
+		fmt.Fprintf(&b, "- Uptime: %.2f%% (signed %d / missed %d)\n", v.UptimePercent, v.BlocksSigned, v.BlocksMissed)
+		fmt.Fprintf(&b, "- Rank: %d → %d (%+d)\n", v.RankStart, v.RankEnd, v.RankChange)
+		if v.TokensChange != "" {
+			fmt.Fprintf(&b, "- Tokens: %s (%s)\n", v.TokensEnd, v.TokensChange)
+		}
+		fmt.Fprintf(&b, "- Proposals: %d voted, %d pending", len(v.ProposalsVoted), len(v.ProposalsPending))
+		if len(v.ProposalsPending) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(v.ProposalsPending, ", "))
+		}
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "- Jail events: %d\n", v.JailEvents)
+	}
+	return b.String()
+}
+
+// 현재 트래커 상태로 최근 period 구간의 리포트 생성
+func (vt *UnifiedValidatorTracker) dailyReport(now time.Time, period time.Duration) DailyReport {
+	from := now.Add(-period)
+
+	labels := make([]string, 0, len(vt.validators))
+	for _, label := range vt.validators {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	return BuildDailyReport(ReportInput{
+		From:       from,
+		To:         now,
+		Validators: labels,
+		Signing:    vt.history.Signing("", from, now),
+		Samples:    vt.history.Samples("", from, now),
+		Proposals:  vt.proposalVotesSnapshot(),
+	})
+}
+
+// 매일 지정한 UTC 시각에 리포트를 생성해 알림 채널로 전송
+func (vt *UnifiedValidatorTracker) StartDailyReporter(ctx context.Context, schedule string, format string, notifiers []Notifier) {
+	at, err := time.Parse("15:04", schedule)
+	if err != nil {
+		alertsLog.Warn("Invalid report schedule (expected HH:MM UTC), daily reports disabled", "schedule", schedule, "error", err)
+		return
+	}
+
+	for {
+		next := nextDailyRun(time.Now().UTC(), at.Hour(), at.Minute())
+		alertsLog.Info("Next daily report scheduled", "at", next.Format(time.RFC3339))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		report := vt.dailyReport(next, 24*time.Hour)
+		msg := Message{Title: "0G Galileo daily validator report", Markdown: RenderReportMarkdown(report)}
+		if format == "json" {
+			encoded, _ := json.MarshalIndent(report, "", "  ")
+			msg.Markdown = "```json\n" + string(encoded) + "\n```"
+			msg.Payload = report
+		}
+		notifyAll(ctx, notifiers, msg)
+	}
+}
+
+// now 이후 처음 돌아오는 hour:minute (UTC)
+func nextDailyRun(now time.Time, hour, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func dailyReportInput() ReportInput {
+	from := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	at := func(hours int) time.Time { return from.Add(time.Duration(hours) * time.Hour) }
+	return ReportInput{
+		From:       from,
+		To:         from.Add(24 * time.Hour),
+		Validators: []string{"alpha", "beta", "gamma"},
+		Signing: []SigningRecord{
+			{Height: 1, Time: at(1), Validator: "alpha", Signed: true},
+			{Height: 1, Time: at(1), Validator: "beta", Signed: true},
+			{Height: 2, Time: at(2), Validator: "alpha", Signed: true},
+			{Height: 2, Time: at(2), Validator: "beta", Signed: false},
+			{Height: 3, Time: at(3), Validator: "alpha", Signed: false},
+			{Height: 3, Time: at(3), Validator: "beta", Signed: false},
+			{Height: 4, Time: at(4), Validator: "alpha", Signed: true},
+			{Height: 4, Time: at(4), Validator: "beta", Signed: true},
+		},
+		Samples: []ValidatorSample{
+			{Time: at(0), Validator: "alpha", Tokens: "1000000000000000000000", Rank: 12},
+			{Time: at(0), Validator: "beta", Tokens: "500", Rank: 40, Jailed: true}, // 기간 시작 전부터 수감
+			{Time: at(6), Validator: "beta", Tokens: "500", Rank: 40},
+			{Time: at(12), Validator: "alpha", Tokens: "1000000000000000000250", Rank: 10},
+			{Time: at(12), Validator: "beta", Tokens: "400", Rank: 45, Jailed: true},
+			{Time: at(18), Validator: "beta", Tokens: "400", Rank: 45},
+			{Time: at(20), Validator: "beta", Tokens: "300", Rank: 47, Jailed: true},
+		},
+		Proposals: map[string]map[string]bool{
+			"12": {"alpha": true, "beta": true},
+			"11": {"alpha": true},
+		},
+	}
+}
+
+func TestBuildDailyReport(t *testing.T) {
+	report := BuildDailyReport(dailyReportInput())
+	if len(report.Validators) != 3 {
+		t.Fatalf("validators = %d, want 3", len(report.Validators))
+	}
+
+	alpha := report.Validators[0]
+	want := ValidatorSummary{
+		Validator:        "alpha",
+		BlocksSigned:     3,
+		BlocksMissed:     1,
+		UptimePercent:    75,
+		RankStart:        12,
+		RankEnd:          10,
+		RankChange:       2,
+		TokensStart:      "1000000000000000000000",
+		TokensEnd:        "1000000000000000000250",
+		TokensChange:     "+250",
+		ProposalsVoted:   []string{"11", "12"},
+		ProposalsPending: []string{},
+	}
+	if !reflect.DeepEqual(alpha, want) {
+		t.Errorf("alpha = %+v\nwant %+v", alpha, want)
+	}
+
+	beta := report.Validators[1]
+	if beta.BlocksSigned != 2 || beta.BlocksMissed != 2 || beta.UptimePercent != 50 {
+		t.Errorf("beta signing = %d/%d (%.2f%%)", beta.BlocksSigned, beta.BlocksMissed, beta.UptimePercent)
+	}
+	// 첫 표본의 수감 상태는 기간 이전 사건이므로 세지 않음
+	if beta.JailEvents != 2 {
+		t.Errorf("beta jail events = %d, want 2", beta.JailEvents)
+	}
+	if beta.RankChange != -7 || beta.TokensChange != "-200" {
+		t.Errorf("beta rank/tokens change = %d / %q", beta.RankChange, beta.TokensChange)
+	}
+	if !reflect.DeepEqual(beta.ProposalsPending, []string{"11"}) {
+		t.Errorf("beta pending = %v, want [11]", beta.ProposalsPending)
+	}
+
+	// 기록이 없는 벨리데이터도 빈 요약으로 포함
+	gamma := report.Validators[2]
+	if gamma.BlocksSigned+gamma.BlocksMissed != 0 || gamma.UptimePercent != 0 || gamma.TokensChange != "" {
+		t.Errorf("gamma = %+v, want empty summary", gamma)
+	}
+}
+
+func TestTokenDelta(t *testing.T) {
+	tests := []struct{ from, to, want string }{
+		{"100", "150", "+50"},
+		{"150", "100", "-50"},
+		{"100", "100", "0"},
+		{"1000000000000000000000000", "1000000000000000000000001", "+1"},
+		{"", "100", ""},
+		{"100", "1e3", ""},
+	}
+	for _, tt := range tests {
+		if got := tokenDelta(tt.from, tt.to); got != tt.want {
+			t.Errorf("tokenDelta(%q, %q) = %q, want %q", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestRenderReportMarkdown(t *testing.T) {
+	markdown := RenderReportMarkdown(BuildDailyReport(dailyReportInput()))
+	for _, want := range []string{
+		"Period: 2025-06-01 09:00 ~ 2025-06-02 09:00 (UTC)\n",
+		"**alpha**\n- Uptime: 75.00% (signed 3 / missed 1)\n- Rank: 12 → 10 (+2)\n- Tokens: 1000000000000000000250 (+250)\n- Proposals: 2 voted, 0 pending\n- Jail events: 0\n",
+		"- Proposals: 1 voted, 1 pending (11)\n- Jail events: 2\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown missing %q:\n%s", want, markdown)
+		}
+	}
+	// 토큰 변화를 계산할 수 없으면 줄을 생략
+	gamma := markdown[strings.Index(markdown, "**gamma**"):]
+	if strings.Contains(gamma, "Tokens:") {
+		t.Errorf("gamma section has a tokens line:\n%s", gamma)
+	}
+}
+
+func TestNextDailyRun(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC), time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC), time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)}, // 정각이면 다음 날
+		{time.Date(2025, 6, 30, 23, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := nextDailyRun(tt.now, 9, 0); !got.Equal(tt.want) {
+			t.Errorf("nextDailyRun(%v) = %v, want %v", tt.now, got, tt.want)
+		}
+	}
+}
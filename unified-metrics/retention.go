@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPruneInterval = 5 * time.Minute
+	pruneBatchSize       = 5000 // 한 번에 잠금을 잡고 삭제하는 최대 기록 수
+)
+
+// 보관 기간 파싱: Go duration(72h)과 일 단위(30d) 모두 허용
+func parseRetention(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid retention %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retention %q: %w", value, err)
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("invalid retention %q", value)
+	}
+	return duration, nil
+}
+
+// 보관 정책에 따라 히스토리를 주기적으로 정리
+func (vt *UnifiedValidatorTracker) StartHistoryPruner(ctx context.Context, interval time.Duration) {
+	persistenceLog.Info("Starting history pruner", "interval", interval,
+		"max_age", vt.history.retention.MaxAge, "max_rows", vt.history.retention.MaxRows)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	vt.pruneHistory(ctx)
+	vt.updateMissIntervals()
+	for {
+		select {
+		case <-ctx.Done():
+			persistenceLog.Info("Context cancelled, stopping history pruner")
+			return
+		case <-ticker.C:
+			vt.pruneHistory(ctx)
+			vt.updateMissIntervals()
+		}
+	}
+}
+
+// 배치 단위로 삭제하고 배치 사이에 잠금을 풀어 쓰기가 오래 막히지 않도록 함
+func (vt *UnifiedValidatorTracker) pruneHistory(ctx context.Context) {
+	start := time.Now()
+	totalSigning, totalSamples, totalEvents := 0, 0, 0
+	for ctx.Err() == nil {
+		signing, samples, events := vt.history.PruneBatch(start, pruneBatchSize)
+		totalSigning += signing
+		totalSamples += samples
+		totalEvents += events
+		if signing < pruneBatchSize && samples < pruneBatchSize && events < pruneBatchSize {
+			break
+		}
+	}
+
+	metrics := vt.metrics.exporter
+	metrics.historyPruneDurationMetric.Observe(time.Since(start).Seconds())
+	metrics.historyPrunedMetric.WithLabelValues("signing").Add(float64(totalSigning))
+	metrics.historyPrunedMetric.WithLabelValues("samples").Add(float64(totalSamples))
+	metrics.historyPrunedMetric.WithLabelValues("events").Add(float64(totalEvents))
+	signingRows, sampleRows, eventRows := vt.history.Rows()
+	metrics.historyRowsMetric.WithLabelValues("signing").Set(float64(signingRows))
+	metrics.historyRowsMetric.WithLabelValues("samples").Set(float64(sampleRows))
+	metrics.historyRowsMetric.WithLabelValues("events").Set(float64(eventRows))
+
+	if totalSigning > 0 || totalSamples > 0 || totalEvents > 0 {
+		persistenceLog.Debug("Pruned history", "signing", totalSigning, "samples", totalSamples,
+			"events", totalEvents, "duration", time.Since(start))
+	}
+}
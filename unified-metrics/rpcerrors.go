@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// fetch 실패 종류 (RPC 에러 카운터의 reason 라벨, /api/status, 로그 필드에 같은 값을 사용)
+const (
+	fetchReasonTimeout     = "timeout"
+	fetchReasonRateLimited = "rate_limited"
+	fetchReasonNotFound    = "not_found"
+	fetchReasonHTTPStatus  = "http_status"
+	fetchReasonDecode      = "decode"
+	fetchReasonConnection  = "connection"
+	fetchReasonOther       = "other"
+)
+
+// errors.Is로 확인할 수 있는 fetch 실패 종류
+var (
+	ErrTimeout     = errors.New("timeout")
+	ErrDecode      = errors.New("decode response")
+	ErrRateLimited = errors.New("rate limited")
+	ErrNotFound    = errors.New("not found")
+
+	// 노드가 정리(pruning)했거나 아직 만들어지지 않은 높이 (ErrNotFound와도 일치)
+	ErrHeightNotAvailable = errors.New("height not available")
+)
+
+// 에러 메시지에 넣는 응답 본문 앞부분 길이 (프록시 HTML 에러 페이지 등)
+const httpErrorSnippetBytes = 200
+
+// 200이 아닌 HTTP 응답 (429, 404는 errors.Is로 ErrRateLimited, ErrNotFound와도 일치)
+// CometBFT는 정리된 높이 요청에 500과 JSON-RPC error 본문으로 응답하므로 그 경우 ErrHeightNotAvailable과도 일치
+type ErrHTTPStatus struct {
+	Code int
+	Body string    // 응답 본문 앞부분 (공백은 한 칸으로)
+	RPC  *RPCError // 본문이 JSON-RPC error 객체인 경우
+}
+
+func (e ErrHTTPStatus) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d", e.Code)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.Code, e.Body)
+}
+
+func (e ErrHTTPStatus) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.Code == http.StatusTooManyRequests
+	case ErrNotFound:
+		return e.Code == http.StatusNotFound || (e.RPC != nil && e.RPC.heightNotAvailable())
+	case ErrHeightNotAvailable:
+		return e.RPC != nil && e.RPC.heightNotAvailable()
+	}
+	return false
+}
+
+// CometBFT가 HTTP 200과 함께 보내는 JSON-RPC error 객체
+// 예: {"code": -32603, "message": "Internal error", "data": "height 5 is not available, lowest height is 100"}
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *RPCError) Error() string {
+	if e.Data == "" {
+		return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("rpc error %d: %s: %s", e.Code, e.Message, e.Data)
+}
+
+func (e *RPCError) Is(target error) bool {
+	switch target {
+	case ErrHeightNotAvailable, ErrNotFound:
+		return e.heightNotAvailable()
+	}
+	return false
+}
+
+// 정리된 높이("is not available, lowest height is N")와 아직 없는 높이("must be less than or equal to")
+func (e *RPCError) heightNotAvailable() bool {
+	text := e.Message + " " + e.Data
+	return strings.Contains(text, "is not available") || strings.Contains(text, "lowest height is") ||
+		strings.Contains(text, "must be less than or equal to the current blockchain height")
+}
+
+// 원인 에러를 유지하면서 실패 종류(ErrTimeout, ErrDecode)를 붙인 에러
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string        { return fmt.Sprintf("%v: %v", e.kind, e.err) }
+func (e *kindError) Is(target error) bool { return target == e.kind }
+func (e *kindError) Unwrap() error        { return e.err }
+
+// 어느 fetch가 어느 엔드포인트에서 실패했는지 담은 에러
+type FetchError struct {
+	Method   string // recordFetch에 쓰는 fetch 이름 (block, validators, ...)
+	Endpoint string // sanitizeEndpoint 적용
+	Err      error
+}
+
+func newFetchError(method, endpoint string, err error) *FetchError {
+	return &FetchError{Method: method, Endpoint: sanitizeEndpoint(endpoint), Err: err}
+}
+
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Method, e.Endpoint, e.Err)
+}
+
+func (e *FetchError) Unwrap() error { return e.Err }
+
+// "error" 속성으로 로그에 남기면 method, endpoint, reason 필드로 펼쳐짐
+func (e *FetchError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("method", e.Method),
+		slog.String("endpoint", e.Endpoint),
+		slog.String("reason", fetchErrorReason(e)),
+		slog.String("message", e.Err.Error()),
+	)
+}
+
+// 상태 코드와 본문 앞부분으로 에러 생성 (body가 nil이면 resp.Body에서 읽음)
+func httpStatusError(resp *http.Response, body []byte) ErrHTTPStatus {
+	if body == nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, httpErrorSnippetBytes*2))
+	}
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if len(snippet) > httpErrorSnippetBytes {
+		snippet = strings.ToValidUTF8(snippet[:httpErrorSnippetBytes], "") + "..."
+	}
+	var envelope struct {
+		Error *RPCError `json:"error"`
+	}
+	if json.Unmarshal(body, &envelope) != nil {
+		envelope.Error = nil
+	}
+	return ErrHTTPStatus{Code: resp.StatusCode, Body: snippet, RPC: envelope.Error}
+}
+
+// 요청 전송 단계의 실패 (타임아웃이면 ErrTimeout으로 분류)
+func transportFailure(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &kindError{kind: ErrTimeout, err: err}
+	}
+	return err
+}
+
+func decodeFailure(err error) error {
+	return &kindError{kind: ErrDecode, err: err}
+}
+
+// 디코딩/검증 실패 분류 (노드가 보낸 JSON-RPC error 객체는 디코딩 문제가 아니므로 그대로)
+func responseFailure(err error) error {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return err
+	}
+	return decodeFailure(err)
+}
+
+// 에러를 reason 라벨 값으로 변환 (성공이면 빈 문자열)
+func fetchErrorReason(err error) string {
+	var statusErr ErrHTTPStatus
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrTimeout):
+		return fetchReasonTimeout
+	case errors.Is(err, ErrRateLimited):
+		return fetchReasonRateLimited
+	case errors.Is(err, ErrNotFound):
+		return fetchReasonNotFound
+	case errors.As(err, &statusErr):
+		return fetchReasonHTTPStatus
+	case errors.Is(err, ErrDecode):
+		return fetchReasonDecode
+	case errors.As(err, &netErr):
+		return fetchReasonConnection
+	}
+	return fetchReasonOther
+}
+
+// GET 후 200 응답 본문을 target으로 디코딩 (실패는 종류별로 분류한 FetchError)
+func (vt *UnifiedValidatorTracker) getJSON(ctx context.Context, method, endpoint, url string, target interface{}) error {
+	resp, err := vt.http.Get(ctx, method, endpoint, url)
+	return decodeJSONResponse(method, endpoint, resp, err, target)
+}
+
+// 요청 결과를 분류하고 200 응답 본문을 target으로 디코딩 (resp, err는 client 호출 결과 그대로)
+func decodeJSONResponse(method, endpoint string, resp *http.Response, err error, target interface{}) error {
+	if err != nil {
+		return newFetchError(method, endpoint, transportFailure(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return newFetchError(method, endpoint, httpStatusError(resp, nil))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return newFetchError(method, endpoint, decodeFailure(err))
+	}
+	if v, ok := target.(responseValidator); ok {
+		if err := v.validate(); err != nil {
+			return newFetchError(method, endpoint, responseFailure(err))
+		}
+	}
+	return nil
+}
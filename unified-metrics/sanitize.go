@@ -0,0 +1,64 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// 체인에서 온 문자열을 라벨로 쓸 때의 최대 길이 (룬 기준, 해시 접미사 포함)
+	maxLabelLength = 64
+	// 길이 초과 시 붙이는 해시 접미사 길이 (16진수 문자 수)
+	labelHashLength = 8
+	// 정리 결과가 비었을 때 사용하는 값
+	emptyLabelPlaceholder = "unknown"
+)
+
+// 체인에서 온 문자열(모니커, 버전 등)을 라벨/응답에 안전한 형태로 정리
+// 제어 문자 제거, 공백 정규화, 길이 초과 시 원본 해시를 붙여 자르기 (서로 다른 원본이 같은 값이 되지 않도록)
+func sanitizeLabel(s string) string {
+	if !utf8.ValidString(s) {
+		s = strings.ToValidUTF8(s, "")
+	}
+
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r), r == utf8.RuneError:
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteRune(r)
+	}
+
+	cleaned := b.String()
+	if cleaned == "" {
+		return emptyLabelPlaceholder
+	}
+	if utf8.RuneCountInString(cleaned) <= maxLabelLength {
+		return cleaned
+	}
+
+	sum := sha256.Sum256([]byte(s))
+	suffix := "~" + hex.EncodeToString(sum[:])[:labelHashLength]
+	runes := []rune(cleaned)[:maxLabelLength-len(suffix)]
+	return strings.TrimRight(string(runes), " ") + suffix
+}
+
+// 값이 없을 수 있는 필드용: 빈 문자열은 자리표시자 대신 그대로 유지
+func sanitizeOptionalLabel(s string) string {
+	if s == "" {
+		return ""
+	}
+	return sanitizeLabel(s)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestSanitizeLabelHostileInput(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "zstake", "zstake"},
+		{"empty", "", emptyLabelPlaceholder},
+		{"only spaces", " \t\n ", emptyLabelPlaceholder},
+		{"only controls", "\x00\x01\x7f", emptyLabelPlaceholder},
+		{"newline injection", "alpha\n# TYPE fake gauge\nfake 1", "alpha # TYPE fake gauge fake 1"},
+		{"quote and backslash kept", `a"b\c`, `a"b\c`},
+		{"control characters", "al\x00ph\x1ba", "alpha"},
+		{"whitespace collapsed", "  node \t\r\n one  ", "node one"},
+		{"unicode spaces", "a\u00a0\u2003b\u3000", "a b"},
+		{"invalid utf-8", "ab\xff\xfecd", "abcd"},
+		{"zero width and bidi", "ad\u200bmin\u202e\ufeff", "admin"},
+		{"replacement character", "a\ufffdb", "ab"},
+		{"emoji kept", "🚀 Rocket 노드", "🚀 Rocket 노드"},
+		{"exactly max length", long[:maxLabelLength], long[:maxLabelLength]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeLabel(tt.in); got != tt.want {
+				t.Errorf("sanitizeLabel(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeLabelTruncation(t *testing.T) {
+	a := strings.Repeat("가", 200) + "a"
+	b := strings.Repeat("가", 200) + "b"
+	gotA, gotB := sanitizeLabel(a), sanitizeLabel(b)
+	if gotA == gotB {
+		t.Errorf("different long inputs collapsed to %q", gotA)
+	}
+	for _, got := range []string{gotA, gotB} {
+		if n := utf8.RuneCountInString(got); n != maxLabelLength {
+			t.Errorf("truncated length = %d runes, want %d", n, maxLabelLength)
+		}
+		if !strings.Contains(got, "~") {
+			t.Errorf("truncated label %q has no hash suffix", got)
+		}
+	}
+	if sanitizeLabel(a) != gotA {
+		t.Error("truncation is not deterministic")
+	}
+	// 자른 위치의 공백은 남기지 않음
+	if got := sanitizeLabel(strings.Repeat("a", maxLabelLength-labelHashLength-2) + " " + strings.Repeat("b", 100)); strings.Contains(got, " ~") {
+		t.Errorf("space before hash suffix: %q", got)
+	}
+}
+
+func TestSanitizeOptionalLabel(t *testing.T) {
+	if got := sanitizeOptionalLabel(""); got != "" {
+		t.Errorf("sanitizeOptionalLabel(\"\") = %q", got)
+	}
+	if got := sanitizeOptionalLabel("\x00"); got != emptyLabelPlaceholder {
+		t.Errorf("sanitizeOptionalLabel(control) = %q", got)
+	}
+}
+
+// 어떤 입력이든 결과는 유효한 UTF-8, 최대 길이 이내, 제어 문자 없음, 공백 정규화, 다시 정리해도 그대로
+func FuzzSanitizeLabel(f *testing.F) {
+	for _, seed := range []string{"", "alpha", "a\nb", "\xff\xfe", "\u202e\u200b", " a  b ", strings.Repeat("가", 100), "🚀\x00🚀"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		got := sanitizeLabel(in)
+		if !utf8.ValidString(got) {
+			t.Fatalf("invalid UTF-8 %q", got)
+		}
+		if got == "" {
+			t.Fatal("empty result")
+		}
+		if n := utf8.RuneCountInString(got); n > maxLabelLength {
+			t.Fatalf("%d runes > %d", n, maxLabelLength)
+		}
+		if got != strings.TrimSpace(got) || strings.Contains(got, "  ") {
+			t.Fatalf("whitespace not normalized: %q", got)
+		}
+		for _, r := range got {
+			if r != ' ' && (unicode.IsSpace(r) || unicode.IsControl(r) || unicode.Is(unicode.Cf, r) || r == utf8.RuneError) {
+				t.Fatalf("forbidden rune %U in %q", r, got)
+			}
+		}
+		if again := sanitizeLabel(got); again != got {
+			t.Fatalf("not idempotent: %q -> %q", got, again)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// 느린 수집기 간격에 더하는 무작위 비율 기본값 (수집기끼리 같은 순간에 몰리지 않도록)
+	defaultCollectorJitter    = 0.1
+	defaultNodeStatusInterval = 10 * time.Second
+	defaultStakingInterval    = time.Minute
+)
+
+// 수집기 이름 (COLLECTOR_INTERVALS에서 간격을 바꿀 수 있는 이름)
+const (
+	collectorBlocks     = "blocks"      // 최신 블록 조회 (서명 판단은 블록마다 필요)
+	collectorNodeStatus = "node_status" // 노드 동기화 상태, 체인 ID
+	collectorStaking    = "staking"     // 스테이킹 벨리데이터, 보상, 커미션, 네트워크 개요
+	collectorParams     = "params"      // 스테이킹 파라미터
+	collectorSlashing   = "slashing"    // 슬래싱 파라미터 (서명 윈도우)
+	collectorEconomics  = "economics"   // 노드 최소 가스 가격, EVM 수수료 시장
+	collectorGovernance = "governance"  // 업그레이드 계획, 투표 기간인 제안과 추적 벨리데이터의 투표 여부
+)
+
+var collectorNames = []string{collectorBlocks, collectorNodeStatus, collectorStaking, collectorParams, collectorSlashing, collectorEconomics, collectorGovernance}
+
+// 자기 간격으로 반복 실행되는 수집 작업
+type Collector struct {
+	Name     string
+	Interval time.Duration
+	Retry    time.Duration   // 실패 후 다음 실행까지 (0이면 Interval)
+	Jitter   float64         // 매 간격에 [0, Jitter×간격) 무작위 지연 추가 (0이면 고정 간격)
+	Wake     <-chan struct{} // 값이 오면 간격을 기다리지 않고 바로 실행하고 간격을 다시 셈 (nil이면 간격만)
+	Run      func(ctx context.Context) error
+}
+
+// 수집기 등록과 실행 (수집기마다 고루틴 하나, 컨텍스트와 메트릭 공유)
+type Scheduler struct {
+	mu         sync.Mutex
+	metrics    *ExporterMetrics
+	overrides  map[string]time.Duration // COLLECTOR_INTERVALS
+	collectors []*Collector
+	started    map[string]bool
+	goroutines *GoroutineTracker
+}
+
+func NewScheduler(metrics *ExporterMetrics, overrides map[string]time.Duration, goroutines *GoroutineTracker) *Scheduler {
+	return &Scheduler{metrics: metrics, overrides: overrides, started: make(map[string]bool), goroutines: goroutines}
+}
+
+// 설정으로 바뀐 간격 (없으면 기본값)
+func (s *Scheduler) IntervalFor(name string, fallback time.Duration) time.Duration {
+	if interval, ok := s.overrides[name]; ok {
+		return interval
+	}
+	return fallback
+}
+
+// 수집기 등록 (간격은 COLLECTOR_INTERVALS 설정이 우선, Start에서 실행 시작)
+func (s *Scheduler) Register(collector Collector) {
+	collector.Interval = s.IntervalFor(collector.Name, collector.Interval)
+	if collector.Retry <= 0 || collector.Retry > collector.Interval {
+		collector.Retry = collector.Interval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.collectors = append(s.collectors, &collector)
+}
+
+// 등록된 수집기 중 아직 시작하지 않은 것을 시작
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, collector := range s.collectors {
+		if s.started[collector.Name] {
+			continue
+		}
+		s.started[collector.Name] = true
+		collector := collector
+		s.goroutines.Go(componentScheduler, func() { s.run(ctx, collector) })
+	}
+}
+
+// 등록 즉시 한 번 실행하고, 이후 이전 실행 시작 시각 기준으로 간격 유지 (실행이 간격보다 길면 바로 다음 실행)
+func (s *Scheduler) run(ctx context.Context, collector *Collector) {
+	trackerLog.Info("Starting collector", "collector", collector.Name, "interval", collector.Interval, "jitter", collector.Jitter)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			trackerLog.Info("Context cancelled, stopping collector", "collector", collector.Name)
+			return
+		case <-timer.C:
+		case <-collector.Wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+		start := time.Now()
+		err := s.runOnce(ctx, collector)
+		next := collector.Interval
+		if err != nil {
+			next = collector.Retry
+		}
+		if collector.Jitter > 0 {
+			next += time.Duration(rand.Float64() * collector.Jitter * float64(next))
+		}
+		timer.Reset(max(next-time.Since(start), 0))
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, collector *Collector) error {
+	start := time.Now()
+	err := collector.Run(ctx)
+	duration := time.Since(start)
+
+	name := collector.Name
+	s.metrics.collectorLastRunMetric.WithLabelValues(name).Set(float64(start.Unix()))
+	s.metrics.collectorDurationMetric.WithLabelValues(name).Set(duration.Seconds())
+	if err != nil {
+		s.metrics.collectorRunsMetric.WithLabelValues(name, "error").Inc()
+		s.metrics.collectorErrorMetric.WithLabelValues(name).Set(1)
+		trackerLog.Debug("Collector run failed", "collector", name, "duration", duration, "error", err)
+		return err
+	}
+	s.metrics.collectorRunsMetric.WithLabelValues(name, "success").Inc()
+	s.metrics.collectorErrorMetric.WithLabelValues(name).Set(0)
+	s.metrics.collectorLastSuccessMetric.WithLabelValues(name).Set(float64(start.Unix()))
+	return nil
+}
+
+// "staking=2m,node_status=15s" 형식의 수집기별 간격
+func parseCollectorIntervals(spec string) (map[string]time.Duration, error) {
+	known := make(map[string]bool, len(collectorNames))
+	for _, name := range collectorNames {
+		known[name] = true
+	}
+
+	intervals := make(map[string]time.Duration)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid collector interval %q (expected name=duration)", entry)
+		}
+		if !known[name] {
+			sorted := append([]string(nil), collectorNames...)
+			sort.Strings(sorted)
+			return nil, fmt.Errorf("unknown collector %q (known: %s)", name, strings.Join(sorted, ", "))
+		}
+		interval, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval for collector %q: %w", name, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("interval for collector %q must be positive", name)
+		}
+		intervals[name] = interval
+	}
+	return intervals, nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// 문자열 또는 숫자로 오는 정수 필드 (CometBFT/Cosmos는 문자열이지만 일부 프록시는 숫자로 바꿔서 보냄)
+// null이나 필드가 없으면 빈 문자열이며, 필수 여부는 응답 타입의 validate에서 확인
+type numericString string
+
+func (s *numericString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*s = ""
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*s = numericString(text)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("expected a string or number, got %s", data)
+	}
+	*s = numericString(number)
+	return nil
+}
+
+// 응답을 디코딩한 뒤 필수 필드 확인 (decodeJSONResponse, fetchBlockFrom에서 호출)
+type responseValidator interface {
+	validate() error
+}
+
+// 필수 정수 필드 확인 (없으면 필드 경로를 담은 에러)
+func requireInteger(path string, value numericString) error {
+	if value == "" {
+		return fmt.Errorf("required field %s missing", path)
+	}
+	digits := string(value)
+	if digits[0] == '-' {
+		digits = digits[1:]
+	}
+	if digits == "" {
+		return fmt.Errorf("field %s is not an integer: %q", path, value)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("field %s is not an integer: %q", path, value)
+		}
+	}
+	return nil
+}
+
+func requireString(path, value string) error {
+	if value == "" {
+		return fmt.Errorf("required field %s missing", path)
+	}
+	return nil
+}
+
+func (b *BlockInfo) validate() error {
+	if b.Error != nil {
+		return b.Error
+	}
+	block := &b.Result.Block
+	if err := requireInteger("result.block.header.height", block.Header.Height); err != nil {
+		return err
+	}
+	if err := requireString("result.block.header.chain_id", block.Header.ChainID); err != nil {
+		return err
+	}
+	return requireInteger("result.block.last_commit.height", block.LastCommit.Height)
+}
+
+func (v *ValidatorInfo) validate() error {
+	if v.Error != nil {
+		return v.Error
+	}
+	for i, validator := range v.Result.Validators {
+		if err := requireString(fmt.Sprintf("result.validators[%d].address", i), validator.Address); err != nil {
+			return err
+		}
+		if err := requireInteger(fmt.Sprintf("result.validators[%d].voting_power", i), validator.VotingPower); err != nil {
+			return err
+		}
+	}
+	return requireInteger("result.total", v.Result.Total)
+}
+
+func (v *ValidatorResponse) validate() error {
+	for i, validator := range v.Validators {
+		if err := requireString(fmt.Sprintf("validators[%d].operator_address", i), validator.OperatorAddress); err != nil {
+			return err
+		}
+		if err := requireInteger(fmt.Sprintf("validators[%d].tokens", i), validator.Tokens); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MempoolResponse) validate() error {
+	if err := requireInteger("result.n_txs", m.Result.NTxs); err != nil {
+		return err
+	}
+	if err := requireInteger("result.total", m.Result.Total); err != nil {
+		return err
+	}
+	return requireInteger("result.total_bytes", m.Result.TotalBytes)
+}
+
+func (s *StatusResponse) validate() error {
+	if err := requireString("result.node_info.network", s.Result.NodeInfo.Network); err != nil {
+		return err
+	}
+	return requireInteger("result.sync_info.latest_block_height", s.Result.SyncInfo.LatestBlockHeight)
+}
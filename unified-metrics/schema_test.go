@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNumericStringEncodings(t *testing.T) {
+	tests := []struct {
+		json string
+		want numericString
+		err  bool
+	}{
+		{`"12345"`, "12345", false},
+		{`12345`, "12345", false},
+		{`"123456789012345678901234567890"`, "123456789012345678901234567890", false},
+		{`123456789012345678901234567890`, "123456789012345678901234567890", false}, // float64으로 잘리지 않음
+		{`"-5"`, "-5", false},
+		{` 7 `, "7", false},
+		{`null`, "", false},
+		{`""`, "", false},
+		{`1.5`, "1.5", false}, // 디코딩은 통과하고 정수 여부는 validate에서 확인
+		{`true`, "", true},
+		{`{"v": 1}`, "", true},
+		{`[1]`, "", true},
+	}
+	for _, tt := range tests {
+		var doc struct {
+			V numericString `json:"v"`
+		}
+		err := json.Unmarshal([]byte(`{"v": `+tt.json+`}`), &doc)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: error = %v, want error %v", tt.json, err, tt.err)
+			continue
+		}
+		if err == nil && doc.V != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.json, doc.V, tt.want)
+		}
+	}
+
+	// 필드가 없으면 빈 값
+	var doc struct {
+		V numericString `json:"v"`
+	}
+	if err := json.Unmarshal([]byte(`{}`), &doc); err != nil || doc.V != "" {
+		t.Errorf("missing field = %q, %v", doc.V, err)
+	}
+}
+
+func TestRequireInteger(t *testing.T) {
+	for value, ok := range map[numericString]bool{
+		"0": true, "42": true, "-42": true, "123456789012345678901234567890": true,
+		"": false, "-": false, "1.5": false, "1e3": false, " 1": false, "0x10": false, "abc": false,
+	} {
+		err := requireInteger("result.total", value)
+		if (err == nil) != ok {
+			t.Errorf("requireInteger(%q) = %v, want ok %v", value, err, ok)
+		}
+		if err != nil && !strings.Contains(err.Error(), "result.total") {
+			t.Errorf("error %q does not name the field", err)
+		}
+	}
+}
+
+// 응답 타입마다 문자열/숫자 인코딩을 모두 받아들이고, 필수 필드가 빠지면 그 경로를 에러로 알림
+func TestResponseSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		target func() responseValidator
+		body   string
+		err    string // 빈 문자열이면 성공
+	}{
+		{"block strings", func() responseValidator { return new(BlockInfo) },
+			`{"result":{"block":{"header":{"height":"10","chain_id":"zgtendermint_16601-2"},"last_commit":{"height":"9"}}}}`, ""},
+		{"block numbers", func() responseValidator { return new(BlockInfo) },
+			`{"result":{"block":{"header":{"height":10,"chain_id":"zgtendermint_16601-2"},"last_commit":{"height":9}}}}`, ""},
+		{"block missing height", func() responseValidator { return new(BlockInfo) },
+			`{"result":{"block":{"header":{"chain_id":"zgtendermint_16601-2"},"last_commit":{"height":"9"}}}}`, "result.block.header.height missing"},
+		{"block null height", func() responseValidator { return new(BlockInfo) },
+			`{"result":{"block":{"header":{"height":null,"chain_id":"c"},"last_commit":{"height":"9"}}}}`, "result.block.header.height missing"},
+		{"block missing chain id", func() responseValidator { return new(BlockInfo) },
+			`{"result":{"block":{"header":{"height":"10"},"last_commit":{"height":"9"}}}}`, "result.block.header.chain_id missing"},
+		{"block missing commit height", func() responseValidator { return new(BlockInfo) },
+			`{"result":{"block":{"header":{"height":"10","chain_id":"c"}}}}`, "result.block.last_commit.height missing"},
+		{"block fractional height", func() responseValidator { return new(BlockInfo) },
+			`{"result":{"block":{"header":{"height":10.5,"chain_id":"c"},"last_commit":{"height":"9"}}}}`, "result.block.header.height is not an integer"},
+		{"block rpc error", func() responseValidator { return new(BlockInfo) },
+			prunedHeightBody, "is not available"},
+		{"empty body", func() responseValidator { return new(BlockInfo) }, `{}`, "result.block.header.height missing"},
+
+		{"validators strings", func() responseValidator { return new(ValidatorInfo) },
+			`{"result":{"validators":[{"address":"AB","voting_power":"100"}],"total":"1"}}`, ""},
+		{"validators numbers", func() responseValidator { return new(ValidatorInfo) },
+			`{"result":{"validators":[{"address":"AB","voting_power":100}],"total":1}}`, ""},
+		{"validators missing power", func() responseValidator { return new(ValidatorInfo) },
+			`{"result":{"validators":[{"address":"AB","voting_power":"1"},{"address":"CD"}],"total":"2"}}`, "result.validators[1].voting_power missing"},
+		{"validators missing address", func() responseValidator { return new(ValidatorInfo) },
+			`{"result":{"validators":[{"voting_power":"1"}],"total":"1"}}`, "result.validators[0].address missing"},
+		{"validators missing total", func() responseValidator { return new(ValidatorInfo) },
+			`{"result":{"validators":[]}}`, "result.total missing"},
+
+		{"staking strings", func() responseValidator { return new(ValidatorResponse) },
+			`{"validators":[{"operator_address":"0gvaloper1","tokens":"1000000000000000000000"}]}`, ""},
+		{"staking numbers", func() responseValidator { return new(ValidatorResponse) },
+			`{"validators":[{"operator_address":"0gvaloper1","tokens":1000000000000000000000}]}`, ""},
+		{"staking empty list", func() responseValidator { return new(ValidatorResponse) }, `{"validators":[]}`, ""},
+		{"staking missing tokens", func() responseValidator { return new(ValidatorResponse) },
+			`{"validators":[{"operator_address":"0gvaloper1"}]}`, "validators[0].tokens missing"},
+		{"staking missing operator", func() responseValidator { return new(ValidatorResponse) },
+			`{"validators":[{"tokens":"1"}]}`, "validators[0].operator_address missing"},
+
+		{"mempool strings", func() responseValidator { return new(MempoolResponse) },
+			`{"result":{"n_txs":"3","total":"12","total_bytes":"4096"}}`, ""},
+		{"mempool numbers", func() responseValidator { return new(MempoolResponse) },
+			`{"result":{"n_txs":3,"total":12,"total_bytes":4096}}`, ""},
+		{"mempool missing bytes", func() responseValidator { return new(MempoolResponse) },
+			`{"result":{"n_txs":"3","total":"12"}}`, "result.total_bytes missing"},
+		{"mempool missing total", func() responseValidator { return new(MempoolResponse) },
+			`{"result":{"n_txs":"3","total_bytes":"1"}}`, "result.total missing"},
+		{"mempool missing n_txs", func() responseValidator { return new(MempoolResponse) },
+			`{"result":{}}`, "result.n_txs missing"},
+
+		{"status strings", func() responseValidator { return new(StatusResponse) },
+			`{"result":{"node_info":{"network":"zgtendermint_16601-2"},"sync_info":{"latest_block_height":"10"}}}`, ""},
+		{"status numbers", func() responseValidator { return new(StatusResponse) },
+			`{"result":{"node_info":{"network":"zgtendermint_16601-2"},"sync_info":{"latest_block_height":10}}}`, ""},
+		{"status missing network", func() responseValidator { return new(StatusResponse) },
+			`{"result":{"node_info":{},"sync_info":{"latest_block_height":"10"}}}`, "result.node_info.network missing"},
+		{"status missing height", func() responseValidator { return new(StatusResponse) },
+			`{"result":{"node_info":{"network":"n"},"sync_info":{}}}`, "result.sync_info.latest_block_height missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target()
+			if err := json.Unmarshal([]byte(tt.body), target); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			err := target.validate()
+			if tt.err == "" {
+				if err != nil {
+					t.Errorf("validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("validate error = %v, want containing %q", err, tt.err)
+			}
+		})
+	}
+}
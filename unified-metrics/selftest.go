@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// 셀프 테스트용 합성 블록의 validators_hash (실제 해시와 겹치지 않는 값)
+const selfTestValidatorsHash = "SELFTEST"
+
+const maxSelfTestBodyBytes = 64 << 10
+
+// POST /api/selftest 요청: 나열한 벨리데이터는 미서명, 나머지 추적 벨리데이터는 서명으로 합성
+type SelfTestRequest struct {
+	Missed []string `json:"missed,omitempty"` // 벨리데이터 라벨
+}
+
+type SelfTestValidator struct {
+	Label          string `json:"label"`
+	Address        string `json:"address"`
+	ExpectedSigned bool   `json:"expected_signed"`
+	Signed         bool   `json:"signed"`
+	Evaluated      bool   `json:"evaluated"` // 서명 스캔에서 이 벨리데이터를 평가했는지
+	Pass           bool   `json:"pass"`
+}
+
+type SelfTestMetricDelta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Before float64           `json:"before"`
+	After  float64           `json:"after"`
+	Delta  float64           `json:"delta"`
+}
+
+// /api/selftest 응답 (실제 메트릭/상태는 변경되지 않음)
+type SelfTestResponse struct {
+	Height     int64                 `json:"height"`
+	Pass       bool                  `json:"pass"`
+	Duration   string                `json:"duration"`
+	Validators []SelfTestValidator   `json:"validators"`
+	Metrics    []SelfTestMetricDelta `json:"metrics"`
+}
+
+// 합성 블록을 섀도 트래커의 applier 단계로 적용하고 섀도 레지스트리의 변화량 반환
+// 섀도 트래커는 설정(추적 벨리데이터)만 공유하고 메트릭, 히스토리, 이벤트, 셋 캐시는 별도이며 RPC를 호출하지 않음
+func (vt *UnifiedValidatorTracker) runSelfTest(ctx context.Context, missed map[string]bool) (SelfTestResponse, error) {
+	start := time.Now()
+	shadow := NewUnifiedValidatorTracker([]string{vt.endpoints.Selected()}, vt.validators)
+	registry := prometheus.NewRegistry()
+	if err := shadow.metrics.Register(registry); err != nil {
+		return SelfTestResponse{}, err
+	}
+
+	before, err := gatherSeries(registry)
+	if err != nil {
+		return SelfTestResponse{}, err
+	}
+
+	height := vt.LastHeight() + 1
+	if height < 3 {
+		height = 3
+	}
+	current, previous, set, err := selfTestBlocks(height, vt.validators, missed)
+	if err != nil {
+		return SelfTestResponse{}, err
+	}
+	// 서명 대상 높이(height-2)의 셋을 미리 넣어 두어 셋 조회가 RPC로 가지 않도록 함
+	shadow.validatorSets.recordHashes(height-2, selfTestValidatorsHash, selfTestValidatorsHash)
+	shadow.validatorSets.put(height-2, set, false)
+
+	shadow.applyBlock(ctx, blockSummary{height: height, block: current, previous: previous})
+
+	after, err := gatherSeries(registry)
+	if err != nil {
+		return SelfTestResponse{}, err
+	}
+
+	response := SelfTestResponse{Height: height, Pass: true, Metrics: diffSeries(before, after)}
+	shadow.mu.Lock()
+	for address, label := range vt.validators {
+		result := SelfTestValidator{
+			Label:          label,
+			Address:        address,
+			ExpectedSigned: !missed[label],
+			Signed:         shadow.signedTotal[label] == 1,
+			Evaluated:      shadow.signedTotal[label]+shadow.missedTotal[label] == 1,
+		}
+		result.Pass = result.Evaluated && result.Signed == result.ExpectedSigned
+		response.Pass = response.Pass && result.Pass
+		response.Validators = append(response.Validators, result)
+	}
+	shadow.mu.Unlock()
+	sort.Slice(response.Validators, func(i, j int) bool { return response.Validators[i].Label < response.Validators[j].Label })
+	response.Duration = time.Since(start).Round(time.Microsecond).String()
+	return response, nil
+}
+
+// 합성 블록 쌍과 셋 생성 (노드 응답과 같은 JSON 형식으로 만든 뒤 디코딩)
+// 서명 주소는 소문자로 넣어 주소 정규화 경로도 함께 확인
+func selfTestBlocks(height int64, validators map[string]string, missed map[string]bool) (*BlockInfo, *BlockInfo, *ValidatorInfo, error) {
+	addresses := make([]string, 0, len(validators))
+	for address := range validators {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	var signatures, members []map[string]interface{}
+	for _, address := range addresses {
+		signature := "c2VsZnRlc3Q="
+		if missed[validators[address]] {
+			signature = ""
+		}
+		signatures = append(signatures, map[string]interface{}{
+			"block_id_flag":     2,
+			"validator_address": strings.ToLower(address),
+			"signature":         signature,
+		})
+		members = append(members, map[string]interface{}{"address": address, "voting_power": "1"})
+	}
+	proposer := ""
+	if len(addresses) > 0 {
+		proposer = addresses[0]
+	}
+
+	block := func(h int64, signatures []map[string]interface{}) (*BlockInfo, error) {
+		var info BlockInfo
+		err := decodeSynthetic(map[string]interface{}{"result": map[string]interface{}{"block": map[string]interface{}{
+			"header": map[string]interface{}{
+				"height":               strconv.FormatInt(h, 10),
+				"time":                 time.Now().UTC().Format(time.RFC3339Nano),
+				"proposer_address":     proposer,
+				"validators_hash":      selfTestValidatorsHash,
+				"next_validators_hash": selfTestValidatorsHash,
+			},
+			"last_commit": map[string]interface{}{"height": strconv.FormatInt(h-1, 10), "signatures": signatures},
+		}}}, &info)
+		return &info, err
+	}
+
+	current, err := block(height, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	previous, err := block(height-1, signatures)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var set ValidatorInfo
+	err = decodeSynthetic(map[string]interface{}{"result": map[string]interface{}{
+		"validators": members,
+		"total":      strconv.Itoa(len(members)),
+	}}, &set)
+	return current, previous, &set, err
+}
+
+func decodeSynthetic(value interface{}, target interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// 레지스트리의 게이지/카운터 값을 "이름{라벨}" 키로 수집 (히스토그램 등은 제외)
+func gatherSeries(registry *prometheus.Registry) (map[string]SelfTestMetricDelta, error) {
+	families, err := registry.Gather()
+	if err != nil {
+		return nil, err
+	}
+	series := make(map[string]SelfTestMetricDelta)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			var value float64
+			switch family.GetType() {
+			case dto.MetricType_GAUGE:
+				value = metric.GetGauge().GetValue()
+			case dto.MetricType_COUNTER:
+				value = metric.GetCounter().GetValue()
+			case dto.MetricType_UNTYPED:
+				value = metric.GetUntyped().GetValue()
+			default:
+				continue
+			}
+			entry := SelfTestMetricDelta{Name: family.GetName(), After: value}
+			key := family.GetName()
+			if pairs := metric.GetLabel(); len(pairs) > 0 {
+				entry.Labels = make(map[string]string, len(pairs))
+				parts := make([]string, 0, len(pairs))
+				for _, pair := range pairs {
+					entry.Labels[pair.GetName()] = pair.GetValue()
+					parts = append(parts, fmt.Sprintf("%s=%q", pair.GetName(), pair.GetValue()))
+				}
+				key += "{" + strings.Join(parts, ",") + "}"
+			}
+			series[key] = entry
+		}
+	}
+	return series, nil
+}
+
+// 값이 바뀌었거나 새로 생긴 시리즈만 이름 순으로 반환
+func diffSeries(before, after map[string]SelfTestMetricDelta) []SelfTestMetricDelta {
+	keys := make([]string, 0, len(after))
+	for key, entry := range after {
+		if previous, ok := before[key]; ok && previous.After == entry.After {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	deltas := make([]SelfTestMetricDelta, 0, len(keys))
+	for _, key := range keys {
+		entry := after[key]
+		entry.Before = before[key].After
+		entry.Delta = entry.After - entry.Before
+		deltas = append(deltas, entry)
+	}
+	return deltas
+}
+
+// POST /api/selftest
+func (vt *UnifiedValidatorTracker) handleSelfTest(w http.ResponseWriter, r *http.Request) {
+	var request SelfTestRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxSelfTestBodyBytes)).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		writeAPIError(w, http.StatusBadRequest, "invalid request: %v", err)
+		return
+	}
+
+	known := make(map[string]bool, len(vt.validators))
+	for _, label := range vt.validators {
+		known[label] = true
+	}
+	missed := make(map[string]bool, len(request.Missed))
+	for _, label := range request.Missed {
+		if !known[label] {
+			writeAPIError(w, http.StatusBadRequest, "unknown validator label %q", label)
+			return
+		}
+		missed[label] = true
+	}
+
+	response, err := vt.runSelfTest(r.Context(), missed)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "self-test failed: %v", err)
+		return
+	}
+	httpLog.Info("Self-test completed", "pass", response.Pass, "validators", len(response.Validators), "missed", len(missed))
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 실제 레지스트리의 시리즈 (런타임/프로세스 컬렉터와 수집 시각으로 계산하는 staleness는 호출과 무관하게 변하므로 제외)
+func gatherTrackerSeries(t *testing.T, tracker *UnifiedValidatorTracker) map[string]SelfTestMetricDelta {
+	t.Helper()
+	series, err := gatherSeries(tracker.registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := range series {
+		if strings.HasPrefix(key, "go_") || strings.HasPrefix(key, "process_") || key == "og_galileo_exporter_data_staleness_seconds" {
+			delete(series, key)
+		}
+	}
+	return series
+}
+
+// 셀프 테스트는 섀도 트래커에만 적용: 실제 메트릭, 높이, 히스토리, 이벤트는 그대로
+func TestSelfTestLeavesTrackerUnchanged(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta", "gamma")
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.advance(3); err != nil {
+		t.Fatal(err)
+	}
+	var published []Event
+	h.tracker.events.AddSink(func(event Event) { published = append(published, event) })
+
+	before := gatherTrackerSeries(t, h.tracker)
+	height := h.tracker.LastHeight()
+	signing := h.tracker.history.Signing("beta", time.Time{}, time.Now().Add(time.Hour))
+
+	response, err := h.tracker.runSelfTest(h.ctx, map[string]bool{"beta": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Pass || response.Height != height+1 {
+		t.Errorf("response pass=%v height=%d, want pass at %d", response.Pass, response.Height, height+1)
+	}
+
+	if after := gatherTrackerSeries(t, h.tracker); !reflect.DeepEqual(after, before) {
+		for key, entry := range after {
+			if before[key].After != entry.After {
+				t.Errorf("real registry changed: %s %v -> %v", key, before[key].After, entry.After)
+			}
+		}
+		for key := range before {
+			if _, ok := after[key]; !ok {
+				t.Errorf("real registry lost %s", key)
+			}
+		}
+	}
+	if got := h.tracker.LastHeight(); got != height {
+		t.Errorf("last height = %d, want %d", got, height)
+	}
+	if got := h.tracker.history.Signing("beta", time.Time{}, time.Now().Add(time.Hour)); len(got) != len(signing) {
+		t.Errorf("signing history = %d records, want %d", len(got), len(signing))
+	}
+	if len(published) != 0 {
+		t.Errorf("self test published events on the real bus: %+v", published)
+	}
+}
+
+// 변화량은 합성한 서명/누락 집합과 일치
+func TestSelfTestMetricDeltas(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta", "gamma")
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	response, err := h.tracker.runSelfTest(h.ctx, map[string]bool{"beta": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantValidators := []SelfTestValidator{
+		{Label: "alpha", ExpectedSigned: true, Signed: true, Evaluated: true, Pass: true},
+		{Label: "beta", ExpectedSigned: false, Signed: false, Evaluated: true, Pass: true},
+		{Label: "gamma", ExpectedSigned: true, Signed: true, Evaluated: true, Pass: true},
+	}
+	for i := range response.Validators {
+		response.Validators[i].Address = ""
+	}
+	if !reflect.DeepEqual(response.Validators, wantValidators) {
+		t.Errorf("validators = %+v", response.Validators)
+	}
+
+	deltas := make(map[string]map[string]float64) // 이름 -> validator -> 변화량
+	for _, delta := range response.Metrics {
+		if deltas[delta.Name] == nil {
+			deltas[delta.Name] = make(map[string]float64)
+		}
+		deltas[delta.Name][delta.Labels["validator"]] = delta.Delta
+		if delta.Delta != delta.After-delta.Before {
+			t.Errorf("%s%v: delta %v != %v - %v", delta.Name, delta.Labels, delta.Delta, delta.After, delta.Before)
+		}
+	}
+	want := map[string]map[string]float64{
+		"og_galileo_validator_beacon_block_signed":       {"alpha": 1, "beta": 0, "gamma": 1},
+		"og_galileo_validator_missed_blocks_total":       {"beta": 1},
+		"cometbft_consensus_validator_missed_blocks":     {"alpha": 0, "beta": 1, "gamma": 0},
+		"og_galileo_validator_consecutive_missed_blocks": {"alpha": 0, "beta": 1, "gamma": 0},
+	}
+	for name, byValidator := range want {
+		if !reflect.DeepEqual(deltas[name], byValidator) {
+			t.Errorf("%s deltas = %v, want %v", name, deltas[name], byValidator)
+		}
+	}
+
+	// 모두 서명한 경우 누락 카운터는 생기지 않음
+	response, err = h.tracker.runSelfTest(h.ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, delta := range response.Metrics {
+		if delta.Name == "og_galileo_validator_missed_blocks_total" {
+			t.Errorf("missed counter with nothing missed: %+v", delta)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// 블록 하나의 서명 스캔에 쓰는 조회용 버퍼
+// 블록마다 맵을 새로 만들지 않도록 풀에서 꺼내 재사용 (캐치업과 실시간 추적이 동시에 호출해도 안전)
+type signatureScan struct {
+	signed  map[string]bool // 정규화한 주소 → 서명 여부
+	inSet   map[string]bool // 정규화한 주소 → 서명 대상 높이의 셋 포함 여부 (hasSet이 false면 사용 안 함)
+	hasSet  bool
+	records []SigningRecord
+
+	signedAt map[string]int64 // 정규화한 주소 → 커밋 서명 타임스탬프 (유닉스 나노초)
+	times    []int64          // 전체 서명 타임스탬프 (commitlatency.go에서 정렬)
+}
+
+// 전체 셋(수백 개) 규모를 기준으로 미리 할당
+const signatureScanCapacity = 256
+
+var signatureScanPool = sync.Pool{
+	New: func() interface{} {
+		return &signatureScan{
+			signed:  make(map[string]bool, signatureScanCapacity),
+			inSet:   make(map[string]bool, signatureScanCapacity),
+			records: make([]SigningRecord, 0, signatureScanCapacity),
+
+			signedAt: make(map[string]int64, signatureScanCapacity),
+			times:    make([]int64, 0, signatureScanCapacity),
+		}
+	},
+}
+
+func acquireSignatureScan() *signatureScan {
+	return signatureScanPool.Get().(*signatureScan)
+}
+
+// 버퍼를 비우고 풀에 반환 (반환 후에는 맵/슬라이스를 참조하면 안 됨)
+func (s *signatureScan) release() {
+	clear(s.signed)
+	clear(s.inSet)
+	s.hasSet = false
+	s.records = s.records[:0]
+	clear(s.signedAt)
+	s.times = s.times[:0]
+	signatureScanPool.Put(s)
+}
+
+// 합의 주소 비교용 정규화 (RPC마다 16진수 대소문자가 다를 수 있음)
+// 이미 대문자면 strings.ToUpper가 할당 없이 그대로 반환
+func normalizeAddress(address string) string {
+	return strings.ToUpper(strings.TrimSpace(address))
+}
+
+// 커밋 서명 목록에서 서명한 주소 집합과 서명 타임스탬프 구성
+func (s *signatureScan) loadSignatures(block *BlockInfo) {
+	for _, sig := range block.Result.Block.LastCommit.Signatures {
+		if sig.Signature == "" {
+			continue
+		}
+		address := normalizeAddress(sig.ValidatorAddress)
+		s.signed[address] = true
+		// RFC3339 형식은 time.Parse가 할당 없이 처리
+		if at, err := time.Parse(time.RFC3339Nano, sig.Timestamp); err == nil {
+			s.signedAt[address] = at.UnixNano()
+			s.times = append(s.times, at.UnixNano())
+		}
+	}
+}
+
+func (s *signatureScan) loadValidatorSet(validatorSet *ValidatorInfo) {
+	s.hasSet = true
+	for _, validator := range validatorSet.Result.Validators {
+		s.inSet[normalizeAddress(validator.Address)] = true
+	}
+}
+
+// 셋 정보를 가져오지 못했으면 모든 추적 벨리데이터를 평가 대상으로 봄
+func (s *signatureScan) expected(address string) bool {
+	return !s.hasSet || s.inSet[address]
+}
+
+// 서명 집합 복사본 (비동기 교차 검증처럼 스캔이 끝난 뒤에도 쓰는 경우)
+func (s *signatureScan) signedCopy() map[string]bool {
+	signed := make(map[string]bool, len(s.signed))
+	for address := range s.signed {
+		signed[address] = true
+	}
+	return signed
+}
+
+// 커밋 참여율: 서명 대상 셋 대비 서명 수 (셋을 모르면 커밋의 서명 슬롯 수 기준)
+func (s *signatureScan) participation(block *BlockInfo) float64 {
+	size := len(block.Result.Block.LastCommit.Signatures)
+	if s.hasSet {
+		size = len(s.inSet)
+	}
+	if size == 0 {
+		return 0
+	}
+	return float64(len(s.signed)) / float64(size)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+type scanFixture struct {
+	block   *BlockInfo
+	set     *ValidatorInfo
+	tracked []string // 추적 주소 (설정에 적힌 그대로, 대소문자와 공백이 섞임)
+}
+
+// n개 벨리데이터 셋과 커밋을 생성 (일부는 서명하지 않고, 일부 추적 벨리데이터는 셋 밖)
+func newScanFixture(t testing.TB, rng *rand.Rand, n int) scanFixture {
+	t.Helper()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var sigs, members []string
+	var tracked []string
+	for i := 0; i < n; i++ {
+		address := fmt.Sprintf("%040X", i+1)
+		members = append(members, fmt.Sprintf(`{"address":%q,"voting_power":"10"}`, address))
+		signature, timestamp := "c2ln", base.Add(time.Duration(rng.Intn(500))*time.Millisecond).Format(time.RFC3339Nano)
+		if rng.Intn(5) == 0 {
+			signature, timestamp = "", "0001-01-01T00:00:00Z" // 부재 서명 슬롯
+		}
+		// RPC에 따라 소문자 주소가 올 수 있음
+		sigAddress := address
+		if rng.Intn(2) == 0 {
+			sigAddress = strings.ToLower(address)
+		}
+		sigs = append(sigs, fmt.Sprintf(`{"validator_address":%q,"timestamp":%q,"signature":%q}`, sigAddress, timestamp, signature))
+		if rng.Intn(2) == 0 {
+			configured := address
+			if rng.Intn(3) == 0 {
+				configured = " " + strings.ToLower(address) + " "
+			}
+			tracked = append(tracked, configured)
+		}
+	}
+	// 셋에 없는 추적 벨리데이터
+	tracked = append(tracked, fmt.Sprintf("%040X", n+1), fmt.Sprintf("%040x", n+2))
+
+	var fixture scanFixture
+	fixture.tracked = tracked
+	fixture.block = new(BlockInfo)
+	blockJSON := `{"result":{"block":{"header":{"height":"100"},"last_commit":{"height":"99","signatures":[` + strings.Join(sigs, ",") + `]}}}}`
+	if err := json.Unmarshal([]byte(blockJSON), fixture.block); err != nil {
+		t.Fatal(err)
+	}
+	fixture.set = new(ValidatorInfo)
+	if err := json.Unmarshal([]byte(`{"result":{"validators":[`+strings.Join(members, ",")+`]}}`), fixture.set); err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+// 최적화 전 방식: 추적 벨리데이터마다 서명과 셋 전체를 훑어 대소문자 무시 비교
+func naiveSignatureScan(f scanFixture, withSet bool) (expected, signed map[string]bool, participation float64) {
+	expected, signed = make(map[string]bool), make(map[string]bool)
+	signatures := f.block.Result.Block.LastCommit.Signatures
+	for _, address := range f.tracked {
+		address = strings.TrimSpace(address)
+		inSet := !withSet
+		for _, member := range f.set.Result.Validators {
+			if withSet && strings.EqualFold(member.Address, address) {
+				inSet = true
+			}
+		}
+		if !inSet {
+			continue
+		}
+		expected[address] = true
+		for _, sig := range signatures {
+			if sig.Signature != "" && strings.EqualFold(sig.ValidatorAddress, address) {
+				signed[address] = true
+			}
+		}
+	}
+	count := 0
+	for _, sig := range signatures {
+		if sig.Signature != "" {
+			count++
+		}
+	}
+	size := len(signatures)
+	if withSet {
+		size = len(f.set.Result.Validators)
+	}
+	return expected, signed, float64(count) / float64(size)
+}
+
+// 풀에서 재사용한 스캔 버퍼도 처음 만든 것과 같은 판정을 내려야 함 (이전 블록의 값이 남지 않음)
+func TestSignatureScanMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		f := newScanFixture(t, rng, 50+rng.Intn(300))
+		withSet := round%3 != 0
+
+		wantExpected, wantSigned, wantParticipation := naiveSignatureScan(f, withSet)
+
+		scan := acquireSignatureScan()
+		if withSet {
+			scan.loadValidatorSet(f.set)
+		}
+		scan.loadSignatures(f.block)
+		for _, address := range f.tracked {
+			key := strings.TrimSpace(address)
+			normalized := normalizeAddress(address)
+			if got := scan.expected(normalized); got != wantExpected[key] {
+				t.Fatalf("round %d: expected(%s) = %v, want %v", round, address, got, wantExpected[key])
+			}
+			if !wantExpected[key] {
+				continue
+			}
+			if got := scan.signed[normalized]; got != wantSigned[key] {
+				t.Fatalf("round %d: signed(%s) = %v, want %v", round, address, got, wantSigned[key])
+			}
+		}
+		if got := scan.participation(f.block); got != wantParticipation {
+			t.Fatalf("round %d: participation = %v, want %v", round, got, wantParticipation)
+		}
+		scan.release()
+	}
+}
+
+// 추적 벨리데이터 수(200개 이상)에 따른 블록당 스캔 비용: 풀 버퍼 + 정규화 맵 vs 최적화 전 중첩 루프
+func BenchmarkSignatureScan(b *testing.B) {
+	for _, n := range []int{200, 500, 1000} {
+		f := newScanFixture(b, rand.New(rand.NewSource(int64(n))), n)
+		b.Run(fmt.Sprintf("pooled/validators=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				scan := acquireSignatureScan()
+				scan.loadValidatorSet(f.set)
+				scan.loadSignatures(f.block)
+				signed := 0
+				for _, address := range f.tracked {
+					normalized := normalizeAddress(address)
+					if scan.expected(normalized) && scan.signed[normalized] {
+						signed++
+					}
+				}
+				scan.release()
+			}
+		})
+		b.Run(fmt.Sprintf("naive/validators=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				naiveSignatureScan(f, true)
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSinkTopic         = "og-galileo.blocks"
+	defaultSinkBufferMax     = 100000
+	defaultSinkRetryInterval = 5 * time.Second
+	defaultSinkTimeout       = 5 * time.Second
+	// 한 번에 outbox에서 꺼내 전송하는 블록 수
+	sinkBatchSize = 100
+)
+
+// 블록 기록을 내보내는 메시지 큐 (StartSink 고루틴에서만 사용하므로 동시 호출 없음)
+// Publish가 nil을 반환하면 브로커가 메시지를 받은 것으로 보고 outbox에서 삭제
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, record BlockRecord, payload []byte) error
+	Close() error
+}
+
+// SINK_URL의 스킴으로 브로커 선택 (비어 있으면 nil)
+// redis://[user:password@]host:port → SINK_TOPIC 스트림에 XADD
+// nats://[user:password@|token@]host:port → SINK_TOPIC 서브젝트에 PUB
+func sinkFromEnv() (Sink, error) {
+	raw := getEnv("SINK_URL", "")
+	if raw == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SINK_URL: %w", err)
+	}
+	topic := getEnv("SINK_TOPIC", defaultSinkTopic)
+	timeout := getEnvDuration("SINK_TIMEOUT", defaultSinkTimeout)
+	switch u.Scheme {
+	case "redis":
+		return &RedisStreamSink{brokerConn: newBrokerConn(u, "6379", timeout), stream: topic,
+			maxLen: getEnvInt64("SINK_REDIS_MAXLEN", 0)}, nil
+	case "nats":
+		return &NATSSink{brokerConn: newBrokerConn(u, "4222", timeout), subject: topic}, nil
+	default:
+		return nil, fmt.Errorf("unsupported SINK_URL scheme %q (expected redis or nats)", u.Scheme)
+	}
+}
+
+// 브로커 TCP 연결 (오류가 나면 닫고 다음 전송 때 다시 연결)
+type brokerConn struct {
+	address  string
+	username string
+	password string
+	timeout  time.Duration
+	conn     net.Conn
+	reader   *bufio.Reader
+}
+
+func newBrokerConn(u *url.URL, defaultPort string, timeout time.Duration) brokerConn {
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	password, _ := u.User.Password()
+	return brokerConn{address: address, username: u.User.Username(), password: password, timeout: timeout}
+}
+
+func (c *brokerConn) dial(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+	return nil
+}
+
+// 요청 하나의 읽기/쓰기 기한 (ctx 기한이 더 이르면 그것을 따름)
+func (c *brokerConn) setDeadline(ctx context.Context) {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.conn.SetDeadline(deadline)
+}
+
+func (c *brokerConn) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *brokerConn) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn, c.reader = nil, nil
+	return err
+}
+
+// Redis 스트림: 항목 ID를 "<높이>-0"으로 지정해 재전송된 블록은 Redis가 거부 (중복 없이 at-least-once)
+type RedisStreamSink struct {
+	brokerConn
+	stream string
+	maxLen int64 // 0이 아니면 MAXLEN ~ 으로 스트림 길이 제한
+}
+
+// 서버가 돌려준 오류 응답 (연결은 정상)
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func (s *RedisStreamSink) Name() string { return "redis" }
+
+func (s *RedisStreamSink) Publish(ctx context.Context, record BlockRecord, payload []byte) error {
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			s.Close()
+			return err
+		}
+	}
+	args := []string{"XADD", s.stream}
+	if s.maxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.FormatInt(s.maxLen, 10))
+	}
+	args = append(args, fmt.Sprintf("%d-0", record.Height), "height", strconv.FormatInt(record.Height, 10), "data", string(payload))
+
+	_, err := s.command(ctx, args...)
+	var replyErr redisError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &replyErr):
+		// 이미 같은(또는 더 높은) 높이가 스트림에 있음: 이전 실행에서 전송 후 확인 전에 종료된 경우
+		if strings.Contains(string(replyErr), "equal or smaller") {
+			sinkLog.Debug("Block already in stream", "stream", s.stream, "height", record.Height)
+			return nil
+		}
+		return err
+	default:
+		s.Close()
+		return err
+	}
+}
+
+func (s *RedisStreamSink) connect(ctx context.Context) error {
+	if err := s.dial(ctx); err != nil {
+		return err
+	}
+	if s.password == "" {
+		return nil
+	}
+	args := []string{"AUTH", s.password}
+	if s.username != "" {
+		args = []string{"AUTH", s.username, s.password}
+	}
+	_, err := s.command(ctx, args...)
+	return err
+}
+
+// RESP 배열로 명령을 보내고 응답 하나를 읽음
+func (s *RedisStreamSink) command(ctx context.Context, args ...string) (string, error) {
+	s.setDeadline(ctx)
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(s.conn, b.String()); err != nil {
+		return "", err
+	}
+
+	line, err := s.readLine()
+	if err != nil {
+		return "", err
+	}
+	if line == "" {
+		return "", fmt.Errorf("redis: empty reply")
+	}
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", redisError(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return "", err
+		}
+		bulk := make([]byte, n+2)
+		if _, err := io.ReadFull(s.reader, bulk); err != nil {
+			return "", err
+		}
+		return string(bulk[:n]), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+// NATS: 서버가 헤더를 지원하면 Nats-Msg-Id(체인 ID와 높이)를 붙여 JetStream 중복 제거에 사용
+// 메시지마다 PING/PONG으로 서버 수신을 확인
+type NATSSink struct {
+	brokerConn
+	subject string
+	headers bool
+}
+
+func (s *NATSSink) Name() string { return "nats" }
+
+func (s *NATSSink) Publish(ctx context.Context, record BlockRecord, payload []byte) error {
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			s.Close()
+			return err
+		}
+	}
+	s.setDeadline(ctx)
+
+	var b strings.Builder
+	if s.headers {
+		header := fmt.Sprintf("NATS/1.0\r\nNats-Msg-Id: %s-%d\r\n\r\n", record.ChainID, record.Height)
+		fmt.Fprintf(&b, "HPUB %s %d %d\r\n%s", s.subject, len(header), len(header)+len(payload), header)
+	} else {
+		fmt.Fprintf(&b, "PUB %s %d\r\n", s.subject, len(payload))
+	}
+	b.Write(payload)
+	b.WriteString("\r\nPING\r\n")
+	if _, err := io.WriteString(s.conn, b.String()); err != nil {
+		s.Close()
+		return err
+	}
+	if err := s.waitPong(); err != nil {
+		s.Close()
+		return err
+	}
+	return nil
+}
+
+func (s *NATSSink) connect(ctx context.Context) error {
+	if err := s.dial(ctx); err != nil {
+		return err
+	}
+	s.setDeadline(ctx)
+
+	line, err := s.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("nats: unexpected greeting %q", line)
+	}
+	var info struct {
+		Headers bool `json:"headers"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info); err != nil {
+		return fmt.Errorf("nats: decode INFO: %w", err)
+	}
+	s.headers = info.Headers
+
+	options := map[string]interface{}{"verbose": false, "pedantic": false, "name": "og-galileo-unified-metrics", "lang": "go", "headers": info.Headers}
+	switch {
+	case s.password != "":
+		options["user"], options["pass"] = s.username, s.password
+	case s.username != "":
+		options["auth_token"] = s.username
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return err
+	}
+	return s.waitPong()
+}
+
+// PONG까지 읽음 (서버 PING에는 응답, -ERR이면 실패)
+func (s *NATSSink) waitPong() error {
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := io.WriteString(s.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+// 적용한 블록을 outbox에 추가하고 sink 깨움 (sink가 없으면 아무것도 하지 않음)
+func (vt *UnifiedValidatorTracker) queueBlockRecord(height int64, block *BlockInfo, records []SigningRecord) {
+	if vt.sink == nil {
+		return
+	}
+	vt.mu.Lock()
+	published := vt.sinkPublished
+	vt.mu.Unlock()
+	if height <= published {
+		return
+	}
+
+	header := block.Result.Block.Header
+	record := BlockRecord{
+		ChainID:           vt.ChainID(),
+		Height:            height,
+		Proposer:          header.ProposerAddress,
+		ProposerValidator: vt.validators[header.ProposerAddress],
+		TxCount:           len(block.Result.Block.Data.Txs),
+		Signed:            make(map[string]bool, len(records)),
+	}
+	if blockTime, err := time.Parse(time.RFC3339Nano, header.Time); err == nil {
+		record.Time = blockTime
+	}
+	for _, signing := range records {
+		record.Signed[signing.Validator] = signing.Signed
+	}
+
+	if dropped := vt.history.AddOutbox(record); dropped > 0 {
+		sinkLog.Warn("Sink buffer full, dropped oldest unpublished blocks", "dropped", dropped)
+		vt.metrics.exporter.sinkDroppedMetric.Add(float64(dropped))
+	}
+	vt.metrics.exporter.sinkBacklogMetric.Set(float64(vt.history.OutboxLen()))
+	select {
+	case vt.sinkWake <- struct{}{}:
+	default:
+	}
+}
+
+// outbox를 브로커로 전송 (실패하면 retry 후 다시 시도, 블록 적용은 outbox에 쌓기만 하므로 막히지 않음)
+func (vt *UnifiedValidatorTracker) StartSink(ctx context.Context, retry time.Duration) {
+	sinkLog.Info("Starting block sink", "sink", vt.sink.Name(), "retry_interval", retry, "backlog", vt.history.OutboxLen())
+	defer vt.sink.Close()
+	ticker := time.NewTicker(retry)
+	defer ticker.Stop()
+
+	for {
+		if err := vt.flushOutbox(ctx); err != nil && ctx.Err() == nil {
+			sinkLog.Warn("Failed to publish block, will retry", "sink", vt.sink.Name(), "backlog", vt.history.OutboxLen(), "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-vt.sinkWake:
+		case <-ticker.C:
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) flushOutbox(ctx context.Context) error {
+	metrics := vt.metrics.exporter
+	for ctx.Err() == nil {
+		batch := vt.history.Outbox(sinkBatchSize)
+		if len(batch) == 0 {
+			return nil
+		}
+		for _, record := range batch {
+			payload, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if err := vt.sink.Publish(ctx, record, payload); err != nil {
+				metrics.sinkFailuresMetric.Inc()
+				return err
+			}
+			vt.history.AckOutbox(record.Height)
+			vt.mu.Lock()
+			vt.sinkPublished = record.Height
+			vt.mu.Unlock()
+			metrics.sinkPublishedMetric.Inc()
+			metrics.sinkHeightMetric.Set(float64(record.Height))
+			metrics.sinkBacklogMetric.Set(float64(vt.history.OutboxLen()))
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,545 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockRecordJSON(t *testing.T) {
+	record := BlockRecord{
+		ChainID:           "zgtendermint_16601-2",
+		Height:            4120345,
+		Time:              time.Date(2025, 6, 1, 12, 0, 1, 250000000, time.UTC),
+		Proposer:          "A1B2C3",
+		ProposerValidator: "alpha",
+		TxCount:           3,
+		Signed:            map[string]bool{"beta": false, "alpha": true},
+	}
+	payload, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"chain_id":"zgtendermint_16601-2","height":4120345,"time":"2025-06-01T12:00:01.25Z","proposer":"A1B2C3","proposer_validator":"alpha","tx_count":3,"signed":{"alpha":true,"beta":false}}`
+	if string(payload) != want {
+		t.Errorf("payload:\n got %s\nwant %s", payload, want)
+	}
+
+	// 추적 벨리데이터가 제안하지 않은 블록은 proposer_validator 생략
+	record.ProposerValidator = ""
+	record.Signed = map[string]bool{}
+	payload, _ = json.Marshal(record)
+	if strings.Contains(string(payload), "proposer_validator") || !strings.Contains(string(payload), `"signed":{}`) {
+		t.Errorf("payload = %s", payload)
+	}
+	var decoded BlockRecord
+	if err := json.Unmarshal(payload, &decoded); err != nil || !reflect.DeepEqual(decoded, record) {
+		t.Errorf("round trip = %+v, %v; want %+v", decoded, err, record)
+	}
+}
+
+func TestSinkFromEnv(t *testing.T) {
+	tests := []struct {
+		url, topic string
+		wantName   string
+		wantAddr   string
+		wantErr    bool
+	}{
+		{"", "", "", "", false},
+		{"redis://localhost", "", "redis", "localhost:6379", false},
+		{"redis://:secret@redis.internal:6380", "blocks", "redis", "redis.internal:6380", false},
+		{"nats://nats.internal", "", "nats", "nats.internal:4222", false},
+		{"nats://token@10.0.0.5:4333", "og.blocks", "nats", "10.0.0.5:4333", false},
+		{"kafka://broker:9092", "", "", "", true},
+		{"redis://%zz", "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Setenv("SINK_URL", tt.url)
+			t.Setenv("SINK_TOPIC", tt.topic)
+			sink, err := sinkFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sinkFromEnv error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantName == "" {
+				if sink != nil {
+					t.Errorf("sink = %v, want nil", sink.Name())
+				}
+				return
+			}
+			if sink.Name() != tt.wantName {
+				t.Errorf("sink = %s, want %s", sink.Name(), tt.wantName)
+			}
+			topic := tt.topic
+			if topic == "" {
+				topic = defaultSinkTopic
+			}
+			switch s := sink.(type) {
+			case *RedisStreamSink:
+				if s.address != tt.wantAddr || s.stream != topic {
+					t.Errorf("redis address %q stream %q", s.address, s.stream)
+				}
+			case *NATSSink:
+				if s.address != tt.wantAddr || s.subject != topic {
+					t.Errorf("nats address %q subject %q", s.address, s.subject)
+				}
+			}
+		})
+	}
+}
+
+// XADD 스트림 항목
+type fakeStreamEntry struct {
+	height int64
+	data   string
+}
+
+// RESP로 AUTH와 XADD만 처리하는 Redis 대역 (항목 ID가 마지막 항목보다 크지 않으면 실제 Redis처럼 거부)
+type fakeRedis struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu         sync.Mutex
+	password   string
+	down       bool
+	conns      map[net.Conn]bool
+	commands   [][]string
+	entries    []fakeStreamEntry
+	duplicates int // 이미 있는 높이를 다시 보낸 XADD 수
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &fakeRedis{listener: listener, conns: make(map[net.Conn]bool)}
+	r.wg.Add(1)
+	go r.accept()
+	t.Cleanup(r.close)
+	return r
+}
+
+func (r *fakeRedis) sink(stream string) *RedisStreamSink {
+	return &RedisStreamSink{brokerConn: brokerConn{address: r.listener.Addr().String(), timeout: time.Second}, stream: stream}
+}
+
+func (r *fakeRedis) accept() {
+	defer r.wg.Done()
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		if r.down {
+			r.mu.Unlock()
+			conn.Close()
+			continue
+		}
+		r.conns[conn] = true
+		r.mu.Unlock()
+		r.wg.Add(1)
+		go r.serve(conn)
+	}
+}
+
+func (r *fakeRedis) serve(conn net.Conn) {
+	defer r.wg.Done()
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authed := false
+	for {
+		args, err := readRESPCommand(reader)
+		if err != nil {
+			return
+		}
+		r.mu.Lock()
+		r.commands = append(r.commands, args)
+		reply := r.handle(args, &authed)
+		r.mu.Unlock()
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (r *fakeRedis) handle(args []string, authed *bool) string {
+	switch {
+	case args[0] == "AUTH":
+		if args[len(args)-1] != r.password {
+			return "-WRONGPASS invalid username-password pair or user is disabled.\r\n"
+		}
+		*authed = true
+		return "+OK\r\n"
+	case r.password != "" && !*authed:
+		return "-NOAUTH Authentication required.\r\n"
+	case args[0] == "XADD":
+		id, fields := args[2], args[3:]
+		if args[2] == "MAXLEN" {
+			id, fields = args[5], args[6:]
+		}
+		height, err := strconv.ParseInt(strings.TrimSuffix(id, "-0"), 10, 64)
+		if err != nil || len(fields) != 4 || fields[0] != "height" || fields[2] != "data" {
+			return "-ERR syntax error\r\n"
+		}
+		if n := len(r.entries); n > 0 && height <= r.entries[n-1].height {
+			r.duplicates++
+			return "-ERR The ID specified in XADD is equal or smaller than the target stream top item\r\n"
+		}
+		r.entries = append(r.entries, fakeStreamEntry{height: height, data: fields[3]})
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(id), id)
+	}
+	return "-ERR unknown command\r\n"
+}
+
+// 장애: 열린 연결을 끊고 새 연결은 바로 닫음
+func (r *fakeRedis) setDown(down bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.down = down
+	if down {
+		for conn := range r.conns {
+			conn.Close()
+		}
+		clear(r.conns)
+	}
+}
+
+func (r *fakeRedis) close() {
+	r.listener.Close()
+	r.setDown(true)
+	r.wg.Wait()
+}
+
+func (r *fakeRedis) heights() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	heights := make([]int64, len(r.entries))
+	for i, entry := range r.entries {
+		heights[i] = entry.height
+	}
+	return heights
+}
+
+func readRESPCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("not an array: %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func TestRedisStreamSinkCommands(t *testing.T) {
+	broker := newFakeRedis(t)
+	broker.password = "s3cret"
+	sink := broker.sink("og-galileo.blocks")
+	sink.username, sink.password, sink.maxLen = "exporter", "s3cret", 1000
+	defer sink.Close()
+
+	record := BlockRecord{Height: 42, Signed: map[string]bool{"alpha": true}}
+	payload := []byte(`{"height":42,"data":"with spaces\r\nand newlines"}`)
+	if err := sink.Publish(context.Background(), record, payload); err != nil {
+		t.Fatal(err)
+	}
+	// 같은 높이를 다시 보내면 Redis가 거부하지만 이미 전송된 것으로 처리
+	if err := sink.Publish(context.Background(), record, payload); err != nil {
+		t.Errorf("republishing a delivered height: %v", err)
+	}
+
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	want := [][]string{
+		{"AUTH", "exporter", "s3cret"},
+		{"XADD", "og-galileo.blocks", "MAXLEN", "~", "1000", "42-0", "height", "42", "data", string(payload)},
+		{"XADD", "og-galileo.blocks", "MAXLEN", "~", "1000", "42-0", "height", "42", "data", string(payload)},
+	}
+	if !reflect.DeepEqual(broker.commands, want) {
+		t.Errorf("commands:\n got %q\nwant %q", broker.commands, want)
+	}
+	if len(broker.entries) != 1 || broker.duplicates != 1 {
+		t.Errorf("entries = %d, duplicates = %d; want 1 and 1", len(broker.entries), broker.duplicates)
+	}
+}
+
+func TestRedisStreamSinkAuthFailure(t *testing.T) {
+	broker := newFakeRedis(t)
+	broker.password = "s3cret"
+	sink := broker.sink("blocks")
+	sink.password = "wrong"
+	defer sink.Close()
+
+	if err := sink.Publish(context.Background(), BlockRecord{Height: 1}, []byte(`{}`)); err == nil {
+		t.Fatal("publish succeeded with a wrong password")
+	}
+	// 실패한 연결은 닫고 다음 전송에서 다시 연결
+	if sink.conn != nil {
+		t.Error("connection kept after an AUTH failure")
+	}
+	sink.password = "s3cret"
+	if err := sink.Publish(context.Background(), BlockRecord{Height: 1}, []byte(`{}`)); err != nil {
+		t.Errorf("publish after fixing the password: %v", err)
+	}
+}
+
+// PUB/HPUB 프레이밍: INFO의 headers 지원 여부에 따라 Nats-Msg-Id를 붙임
+func TestNATSSinkFraming(t *testing.T) {
+	payload := []byte(`{"height":7}`)
+	tests := []struct {
+		name    string
+		headers bool
+		want    string
+	}{
+		{"pub", false, "PUB og.blocks 12\r\n" + string(payload) + "\r\nPING\r\n"},
+		{"hpub", true, "HPUB og.blocks 49 61\r\nNATS/1.0\r\nNats-Msg-Id: zgtendermint_16601-2-7\r\n\r\n" + string(payload) + "\r\nPING\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer listener.Close()
+
+			type result struct {
+				connect, publish string
+				err              error
+			}
+			done := make(chan result, 1)
+			go func() {
+				var res result
+				defer func() { done <- res }()
+				conn, err := listener.Accept()
+				if err != nil {
+					res.err = err
+					return
+				}
+				defer conn.Close()
+				fmt.Fprintf(conn, "INFO {\"server_id\":\"test\",\"headers\":%v}\r\n", tt.headers)
+				reader := bufio.NewReader(conn)
+				if res.connect, res.err = reader.ReadString('\n'); res.err != nil {
+					return
+				}
+				reader.ReadString('\n') // PING
+				io.WriteString(conn, "PONG\r\n")
+				buf := make([]byte, len(tt.want))
+				if _, res.err = io.ReadFull(reader, buf); res.err != nil {
+					return
+				}
+				res.publish = string(buf)
+				io.WriteString(conn, "PONG\r\n")
+			}()
+
+			sink := &NATSSink{brokerConn: brokerConn{address: listener.Addr().String(), username: "tok", timeout: time.Second}, subject: "og.blocks"}
+			defer sink.Close()
+			if err := sink.Publish(context.Background(), BlockRecord{ChainID: "zgtendermint_16601-2", Height: 7}, payload); err != nil {
+				t.Fatal(err)
+			}
+			res := <-done
+			if res.err != nil {
+				t.Fatal(res.err)
+			}
+			var options map[string]interface{}
+			if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(res.connect, "CONNECT "))), &options); err != nil {
+				t.Fatalf("CONNECT %q: %v", res.connect, err)
+			}
+			if options["auth_token"] != "tok" || options["headers"] != tt.headers {
+				t.Errorf("CONNECT options = %v", options)
+			}
+			if res.publish != tt.want {
+				t.Errorf("publish:\n got %q\nwant %q", res.publish, tt.want)
+			}
+		})
+	}
+}
+
+// 브로커에 sink를 붙여 sink 고루틴 시작 (재시도 간격은 짧게)
+func startTestSink(h *testHarness, broker *fakeRedis) {
+	h.tracker.sink = broker.sink("og-galileo.blocks")
+	h.tracker.goroutines.Go(componentSink, func() { h.tracker.StartSink(h.ctx, 10*time.Millisecond) })
+}
+
+// 체인 ID를 확인하고, 첫 주기는 최신 블록만 처리하므로 한 주기를 먼저 돌려 그 높이를 반환 (이후 주기는 사이 블록을 모두 처리)
+func startSinkChain(t *testing.T, h *testHarness) int64 {
+	t.Helper()
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	return h.tracker.LastHeight()
+}
+
+func waitForHeights(t *testing.T, broker *fakeRedis, want []int64) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !reflect.DeepEqual(broker.heights(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("broker heights = %v, want %v", broker.heights(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func heightRange(from, to int64) []int64 {
+	var heights []int64
+	for height := from; height <= to; height++ {
+		heights = append(heights, height)
+	}
+	return heights
+}
+
+// 적용한 블록마다 메시지 하나, 브로커 장애 동안에도 블록 처리는 계속되고 복구 후 밀린 블록을 순서대로 전송
+func TestSinkIntegrationOutage(t *testing.T) {
+	h := newTestHarness(t, "alpha", "beta")
+	broker := newFakeRedis(t)
+	startTestSink(h, broker)
+	base := startSinkChain(t, h)
+
+	h.chain.SetSigning("beta", false)
+	if err := h.advance(3); err != nil {
+		t.Fatal(err)
+	}
+	first := h.tracker.LastHeight()
+	waitForHeights(t, broker, heightRange(base, first))
+
+	broker.mu.Lock()
+	var last BlockRecord
+	if err := json.Unmarshal([]byte(broker.entries[len(broker.entries)-1].data), &last); err != nil {
+		t.Fatal(err)
+	}
+	broker.mu.Unlock()
+	if last.Height != first || last.ChainID == "" || last.Time.IsZero() || last.Proposer == "" {
+		t.Errorf("last message = %+v", last)
+	}
+	if !last.Signed["alpha"] || last.Signed["beta"] {
+		t.Errorf("signed = %v, want alpha true and beta false", last.Signed)
+	}
+
+	broker.setDown(true)
+	if err := h.advance(4); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.tracker.LastHeight(); got != first+4 {
+		t.Fatalf("block processing stalled during a sink outage: last height %d, want %d", got, first+4)
+	}
+	if got := h.mustValue("og_galileo_exporter_sink_backlog"); got != 4 {
+		t.Errorf("backlog during outage = %v, want 4", got)
+	}
+
+	broker.setDown(false)
+	waitForHeights(t, broker, heightRange(base, first+4))
+	if got := h.mustValue("og_galileo_exporter_sink_failures_total"); got == 0 {
+		t.Error("publish failures during the outage not counted")
+	}
+	if got := h.mustValue("og_galileo_exporter_sink_published_height"); got != float64(first+4) {
+		t.Errorf("published height = %v, want %d", got, first+4)
+	}
+}
+
+// 전송 후 확인 높이를 저장하기 전에 크래시: 재시작 후 저장된 확인 높이 다음부터 다시 보내고, 브로커에는 한 번씩만 남음
+func TestSinkResumeFromPersistedHeight(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	h := newTestHarness(t, "alpha", "beta")
+	broker := newFakeRedis(t)
+	startTestSink(h, broker)
+	base := startSinkChain(t, h)
+
+	if err := h.advance(3); err != nil {
+		t.Fatal(err)
+	}
+	saved := h.tracker.LastHeight()
+	waitForHeights(t, broker, heightRange(base, saved))
+	if err := h.tracker.saveStateFile(stateFile); err != nil {
+		t.Fatal(err)
+	}
+	// 저장 이후 전송한 블록은 상태 파일에 반영되지 않음
+	if err := h.advance(3); err != nil {
+		t.Fatal(err)
+	}
+	waitForHeights(t, broker, heightRange(base, saved+3))
+
+	h.restart(stateFile)
+	startTestSink(h, broker)
+	if err := h.advance(2); err != nil {
+		t.Fatal(err)
+	}
+	tip := h.chain.Height()
+	waitForHeights(t, broker, heightRange(base, tip))
+	broker.mu.Lock()
+	duplicates := broker.duplicates
+	broker.mu.Unlock()
+	if duplicates != 3 {
+		t.Errorf("redelivered heights = %d, want the 3 published after the last save", duplicates)
+	}
+}
+
+// 브로커 장애 중 저장한 outbox는 재시작 후 다시 처리하지 않고 그대로 전송
+func TestSinkOutboxSurvivesRestart(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	h := newTestHarness(t, "alpha")
+	broker := newFakeRedis(t)
+	startTestSink(h, broker)
+	base := startSinkChain(t, h)
+
+	if err := h.advance(2); err != nil {
+		t.Fatal(err)
+	}
+	published := h.tracker.LastHeight()
+	waitForHeights(t, broker, heightRange(base, published))
+	broker.setDown(true)
+	if err := h.advance(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.saveStateFile(stateFile); err != nil {
+		t.Fatal(err)
+	}
+
+	h.restart(stateFile)
+	if got := h.tracker.history.OutboxLen(); got != 3 {
+		t.Fatalf("restored outbox = %d blocks, want 3", got)
+	}
+	broker.setDown(false)
+	startTestSink(h, broker)
+	waitForHeights(t, broker, heightRange(base, published+3))
+	broker.mu.Lock()
+	duplicates := broker.duplicates
+	broker.mu.Unlock()
+	if duplicates != 0 {
+		t.Errorf("redelivered heights = %d, want 0", duplicates)
+	}
+}
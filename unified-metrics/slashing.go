@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"og-galileo-unified-metrics/internal/bech32"
+)
+
+// 슬래싱 파라미터 (정수와 소수 모두 문자열로 응답)
+type SlashingParamsResponse struct {
+	Params struct {
+		SignedBlocksWindow      string `json:"signed_blocks_window"`
+		MinSignedPerWindow      string `json:"min_signed_per_window"`
+		DowntimeJailDuration    string `json:"downtime_jail_duration"`
+		SlashFractionDoubleSign string `json:"slash_fraction_double_sign"`
+		SlashFractionDowntime   string `json:"slash_fraction_downtime"`
+	} `json:"params"`
+}
+
+// 슬래싱 파라미터를 메트릭 단위로 변환한 값
+type SlashingParams struct {
+	SignedBlocksWindow      int64
+	MaxMissedBlocks         int64         // 윈도우에서 허용되는 최대 누락 수
+	DowntimeJailDuration    time.Duration // 응답은 "600s" 형식
+	SlashFractionDoubleSign float64
+	SlashFractionDowntime   float64
+}
+
+func parseSlashingParams(resp *SlashingParamsResponse) (*SlashingParams, error) {
+	raw := resp.Params
+	window, err := strconv.ParseInt(raw.SignedBlocksWindow, 10, 64)
+	if err != nil || window <= 0 {
+		return nil, fmt.Errorf("invalid signed_blocks_window %q", raw.SignedBlocksWindow)
+	}
+	maxMissed, err := maxMissedBlocks(window, raw.MinSignedPerWindow)
+	if err != nil {
+		return nil, err
+	}
+	jailDuration, err := time.ParseDuration(raw.DowntimeJailDuration)
+	if err != nil || jailDuration < 0 {
+		return nil, fmt.Errorf("invalid downtime_jail_duration %q", raw.DowntimeJailDuration)
+	}
+	doubleSign, err := parseSlashFraction("slash_fraction_double_sign", raw.SlashFractionDoubleSign)
+	if err != nil {
+		return nil, err
+	}
+	downtime, err := parseSlashFraction("slash_fraction_downtime", raw.SlashFractionDowntime)
+	if err != nil {
+		return nil, err
+	}
+	return &SlashingParams{
+		SignedBlocksWindow:      window,
+		MaxMissedBlocks:         maxMissed,
+		DowntimeJailDuration:    jailDuration,
+		SlashFractionDoubleSign: doubleSign,
+		SlashFractionDowntime:   downtime,
+	}, nil
+}
+
+// 소수 문자열 ("0.050000000000000000") -> 0~1
+func parseSlashFraction(name, value string) (float64, error) {
+	fraction, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || fraction < 0 || fraction > 1 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return fraction, nil
+}
+
+// 합의 주소별 서명 정보 (address는 valcons bech32)
+type SigningInfosResponse struct {
+	Info []struct {
+		Address             string `json:"address"`
+		StartHeight         string `json:"start_height"`
+		IndexOffset         string `json:"index_offset"`
+		JailedUntil         string `json:"jailed_until"`
+		Tombstoned          bool   `json:"tombstoned"`
+		MissedBlocksCounter string `json:"missed_blocks_counter"`
+	} `json:"info"`
+	Pagination struct {
+		NextKey string `json:"next_key"`
+	} `json:"pagination"`
+}
+
+func (vt *UnifiedValidatorTracker) fetchSlashingParams(ctx context.Context) (result *SlashingParamsResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("slashing_params", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/slashing/v1beta1/params", endpoint)
+	var params SlashingParamsResponse
+	if err := vt.getJSON(ctx, "slashing_params", endpoint, url, &params); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// 전체 서명 정보를 next_key 기준으로 페이지 단위 조회
+func (vt *UnifiedValidatorTracker) fetchSigningInfos(ctx context.Context) (result *SigningInfosResponse, err error) {
+	defer func(start time.Time) { vt.recordFetch("signing_infos", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	var infos SigningInfosResponse
+	nextKey := ""
+	for {
+		url := fmt.Sprintf("%s/cosmos/slashing/v1beta1/signing_infos?pagination.limit=%d", endpoint, stakingValidatorsPerPage)
+		if nextKey != "" {
+			url += "&pagination.key=" + neturl.QueryEscape(nextKey)
+		}
+		var page SigningInfosResponse
+		if err := vt.getJSON(ctx, "signing_infos", endpoint, url, &page); err != nil {
+			return nil, err
+		}
+		infos.Info = append(infos.Info, page.Info...)
+		nextKey = page.Pagination.NextKey
+		if nextKey == "" {
+			break
+		}
+	}
+	return &infos, nil
+}
+
+// valcons bech32 주소를 추적 목록과 같은 대문자 hex 합의 주소로 변환
+func consensusHexAddress(address string) (string, error) {
+	_, raw, err := bech32.Decode(address)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(raw)), nil
+}
+
+// 윈도우에서 허용되는 최대 누락 수: window - round(min_signed_per_window × window)
+// (x/slashing의 MinSignedPerWindowInt와 같이 half-even 반올림)
+func maxMissedBlocks(window int64, minSignedPerWindow string) (int64, error) {
+	ratio, ok := new(big.Rat).SetString(strings.TrimSpace(minSignedPerWindow))
+	if !ok || ratio.Sign() < 0 || ratio.Cmp(big.NewRat(1, 1)) > 0 {
+		return 0, fmt.Errorf("invalid min_signed_per_window %q", minSignedPerWindow)
+	}
+	product := new(big.Rat).Mul(ratio, new(big.Rat).SetInt64(window))
+	quotient, remainder := new(big.Int).QuoRem(product.Num(), product.Denom(), new(big.Int))
+	// 나머지 × 2와 분모 비교 (같으면 짝수 쪽으로)
+	switch new(big.Int).Lsh(remainder, 1).Cmp(product.Denom()) {
+	case 1:
+		quotient.Add(quotient, big.NewInt(1))
+	case 0:
+		if quotient.Bit(0) == 1 {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return window - quotient.Int64(), nil
+}
+
+// 슬래싱 파라미터와 벨리데이터별 missed_blocks_counter (둘 중 하나가 갱신될 때마다 다시 계산)
+type JailCountdown struct {
+	mu        sync.Mutex
+	maxMissed int64 // 이 값을 넘으면 제일
+	known     bool  // 슬래싱 파라미터를 한 번이라도 받았는지
+	missed    map[string]int64
+}
+
+func NewJailCountdown() *JailCountdown {
+	return &JailCountdown{missed: make(map[string]int64)}
+}
+
+func (jc *JailCountdown) SetMaxMissed(maxMissed int64) {
+	jc.mu.Lock()
+	defer jc.mu.Unlock()
+
+	jc.maxMissed = maxMissed
+	jc.known = true
+}
+
+func (jc *JailCountdown) SetMissed(validator string, missed int64) {
+	jc.mu.Lock()
+	defer jc.mu.Unlock()
+
+	jc.missed[validator] = missed
+}
+
+// validator -> 추가로 몇 블록 연속 누락하면 제일되는지 (이미 넘었으면 0, 파라미터를 모르면 nil)
+func (jc *JailCountdown) Remaining() map[string]int64 {
+	jc.mu.Lock()
+	defer jc.mu.Unlock()
+
+	if !jc.known {
+		return nil
+	}
+	remaining := make(map[string]int64, len(jc.missed))
+	for validator, missed := range jc.missed {
+		remaining[validator] = max(jc.maxMissed-missed+1, 0)
+	}
+	return remaining
+}
+
+func (vt *UnifiedValidatorTracker) updateBlocksToJail() {
+	for validator, blocks := range vt.jailCountdown.Remaining() {
+		vt.metrics.cosmos.blocksToJailMetric.WithLabelValues(validator).Set(float64(blocks))
+	}
+}
+
+// slashing 수집기: 서명 윈도우와 제일/슬래싱 파라미터 갱신 (시작 시 한 번, 이후 SLASHING_PARAMS_INTERVAL마다)
+func (vt *UnifiedValidatorTracker) refreshSlashingParams(ctx context.Context) error {
+	resp, err := vt.fetchSlashingParams(ctx)
+	if err != nil {
+		restLog.Error("Error fetching slashing params", "error", err)
+		return err
+	}
+	params, err := parseSlashingParams(resp)
+	if err != nil {
+		restLog.Error("Invalid slashing params", "error", err)
+		return err
+	}
+
+	window := params.SignedBlocksWindow
+	vt.metrics.cosmos.signedBlocksWindowMetric.Set(float64(window))
+	vt.metrics.cosmos.minSignedBlocksPerWindowMetric.Set(float64(window - params.MaxMissedBlocks))
+	vt.metrics.cosmos.downtimeJailDurationMetric.Set(params.DowntimeJailDuration.Seconds())
+	vt.metrics.cosmos.slashFractionDoubleSignMetric.Set(params.SlashFractionDoubleSign)
+	vt.metrics.cosmos.slashFractionDowntimeMetric.Set(params.SlashFractionDowntime)
+	vt.jailCountdown.SetMaxMissed(params.MaxMissedBlocks)
+	vt.missedWindow.Resize(int(window))
+	vt.updateMissedWindowMetrics()
+	vt.updateBlocksToJail()
+	restLog.Debug("Updated slashing params", "signed_blocks_window", window, "max_missed_blocks", params.MaxMissedBlocks,
+		"downtime_jail_duration", params.DowntimeJailDuration)
+	return nil
+}
+
+// staking 수집기에서 호출: 추적 중인 벨리데이터의 누락 카운터 갱신 (실패해도 다른 스테이킹 메트릭은 유지)
+func (vt *UnifiedValidatorTracker) updateSigningInfos(ctx context.Context) {
+	infos, err := vt.fetchSigningInfos(ctx)
+	if err != nil {
+		restLog.Warn("Error fetching signing infos", "error", err)
+		return
+	}
+	for _, info := range infos.Info {
+		address, err := consensusHexAddress(info.Address)
+		if err != nil {
+			restLog.Debug("Skipping signing info with invalid address", "address", info.Address, "error", err)
+			continue
+		}
+		label, tracked := vt.validators[address]
+		if !tracked {
+			continue
+		}
+		metrics := vt.metrics.cosmos
+		metrics.tombstonedMetric.WithLabelValues(label).Set(boolToFloat(info.Tombstoned))
+		if startHeight, err := strconv.ParseInt(info.StartHeight, 10, 64); err == nil {
+			metrics.startHeightMetric.WithLabelValues(label).Set(float64(startHeight))
+		}
+		// 제일된 적이 없으면 1970-01-01T00:00:00Z
+		if jailedUntil, err := time.Parse(time.RFC3339Nano, info.JailedUntil); err == nil {
+			metrics.jailedUntilMetric.WithLabelValues(label).Set(float64(max(jailedUntil.Unix(), 0)))
+		}
+		missed, err := strconv.ParseInt(info.MissedBlocksCounter, 10, 64)
+		if err != nil {
+			continue
+		}
+		metrics.signingMissedBlocksMetric.WithLabelValues(label).Set(float64(missed))
+		vt.jailCountdown.SetMissed(label, missed)
+	}
+	vt.updateBlocksToJail()
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// 스냅샷 스키마 버전 (필드 의미가 바뀌면 올릴 것)
+const snapshotVersion = 1
+
+// 복원 요청 본문 최대 크기
+const maxSnapshotBytes = 256 << 20
+
+// 트래커 전체 상태 스냅샷
+type TrackerSnapshot struct {
+	Version         int                        `json:"version"`
+	ChainID         string                     `json:"chain_id"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	LastBlockHeight int64                      `json:"last_block_height"`
+	Counters        map[string]float64         `json:"counters"`
+	Jailed          map[string]bool            `json:"jailed"`
+	Bonded          map[string]bool            `json:"bonded"`
+	ActiveProposals []string                   `json:"active_proposals"`
+	ProposalVotes   map[string]map[string]bool `json:"proposal_votes"`
+	ProposalPeriods map[string]ProposalPeriod  `json:"proposal_periods,omitempty"` // 제안별 투표 기간
+	Signing         []SigningRecord            `json:"signing"`
+	Samples         []ValidatorSample          `json:"samples"`
+	Events          []Event                    `json:"events,omitempty"`
+	ChangeSamples   map[string][]ChangeSample  `json:"change_samples,omitempty"`
+	Lifecycle       *ExporterLifecycle         `json:"lifecycle,omitempty"` // 복원 대상이 아님 (시작 시 loadLifecycle에서만 읽음)
+	Outbox          []BlockRecord              `json:"outbox,omitempty"`    // sink로 아직 전송하지 못한 블록
+	SinkPublished   int64                      `json:"sink_published_height,omitempty"`
+	Pubkeys         map[string]string          `json:"consensus_pubkeys,omitempty"` // 공개키 교체 감지 기준값
+	SignedTotals    map[string]int             `json:"signed_totals,omitempty"`     // 벨리데이터별 누적 서명 블록 수
+	MissedTotals    map[string]int             `json:"missed_totals,omitempty"`     // 벨리데이터별 누적 누락 블록 수
+	MissStreaks     map[string]int             `json:"miss_streaks,omitempty"`      // 벨리데이터별 현재 연속 누락 블록 수
+	MissedWindow    *MissedWindowSnapshot      `json:"missed_window,omitempty"`     // 서명 윈도우 안의 블록별 서명 상태
+	UptimeWindows   []UptimeWindowSnapshot     `json:"uptime_windows,omitempty"`    // UPTIME_WINDOWS 윈도우별 서명 비트 배열
+}
+
+// /api/state/restore 응답
+type RestoreResponse struct {
+	Restored        bool  `json:"restored"`
+	LastBlockHeight int64 `json:"last_block_height"`
+}
+
+// 스냅샷에 포함되는 누적 카운터
+func (vt *UnifiedValidatorTracker) snapshotCounters() map[string]prometheus.Counter {
+	return map[string]prometheus.Counter{
+		"tracked_blocks": vt.metrics.cosmos.trackedBlocksMetric,
+		"skipped_blocks": vt.metrics.cosmos.skippedBlocksMetric,
+		"transactions":   vt.metrics.cosmos.transactionsMetric,
+	}
+}
+
+func (vt *UnifiedValidatorTracker) Snapshot() TrackerSnapshot {
+	counters := make(map[string]float64)
+	for name, counter := range vt.snapshotCounters() {
+		counters[name] = counterValue(counter)
+	}
+	signing, samples, events := vt.history.Export()
+	outbox := vt.history.ExportOutbox()
+	missedWindow := vt.missedWindow.Export()
+	uptimeWindows := vt.uptime.Export()
+
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	snapshot := TrackerSnapshot{
+		Version:         snapshotVersion,
+		ChainID:         vt.chainID,
+		CreatedAt:       time.Now().UTC(),
+		LastBlockHeight: vt.lastBlockHeight,
+		Counters:        counters,
+		Jailed:          copyBoolMap(vt.prevJailed),
+		Bonded:          copyBoolMap(vt.prevBonded),
+		ActiveProposals: []string{},
+		ProposalVotes:   make(map[string]map[string]bool, len(vt.proposalVotes)),
+		ProposalPeriods: make(map[string]ProposalPeriod, len(vt.proposalPeriods)),
+		Signing:         signing,
+		Samples:         samples,
+		Events:          events,
+		ChangeSamples:   vt.changes.Export(),
+		Lifecycle:       vt.lifecycleLocked(),
+		Outbox:          outbox,
+		SinkPublished:   vt.sinkPublished,
+		Pubkeys:         copyStringMap(vt.consensusPubkeys),
+		SignedTotals:    copyIntMap(vt.signedTotal),
+		MissedTotals:    copyIntMap(vt.missedTotal),
+		MissStreaks:     copyIntMap(vt.missStreak),
+		MissedWindow:    missedWindow,
+		UptimeWindows:   uptimeWindows,
+	}
+	for id := range vt.activeProposals {
+		snapshot.ActiveProposals = append(snapshot.ActiveProposals, id)
+	}
+	for id, votes := range vt.proposalVotes {
+		snapshot.ProposalVotes[id] = copyBoolMap(votes)
+	}
+	for id, period := range vt.proposalPeriods {
+		snapshot.ProposalPeriods[id] = period
+	}
+	return snapshot
+}
+
+// 스냅샷 검증 후 상태 복원 (다른 체인 또는 버전의 스냅샷은 거부)
+func (vt *UnifiedValidatorTracker) Restore(snapshot TrackerSnapshot) error {
+	if snapshot.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d (expected %d)", snapshot.Version, snapshotVersion)
+	}
+	chainID := vt.ChainID()
+	if chainID == "" {
+		return fmt.Errorf("chain id not yet known, retry after the node status has been fetched")
+	}
+	if snapshot.ChainID != chainID {
+		return fmt.Errorf("snapshot is for chain %q but this exporter tracks %q", snapshot.ChainID, chainID)
+	}
+
+	// 카운터는 감소시킬 수 없으므로 스냅샷 값까지만 증가
+	for name, counter := range vt.snapshotCounters() {
+		if delta := snapshot.Counters[name] - counterValue(counter); delta > 0 {
+			counter.Add(delta)
+		}
+	}
+	for label, missed := range snapshot.MissedTotals {
+		counter := vt.metrics.cosmos.missedBlocksTotalMetric.WithLabelValues(label)
+		if delta := float64(missed) - counterValue(counter); delta > 0 {
+			counter.Add(delta)
+		}
+	}
+	// 재시작 직후에도 연속 누락 알림 기준이 0부터 다시 세지지 않도록
+	for label, streak := range snapshot.MissStreaks {
+		vt.metrics.cosmos.consecutiveMissedBlocksMetric.WithLabelValues(label).Set(float64(streak))
+	}
+	vt.history.Import(snapshot.Signing, snapshot.Samples, snapshot.Events)
+	vt.changes.Import(snapshot.ChangeSamples)
+	vt.history.ImportOutbox(snapshot.Outbox)
+	vt.missedWindow.Import(snapshot.MissedWindow)
+	vt.updateMissedWindowMetrics()
+	vt.uptime.Import(snapshot.UptimeWindows)
+	vt.updateUptimeMetrics()
+	if snapshot.LastBlockHeight > vt.LastHeight() {
+		// 재시작 직후 첫 블록을 처리하기 전에도 처리 높이가 0으로 보이지 않도록
+		vt.metrics.cosmos.blockHeightMetric.Set(float64(snapshot.LastBlockHeight))
+	}
+
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	if snapshot.LastBlockHeight > vt.lastBlockHeight {
+		vt.lastBlockHeight = snapshot.LastBlockHeight
+	}
+	if snapshot.SinkPublished > vt.sinkPublished {
+		vt.sinkPublished = snapshot.SinkPublished
+	}
+	vt.prevJailed = copyBoolMap(snapshot.Jailed)
+	vt.prevBonded = copyBoolMap(snapshot.Bonded)
+	vt.consensusPubkeys = copyStringMap(snapshot.Pubkeys)
+	vt.signedTotal = copyIntMap(snapshot.SignedTotals)
+	vt.missedTotal = copyIntMap(snapshot.MissedTotals)
+	vt.missStreak = copyIntMap(snapshot.MissStreaks)
+	vt.activeProposals = make(map[string]bool, len(snapshot.ActiveProposals))
+	for _, id := range snapshot.ActiveProposals {
+		vt.activeProposals[id] = true
+	}
+	vt.proposalVotes = make(map[string]map[string]bool, len(snapshot.ProposalVotes))
+	for id, votes := range snapshot.ProposalVotes {
+		vt.proposalVotes[id] = copyBoolMap(votes)
+	}
+	vt.proposalPeriods = make(map[string]ProposalPeriod, len(snapshot.ProposalPeriods))
+	for id, period := range snapshot.ProposalPeriods {
+		vt.proposalPeriods[id] = period
+	}
+	return nil
+}
+
+func counterValue(counter prometheus.Counter) float64 {
+	var m dto.Metric
+	if err := counter.Write(&m); err != nil {
+		return 0
+	}
+	return m.GetCounter().GetValue()
+}
+
+func copyBoolMap(src map[string]bool) map[string]bool {
+	dst := make(map[string]bool, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+func copyIntMap(src map[string]int) map[string]int {
+	dst := make(map[string]int, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// ADMIN_TOKEN Bearer 토큰 인증 (토큰이 설정되지 않으면 관리 API 비활성화)
+func requireAdmin(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			writeAPIError(w, http.StatusForbidden, "admin API disabled (ADMIN_TOKEN not set)")
+			return
+		}
+		provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// GET /api/state/snapshot
+func (vt *UnifiedValidatorTracker) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tracker-snapshot-%d.json"`, time.Now().Unix()))
+	writeJSON(w, http.StatusOK, vt.Snapshot())
+}
+
+// POST /api/state/restore
+func (vt *UnifiedValidatorTracker) handleRestore(w http.ResponseWriter, r *http.Request) {
+	var snapshot TrackerSnapshot
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxSnapshotBytes)).Decode(&snapshot); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid snapshot: %v", err)
+		return
+	}
+	if err := vt.Restore(snapshot); err != nil {
+		writeAPIError(w, http.StatusConflict, "%v", err)
+		return
+	}
+
+	persistenceLog.Info("Restored tracker state from snapshot", "created_at", snapshot.CreatedAt.Format(time.RFC3339), "height", snapshot.LastBlockHeight)
+	writeJSON(w, http.StatusOK, RestoreResponse{Restored: true, LastBlockHeight: vt.LastHeight()})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// 연속 누락 수는 재시작 후에도 이어서 셈
+func TestSnapshotRestoresMissStreak(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	h := newTestHarness(t, "alpha", "beta")
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	h.chain.SetSigning("beta", false)
+	if err := h.advance(4); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", "beta"); got != 3 {
+		t.Fatalf("beta streak before restart = %v, want 3", got)
+	}
+	snapshot := h.tracker.Snapshot()
+	if got := snapshot.MissStreaks["beta"]; got != 3 {
+		t.Errorf("snapshot miss streak = %d, want 3", got)
+	}
+	if err := h.tracker.saveStateFile(stateFile); err != nil {
+		t.Fatal(err)
+	}
+
+	h.restart(stateFile)
+	if got := h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", "beta"); got != 3 {
+		t.Errorf("beta streak after restore = %v, want 3", got)
+	}
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", "beta"); got != 4 {
+		t.Errorf("beta streak after one more missed block = %v, want 4", got)
+	}
+	if got := h.mustValue("og_galileo_validator_consecutive_missed_blocks", "validator", "alpha"); got != 0 {
+		t.Errorf("alpha streak = %v, want 0", got)
+	}
+}
+
+// 연속 누락 수만 남은 라벨도 이름 변경 대상
+func TestRenameSnapshotLabelMissStreak(t *testing.T) {
+	snapshot := &TrackerSnapshot{MissStreaks: map[string]int{"old-name": 7}}
+	if !snapshotHasLabel(snapshot, "old-name") {
+		t.Fatal("label with only a miss streak not found")
+	}
+	rows, err := renameSnapshotLabel(snapshot, "old-name", "new-name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows != 1 || snapshot.MissStreaks["new-name"] != 7 {
+		t.Errorf("rows = %d, streaks = %v", rows, snapshot.MissStreaks)
+	}
+	if _, ok := snapshot.MissStreaks["old-name"]; ok {
+		t.Error("old label still present")
+	}
+
+	snapshot.MissStreaks["old-name"] = 1
+	if _, err := renameSnapshotLabel(snapshot, "old-name", "new-name"); !errors.Is(err, errLabelCollision) {
+		t.Errorf("rename onto an existing streak label: %v, want errLabelCollision", err)
+	}
+}
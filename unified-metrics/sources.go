@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultSourceTimeout = 15 * time.Second
+
+// 업스트림 메트릭 소스(node_exporter, og_node) 인증/TLS 설정
+// 비밀 값은 "env:NAME" 또는 "file:/path" 참조로 지정 가능
+type SourceConfig struct {
+	Basic       *BasicAuth        `json:"basic,omitempty"`
+	BearerToken string            `json:"bearer_token,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	TLS         OutboundTLS       `json:"tls,omitempty"`
+	Timeout     jsonDuration      `json:"timeout,omitempty"`
+}
+
+type BasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// 외부로 나가는 HTTP 클라이언트 TLS 옵션 (프로브와 메트릭 소스가 공유)
+type OutboundTLS struct {
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+	CAFile             string `json:"ca_file,omitempty"`
+	ServerName         string `json:"server_name,omitempty"`
+}
+
+// 타임아웃과 TLS 옵션으로 외부 요청용 클라이언트 생성
+func newOutboundClient(timeout time.Duration, opts OutboundTLS) (*http.Client, error) {
+	client := &http.Client{Timeout: timeout}
+	if opts == (OutboundTLS{}) {
+		return client, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify, ServerName: opts.ServerName}
+	if opts.CAFile != "" {
+		pem, err := os.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client.Transport = transport
+	return client, nil
+}
+
+// "env:NAME"은 환경 변수, "file:/path"는 파일 내용(앞뒤 공백 제거), 그 외는 값 그대로
+func resolveSecret(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "env:"):
+		name := strings.TrimPrefix(value, "env:")
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+	case strings.HasPrefix(value, "file:"):
+		data, err := os.ReadFile(strings.TrimPrefix(value, "file:"))
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return value, nil
+}
+
+// /all-metrics에 합치는 업스트림 메트릭 소스
+type MetricSource struct {
+	Name    string
+	URL     string
+	client  *http.Client
+	headers http.Header // 비밀 값이 해석된 인증 헤더 (로그/API에 노출하지 않음)
+	auth    string      // 인증 방식 (basic, bearer, headers)
+}
+
+var errSourceAuth = errors.New("authentication failed")
+
+func NewMetricSource(name, url string, config SourceConfig) (*MetricSource, error) {
+	if err := validateEndpointURL(url); err != nil {
+		return nil, fmt.Errorf("source %s: %w", name, err)
+	}
+	timeout := time.Duration(config.Timeout)
+	if timeout <= 0 {
+		timeout = defaultSourceTimeout
+	}
+	client, err := newOutboundClient(timeout, config.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("source %s: %w", name, err)
+	}
+
+	source := &MetricSource{Name: name, URL: url, client: client, headers: make(http.Header)}
+	var methods []string
+	if config.Basic != nil {
+		password, err := resolveSecret(config.Basic.Password)
+		if err != nil {
+			return nil, fmt.Errorf("source %s basic password: %w", name, err)
+		}
+		request, _ := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		request.SetBasicAuth(config.Basic.Username, password)
+		source.headers.Set("Authorization", request.Header.Get("Authorization"))
+		methods = append(methods, "basic")
+	}
+	if config.BearerToken != "" {
+		token, err := resolveSecret(config.BearerToken)
+		if err != nil {
+			return nil, fmt.Errorf("source %s bearer token: %w", name, err)
+		}
+		source.headers.Set("Authorization", "Bearer "+token)
+		methods = append(methods, "bearer")
+	}
+	if config.Basic != nil && config.BearerToken != "" {
+		return nil, fmt.Errorf("source %s: basic and bearer_token are mutually exclusive", name)
+	}
+	for header, value := range config.Headers {
+		resolved, err := resolveSecret(value)
+		if err != nil {
+			return nil, fmt.Errorf("source %s header %s: %w", name, header, err)
+		}
+		source.headers.Set(header, resolved)
+	}
+	if len(config.Headers) > 0 {
+		methods = append(methods, "headers")
+	}
+	source.auth = strings.Join(methods, "+")
+	return source, nil
+}
+
+// 메트릭 본문 조회, 실패 시 원인(auth, status, connection, read) 반환
+func (s *MetricSource) Fetch(ctx context.Context) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, "connection", err
+	}
+	for header, values := range s.headers {
+		req.Header[header] = values
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "connection", err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, "auth", fmt.Errorf("%w: status %d", errSourceAuth, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, "status", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "read", err
+	}
+	return body, "ok", nil
+}
+
+// 소스 조회 후 scrape_success 갱신 (원인 라벨이 바뀌면 이전 시리즈 삭제)
+func (vt *UnifiedValidatorTracker) scrapeSource(ctx context.Context, source *MetricSource) ([]byte, error) {
+	start := time.Now()
+	body, reason, err := source.Fetch(ctx)
+	vt.observeFetch(source.Name, time.Since(start), err)
+	metric := vt.metrics.exporter.scrapeSuccessMetric
+	metric.DeletePartialMatch(map[string]string{"source": source.Name})
+	metric.WithLabelValues(source.Name, reason).Set(boolToFloat(err == nil))
+	return body, err
+}
+
+// METRIC_SOURCES_AUTH_FILE(JSON 파일) 또는 METRIC_SOURCES_AUTH(JSON 문자열)에서 소스별 설정 읽기
+// {"node_exporter": {"basic": {"username": "prom", "password": "env:NODE_EXPORTER_PASSWORD"}}}
+func sourceConfigsFromEnv(known []string) (map[string]SourceConfig, error) {
+	data := []byte(getEnv("METRIC_SOURCES_AUTH", ""))
+	if path := getEnv("METRIC_SOURCES_AUTH_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	configs := make(map[string]SourceConfig)
+	if len(data) == 0 {
+		return configs, nil
+	}
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("decode metric source auth: %w", err)
+	}
+	for name := range configs {
+		if !slices.Contains(known, name) {
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown metric source %q (known: %s)", name, strings.Join(known, ", "))
+		}
+	}
+	return configs, nil
+}
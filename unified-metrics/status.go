@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// 최근 블록 처리 이후 이 시간이 지나면 준비되지 않은 것으로 판단
+	defaultReadyMaxStaleness = 60 * time.Second
+
+	// 종료 시 진행 중인 HTTP 요청을 기다리는 최대 시간
+	shutdownTimeout = 10 * time.Second
+)
+
+// 블록 처리 완료 시 exporter 자체 위치/시각을 기록
+func (vt *UnifiedValidatorTracker) markProcessed(height int64) {
+	now := time.Now()
+
+	vt.mu.Lock()
+	vt.lastBlockHeight = height
+	vt.lastProcessedAt = now
+	vt.mu.Unlock()
+
+	vt.metrics.exporter.lastProcessedHeightMetric.Set(float64(height))
+	vt.metrics.exporter.lastProcessedTimestampMetric.Set(float64(now.Unix()))
+
+	vt.events.Publish(Event{Type: EventBlockProcessed, Time: now.UTC(), Height: height})
+}
+
+// 마지막으로 처리한 블록 높이
+func (vt *UnifiedValidatorTracker) LastHeight() int64 {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	return vt.lastBlockHeight
+}
+
+// 추적 벨리데이터 address -> label 복사본 (핸들러에서 순회해도 안전)
+func (vt *UnifiedValidatorTracker) Validators() map[string]string {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	validators := make(map[string]string, len(vt.validators))
+	for address, label := range vt.validators {
+		validators[address] = label
+	}
+	return validators
+}
+
+// 노드가 보고한 체인 ID (아직 조회 전이면 빈 문자열)
+func (vt *UnifiedValidatorTracker) ChainID() string {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	return vt.chainID
+}
+
+// 마지막 블록 처리 이후 경과 시간 (아직 처리한 블록이 없으면 ok=false)
+func (vt *UnifiedValidatorTracker) dataStaleness() (time.Duration, bool) {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	if vt.lastProcessedAt.IsZero() {
+		return 0, false
+	}
+	return time.Since(vt.lastProcessedAt), true
+}
+
+// 준비 상태 판단: 블록을 한 번 이상 처리했고 데이터가 maxStaleness보다 오래되지 않았는지
+func (vt *UnifiedValidatorTracker) Ready(maxStaleness time.Duration) (bool, string) {
+	if vt.shuttingDown.Load() {
+		return false, "shutting down"
+	}
+	staleness, ok := vt.dataStaleness()
+	if !ok {
+		return false, "no block processed yet"
+	}
+	if staleness > maxStaleness {
+		return false, fmt.Sprintf("last block processed %s ago (max %s)", staleness.Round(time.Second), maxStaleness)
+	}
+	return true, ""
+}
+
+// 제안의 투표 기간 (zero면 알 수 없음)
+type ProposalPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// from~to 구간과 겹치는지 (알 수 없는 시작/끝은 열린 것으로 봄)
+func (p ProposalPeriod) overlaps(from, to time.Time) bool {
+	return (p.Start.IsZero() || p.Start.Before(to)) && (p.End.IsZero() || p.End.After(from))
+}
+
+// 제안별 투표 여부와 투표 기간 갱신 (거버넌스 수집 시 사용)
+func (vt *UnifiedValidatorTracker) setProposalVotes(proposal ActiveProposal, votes map[string]bool) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	vt.proposalVotes[proposal.ID] = votes
+	vt.proposalPeriods[proposal.ID] = ProposalPeriod{Start: proposal.VotingStartTime, End: proposal.VotingEndTime}
+}
+
+// 투표 기간이 [from, to)와 겹치는 제안들의 투표 여부 (이미 끝난 제안 포함, 기간 기록이 없는 제안은 제외)
+func (vt *UnifiedValidatorTracker) proposalVotesBetween(from, to time.Time) map[string]map[string]bool {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	result := make(map[string]map[string]bool)
+	for id, votes := range vt.proposalVotes {
+		period, ok := vt.proposalPeriods[id]
+		if !ok || !period.overlaps(from, to) {
+			continue
+		}
+		result[id] = copyBoolMap(votes)
+	}
+	return result
+}
+
+// 현재 투표 기간인 제안들의 투표 여부 복사본
+func (vt *UnifiedValidatorTracker) proposalVotesSnapshot() map[string]map[string]bool {
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+
+	snapshot := make(map[string]map[string]bool, len(vt.activeProposals))
+	for id := range vt.activeProposals {
+		votes := make(map[string]bool, len(vt.proposalVotes[id]))
+		for validator, voted := range vt.proposalVotes[id] {
+			votes[validator] = voted
+		}
+		snapshot[id] = votes
+	}
+	return snapshot
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// systemd sd_notify 프로토콜 (NOTIFY_SOCKET이 없으면 아무 것도 하지 않음)
+func sdNotify(state string) error {
+	socketPath := os.Getenv("NOTIFY_SOCKET")
+	if socketPath == "" {
+		return nil
+	}
+	// '@'로 시작하면 abstract namespace 소켓
+	if socketPath[0] == '@' {
+		socketPath = "\x00" + socketPath[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// WATCHDOG_USEC가 설정된 경우 트래킹 루프에서 워치독 핑을 보냄
+func watchdogEnabled() bool {
+	return os.Getenv("NOTIFY_SOCKET") != "" && os.Getenv("WATCHDOG_USEC") != ""
+}
+
+// 첫 블록 조회 성공 시 systemd에 READY 통지
+func (vt *UnifiedValidatorTracker) notifyReady() {
+	vt.readyOnce.Do(func() {
+		if err := sdNotify("READY=1"); err != nil {
+			trackerLog.Warn("Failed to notify systemd readiness", "error", err)
+			return
+		}
+		trackerLog.Info("First block fetched, service ready")
+	})
+}
+
+func (vt *UnifiedValidatorTracker) notifyWatchdog() {
+	if !watchdogEnabled() {
+		return
+	}
+	if err := sdNotify("WATCHDOG=1"); err != nil {
+		trackerLog.Warn("Failed to send systemd watchdog ping", "error", err)
+	}
+}
+
+// healthcheck 서브커맨드: 로컬 /ready를 조회해 종료 코드로 결과 반환 (Docker HEALTHCHECK용)
+func runHealthcheck() int {
+	target, err := parseListenTarget(getEnv("LISTEN_ADDR", defaultListenAddr))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "healthcheck failed: %v\n", err)
+		return 1
+	}
+	client, baseURL := target.LocalClient(5 * time.Second)
+	url := getEnv("HEALTHCHECK_URL", baseURL+"/ready")
+
+	resp, err := client.Get(url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "healthcheck failed: %v\n", err)
+		return 1
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintf(os.Stderr, "healthcheck failed: %s returned %d\n", url, resp.StatusCode)
+		return 1
+	}
+	return 0
+}
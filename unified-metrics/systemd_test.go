@@ -0,0 +1,152 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// NOTIFY_SOCKET으로 쓸 unixgram 소켓 (sun_path 길이 제한 때문에 짧은 임시 경로 사용)
+func listenNotifySocket(t *testing.T) *net.UnixConn {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "sd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "notify")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	t.Setenv("NOTIFY_SOCKET", path)
+	return conn
+}
+
+// 지금까지 소켓에 도착한 통지 (잠시 기다려도 더 없으면 반환)
+func readNotifications(t *testing.T, conn *net.UnixConn) []string {
+	t.Helper()
+	var states []string
+	buf := make([]byte, 256)
+	for {
+		conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		n, err := conn.Read(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				return states
+			}
+			t.Fatal(err)
+		}
+		states = append(states, string(buf[:n]))
+	}
+}
+
+func TestSdNotifyWithoutSocket(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	if err := sdNotify("READY=1"); err != nil {
+		t.Errorf("sdNotify without NOTIFY_SOCKET = %v", err)
+	}
+	if watchdogEnabled() {
+		t.Error("watchdog enabled without NOTIFY_SOCKET")
+	}
+}
+
+// READY=1은 첫 블록을 받은 뒤 한 번만, WATCHDOG=1은 WATCHDOG_USEC가 있을 때 주기마다
+func TestSystemdNotify(t *testing.T) {
+	conn := listenNotifySocket(t)
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	h := newTestHarness(t, "alpha", "beta")
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.checkRPCCompat(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 노드 장애로 블록을 받지 못한 주기: 워치독만
+	h.chain.SetOutage(true)
+	if err := h.advance(1); err == nil {
+		t.Fatal("cycle succeeded during outage")
+	}
+	if got := readNotifications(t, conn); strings.Join(got, ",") != "WATCHDOG=1" {
+		t.Fatalf("notifications before the first block = %q, want only WATCHDOG=1", got)
+	}
+
+	h.chain.SetOutage(false)
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if got := readNotifications(t, conn); strings.Join(got, ",") != "READY=1,WATCHDOG=1" {
+		t.Fatalf("notifications after the first block = %q", got)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := h.advance(1); err != nil {
+			t.Fatal(err)
+		}
+		if got := readNotifications(t, conn); strings.Join(got, ",") != "WATCHDOG=1" {
+			t.Fatalf("cycle %d notifications = %q, want WATCHDOG=1 without another READY=1", i, got)
+		}
+	}
+}
+
+// WATCHDOG_USEC가 없으면 READY=1만 보냄
+func TestSystemdNotifyWithoutWatchdog(t *testing.T) {
+	conn := listenNotifySocket(t)
+	t.Setenv("WATCHDOG_USEC", "")
+	h := newTestHarness(t, "alpha")
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := h.advance(1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := readNotifications(t, conn); strings.Join(got, ",") != "READY=1" {
+		t.Errorf("notifications = %q, want READY=1 once", got)
+	}
+}
+
+func TestRunHealthcheck(t *testing.T) {
+	var ready atomic.Bool
+	ready.Store(true)
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if !ready.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("HEALTHCHECK_URL", "")
+	t.Setenv("LISTEN_ADDR", strings.TrimPrefix(server.URL, "http://"))
+
+	if code := runHealthcheck(); code != 0 {
+		t.Errorf("healthcheck while ready = %d, want 0", code)
+	}
+	ready.Store(false)
+	if code := runHealthcheck(); code != 1 {
+		t.Errorf("healthcheck while not ready = %d, want 1", code)
+	}
+	if strings.Join(paths, ",") != "/ready,/ready" {
+		t.Errorf("requested paths = %v", paths)
+	}
+
+	server.Close()
+	if code := runHealthcheck(); code != 1 {
+		t.Errorf("healthcheck with nothing listening = %d, want 1", code)
+	}
+	t.Setenv("LISTEN_ADDR", "not-an-address")
+	if code := runHealthcheck(); code != 1 {
+		t.Errorf("healthcheck with invalid LISTEN_ADDR = %d, want 1", code)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 추적 벨리데이터의 알려진 주소 형식과 이름
+type ValidatorIdentity struct {
+	Label            string `json:"label"`
+	ConsensusAddress string `json:"consensus_address"`
+	ConsensusPubKey  string `json:"consensus_pubkey,omitempty"`
+	OperatorAddress  string `json:"operator_address,omitempty"`
+	Moniker          string `json:"moniker,omitempty"`
+}
+
+type TargetEndpoint struct {
+	URL      string `json:"url"`
+	Selected bool   `json:"selected"`
+	Pinned   bool   `json:"pinned,omitempty"`
+}
+
+type TargetProbe struct {
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	ExpectedStatus int    `json:"expected_status"`
+	Interval       string `json:"interval"`
+}
+
+type TargetNode struct {
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	RPC          string `json:"rpc,omitempty"`
+	DirectAccess bool   `json:"direct_access"` // false면 호스트 상태를 확인할 수 없음
+}
+
+type TargetPeerCheck struct {
+	Node          string   `json:"node"`
+	RPC           string   `json:"rpc"`
+	ExpectedPeers []string `json:"expected_peers"` // 피어 ID만
+	Interval      string   `json:"interval"`
+}
+
+// /api/targets 응답: 이 인스턴스가 감시하는 대상 목록 (비밀 값 제외)
+type TargetsResponse struct {
+	ChainID          string              `json:"chain_id"`
+	Denom            DenomMetadata       `json:"denom"`
+	RPCEndpoints     []TargetEndpoint    `json:"rpc_endpoints"`
+	Validators       []ValidatorIdentity `json:"validators"`
+	MetricSources    map[string]string   `json:"metric_sources"`
+	MetricSourceAuth map[string]string   `json:"metric_source_auth,omitempty"` // 소스별 인증 방식 (값은 노출하지 않음)
+	Probes           []TargetProbe       `json:"probes"`
+	PeerChecks       []TargetPeerCheck   `json:"peer_checks,omitempty"`
+	Nodes            []TargetNode        `json:"nodes,omitempty"`
+	Notifiers        []string            `json:"notifiers"`
+	NodeVersions     NodeVersions        `json:"node_versions"`
+	DryRun           bool                `json:"dry_run,omitempty"`
+}
+
+// 벨리데이터 셋에서 확인한 합의 공개키 기록
+func (vt *UnifiedValidatorTracker) recordConsensusPubKey(address, pubKey string) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	vt.identityLocked(address).ConsensusPubKey = pubKey
+}
+
+// 스테이킹 벨리데이터를 합의 공개키로 추적 대상과 연결해 운영자 주소와 모니커 기록
+// 모니커는 체인에서 온 임의 문자열이므로 정리한 값만 저장/노출
+func (vt *UnifiedValidatorTracker) recordStakingIdentity(pubKey, operatorAddress, moniker string) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+
+	moniker = sanitizeLabel(moniker)
+	operatorAddress = sanitizeLabel(operatorAddress)
+	for address, label := range vt.validators {
+		identity := vt.identityLocked(address)
+		if identity.ConsensusPubKey == "" || identity.ConsensusPubKey != pubKey {
+			continue
+		}
+		if identity.Moniker != moniker || identity.OperatorAddress != operatorAddress {
+			vt.metrics.cosmos.validatorInfoMetric.DeletePartialMatch(prometheus.Labels{"validator": label})
+		}
+		identity.OperatorAddress = operatorAddress
+		identity.Moniker = moniker
+		vt.metrics.cosmos.validatorInfoMetric.WithLabelValues(label, moniker, operatorAddress).Set(1)
+	}
+}
+
+func (vt *UnifiedValidatorTracker) identityLocked(address string) *ValidatorIdentity {
+	identity, ok := vt.identities[address]
+	if !ok {
+		identity = &ValidatorIdentity{Label: vt.validators[address], ConsensusAddress: address}
+		vt.identities[address] = identity
+	}
+	return identity
+}
+
+// 현재 설정과 상태로부터 감시 대상 구성 (파일을 다시 읽지 않음)
+func (vt *UnifiedValidatorTracker) Targets() TargetsResponse {
+	selected := vt.endpoints.Selected()
+	targets := TargetsResponse{
+		ChainID:          vt.ChainID(),
+		Denom:            vt.denom,
+		RPCEndpoints:     []TargetEndpoint{},
+		Validators:       []ValidatorIdentity{},
+		MetricSources:    make(map[string]string, len(vt.metricSources)),
+		MetricSourceAuth: make(map[string]string),
+		Probes:           []TargetProbe{},
+		Notifiers:        []string{},
+		DryRun:           vt.dryRun,
+		NodeVersions:     vt.nodeVersionsSnapshot(),
+	}
+	for _, endpoint := range vt.endpoints.Endpoints() {
+		targets.RPCEndpoints = append(targets.RPCEndpoints, TargetEndpoint{
+			URL:      sanitizeEndpoint(endpoint),
+			Selected: endpoint == selected,
+			Pinned:   endpoint == vt.endpoints.pinned,
+		})
+	}
+	for _, source := range vt.metricSources {
+		targets.MetricSources[source.Name] = sanitizeEndpoint(source.URL)
+		if source.auth != "" {
+			targets.MetricSourceAuth[source.Name] = source.auth
+		}
+	}
+	for _, probe := range vt.probes {
+		targets.Probes = append(targets.Probes, TargetProbe{
+			Name:           probe.Name,
+			URL:            sanitizeEndpoint(probe.URL),
+			ExpectedStatus: probe.ExpectedStatus,
+			Interval:       jsonDurationString(probe.Interval),
+		})
+	}
+	for _, check := range vt.peerChecks {
+		targets.PeerChecks = append(targets.PeerChecks, TargetPeerCheck{
+			Node:          check.Node,
+			RPC:           sanitizeEndpoint(check.RPC),
+			ExpectedPeers: check.peerIDs,
+			Interval:      jsonDurationString(check.Interval),
+		})
+	}
+	for _, node := range vt.nodes {
+		targets.Nodes = append(targets.Nodes, TargetNode{
+			Name:         node.Name,
+			Role:         node.Role,
+			RPC:          sanitizeEndpoint(node.RPC),
+			DirectAccess: node.RPC != "",
+		})
+	}
+	for _, notifier := range vt.notifiers {
+		targets.Notifiers = append(targets.Notifiers, notifier.Name())
+	}
+
+	vt.mu.Lock()
+	for address := range vt.validators {
+		targets.Validators = append(targets.Validators, *vt.identityLocked(address))
+	}
+	vt.mu.Unlock()
+	sort.Slice(targets.Validators, func(i, j int) bool { return targets.Validators[i].Label < targets.Validators[j].Label })
+
+	return targets
+}
+
+// GET /api/targets
+func (vt *UnifiedValidatorTracker) handleTargets(w http.ResponseWriter, r *http.Request) {
+	targets := vt.Targets()
+	if tenant := tenantFromContext(r.Context()); tenant != nil {
+		visible := targets.Validators[:0]
+		for _, identity := range targets.Validators {
+			if tenant.Allows(identity.Label) {
+				visible = append(visible, identity)
+			}
+		}
+		targets.Validators = visible
+	}
+	writeJSON(w, http.StatusOK, targets)
+}
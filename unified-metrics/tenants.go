@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// 팀별 토큰과 그 팀이 볼 수 있는 벨리데이터 라벨
+type TenantConfig struct {
+	Name       string   `json:"name"`
+	Token      string   `json:"token"` // 값 또는 env:NAME, file:/path
+	Validators []string `json:"validators"`
+}
+
+// 요청한 팀 (nil이면 전체를 볼 수 있음: 테넌시 비활성화 또는 관리자 토큰)
+type Tenant struct {
+	Name   string
+	labels map[string]bool
+}
+
+func (t *Tenant) Allows(label string) bool {
+	return t == nil || t.labels[label]
+}
+
+// 벨리데이터가 없는 체인 이벤트(정지, 재시작 등)는 모든 팀에 보임
+func (t *Tenant) AllowsEvent(event Event) bool {
+	return event.Validator == "" || t.Allows(event.Validator)
+}
+
+// 토큰 -> 팀 (SIGHUP으로 다시 읽음, 비어 있으면 테넌시 비활성화)
+type TenantRegistry struct {
+	mu      sync.RWMutex
+	tenants []*tenantEntry
+}
+
+type tenantEntry struct {
+	token  string
+	tenant *Tenant
+}
+
+func (r *TenantRegistry) Enabled() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.tenants) > 0
+}
+
+// 모든 토큰과 상수 시간 비교 (일치하는 토큰의 위치가 드러나지 않도록 끝까지 비교)
+func (r *TenantRegistry) lookup(token string) *Tenant {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *Tenant
+	for _, entry := range r.tenants {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(entry.token)) == 1 {
+			found = entry.tenant
+		}
+	}
+	return found
+}
+
+func (r *TenantRegistry) set(entries []*tenantEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tenants = entries
+}
+
+// TENANTS_FILE(JSON 파일) 또는 TENANTS(JSON 문자열)에서 팀 목록 읽기
+func tenantsFromEnv() ([]TenantConfig, error) {
+	data := []byte(getEnv("TENANTS", ""))
+	if path := getEnv("TENANTS_FILE", ""); path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var configs []TenantConfig
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("decode tenants: %w", err)
+	}
+	return configs, nil
+}
+
+// 설정 검증 후 토큰 표 구성 (추적하지 않는 라벨, 중복 토큰, 관리자 토큰과 같은 토큰은 거부)
+func buildTenants(configs []TenantConfig, trackedLabels map[string]bool, adminToken string) ([]*tenantEntry, error) {
+	names := make(map[string]bool)
+	tokens := make(map[string]bool)
+	entries := make([]*tenantEntry, 0, len(configs))
+	for i, config := range configs {
+		if config.Name == "" || config.Token == "" {
+			return nil, fmt.Errorf("tenant %d: name and token are required", i)
+		}
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicate tenant name %q", config.Name)
+		}
+		names[config.Name] = true
+
+		token, err := resolveSecret(config.Token)
+		if err != nil {
+			return nil, fmt.Errorf("tenant %q: %w", config.Name, err)
+		}
+		if token == "" {
+			return nil, fmt.Errorf("tenant %q: token is empty", config.Name)
+		}
+		if tokens[token] || (adminToken != "" && token == adminToken) {
+			return nil, fmt.Errorf("tenant %q: token must be unique and differ from ADMIN_TOKEN", config.Name)
+		}
+		tokens[token] = true
+
+		tenant := &Tenant{Name: config.Name, labels: make(map[string]bool, len(config.Validators))}
+		for _, label := range config.Validators {
+			if !trackedLabels[label] {
+				return nil, fmt.Errorf("tenant %q: unknown validator label %q", config.Name, label)
+			}
+			tenant.labels[label] = true
+		}
+		entries = append(entries, &tenantEntry{token: token, tenant: tenant})
+	}
+	return entries, nil
+}
+
+// 팀 설정 읽기 (실패하면 기존 설정 유지)
+func (vt *UnifiedValidatorTracker) loadTenants(adminToken string) error {
+	configs, err := tenantsFromEnv()
+	if err != nil {
+		return err
+	}
+	tracked := make(map[string]bool, len(vt.validators))
+	for _, label := range vt.validators {
+		tracked[label] = true
+	}
+	entries, err := buildTenants(configs, tracked, adminToken)
+	if err != nil {
+		return err
+	}
+	vt.tenants.set(entries)
+	return nil
+}
+
+// SIGHUP 수신 시 팀 설정 다시 읽기 (로그 레벨과 같은 신호로 함께 갱신)
+func (vt *UnifiedValidatorTracker) HandleTenantReload(ctx context.Context, adminToken string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			if err := vt.loadTenants(adminToken); err != nil {
+				httpLog.Error("Failed to reload tenants, keeping current tenants", "error", err)
+				continue
+			}
+			httpLog.Info("Reloaded tenants", "enabled", vt.tenants.Enabled())
+		}
+	}
+}
+
+type tenantContextKey struct{}
+
+func tenantFromContext(ctx context.Context) *Tenant {
+	tenant, _ := ctx.Value(tenantContextKey{}).(*Tenant)
+	return tenant
+}
+
+// 테넌시가 켜져 있으면 팀 토큰 또는 관리자 토큰을 요구하고 요청 컨텍스트에 팀을 넣음
+// 관리자 토큰은 팀 없이(전체 보기) 통과
+func (vt *UnifiedValidatorTracker) requireTenant(adminToken string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !vt.tenants.Enabled() {
+			next(w, r)
+			return
+		}
+		provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if adminToken != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(adminToken)) == 1 {
+			next(w, r)
+			return
+		}
+		tenant := vt.tenants.lookup(provided)
+		if provided == "" || tenant == nil {
+			writeAPIError(w, http.StatusUnauthorized, "tenant token required")
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), tenantContextKey{}, tenant)))
+	}
+}
+
+// 팀이 볼 수 없는 validator 라벨 시리즈를 제외하는 Gatherer (레지스트리는 하나로 유지)
+type tenantGatherer struct {
+	gatherer prometheus.Gatherer
+	tenant   *Tenant
+}
+
+func (g tenantGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	filtered := families[:0]
+	for _, family := range families {
+		metrics := family.Metric[:0]
+		for _, metric := range family.Metric {
+			if g.allows(metric) {
+				metrics = append(metrics, metric)
+			}
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+		family.Metric = metrics
+		filtered = append(filtered, family)
+	}
+	return filtered, err
+}
+
+// validator 라벨만 보고 거름: 블록 높이, 엔드포인트 상태처럼 validator 라벨이 없는 체인 전체 시리즈는
+// 어느 팀의 것도 아니므로 모든 팀에 보이는 것이 의도된 동작 (/api/events의 AllowsEvent와 같은 기준)
+func (g tenantGatherer) allows(metric *dto.Metric) bool {
+	for _, pair := range metric.GetLabel() {
+		if pair.GetName() == "validator" {
+			return g.tenant.Allows(pair.GetValue())
+		}
+	}
+	return true
+}
+
+// 팀이면 필터링한 Gatherer로, 아니면 full 핸들러로 응답
+func (vt *UnifiedValidatorTracker) tenantMetricsHandler(adminToken string, full http.Handler, gatherer prometheus.Gatherer, opts promhttp.HandlerOpts) http.HandlerFunc {
+	return vt.requireTenant(adminToken, func(w http.ResponseWriter, r *http.Request) {
+		tenant := tenantFromContext(r.Context())
+		if tenant == nil {
+			full.ServeHTTP(w, r)
+			return
+		}
+		promhttp.HandlerFor(tenantGatherer{gatherer: gatherer, tenant: tenant}, opts).ServeHTTP(w, r)
+	})
+}
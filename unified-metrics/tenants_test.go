@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	testAdminToken = "admin-token"
+	testTeamAToken = "team-a-token"
+	testTeamBToken = "team-b-token"
+)
+
+// main과 같은 구성의 /metrics, /all-metrics, /api/* 서버 (노드 쪽 소스는 고정 본문)
+func newTenantServer(t *testing.T, tracker *UnifiedValidatorTracker) *httptest.Server {
+	t.Helper()
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "# TYPE node_load1 gauge\nnode_load1 0.5\n")
+	}))
+	t.Cleanup(node.Close)
+	nodeExporter, err := NewMetricSource("node_exporter", node.URL, SourceConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ogNode, err := NewMetricSource("og_node", node.URL, SourceConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	full := promhttp.HandlerFor(tracker.registry, promhttp.HandlerOpts{})
+	mux.Handle("/metrics", tracker.tenantMetricsHandler(testAdminToken, full, tracker.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/all-metrics", tracker.requireTenant(testAdminToken,
+		tracker.allMetricsHandler(tracker.registry, metricDedupBoth, nodeExporter, ogNode)))
+	tracker.RegisterAPI(mux, APIOptions{AdminToken: testAdminToken, Limiter: NewRequestLimiter(0, 0, 10, tracker.metrics.exporter.rejectedRequestsMetric)})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTenantTracker(t *testing.T) *UnifiedValidatorTracker {
+	t.Helper()
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"},
+		map[string]string{"ADDRALPHA": "alpha", "ADDRBETA": "beta", "ADDRGAMMA": "gamma"})
+	if err := tracker.RegisterMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	for _, label := range []string{"alpha", "beta", "gamma"} {
+		tracker.metrics.cosmos.missedBlocksTotalMetric.WithLabelValues(label).Inc()
+	}
+	tracker.metrics.cosmos.blockHeightMetric.Set(120)
+
+	t.Setenv("TENANTS_FILE", "")
+	t.Setenv("TENANTS", `[{"name": "team-a", "token": "`+testTeamAToken+`", "validators": ["alpha"]},
+		{"name": "team-b", "token": "`+testTeamBToken+`", "validators": ["beta", "gamma"]}]`)
+	if err := tracker.loadTenants(testAdminToken); err != nil {
+		t.Fatal(err)
+	}
+	return tracker
+}
+
+func tenantGet(t *testing.T, server *httptest.Server, path, token string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+// 응답 본문에 나온 missed_blocks_total의 validator 라벨
+func visibleValidators(body string) map[string]bool {
+	visible := make(map[string]bool)
+	for _, label := range []string{"alpha", "beta", "gamma"} {
+		if strings.Contains(body, `og_galileo_validator_missed_blocks_total{validator="`+label+`"}`) {
+			visible[label] = true
+		}
+	}
+	return visible
+}
+
+// /api/status, /api/targets에 나온 벨리데이터 라벨
+func apiValidators(t *testing.T, body string) map[string]bool {
+	t.Helper()
+	var response struct {
+		Validators []struct {
+			Label string `json:"label"`
+		} `json:"validators"`
+	}
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("decode %s: %v", body, err)
+	}
+	labels := make(map[string]bool)
+	for _, v := range response.Validators {
+		labels[v.Label] = true
+	}
+	return labels
+}
+
+func sameLabels(got map[string]bool, want ...string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for _, label := range want {
+		if !got[label] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTenantVisibility(t *testing.T) {
+	tracker := newTenantTracker(t)
+	server := newTenantServer(t, tracker)
+
+	tests := []struct {
+		name, token string
+		want        []string
+	}{
+		{"team a", testTeamAToken, []string{"alpha"}},
+		{"team b", testTeamBToken, []string{"beta", "gamma"}},
+		{"admin", testAdminToken, []string{"alpha", "beta", "gamma"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, path := range []string{"/metrics", "/all-metrics"} {
+				status, body := tenantGet(t, server, path, tt.token)
+				if status != http.StatusOK {
+					t.Fatalf("%s: status %d", path, status)
+				}
+				if got := visibleValidators(body); !sameLabels(got, tt.want...) {
+					t.Errorf("%s validators = %v, want %v", path, got, tt.want)
+				}
+				// validator 라벨이 없는 체인 전체 시리즈는 모든 팀에 보임
+				if !strings.Contains(body, "og_galileo_validator_block_height 120") {
+					t.Errorf("%s: chain-wide block height missing", path)
+				}
+			}
+			if _, body := tenantGet(t, server, "/all-metrics", tt.token); !strings.Contains(body, "node_load1 0.5") {
+				t.Error("/all-metrics: node exporter metrics missing")
+			}
+			for _, path := range []string{"/api/status", "/api/targets"} {
+				status, body := tenantGet(t, server, path, tt.token)
+				if status != http.StatusOK {
+					t.Fatalf("%s: status %d", path, status)
+				}
+				if got := apiValidators(t, body); !sameLabels(got, tt.want...) {
+					t.Errorf("%s validators = %v, want %v", path, got, tt.want)
+				}
+			}
+		})
+	}
+
+	// 다른 팀의 벨리데이터는 없는 것처럼 응답
+	if status, _ := tenantGet(t, server, "/api/heatmap?validator=beta", testTeamAToken); status != http.StatusNotFound {
+		t.Errorf("team a heatmap for beta = %d, want 404", status)
+	}
+	if status, _ := tenantGet(t, server, "/api/heatmap?validator=alpha", testTeamAToken); status != http.StatusOK {
+		t.Errorf("team a heatmap for alpha = %d, want 200", status)
+	}
+}
+
+func TestTenantUnauthorized(t *testing.T) {
+	server := newTenantServer(t, newTenantTracker(t))
+	for _, token := range []string{"", "unknown-token", testTeamAToken + "x"} {
+		for _, path := range []string{"/metrics", "/all-metrics", "/api/status", "/api/targets"} {
+			if status, body := tenantGet(t, server, path, token); status != http.StatusUnauthorized {
+				t.Errorf("%s with token %q = %d, want 401", path, token, status)
+			} else if strings.Contains(body, "og_galileo") {
+				t.Errorf("%s with token %q leaked metrics", path, token)
+			}
+		}
+	}
+}
+
+// SIGHUP으로 팀을 지우면 그 팀의 토큰은 바로 거부
+func TestTenantReloadRevokesAccess(t *testing.T) {
+	tracker := newTenantTracker(t)
+	server := newTenantServer(t, tracker)
+	if status, _ := tenantGet(t, server, "/metrics", testTeamBToken); status != http.StatusOK {
+		t.Fatalf("team b before reload = %d", status)
+	}
+
+	// 핸들러가 신호를 등록하기 전에 보낸 SIGHUP으로 테스트 프로세스가 종료되지 않도록 먼저 등록
+	guard := make(chan os.Signal, 16)
+	signal.Notify(guard, syscall.SIGHUP)
+	defer signal.Stop(guard)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		tracker.goroutines.WaitIdle(5 * time.Second)
+	}()
+	tracker.goroutines.Go(componentSignals, func() { tracker.HandleTenantReload(ctx, testAdminToken) })
+
+	t.Setenv("TENANTS", `[{"name": "team-a", "token": "`+testTeamAToken+`", "validators": ["alpha"]}]`)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		syscall.Kill(os.Getpid(), syscall.SIGHUP)
+		time.Sleep(20 * time.Millisecond)
+		if status, _ := tenantGet(t, server, "/metrics", testTeamBToken); status == http.StatusUnauthorized {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("team b token still accepted after reload")
+		}
+	}
+	status, body := tenantGet(t, server, "/metrics", testTeamAToken)
+	if status != http.StatusOK || !sameLabels(visibleValidators(body), "alpha") {
+		t.Errorf("team a after reload = %d, %v", status, visibleValidators(body))
+	}
+	if status, body := tenantGet(t, server, "/metrics", testAdminToken); status != http.StatusOK || len(visibleValidators(body)) != 3 {
+		t.Errorf("admin after reload = %d, %v", status, visibleValidators(body))
+	}
+
+	// 잘못된 설정으로 다시 읽으면 기존 팀 유지
+	t.Setenv("TENANTS", `[{"name": "team-a", "token": "`+testTeamAToken+`", "validators": ["unknown"]}]`)
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	time.Sleep(100 * time.Millisecond)
+	if status, _ := tenantGet(t, server, "/metrics", testTeamAToken); status != http.StatusOK {
+		t.Errorf("team a after invalid reload = %d, want 200", status)
+	}
+}
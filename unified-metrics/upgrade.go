@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// 예약된 업그레이드 (없으면 plan이 null)
+type UpgradePlanResponse struct {
+	Plan *struct {
+		Name   string `json:"name"`
+		Height string `json:"height"`
+		Info   string `json:"info"`
+	} `json:"plan"`
+}
+
+// 예약된 업그레이드 높이 (예약이 없으면 -1)
+func (vt *UnifiedValidatorTracker) fetchUpgradePlan(ctx context.Context) (height int64, name string, err error) {
+	defer func(start time.Time) { vt.recordFetch("upgrade_plan", time.Since(start), err) }(time.Now())
+
+	endpoint := vt.restBase()
+	url := fmt.Sprintf("%s/cosmos/upgrade/v1beta1/current_plan", endpoint)
+	var resp UpgradePlanResponse
+	if err := vt.getJSON(ctx, "upgrade_plan", endpoint, url, &resp); err != nil {
+		return 0, "", err
+	}
+	if resp.Plan == nil {
+		return -1, "", nil
+	}
+	height, err = strconv.ParseInt(resp.Plan.Height, 10, 64)
+	if err != nil || height <= 0 {
+		return 0, "", newFetchError("upgrade_plan", endpoint, decodeFailure(fmt.Errorf("invalid upgrade plan height %q", resp.Plan.Height)))
+	}
+	return height, sanitizeLabel(resp.Plan.Name), nil
+}
+
+// governance 수집기에서 호출: 업그레이드 높이와 남은 블록 수 갱신
+func (vt *UnifiedValidatorTracker) refreshUpgradePlan(ctx context.Context) error {
+	height, name, err := vt.fetchUpgradePlan(ctx)
+	if err != nil {
+		restLog.Error("Error fetching upgrade plan", "error", err)
+		return err
+	}
+	if previous := vt.upgradeHeight.Swap(height); previous != height && height > 0 {
+		restLog.Info("Upgrade scheduled", "name", name, "height", height)
+	}
+	vt.metrics.cosmos.upgradePlanMetric.Set(float64(height))
+	vt.updateBlocksUntilUpgrade(vt.LastHeight())
+	return nil
+}
+
+// 업그레이드까지 남은 블록 수 (예약이 없으면 -1, 업그레이드 높이에 도달하면 0)
+// 실시간 블록마다, 업그레이드 계획을 받을 때마다 호출
+func (vt *UnifiedValidatorTracker) updateBlocksUntilUpgrade(current int64) {
+	upgrade := vt.upgradeHeight.Load()
+	switch {
+	case upgrade == 0:
+		return // 아직 조회하지 않음
+	case upgrade < 0:
+		vt.metrics.cosmos.blocksUntilUpgradeMetric.Set(-1)
+	case current > 0:
+		vt.metrics.cosmos.blocksUntilUpgradeMetric.Set(float64(max(upgrade-current, 0)))
+	}
+}
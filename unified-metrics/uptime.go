@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UPTIME_WINDOWS 기본값 (블록 수)
+const defaultUptimeWindow = 10000
+
+// 최근 size 높이의 서명 여부를 높이 % size 위치의 비트로 기록 (벨리데이터 하나, 블록당 2비트)
+// observed는 판단한 높이, signed는 그중 서명한 높이 (건너뛴 높이는 비율에서 빠짐)
+type UptimeCalculator struct {
+	size     int64
+	newest   int64
+	observed []uint64
+	signed   []uint64
+	nObs     int
+	nSigned  int
+}
+
+func NewUptimeCalculator(size int64) *UptimeCalculator {
+	words := (size + 63) / 64
+	return &UptimeCalculator{size: size, observed: make([]uint64, words), signed: make([]uint64, words)}
+}
+
+func (c *UptimeCalculator) bit(height int64) (int, uint64) {
+	slot := height % c.size
+	return int(slot / 64), 1 << uint(slot%64)
+}
+
+func (c *UptimeCalculator) clear(height int64) {
+	word, mask := c.bit(height)
+	if c.observed[word]&mask != 0 {
+		c.nObs--
+		if c.signed[word]&mask != 0 {
+			c.nSigned--
+		}
+	}
+	c.observed[word] &^= mask
+	c.signed[word] &^= mask
+}
+
+// 한 블록의 결과 반영 (윈도우보다 오래됐거나 이미 반영한 높이면 무시)
+func (c *UptimeCalculator) Observe(height int64, signed bool) {
+	if height <= 0 || height <= c.newest-c.size {
+		return
+	}
+	if height > c.newest {
+		// 윈도우에서 밀려나는 높이의 비트 지움
+		for h := max(c.newest+1, height-c.size+1); h <= height; h++ {
+			c.clear(h)
+		}
+		c.newest = height
+	}
+	word, mask := c.bit(height)
+	if c.observed[word]&mask != 0 {
+		return
+	}
+	c.observed[word] |= mask
+	c.nObs++
+	if signed {
+		c.signed[word] |= mask
+		c.nSigned++
+	}
+}
+
+// 윈도우 안에서 서명한 비율 (판단한 블록이 없으면 ok=false)
+func (c *UptimeCalculator) Ratio() (ratio float64, ok bool) {
+	if c.nObs == 0 {
+		return 0, false
+	}
+	return float64(c.nSigned) / float64(c.nObs), true
+}
+
+// 상태 파일에 저장하는 윈도우 하나 (벨리데이터별 비트 배열은 base64)
+type UptimeWindowSnapshot struct {
+	Window     int64                   `json:"window"`
+	Validators map[string]UptimeBitmap `json:"validators"`
+}
+
+type UptimeBitmap struct {
+	Newest   int64  `json:"newest"`
+	Observed string `json:"observed"`
+	Signed   string `json:"signed"`
+}
+
+func (c *UptimeCalculator) export() UptimeBitmap {
+	return UptimeBitmap{Newest: c.newest, Observed: encodeBitmap(c.observed), Signed: encodeBitmap(c.signed)}
+}
+
+func importUptimeCalculator(size int64, bitmap UptimeBitmap) (*UptimeCalculator, error) {
+	c := NewUptimeCalculator(size)
+	observed, err := decodeBitmap(bitmap.Observed, len(c.observed))
+	if err != nil {
+		return nil, err
+	}
+	signed, err := decodeBitmap(bitmap.Signed, len(c.signed))
+	if err != nil {
+		return nil, err
+	}
+	c.newest, c.observed, c.signed = bitmap.Newest, observed, signed
+	for i := range c.observed {
+		c.signed[i] &= c.observed[i]
+		c.nObs += bits.OnesCount64(c.observed[i])
+		c.nSigned += bits.OnesCount64(c.signed[i])
+	}
+	return c, nil
+}
+
+func encodeBitmap(words []uint64) string {
+	raw := make([]byte, 0, len(words)*8)
+	for _, word := range words {
+		for i := 0; i < 8; i++ {
+			raw = append(raw, byte(word>>(8*i)))
+		}
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func decodeBitmap(encoded string, words int) ([]uint64, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != words*8 {
+		return nil, fmt.Errorf("bitmap has %d bytes, expected %d", len(raw), words*8)
+	}
+	out := make([]uint64, words)
+	for i := range out {
+		for j := 0; j < 8; j++ {
+			out[i] |= uint64(raw[i*8+j]) << (8 * j)
+		}
+	}
+	return out, nil
+}
+
+// 설정된 윈도우마다 벨리데이터별 UptimeCalculator (applier 고루틴과 스냅샷이 함께 쓰므로 mu로 보호)
+type UptimeTracker struct {
+	mu          sync.Mutex
+	windows     []int64
+	calculators map[int64]map[string]*UptimeCalculator
+}
+
+func NewUptimeTracker(windows []int64) *UptimeTracker {
+	t := &UptimeTracker{windows: windows}
+	t.reset()
+	return t
+}
+
+func (t *UptimeTracker) reset() {
+	t.calculators = make(map[int64]map[string]*UptimeCalculator, len(t.windows))
+	for _, window := range t.windows {
+		t.calculators[window] = make(map[string]*UptimeCalculator)
+	}
+}
+
+// 한 벨리데이터의 블록 결과를 모든 윈도우에 반영하고 윈도우별 비율 반환
+func (t *UptimeTracker) Observe(validator string, height int64, signed bool) map[int64]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ratios := make(map[int64]float64, len(t.windows))
+	for _, window := range t.windows {
+		calculator, ok := t.calculators[window][validator]
+		if !ok {
+			calculator = NewUptimeCalculator(window)
+			t.calculators[window][validator] = calculator
+		}
+		calculator.Observe(height, signed)
+		if ratio, ok := calculator.Ratio(); ok {
+			ratios[window] = ratio
+		}
+	}
+	return ratios
+}
+
+// 윈도우 -> 벨리데이터 -> 비율 (판단한 블록이 없는 벨리데이터는 제외)
+func (t *UptimeTracker) Ratios() map[int64]map[string]float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ratios := make(map[int64]map[string]float64, len(t.windows))
+	for window, calculators := range t.calculators {
+		ratios[window] = make(map[string]float64, len(calculators))
+		for validator, calculator := range calculators {
+			if ratio, ok := calculator.Ratio(); ok {
+				ratios[window][validator] = ratio
+			}
+		}
+	}
+	return ratios
+}
+
+func (t *UptimeTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.reset()
+}
+
+func (t *UptimeTracker) Export() []UptimeWindowSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshots := make([]UptimeWindowSnapshot, 0, len(t.windows))
+	for _, window := range t.windows {
+		snapshot := UptimeWindowSnapshot{Window: window, Validators: make(map[string]UptimeBitmap, len(t.calculators[window]))}
+		for validator, calculator := range t.calculators[window] {
+			snapshot.Validators[validator] = calculator.export()
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// 저장된 윈도우 중 현재 설정에 있는 윈도우만 복원 (크기가 바뀐 윈도우는 새로 시작)
+func (t *UptimeTracker) Import(snapshots []UptimeWindowSnapshot) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, snapshot := range snapshots {
+		calculators, ok := t.calculators[snapshot.Window]
+		if !ok {
+			continue
+		}
+		for validator, bitmap := range snapshot.Validators {
+			calculator, err := importUptimeCalculator(snapshot.Window, bitmap)
+			if err != nil {
+				persistenceLog.Warn("Skipping invalid uptime window in state file", "window", snapshot.Window, "validator", validator, "error", err)
+				continue
+			}
+			calculators[validator] = calculator
+		}
+	}
+}
+
+// "10000,1000" -> 블록 수 목록 (중복 제거, 오름차순)
+func parseUptimeWindows(spec string) ([]int64, error) {
+	seen := make(map[int64]bool)
+	var windows []int64
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		window, err := strconv.ParseInt(entry, 10, 64)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid uptime window %q (expected a positive block count)", entry)
+		}
+		if !seen[window] {
+			seen[window] = true
+			windows = append(windows, window)
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	return windows, nil
+}
+
+// 블록마다 호출: 벨리데이터의 윈도우별 서명 비율 메트릭 갱신
+func (vt *UnifiedValidatorTracker) observeUptime(validator string, height int64, signed bool) {
+	for window, ratio := range vt.uptime.Observe(validator, height, signed) {
+		vt.metrics.cosmos.uptimeRatioMetric.WithLabelValues(validator, strconv.FormatInt(window, 10)).Set(ratio)
+	}
+}
+
+// 상태 파일 복원 후 저장된 비율로 메트릭 채움
+func (vt *UnifiedValidatorTracker) updateUptimeMetrics() {
+	for window, ratios := range vt.uptime.Ratios() {
+		for validator, ratio := range ratios {
+			vt.metrics.cosmos.uptimeRatioMetric.WithLabelValues(validator, strconv.FormatInt(window, 10)).Set(ratio)
+		}
+	}
+}
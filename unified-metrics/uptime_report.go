@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultUptimeReportDays = 7
+	maxUptimeReportDays     = 31 // 위임 프로그램 월간 리포트 기준
+)
+
+type UptimeReport struct {
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Validators []ValidatorUptime `json:"validators"`
+}
+
+type ValidatorUptime struct {
+	Validator     string        `json:"validator"`
+	Signed        int           `json:"signed"`
+	Missed        int           `json:"missed"`
+	UptimePercent float64       `json:"uptime_percent"`
+	Days          []DailyUptime `json:"days"`
+	JailPeriods   []JailPeriod  `json:"jail_periods"`
+	Governance    Participation `json:"governance"`
+}
+
+type DailyUptime struct {
+	Date          string  `json:"date"` // YYYY-MM-DD (UTC)
+	Signed        int     `json:"signed"`
+	Missed        int     `json:"missed"`
+	UptimePercent float64 `json:"uptime_percent"`
+}
+
+// 수감 구간 (To가 nil이면 기간 끝까지 수감 상태)
+type JailPeriod struct {
+	From time.Time  `json:"from"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+type Participation struct {
+	Voted    []string `json:"voted"`
+	NotVoted []string `json:"not_voted"`
+}
+
+// 일별 히트맵 버킷과 상태 샘플로부터 업타임 리포트 계산 (부수 효과 없는 순수 함수)
+func BuildUptimeReport(from, to time.Time, daily map[string][]HeatmapBucket, samples []ValidatorSample, proposals map[string]map[string]bool) UptimeReport {
+	report := UptimeReport{From: from, To: to, Validators: []ValidatorUptime{}}
+
+	validators := make([]string, 0, len(daily))
+	for validator := range daily {
+		validators = append(validators, validator)
+	}
+	sort.Strings(validators)
+
+	ids := make([]string, 0, len(proposals))
+	for id := range proposals {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, validator := range validators {
+		entry := ValidatorUptime{
+			Validator:   validator,
+			Days:        []DailyUptime{},
+			JailPeriods: []JailPeriod{},
+			Governance:  Participation{Voted: []string{}, NotVoted: []string{}},
+		}
+
+		for _, bucket := range daily[validator] {
+			entry.Days = append(entry.Days, DailyUptime{
+				Date:          bucket.Start.UTC().Format("2006-01-02"),
+				Signed:        bucket.Signed,
+				Missed:        bucket.Missed,
+				UptimePercent: uptimePercent(bucket.Signed, bucket.Missed),
+			})
+			entry.Signed += bucket.Signed
+			entry.Missed += bucket.Missed
+		}
+		entry.UptimePercent = uptimePercent(entry.Signed, entry.Missed)
+
+		var open *JailPeriod
+		for _, sample := range samples {
+			if sample.Validator != validator {
+				continue
+			}
+			if sample.Jailed && open == nil {
+				open = &JailPeriod{From: sample.Time}
+			} else if !sample.Jailed && open != nil {
+				end := sample.Time
+				open.To = &end
+				entry.JailPeriods = append(entry.JailPeriods, *open)
+				open = nil
+			}
+		}
+		if open != nil {
+			entry.JailPeriods = append(entry.JailPeriods, *open)
+		}
+
+		for _, id := range ids {
+			if proposals[id][validator] {
+				entry.Governance.Voted = append(entry.Governance.Voted, id)
+			} else {
+				entry.Governance.NotVoted = append(entry.Governance.NotVoted, id)
+			}
+		}
+
+		report.Validators = append(report.Validators, entry)
+	}
+
+	return report
+}
+
+func uptimePercent(signed, missed int) float64 {
+	if total := signed + missed; total > 0 {
+		return float64(signed) / float64(total) * 100
+	}
+	return 0
+}
+
+func RenderUptimeReportMarkdown(report UptimeReport) string {
+	var b strings.Builder
+	// To는 포함하지 않으므로 마지막 날은 그 직전 시각의 날짜
+	fmt.Fprintf(&b, "# Uptime report %s ~ %s (UTC)\n", report.From.UTC().Format("2006-01-02"), report.To.Add(-time.Nanosecond).UTC().Format("2006-01-02"))
+	for _, v := range report.Validators {
+		fmt.Fprintf(&b, "\n## %s\n\n", v.Validator)
+		fmt.Fprintf(&b, "Uptime: %.2f%% (signed %d / missed %d)\n\n", v.UptimePercent, v.Signed, v.Missed)
+		b.WriteString("| Date | Signed | Missed | Uptime |\n|---|---:|---:|---:|\n")
+		for _, day := range v.Days {
+			fmt.Fprintf(&b, "| %s | %d | %d | %.2f%% |\n", day.Date, day.Signed, day.Missed, day.UptimePercent)
+		}
+		if len(v.JailPeriods) > 0 {
+			b.WriteString("\nJail periods:\n")
+			for _, period := range v.JailPeriods {
+				end := "ongoing"
+				if period.To != nil {
+					end = period.To.UTC().Format(time.RFC3339)
+				}
+				fmt.Fprintf(&b, "- %s ~ %s\n", period.From.UTC().Format(time.RFC3339), end)
+			}
+		}
+		fmt.Fprintf(&b, "\nGovernance: %d voted, %d not voted", len(v.Governance.Voted), len(v.Governance.NotVoted))
+		if len(v.Governance.NotVoted) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(v.Governance.NotVoted, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// 날짜(YYYY-MM-DD) 또는 RFC3339 시각 파싱 (dateOnly는 날짜만 지정했는지)
+func parseReportTime(value string) (t time.Time, dateOnly bool, err error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+// GET /api/report
+func (vt *UnifiedValidatorTracker) handleUptimeReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// 구간은 [from, to): 날짜만 지정한 to는 그날 끝까지 포함 (다음 날 0시 직전까지)
+	to := time.Now().UTC()
+	if value := query.Get("to"); value != "" {
+		parsed, dateOnly, err := parseReportTime(value)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid to %q (expected YYYY-MM-DD or RFC3339)", value)
+			return
+		}
+		to = parsed.UTC()
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+	from := to.AddDate(0, 0, -defaultUptimeReportDays)
+	if value := query.Get("from"); value != "" {
+		parsed, _, err := parseReportTime(value)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "invalid from %q (expected YYYY-MM-DD or RFC3339)", value)
+			return
+		}
+		from = parsed.UTC()
+	}
+	// 일 단위 버킷이 UTC 자정에 맞도록 정렬
+	from = from.Truncate(24 * time.Hour)
+	if !from.Before(to) {
+		writeAPIError(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+	if to.Sub(from) > maxUptimeReportDays*24*time.Hour {
+		writeAPIError(w, http.StatusBadRequest, "range must not exceed %d days", maxUptimeReportDays)
+		return
+	}
+
+	tenant := tenantFromContext(r.Context())
+	var labels []string
+	if validator := query.Get("validator"); validator != "" {
+		if !vt.isTrackedLabel(validator) || !tenant.Allows(validator) {
+			writeAPIError(w, http.StatusNotFound, "unknown validator %q", validator)
+			return
+		}
+		labels = []string{validator}
+	} else {
+		for _, label := range vt.validators {
+			if tenant.Allows(label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	daily := make(map[string][]HeatmapBucket, len(labels))
+	for _, label := range labels {
+		daily[label] = vt.history.Heatmap(label, from, to, 24*time.Hour)
+	}
+	report := BuildUptimeReport(from, to, daily, vt.history.Samples("", from, to), vt.proposalVotesBetween(from, to))
+
+	switch query.Get("format") {
+	case "", "json":
+		writeJSON(w, http.StatusOK, report)
+	case "markdown":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Write([]byte(RenderUptimeReportMarkdown(report)))
+	default:
+		writeAPIError(w, http.StatusBadRequest, "format must be json or markdown")
+	}
+}
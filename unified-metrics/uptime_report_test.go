@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func reportDate(day int, hour, minute, second int) time.Time {
+	return time.Date(2025, 6, day, hour, minute, second, 0, time.UTC)
+}
+
+// 2025-06-01 ~ 06-08 서명 기록, beta 수감 구간, 기간이 다른 제안 네 개
+func newReportTracker(t *testing.T) *UnifiedValidatorTracker {
+	t.Helper()
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha", "ADDRBETA": "beta"})
+
+	var height int64
+	for day := 1; day <= 8; day++ {
+		for _, at := range []time.Time{reportDate(day, 0, 0, 0), reportDate(day, 12, 0, 0), reportDate(day, 23, 59, 59)} {
+			height++
+			tracker.history.AddSigning(
+				SigningRecord{Height: height, Time: at, Validator: "alpha", Signed: true},
+				SigningRecord{Height: height, Time: at, Validator: "beta", Signed: at.Hour() != 12},
+			)
+		}
+	}
+	tracker.history.AddSample(ValidatorSample{Time: reportDate(3, 10, 0, 0), Validator: "beta", Jailed: true})
+	tracker.history.AddSample(ValidatorSample{Time: reportDate(4, 8, 0, 0), Validator: "beta", Jailed: false})
+
+	for _, p := range []struct {
+		id         string
+		start, end time.Time
+		votes      map[string]bool
+	}{
+		{"1", time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC), time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), map[string]bool{"alpha": true}}, // 5월에 끝남
+		{"2", reportDate(2, 0, 0, 0), reportDate(4, 0, 0, 0), map[string]bool{"alpha": true}},
+		{"3", reportDate(7, 18, 0, 0), reportDate(9, 0, 0, 0), map[string]bool{"beta": true}}, // 마지막 날 저녁에 시작
+		{"4", reportDate(8, 0, 0, 0), reportDate(10, 0, 0, 0), map[string]bool{"alpha": true, "beta": true}},
+	} {
+		tracker.setProposalVotes(ActiveProposal{ID: p.id, VotingStartTime: p.start, VotingEndTime: p.end}, p.votes)
+	}
+	return tracker
+}
+
+func getReport(t *testing.T, tracker *UnifiedValidatorTracker, query string) UptimeReport {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	tracker.handleUptimeReport(rec, httptest.NewRequest("GET", "/api/report?"+query, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/report?%s = %d: %s", query, rec.Code, rec.Body.String())
+	}
+	var report UptimeReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+// 날짜만 지정한 to는 그날 23:59:59 기록까지 포함
+func TestUptimeReportIncludesEndDay(t *testing.T) {
+	tracker := newReportTracker(t)
+	report := getReport(t, tracker, "from=2025-06-01&to=2025-06-07")
+
+	if !report.From.Equal(reportDate(1, 0, 0, 0)) || !report.To.Equal(reportDate(8, 0, 0, 0)) {
+		t.Errorf("range = %v ~ %v, want 2025-06-01 ~ 2025-06-08 (exclusive)", report.From, report.To)
+	}
+	if len(report.Validators) != 2 {
+		t.Fatalf("validators = %d, want 2", len(report.Validators))
+	}
+	for _, v := range report.Validators {
+		if len(v.Days) != 7 {
+			t.Fatalf("%s days = %d, want 7", v.Validator, len(v.Days))
+		}
+		last := v.Days[6]
+		if last.Date != "2025-06-07" || last.Signed+last.Missed != 3 {
+			t.Errorf("%s last day = %+v, want 2025-06-07 with 3 blocks", v.Validator, last)
+		}
+	}
+	alpha, beta := report.Validators[0], report.Validators[1]
+	if alpha.Signed != 21 || alpha.Missed != 0 {
+		t.Errorf("alpha signed/missed = %d/%d, want 21/0", alpha.Signed, alpha.Missed)
+	}
+	if beta.Signed != 14 || beta.Missed != 7 {
+		t.Errorf("beta signed/missed = %d/%d, want 14/7", beta.Signed, beta.Missed)
+	}
+
+	if len(beta.JailPeriods) != 1 || !beta.JailPeriods[0].From.Equal(reportDate(3, 10, 0, 0)) ||
+		beta.JailPeriods[0].To == nil || !beta.JailPeriods[0].To.Equal(reportDate(4, 8, 0, 0)) {
+		t.Errorf("beta jail periods = %+v", beta.JailPeriods)
+	}
+	if len(alpha.JailPeriods) != 0 {
+		t.Errorf("alpha jail periods = %+v, want none", alpha.JailPeriods)
+	}
+}
+
+// 거버넌스 참여는 투표 기간이 리포트 구간과 겹치는 제안만 집계
+func TestUptimeReportGovernanceInRange(t *testing.T) {
+	tracker := newReportTracker(t)
+	report := getReport(t, tracker, "from=2025-06-01&to=2025-06-07")
+
+	want := map[string]Participation{
+		"alpha": {Voted: []string{"2"}, NotVoted: []string{"3"}},
+		"beta":  {Voted: []string{"3"}, NotVoted: []string{"2"}},
+	}
+	for _, v := range report.Validators {
+		if !reflect.DeepEqual(v.Governance, want[v.Validator]) {
+			t.Errorf("%s governance = %+v, want %+v", v.Validator, v.Governance, want[v.Validator])
+		}
+	}
+
+	// 기간 기록이 없는 제안은 어느 구간에도 넣지 않음
+	tracker.mu.Lock()
+	tracker.proposalVotes["5"] = map[string]bool{"alpha": true}
+	tracker.mu.Unlock()
+	if votes := tracker.proposalVotesBetween(reportDate(1, 0, 0, 0), reportDate(8, 0, 0, 0)); votes["5"] != nil {
+		t.Errorf("proposal without a voting period included: %v", votes)
+	}
+}
+
+// RFC3339 시각의 to는 그대로 포함하지 않는 끝
+func TestUptimeReportRFC3339End(t *testing.T) {
+	tracker := newReportTracker(t)
+	report := getReport(t, tracker, "from=2025-06-01&to=2025-06-07T12:00:00Z")
+
+	if !report.To.Equal(reportDate(7, 12, 0, 0)) {
+		t.Errorf("to = %v, want 2025-06-07T12:00:00Z", report.To)
+	}
+	for _, v := range report.Validators {
+		last := v.Days[len(v.Days)-1]
+		if last.Date != "2025-06-07" || last.Signed+last.Missed != 1 {
+			t.Errorf("%s last day = %+v, want only the 00:00 block", v.Validator, last)
+		}
+	}
+}
+
+func TestUptimeReportMarkdownHeader(t *testing.T) {
+	tracker := newReportTracker(t)
+	rec := httptest.NewRecorder()
+	tracker.handleUptimeReport(rec, httptest.NewRequest("GET", "/api/report?from=2025-06-01&to=2025-06-07&format=markdown", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "# Uptime report 2025-06-01 ~ 2025-06-07 (UTC)\n") {
+		t.Errorf("header = %q", strings.SplitN(body, "\n", 2)[0])
+	}
+	if !strings.Contains(body, "| 2025-06-07 | 2 | 1 | 66.67% |") {
+		t.Errorf("last day row for beta missing:\n%s", body)
+	}
+}
+
+// /api/events도 날짜만 지정한 to는 그날 끝까지 포함
+func TestEventsDateOnlyEnd(t *testing.T) {
+	tracker := newReportTracker(t)
+	for _, at := range []time.Time{reportDate(6, 12, 0, 0), reportDate(7, 23, 30, 0), reportDate(8, 0, 0, 0)} {
+		tracker.history.AddEvent(Event{Type: EventJailed, Time: at, Validator: "beta"})
+	}
+
+	rec := httptest.NewRecorder()
+	tracker.handleEvents(rec, httptest.NewRequest("GET", "/api/events?from=2025-06-07&to=2025-06-07", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var page EventPage
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Events) != 1 || !page.Events[0].Time.Equal(reportDate(7, 23, 30, 0)) {
+		t.Errorf("events = %+v, want only the 23:30 event on 2025-06-07", page.Events)
+	}
+}
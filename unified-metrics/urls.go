@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// 로그에서 값을 가리는 쿼리 파라미터 (소문자, 이름에 이 단어가 들어가면 비밀로 취급)
+var secretQueryParams = []string{"token", "key", "secret", "password", "passwd", "auth", "signature", "sig", "credential"}
+
+const redactedValue = "REDACTED"
+
+func isSecretQueryParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, secret := range secretQueryParams {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// 요청 URL을 로그에 남길 형태로 정리: userinfo 제거, 비밀 쿼리 값 가림 (height 같은 나머지 파라미터는 유지)
+// 라벨/API에 쓰는 엔드포인트는 쿼리 전체를 버리는 sanitizeEndpoint 사용
+func sanitizeURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "<invalid>"
+	}
+	u.User = nil
+	u.Fragment = ""
+	if u.RawQuery != "" {
+		query := u.Query()
+		for name, values := range query {
+			if isSecretQueryParam(name) {
+				for i := range values {
+					values[i] = redactedValue
+				}
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+var embeddedURLPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+
+// 에러 메시지 등 자유 텍스트 안의 URL 정리 (http.Get 에러는 요청 URL을 그대로 포함)
+func redactURLs(text string) string {
+	if !strings.Contains(text, "://") {
+		return text
+	}
+	return embeddedURLPattern.ReplaceAllStringFunc(text, sanitizeURL)
+}
+
+// 모든 로그 핸들러의 ReplaceAttr: 호출 지점에서 빠뜨린 URL도 출력 전에 정리
+func redactLogAttr(_ []string, attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		if value := attr.Value.String(); strings.Contains(value, "://") {
+			return slog.String(attr.Key, redactURLs(value))
+		}
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok && err != nil {
+			if message := err.Error(); strings.Contains(message, "://") {
+				return slog.String(attr.Key, redactURLs(message))
+			}
+		}
+	}
+	return attr
+}
+
+// 설정된 엔드포인트가 절대 http(s) URL인지 확인 (흔한 실수는 고칠 방법을 함께 안내)
+func validateEndpointURL(value string) error {
+	if value != strings.TrimSpace(value) {
+		return fmt.Errorf("%q has leading or trailing whitespace", sanitizeURL(value))
+	}
+	if value == "" {
+		return fmt.Errorf("empty URL")
+	}
+	if !strings.Contains(value, "://") {
+		// 스킴이 없으면 userinfo가 불투명 값으로 파싱되므로 http://를 붙인 형태로만 표시
+		return fmt.Errorf("URL has no scheme (did you mean %q?)", sanitizeURL("http://"+value))
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		// url.Error는 원본 URL을 포함하므로 원인만 사용
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("%q is not a valid URL: %v", sanitizeEndpoint(value), err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q has unsupported scheme %q (expected http or https)", sanitizeURL(value), u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", sanitizeURL(value))
+	}
+	return nil
+}
+
+// 환경 변수로 받는 엔드포인트 검증 (JSON 설정의 URL은 각 설정을 읽을 때 검증)
+func validateEndpointEnv() error {
+	single := []string{"RPC_ENDPOINT", "REST_ENDPOINT", "RPC_ENDPOINT_PIN", "VERIFY_ENDPOINT", "EVM_RPC_ENDPOINT",
+		"NODE_EXPORTER_URL", "OG_NODE_METRICS_URL",
+		"NOTIFY_WEBHOOK_URL", "NOTIFY_DISCORD_WEBHOOK_URL", "NOTIFY_SLACK_WEBHOOK_URL", "HEARTBEAT_URL"}
+	for _, key := range single {
+		value, ok := lookupEnv(key)
+		if !ok || value == "" {
+			continue
+		}
+		if err := validateEndpointURL(value); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	for _, endpoint := range getEnvList("RPC_ENDPOINTS", nil) {
+		if err := validateEndpointURL(endpoint); err != nil {
+			return fmt.Errorf("RPC_ENDPOINTS: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"og-galileo-unified-metrics/internal/confparse"
+)
+
+// 추적할 벨리데이터 한 개 (합의 주소와 라벨은 필수, 운영자 주소와 모니커는 스테이킹 목록과 맞출 때 사용)
+// 라벨은 메트릭의 validator 라벨 값이므로 벨리데이터마다 달라야 하고, 모니커는 moniker 라벨로도 노출
+type ValidatorConfig struct {
+	ConsensusAddress string `json:"consensus_address"`
+	Label            string `json:"label"`
+	OperatorAddress  string `json:"operator_address,omitempty"`
+	Moniker          string `json:"moniker,omitempty"`
+}
+
+// 추적 목록 결정: 설정 파일의 validators가 있으면 그 목록 (파일 우선), 아니면 VALIDATORS_FILE 또는 VALIDATORS
+// 모두 없으면 nil (기본 목록 사용)
+func validatorConfigsFromEnv(config *Config) ([]ValidatorConfig, error) {
+	path := getEnv("VALIDATORS_FILE", "")
+	list := getEnv("VALIDATORS", "")
+	var validators []ValidatorConfig
+	var err error
+	switch {
+	case config != nil && len(config.validatorConfigs) > 0:
+		if path != "" {
+			return nil, fmt.Errorf("validators are defined in both the config file and VALIDATORS_FILE; use one of them")
+		}
+		validators = config.validatorConfigs
+	case path != "" && list != "":
+		return nil, fmt.Errorf("both VALIDATORS_FILE and VALIDATORS are set; use one of them")
+	case path != "":
+		validators, err = loadValidatorsFile(path)
+	case list != "":
+		validators, err = parseValidatorList(list)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return validators, checkUniqueLabels(validators)
+}
+
+// 같은 라벨을 쓰는 벨리데이터가 있으면 WithLabelValues 시리즈를 서로 덮어쓰므로 시작 시 거부
+func checkUniqueLabels(validators []ValidatorConfig) error {
+	byLabel := make(map[string][]string)
+	var labels []string
+	for _, validator := range validators {
+		if len(byLabel[validator.Label]) == 0 {
+			labels = append(labels, validator.Label)
+		}
+		byLabel[validator.Label] = append(byLabel[validator.Label], validator.ConsensusAddress)
+	}
+	var problems []string
+	for _, label := range labels {
+		if addresses := byLabel[label]; len(addresses) > 1 {
+			problems = append(problems, fmt.Sprintf("label %q is used by %s", label, strings.Join(addresses, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("duplicate validator labels: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// VALIDATORS=addr1:label1,addr2:label2 (항목 앞뒤 공백과 빈 항목은 무시, 주소는 대문자 hex로 정규화)
+func parseValidatorList(value string) ([]ValidatorConfig, error) {
+	var validators []ValidatorConfig
+	seen := make(map[string]string) // 주소 -> 처음 나온 항목
+	for _, token := range strings.Split(value, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		address, label, ok := strings.Cut(token, ":")
+		label = strings.TrimSpace(label)
+		if !ok || label == "" {
+			return nil, fmt.Errorf("VALIDATORS entry %q: expected address:label", token)
+		}
+		normalized, err := normalizeConsensusAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("VALIDATORS entry %q: %w", token, err)
+		}
+		if previous, dup := seen[normalized]; dup {
+			return nil, fmt.Errorf("VALIDATORS entry %q: duplicate address (same as %q)", token, previous)
+		}
+		seen[normalized] = token
+		validators = append(validators, ValidatorConfig{ConsensusAddress: normalized, Label: label})
+	}
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("VALIDATORS has no entries")
+	}
+	return validators, nil
+}
+
+// VALIDATORS_FILE (JSON 또는 YAML): 합의 hex 주소 -> 라벨 문자열, 또는 label/operator_address/moniker 객체
+//
+//	21F5C524FCA565DD50841FF4B92A7220AA5B0BDD: validator1
+//	0A1B2C3D4E5F60718293A4B5C6D7E8F901234567:
+//	  label: validator2
+//	  operator_address: 0gvaloper1...
+//	  moniker: my-node
+func loadValidatorsFile(path string) ([]ValidatorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		doc, err = confparse.ParseYAML(data)
+	case ".json":
+		doc, err = decodeValidatorsJSON(data)
+	default:
+		return nil, fmt.Errorf("%s: unsupported validators file format (expected .json, .yaml or .yml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	validators, err := validatorConfigsFromMap(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return validators, nil
+}
+
+// 최상위 객체를 키 순서대로 읽어 중복 키를 에러로 처리 (encoding/json은 마지막 값으로 덮어씀)
+func decodeValidatorsJSON(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object of address -> label or entry")
+	}
+	doc := make(map[string]interface{})
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := token.(string)
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if _, dup := doc[key]; dup {
+			return nil, fmt.Errorf("duplicate address %s", key)
+		}
+		doc[key] = value
+	}
+	return doc, nil
+}
+
+// 항목별로 검증하고 잘못된 항목을 모두 모아 한 번에 보고
+func validatorConfigsFromMap(doc map[string]interface{}) ([]ValidatorConfig, error) {
+	if len(doc) == 0 {
+		return nil, fmt.Errorf("no validators defined")
+	}
+	keys := make([]string, 0, len(doc))
+	for key := range doc {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	seen := make(map[string]string) // 정규화한 주소 -> 원래 키
+	operators := make(map[string]string)
+	monikers := make(map[string]string)
+	validators := make([]ValidatorConfig, 0, len(doc))
+	for _, key := range keys {
+		validator := ValidatorConfig{}
+		switch value := doc[key].(type) {
+		case string:
+			validator.Label = value
+		case map[string]interface{}:
+			for field, raw := range value {
+				text, ok := raw.(string)
+				if !ok {
+					problems = append(problems, fmt.Sprintf("%s: %s must be a string", key, field))
+					continue
+				}
+				switch field {
+				case "label":
+					validator.Label = text
+				case "operator_address":
+					validator.OperatorAddress = strings.TrimSpace(text)
+				case "moniker":
+					validator.Moniker = strings.TrimSpace(text)
+				default:
+					problems = append(problems, fmt.Sprintf("%s: unknown field %q", key, field))
+				}
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("%s: expected a label or an entry with label, operator_address, moniker", key))
+			continue
+		}
+
+		address, err := normalizeConsensusAddress(key)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		if previous, dup := seen[address]; dup {
+			problems = append(problems, fmt.Sprintf("%s: duplicate address (same as %s)", key, previous))
+			continue
+		}
+		seen[address] = key
+		validator.ConsensusAddress = address
+		validator.Label = strings.TrimSpace(validator.Label)
+		if validator.Label == "" {
+			problems = append(problems, fmt.Sprintf("%s: label is required", key))
+			continue
+		}
+		if previous, dup := operators[validator.OperatorAddress]; dup && validator.OperatorAddress != "" {
+			problems = append(problems, fmt.Sprintf("%s: operator_address %s is also used by %s", key, validator.OperatorAddress, previous))
+			continue
+		}
+		if previous, dup := monikers[validator.Moniker]; dup && validator.Moniker != "" {
+			problems = append(problems, fmt.Sprintf("%s: moniker %q is also used by %s", key, validator.Moniker, previous))
+			continue
+		}
+		operators[validator.OperatorAddress] = key
+		monikers[validator.Moniker] = key
+		validators = append(validators, validator)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid validators: %s", strings.Join(problems, "; "))
+	}
+	return validators, nil
+}
+
+// LastCommit.Signatures와 같은 형식(대문자 hex 20바이트)으로 정규화 (0x 접두사 허용)
+func normalizeConsensusAddress(address string) (string, error) {
+	address = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(address), "0x"), "0X")
+	decoded, err := hex.DecodeString(address)
+	if err != nil || len(decoded) != 20 {
+		return "", fmt.Errorf("not a 20-byte hex consensus address")
+	}
+	return strings.ToUpper(address), nil
+}
+
+// 트래커에 넘기는 합의 주소 -> 라벨 맵
+func validatorLabels(validators []ValidatorConfig) map[string]string {
+	labels := make(map[string]string, len(validators))
+	for _, validator := range validators {
+		labels[validator.ConsensusAddress] = validator.Label
+	}
+	return labels
+}
+
+// 운영자 주소와 모니커로 스테이킹 목록의 항목을 라벨에 연결
+func (vt *UnifiedValidatorTracker) setValidatorConfigs(validators []ValidatorConfig) {
+	vt.operatorLabels = make(map[string]string)
+	vt.monikerLabels = make(map[string]string)
+	vt.monikers = make(map[string]string)
+	for _, validator := range validators {
+		if validator.OperatorAddress != "" {
+			vt.operatorLabels[validator.OperatorAddress] = validator.Label
+		}
+		if validator.Moniker != "" {
+			vt.monikerLabels[validator.Moniker] = validator.Label
+			vt.monikers[validator.Label] = sanitizeLabel(validator.Moniker)
+		}
+	}
+}
+
+// validator 라벨이 있는 시리즈에 설정한 모니커를 moniker 라벨로 추가하는 Gatherer
+// 대시보드가 validator_info와 조인하지 않고 이름을 표시할 수 있음 (이미 moniker 라벨이 있는 패밀리는 그대로)
+type monikerGatherer struct {
+	gatherer prometheus.Gatherer
+	monikers map[string]string // validator 라벨 -> 모니커
+}
+
+func (g monikerGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.gatherer.Gather()
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			addMonikerLabel(metric, g.monikers)
+		}
+	}
+	return families, err
+}
+
+func addMonikerLabel(metric *dto.Metric, monikers map[string]string) {
+	moniker := ""
+	for _, pair := range metric.Label {
+		switch pair.GetName() {
+		case "moniker":
+			return
+		case "validator":
+			moniker = monikers[pair.GetValue()]
+		}
+	}
+	if moniker == "" {
+		return
+	}
+	name := "moniker"
+	metric.Label = append(metric.Label, &dto.LabelPair{Name: &name, Value: &moniker})
+	sort.Slice(metric.Label, func(i, j int) bool { return metric.Label[i].GetName() < metric.Label[j].GetName() })
+}
+
+// 스테이킹 목록의 벨리데이터가 추적 대상인지 확인
+// 운영자 주소, 모니커, 합의 공개키(블록 서명 쪽 벨리데이터 셋에서 본 값) 순으로 맞춰봄
+func (vt *UnifiedValidatorTracker) stakingLabel(operatorAddress, moniker, pubKey string) (string, bool) {
+	if label, ok := vt.operatorLabels[operatorAddress]; ok {
+		return label, true
+	}
+	if label, ok := vt.monikerLabels[moniker]; ok {
+		return label, true
+	}
+	if label, ok := vt.validators[operatorAddress]; ok {
+		return label, true
+	}
+	if pubKey == "" {
+		return "", false
+	}
+	vt.mu.RLock()
+	defer vt.mu.RUnlock()
+	for address, identity := range vt.identities {
+		if identity.ConsensusPubKey == pubKey {
+			label, ok := vt.validators[address]
+			return label, ok
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testAddressA = "21F5C524FCA565DD50841FF4B92A7220AA5B0BDD"
+	testAddressB = "0A1B2C3D4E5F60718293A4B5C6D7E8F901234567"
+)
+
+func TestParseValidatorList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []ValidatorConfig
+		err   string
+	}{
+		{"single", testAddressA + ":alpha",
+			[]ValidatorConfig{{ConsensusAddress: testAddressA, Label: "alpha"}}, ""},
+		{"two entries", testAddressA + ":alpha," + testAddressB + ":beta",
+			[]ValidatorConfig{{ConsensusAddress: testAddressA, Label: "alpha"}, {ConsensusAddress: testAddressB, Label: "beta"}}, ""},
+		{"trailing comma", testAddressA + ":alpha,",
+			[]ValidatorConfig{{ConsensusAddress: testAddressA, Label: "alpha"}}, ""},
+		{"empty entries and spaces", " , " + testAddressA + " : alpha ,, ",
+			[]ValidatorConfig{{ConsensusAddress: testAddressA, Label: "alpha"}}, ""},
+		{"lowercase with 0x prefix", "0x" + strings.ToLower(testAddressA) + ":alpha",
+			[]ValidatorConfig{{ConsensusAddress: testAddressA, Label: "alpha"}}, ""},
+		{"colon in label", testAddressA + ":team:alpha",
+			[]ValidatorConfig{{ConsensusAddress: testAddressA, Label: "team:alpha"}}, ""},
+		{"only commas", ",,", nil, "has no entries"},
+		{"only spaces", "   ", nil, "has no entries"},
+		{"missing label", testAddressA, nil, "expected address:label"},
+		{"empty label", testAddressA + ":  ", nil, "expected address:label"},
+		{"empty address", ":alpha", nil, "not a 20-byte hex consensus address"},
+		{"short address", "ABCD:alpha", nil, "not a 20-byte hex consensus address"},
+		{"non-hex address", strings.Repeat("Z", 40) + ":alpha", nil, "not a 20-byte hex consensus address"},
+		{"duplicate address", testAddressA + ":alpha," + strings.ToLower(testAddressA) + ":beta", nil, "duplicate address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseValidatorList(tt.value)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 같은 라벨은 시리즈를 서로 덮어쓰므로 출처와 상관없이 거부
+func TestValidatorConfigsDuplicateLabels(t *testing.T) {
+	t.Setenv("VALIDATORS_FILE", "")
+	t.Setenv("VALIDATORS", testAddressA+":alpha,"+testAddressB+":alpha")
+	_, err := validatorConfigsFromEnv(nil)
+	if err == nil || !strings.Contains(err.Error(), `label "alpha" is used by `+testAddressA+", "+testAddressB) {
+		t.Errorf("error = %v", err)
+	}
+
+	path := writeConfigFile(t, "validators.yaml", testAddressA+": alpha\n"+testAddressB+":\n  label: alpha\n")
+	t.Setenv("VALIDATORS", "")
+	t.Setenv("VALIDATORS_FILE", path)
+	if _, err := validatorConfigsFromEnv(nil); err == nil || !strings.Contains(err.Error(), "duplicate validator labels") {
+		t.Errorf("file error = %v", err)
+	}
+}
+
+func TestValidatorConfigsFromEnvSources(t *testing.T) {
+	t.Setenv("VALIDATORS_FILE", "")
+	t.Setenv("VALIDATORS", "")
+	if got, err := validatorConfigsFromEnv(nil); err != nil || got != nil {
+		t.Errorf("no source = %v, %v, want nil (default list)", got, err)
+	}
+
+	path := writeConfigFile(t, "validators.json", `{"`+testAddressA+`": "alpha"}`)
+	t.Setenv("VALIDATORS_FILE", path)
+	t.Setenv("VALIDATORS", testAddressB+":beta")
+	if _, err := validatorConfigsFromEnv(nil); err == nil || !strings.Contains(err.Error(), "use one of them") {
+		t.Errorf("both sources error = %v", err)
+	}
+}
+
+func TestLoadValidatorsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []ValidatorConfig
+		err     string
+	}{
+		{"yaml", "v.yaml", testAddressB + ": beta\n" + strings.ToLower(testAddressA) + ":\n  label: alpha\n  operator_address: 0gvaloper1xyz\n  moniker: node-a\n",
+			[]ValidatorConfig{
+				{ConsensusAddress: testAddressB, Label: "beta"},
+				{ConsensusAddress: testAddressA, Label: "alpha", OperatorAddress: "0gvaloper1xyz", Moniker: "node-a"},
+			}, ""},
+		{"json", "v.json", `{"` + testAddressA + `": {"label": " alpha "}}`,
+			[]ValidatorConfig{{ConsensusAddress: testAddressA, Label: "alpha"}}, ""},
+		{"empty yaml", "v.yaml", "", nil, "no validators defined"},
+		{"empty json object", "v.json", "{}", nil, "no validators defined"},
+		{"empty label", "v.yaml", testAddressA + ": \"\"\n", nil, "label is required"},
+		{"duplicate json key", "v.json", `{"` + testAddressA + `": "a", "` + testAddressA + `": "b"}`, nil, "duplicate address"},
+		{"same address in two cases", "v.json", `{"` + testAddressA + `": "a", "` + strings.ToLower(testAddressA) + `": "b"}`, nil, "duplicate address"},
+		{"duplicate moniker", "v.yaml", testAddressA + ":\n  label: a\n  moniker: m\n" + testAddressB + ":\n  label: b\n  moniker: m\n", nil, `moniker "m" is also used`},
+		{"unknown field", "v.yaml", testAddressA + ":\n  label: a\n  color: red\n", nil, `unknown field "color"`},
+		{"unsupported extension", "v.txt", testAddressA + ": a\n", nil, "unsupported validators file format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadValidatorsFile(writeConfigFile(t, tt.file, tt.content))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// validators_hash를 알 수 없는 높이는 높이 기준으로만 캐시하며, 이 개수만큼 최근 높이를 유지
+const validatorSetCacheHeights = 200
+
+// 높이별 벨리데이터 셋 캐시
+// 블록 헤더의 validators_hash로 같은 셋을 공유하므로 셋이 바뀌는 높이에서만 다시 조회함
+type ValidatorSetCache struct {
+	mu       sync.Mutex
+	hashes   map[int64]string          // height -> validators_hash (블록 헤더에서 수집)
+	byHash   map[string]*ValidatorInfo // validators_hash -> 셋
+	byHeight map[int64]*ValidatorInfo  // 해시를 모르는 높이의 셋
+}
+
+func NewValidatorSetCache() *ValidatorSetCache {
+	return &ValidatorSetCache{
+		hashes:   make(map[int64]string),
+		byHash:   make(map[string]*ValidatorInfo),
+		byHeight: make(map[int64]*ValidatorInfo),
+	}
+}
+
+func (c *ValidatorSetCache) get(height int64) (*ValidatorInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hash, ok := c.hashes[height]; ok {
+		if set, ok := c.byHash[hash]; ok {
+			return set, true
+		}
+	}
+	set, ok := c.byHeight[height]
+	return set, ok
+}
+
+func (c *ValidatorSetCache) put(height int64, set *ValidatorInfo, fallback bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 최신 셋으로 대신한 결과는 해시에 연결하지 않음 (다른 높이에 잘못 재사용되지 않도록)
+	if hash, ok := c.hashes[height]; ok && !fallback {
+		c.byHash[hash] = set
+	} else {
+		c.byHeight[height] = set
+	}
+	c.pruneLocked(height)
+}
+
+// 블록 헤더에서 height와 height+1의 validators_hash 기록
+func (c *ValidatorSetCache) recordHashes(height int64, hash, nextHash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hash != "" {
+		c.hashes[height] = hash
+	}
+	if nextHash != "" {
+		c.hashes[height+1] = nextHash
+	}
+	c.pruneLocked(height)
+}
+
+// 체인 리셋 시 이전 체인의 높이/해시 정보 삭제
+func (c *ValidatorSetCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.hashes)
+	clear(c.byHash)
+	clear(c.byHeight)
+}
+
+func (c *ValidatorSetCache) pruneLocked(height int64) {
+	if len(c.hashes)+len(c.byHeight) <= 2*validatorSetCacheHeights {
+		return
+	}
+	cutoff := height - validatorSetCacheHeights
+	for h := range c.hashes {
+		if h < cutoff {
+			delete(c.hashes, h)
+		}
+	}
+	for h := range c.byHeight {
+		if h < cutoff {
+			delete(c.byHeight, h)
+		}
+	}
+
+	// 더 이상 참조되지 않는 해시의 셋 정리
+	live := make(map[string]bool, len(c.hashes))
+	for _, hash := range c.hashes {
+		live[hash] = true
+	}
+	for hash := range c.byHash {
+		if !live[hash] {
+			delete(c.byHash, hash)
+		}
+	}
+}
+
+func (vt *UnifiedValidatorTracker) recordValidatorsHashes(blockInfo *BlockInfo) {
+	header := blockInfo.Result.Block.Header
+	height, err := strconv.ParseInt(string(header.Height), 10, 64)
+	if err != nil {
+		return
+	}
+	vt.validatorSets.recordHashes(height, header.ValidatorsHash, header.NextValidatorsHash)
+}
+
+// height 시점의 벨리데이터 셋 (노드가 해당 높이를 프루닝했으면 최신 셋으로 대체)
+func (vt *UnifiedValidatorTracker) validatorSetAt(ctx context.Context, height int64) (*ValidatorInfo, error) {
+	if set, ok := vt.validatorSets.get(height); ok {
+		return set, nil
+	}
+
+	set, err := vt.fetchValidators(ctx, height)
+	if err == nil {
+		vt.validatorSets.put(height, set, false)
+		return set, nil
+	}
+	// 일시적인 오류는 최신 셋으로 대신하지 않고 캐시하지도 않음 (다음 시도에서 다시 조회)
+	if !errors.Is(err, ErrHeightNotAvailable) {
+		return nil, err
+	}
+
+	rpcLog.Debug("Validator set at height unavailable, falling back to latest", "height", height, "error", err)
+	latest, latestErr := vt.fetchValidators(ctx, 0)
+	if latestErr != nil {
+		return nil, latestErr
+	}
+	vt.validatorSets.put(height, latest, true)
+	return latest, nil
+}
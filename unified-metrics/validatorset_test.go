@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"og-galileo-unified-metrics/internal/rpcmock"
+)
+
+// 가짜 체인에 붙이고 /validators 요청 수를 세는 트래커
+func newValidatorSetTracker(t *testing.T, chain *rpcmock.Chain) (*UnifiedValidatorTracker, *atomic.Int64) {
+	t.Helper()
+	requests := new(atomic.Int64)
+	handler := chain.Handler()
+	tracker := newServedTracker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/validators" {
+			requests.Add(1)
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	return tracker, requests
+}
+
+// 블록 헤더의 validators_hash를 캐시에 기록
+func recordHeaders(t *testing.T, tracker *UnifiedValidatorTracker, from, to int64) {
+	t.Helper()
+	for height := from; height <= to; height++ {
+		blockInfo, err := tracker.fetchBlock(context.Background(), height)
+		if err != nil {
+			t.Fatalf("fetch block %d: %v", height, err)
+		}
+		tracker.recordValidatorsHashes(blockInfo)
+	}
+}
+
+func mustValidatorSet(t *testing.T, tracker *UnifiedValidatorTracker, height int64) *ValidatorInfo {
+	t.Helper()
+	set, err := tracker.validatorSetAt(context.Background(), height)
+	if err != nil {
+		t.Fatalf("validatorSetAt(%d): %v", height, err)
+	}
+	return set
+}
+
+// 같은 validators_hash를 가진 높이는 한 번만 조회하고, 셋이 바뀐 높이에서만 다시 조회
+func TestValidatorSetCacheInvalidation(t *testing.T) {
+	chain := rpcmock.NewChain("alpha", "beta", "gamma")
+	chain.Advance(5)
+	tracker, requests := newValidatorSetTracker(t, chain)
+	recordHeaders(t, tracker, 1, 5)
+
+	for height := int64(2); height <= 5; height++ {
+		if got := len(mustValidatorSet(t, tracker, height).Result.Validators); got != 3 {
+			t.Errorf("set at %d has %d validators, want 3", height, got)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("validators requests for one hash = %d, want 1", got)
+	}
+
+	chain.SetInSet("gamma", false)
+	chain.Advance(2)
+	recordHeaders(t, tracker, 6, 7)
+	// 변경은 한 블록 뒤(7)부터 적용되며, 6은 이전 해시라 캐시를 그대로 사용
+	if got := len(mustValidatorSet(t, tracker, 6).Result.Validators); got != 3 {
+		t.Errorf("set at 6 has %d validators, want 3", got)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("validators requests before the change height = %d, want 1", got)
+	}
+	if got := len(mustValidatorSet(t, tracker, 7).Result.Validators); got != 2 {
+		t.Errorf("set at the change height has %d validators, want 2", got)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("validators requests after a set change = %d, want 2", got)
+	}
+	if got := len(mustValidatorSet(t, tracker, 5).Result.Validators); got != 3 {
+		t.Errorf("set at 5 after the change has %d validators, want 3", got)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("validators requests = %d, want 2", got)
+	}
+}
+
+// 노드가 정리한 높이는 최신 셋으로 대체하고 그 높이에 캐시
+func TestValidatorSetPrunedFallback(t *testing.T) {
+	chain := rpcmock.NewChain("alpha", "beta", "gamma")
+	chain.Advance(5)
+	tracker, requests := newValidatorSetTracker(t, chain)
+
+	chain.SetInSet("gamma", false)
+	chain.Advance(1005)
+	if got := len(mustValidatorSet(t, tracker, 2).Result.Validators); got != 2 {
+		t.Errorf("fallback set has %d validators, want the latest 2", got)
+	}
+	// 높이 지정 조회 실패 + 최신 셋 조회
+	if got := requests.Load(); got != 2 {
+		t.Errorf("validators requests = %d, want 2", got)
+	}
+	mustValidatorSet(t, tracker, 2)
+	if got := requests.Load(); got != 2 {
+		t.Errorf("fallback not cached: %d requests", got)
+	}
+}
+
+// 일시적인 장애는 최신 셋으로 대신하지 않고 캐시하지도 않음
+func TestValidatorSetTransientErrorNotCached(t *testing.T) {
+	chain := rpcmock.NewChain("alpha", "beta", "gamma")
+	chain.Advance(5)
+	tracker, requests := newValidatorSetTracker(t, chain)
+
+	chain.SetOutage(true)
+	if _, err := tracker.validatorSetAt(context.Background(), 4); err == nil {
+		t.Fatal("validatorSetAt succeeded during an outage")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("validators requests during outage = %d, want 1 (no fallback)", got)
+	}
+
+	// 복구 후에는 해당 높이의 실제 셋을 조회
+	chain.SetOutage(false)
+	chain.SetInSet("gamma", false)
+	chain.Advance(1)
+	if got := len(mustValidatorSet(t, tracker, 4).Result.Validators); got != 3 {
+		t.Errorf("set at 4 after recovery has %d validators, want 3", got)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("validators requests after recovery = %d, want 2", got)
+	}
+}
+
+func TestValidatorSetErrorClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		fallback bool
+	}{
+		{"pruned with 200", http.StatusOK, prunedHeightBody, true},
+		{"pruned with 500", http.StatusInternalServerError, prunedHeightBody, true},
+		{"other rpc error", http.StatusInternalServerError,
+			`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"database closed"}}`, false},
+		{"proxy 502 page", http.StatusBadGateway, `<html><body>Bad Gateway</body></html>`, false},
+	}
+	latest := `{"jsonrpc":"2.0","id":-1,"result":{"block_height":"9","validators":[{"address":"AA","pub_key":{"value":"a2V5"},"voting_power":"10"}],"count":"1","total":"1"}}`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 높이를 지정한 요청만 실패하는 노드
+			tracker := newServedTracker(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Query().Get("height") != "" {
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.body))
+					return
+				}
+				w.Write([]byte(latest))
+			}))
+
+			set, err := tracker.validatorSetAt(context.Background(), 2)
+			if !tt.fallback {
+				if err == nil {
+					t.Fatal("validatorSetAt fell back to the latest set on a transient error")
+				}
+				if errors.Is(err, ErrHeightNotAvailable) {
+					t.Errorf("error %v classified as height not available", err)
+				}
+				if _, ok := tracker.validatorSets.get(2); ok {
+					t.Error("failed lookup cached")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validatorSetAt: %v", err)
+			}
+			if len(set.Result.Validators) != 1 || set.Result.Validators[0].Address != "AA" {
+				t.Errorf("fallback set = %+v", set.Result.Validators)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultVerifySampleRate = 50 // 처리한 높이 N개 중 1개 검증
+	verifyAttempts          = 3  // 보조 엔드포인트가 아직 해당 높이를 제공하지 않을 때 재시도 횟수
+	verifyRetryDelay        = 2 * time.Second
+	verifyTimeout           = 10 * time.Second
+)
+
+// 보조 엔드포인트로 서명 데이터를 교차 검증 (캐시된 오래된 블록을 제공하는 RPC 감지)
+type BlockVerifier struct {
+	endpoint   string
+	sampleRate int64
+	counter    atomic.Int64
+	client     *http.Client
+}
+
+func NewBlockVerifier(endpoint string, sampleRate int64) *BlockVerifier {
+	if sampleRate < 1 {
+		sampleRate = 1
+	}
+	return &BlockVerifier{
+		endpoint:   endpoint,
+		sampleRate: sampleRate,
+		client:     &http.Client{Timeout: verifyTimeout},
+	}
+}
+
+// 표본 추출 (N번째 호출마다 true)
+func (bv *BlockVerifier) sample() bool {
+	return bv.counter.Add(1)%bv.sampleRate == 0
+}
+
+func (bv *BlockVerifier) fetchBlock(ctx context.Context, height int64) (*BlockInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/block?height=%d", bv.endpoint, height), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := bv.client.Do(req)
+
+	var blockInfo BlockInfo
+	if err := decodeJSONResponse("verify_block", bv.endpoint, resp, err, &blockInfo); err != nil {
+		return nil, err
+	}
+	if string(blockInfo.Result.Block.Header.Height) != fmt.Sprint(height) {
+		notFound := &kindError{kind: ErrNotFound, err: fmt.Errorf("block %d (got %q)", height, blockInfo.Result.Block.Header.Height)}
+		return nil, newFetchError("verify_block", bv.endpoint, notFound)
+	}
+	return &blockInfo, nil
+}
+
+// 주 엔드포인트에서 판단한 서명 여부를 보조 엔드포인트의 같은 블록과 비교 (비동기)
+// 스캔 버퍼는 호출이 끝나면 재사용되므로 서명 집합을 복사해서 넘김
+func (vt *UnifiedValidatorTracker) maybeVerifySigning(ctx context.Context, height int64, scan *signatureScan) {
+	if vt.verifier == nil || !vt.verifier.sample() {
+		return
+	}
+	signed := scan.signedCopy()
+	vt.goroutines.Go(componentVerifier, func() { vt.verifySigning(ctx, height, signed) })
+}
+
+func (vt *UnifiedValidatorTracker) verifySigning(ctx context.Context, height int64, signed map[string]bool) {
+	metrics := vt.metrics.exporter
+	start := time.Now()
+
+	var blockInfo *BlockInfo
+	var err error
+	for attempt := 1; attempt <= verifyAttempts; attempt++ {
+		if blockInfo, err = vt.verifier.fetchBlock(ctx, height); err == nil {
+			break
+		}
+		if attempt < verifyAttempts {
+			time.Sleep(verifyRetryDelay)
+		}
+	}
+	if err != nil {
+		rpcLog.Warn("Verification endpoint could not serve block", "height", height,
+			"endpoint", sanitizeEndpoint(vt.verifier.endpoint), "error", err)
+		metrics.verificationsMetric.WithLabelValues("unavailable").Inc()
+		return
+	}
+	metrics.verificationLatencyMetric.Observe(time.Since(start).Seconds())
+
+	secondary := make(map[string]bool)
+	for _, sig := range blockInfo.Result.Block.LastCommit.Signatures {
+		if sig.Signature != "" {
+			secondary[normalizeAddress(sig.ValidatorAddress)] = true
+		}
+	}
+
+	discrepancies := 0
+	for address, label := range vt.validators {
+		address = normalizeAddress(address)
+		if signed[address] == secondary[address] {
+			continue
+		}
+		discrepancies++
+		rpcLog.Warn("Signing data discrepancy between endpoints", "height", height, "validator", label,
+			"primary", sanitizeEndpoint(vt.endpoints.Selected()), "primary_signed", signed[address],
+			"secondary", sanitizeEndpoint(vt.verifier.endpoint), "secondary_signed", secondary[address])
+	}
+	if discrepancies > 0 {
+		metrics.dataDiscrepanciesMetric.Add(float64(discrepancies))
+		metrics.verificationsMetric.WithLabelValues("discrepancy").Inc()
+		return
+	}
+	metrics.verificationsMetric.WithLabelValues("match").Inc()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 값이 한 번이라도 설정되기 전에는 노출하지 않는 Gauge
+// 시작 직후 첫 수집 전까지 체인/벨리데이터 게이지가 0으로 보이면 "본딩 안 됨, 높이 0" 같은 샘플이 알림을 울리므로 사용
+// (Vec 타입은 WithLabelValues로 처음 설정할 때 시리즈가 생기므로 따로 감쌀 필요 없음)
+type lazyGauge struct {
+	prometheus.Gauge
+	hasData atomic.Bool
+}
+
+func newLazyGauge(opts prometheus.GaugeOpts) *lazyGauge {
+	return &lazyGauge{Gauge: prometheus.NewGauge(opts)}
+}
+
+// 값을 먼저 쓴 뒤 표시해 수집 시 설정 전 값이 보이지 않도록 함
+func (g *lazyGauge) Set(value float64) {
+	g.Gauge.Set(value)
+	g.hasData.Store(true)
+}
+
+func (g *lazyGauge) Inc() {
+	g.Gauge.Inc()
+	g.hasData.Store(true)
+}
+
+func (g *lazyGauge) Dec() {
+	g.Gauge.Dec()
+	g.hasData.Store(true)
+}
+
+func (g *lazyGauge) Add(value float64) {
+	g.Gauge.Add(value)
+	g.hasData.Store(true)
+}
+
+func (g *lazyGauge) Sub(value float64) {
+	g.Gauge.Sub(value)
+	g.hasData.Store(true)
+}
+
+func (g *lazyGauge) SetToCurrentTime() {
+	g.Gauge.SetToCurrentTime()
+	g.hasData.Store(true)
+}
+
+func (g *lazyGauge) Collect(ch chan<- prometheus.Metric) {
+	if g.hasData.Load() {
+		g.Gauge.Collect(ch)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Register에 넘긴 컬렉터를 모으는 Registerer
+type recordingRegisterer struct {
+	collectors []prometheus.Collector
+}
+
+func (r *recordingRegisterer) Register(c prometheus.Collector) error {
+	r.collectors = append(r.collectors, c)
+	return nil
+}
+
+func (r *recordingRegisterer) MustRegister(cs ...prometheus.Collector) {
+	r.collectors = append(r.collectors, cs...)
+}
+
+func (r *recordingRegisterer) Unregister(prometheus.Collector) bool { return false }
+
+func TestLazyGauge(t *testing.T) {
+	gauge := newLazyGauge(prometheus.GaugeOpts{Name: "test_lazy"})
+	if n := testutil.CollectAndCount(gauge); n != 0 {
+		t.Fatalf("series before first set = %d", n)
+	}
+	// 명시적으로 설정한 0은 노출
+	gauge.Set(0)
+	if n := testutil.CollectAndCount(gauge); n != 1 || testutil.ToFloat64(gauge) != 0 {
+		t.Errorf("after Set(0): %d series, value %v", n, testutil.ToFloat64(gauge))
+	}
+
+	for name, update := range map[string]func(*lazyGauge){
+		"Inc":              func(g *lazyGauge) { g.Inc() },
+		"Dec":              func(g *lazyGauge) { g.Dec() },
+		"Add":              func(g *lazyGauge) { g.Add(2) },
+		"Sub":              func(g *lazyGauge) { g.Sub(2) },
+		"SetToCurrentTime": func(g *lazyGauge) { g.SetToCurrentTime() },
+	} {
+		g := newLazyGauge(prometheus.GaugeOpts{Name: "test_lazy"})
+		update(g)
+		if n := testutil.CollectAndCount(g); n != 1 {
+			t.Errorf("after %s: %d series, want 1", name, n)
+		}
+	}
+}
+
+// 시작 직후에는 체인/벨리데이터 게이지가 0으로 보이지 않음 (카운터의 0은 의미가 있으므로 제외)
+func TestWarmupNoZeroGauges(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha"})
+	if err := tracker.RegisterMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	families, err := tracker.registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		name := family.GetName()
+		if family.GetType() != dto.MetricType_GAUGE ||
+			!(strings.HasPrefix(name, "og_galileo_validator_") || strings.HasPrefix(name, "og_galileo_chain_") || strings.HasPrefix(name, "cometbft_")) {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			t.Errorf("%s%v exported before any data: %v", name, metric.GetLabel(), metric.GetGauge().GetValue())
+		}
+	}
+	if ready, _ := tracker.Ready(time.Minute); ready {
+		t.Error("ready before the first block")
+	}
+}
+
+// 지연 게이지는 각각 처음 설정할 때 실제 레지스트리에 나타남
+func TestLazyGaugesAppearOnFirstSet(t *testing.T) {
+	tracker := NewUnifiedValidatorTracker([]string{"http://127.0.0.1:1"}, map[string]string{"ADDRALPHA": "alpha"})
+	if err := tracker.RegisterMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	var recorder recordingRegisterer
+	if err := tracker.metrics.Register(&recorder); err != nil {
+		t.Fatal(err)
+	}
+
+	lazy := 0
+	for _, collector := range recorder.collectors {
+		gauge, ok := collector.(*lazyGauge)
+		if !ok {
+			continue
+		}
+		lazy++
+		before := gatheredNames(t, tracker.registry)
+		gauge.Set(42)
+		var added []string
+		for name := range gatheredNames(t, tracker.registry) {
+			if !before[name] {
+				added = append(added, name)
+			}
+		}
+		if len(added) != 1 {
+			t.Errorf("%s: families added on first Set = %v, want one", gauge.Desc(), added)
+			continue
+		}
+		if got, ok := gatherValue(t, tracker.registry, added[0]); !ok || got != 42 {
+			t.Errorf("%s = %v, %v after Set(42)", added[0], got, ok)
+		}
+	}
+	if lazy == 0 {
+		t.Fatal("no lazy gauges registered")
+	}
+}
+
+// 첫 블록을 적용하기 전까지 준비되지 않음, 적용하면 블록 높이 게이지가 나타남
+func TestReadyAfterFirstBlock(t *testing.T) {
+	h := newTestHarness(t, "alpha")
+	if err := h.tracker.updateNodeStatus(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.tracker.checkRPCCompat(h.ctx); err != nil {
+		t.Fatal(err)
+	}
+	if ready, reason := h.tracker.Ready(time.Minute); ready || reason != "no block processed yet" {
+		t.Errorf("before the first block: ready=%v reason=%q", ready, reason)
+	}
+	if _, ok := h.value("og_galileo_validator_block_height"); ok {
+		t.Error("block height exported before the first block")
+	}
+
+	h.chain.SetOutage(true)
+	h.advance(1)
+	if ready, _ := h.tracker.Ready(time.Minute); ready {
+		t.Error("ready after a failed cycle")
+	}
+
+	h.chain.SetOutage(false)
+	if err := h.advance(1); err != nil {
+		t.Fatal(err)
+	}
+	if ready, reason := h.tracker.Ready(time.Minute); !ready {
+		t.Errorf("not ready after the first block: %s", reason)
+	}
+	if got := h.mustValue("og_galileo_validator_block_height"); got != float64(h.tracker.LastHeight()) {
+		t.Errorf("block height = %v, want %d", got, h.tracker.LastHeight())
+	}
+}
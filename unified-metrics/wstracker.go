@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"og-galileo-unified-metrics/internal/websocket"
+)
+
+const (
+	wsReconnectMin = time.Second
+	wsReconnectMax = time.Minute
+	// CometBFT는 약 27초마다 ping을 보내므로 이 시간 동안 아무 프레임도 없으면 끊긴 연결로 판단
+	wsReadTimeout = time.Minute
+	wsDialTimeout = 10 * time.Second
+)
+
+const newBlockQuery = "tm.event='NewBlock'"
+
+// 구독 응답과 이벤트 (이벤트에서는 높이만 사용하고, 블록 내용은 blocks 수집기가 평소처럼 조회)
+type wsEventMessage struct {
+	Error  *RPCError `json:"error"`
+	Result struct {
+		Data struct {
+			Value struct {
+				Block struct {
+					Header struct {
+						Height numericString `json:"height"`
+					} `json:"header"`
+				} `json:"block"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+}
+
+// RPC WebSocket으로 NewBlock 이벤트를 구독해 blocks 수집기를 바로 깨움
+// 연결이 끊긴 동안에는 blocks 수집기가 POLL_INTERVAL 간격 폴링으로 계속 동작 (--poll-mode면 구독하지 않음)
+type WebSocketTracker struct {
+	vt   *UnifiedValidatorTracker
+	wake chan struct{}
+}
+
+func NewWebSocketTracker(vt *UnifiedValidatorTracker) *WebSocketTracker {
+	return &WebSocketTracker{vt: vt, wake: make(chan struct{}, 1)}
+}
+
+// 새 블록 알림 (수집기가 아직 처리 중이면 한 번만 대기)
+func (t *WebSocketTracker) Wake() <-chan struct{} {
+	return t.wake
+}
+
+// 종료될 때까지 연결을 유지하고, 끊기면 지수 백오프로 재연결
+func (t *WebSocketTracker) Run(ctx context.Context) {
+	backoff := wsReconnectMin
+	for ctx.Err() == nil {
+		endpoint := wsEndpoint(t.vt.endpoints.Selected())
+		subscribed, err := t.session(ctx, endpoint)
+		t.vt.metrics.exporter.websocketConnectedMetric.Set(0)
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			backoff = wsReconnectMin
+		}
+		trackerLog.Warn("Block event subscription lost, polling until reconnected",
+			"endpoint", sanitizeEndpoint(endpoint), "retry_in", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, wsReconnectMax)
+		t.vt.metrics.exporter.websocketReconnectsMetric.Inc()
+	}
+}
+
+// 연결 하나의 수명: 연결, 구독, 이벤트 수신 (구독까지 성공했으면 subscribed)
+func (t *WebSocketTracker) session(ctx context.Context, endpoint string) (subscribed bool, err error) {
+	dialCtx, cancel := context.WithTimeout(ctx, wsDialTimeout)
+	conn, err := websocket.Dial(dialCtx, endpoint, nil)
+	cancel()
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	conn.ReadTimeout = wsReadTimeout
+
+	request, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "subscribe",
+		"params":  map[string]string{"query": newBlockQuery},
+	})
+	if err := conn.WriteText(request); err != nil {
+		return false, err
+	}
+
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			return subscribed, err
+		}
+		var message wsEventMessage
+		if err := json.Unmarshal(data, &message); err != nil {
+			return subscribed, fmt.Errorf("decode event: %w", err)
+		}
+		if message.Error != nil {
+			return subscribed, message.Error
+		}
+		height := string(message.Result.Data.Value.Block.Header.Height)
+		if height == "" {
+			// 구독 응답 ({"result": {}})
+			if !subscribed {
+				subscribed = true
+				t.vt.metrics.exporter.websocketConnectedMetric.Set(1)
+				trackerLog.Info("Subscribed to block events", "endpoint", sanitizeEndpoint(endpoint), "query", newBlockQuery)
+			}
+			continue
+		}
+		trackerLog.Debug("Received new block event", "height", height)
+		select {
+		case t.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// RPC 주소 (http://host:26657) -> WebSocket 주소 (ws://host:26657/websocket)
+func wsEndpoint(rpc string) string {
+	endpoint := strings.TrimRight(rpc, "/")
+	switch {
+	case strings.HasPrefix(endpoint, "https://"):
+		endpoint = "wss://" + strings.TrimPrefix(endpoint, "https://")
+	case strings.HasPrefix(endpoint, "http://"):
+		endpoint = "ws://" + strings.TrimPrefix(endpoint, "http://")
+	}
+	return endpoint + "/websocket"
+}